@@ -16,4 +16,4 @@ limitations under the License.
 
 package v1alpha3
 
-// +k8s:conversion-gen=sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4
+// +k8s:conversion-gen=sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1
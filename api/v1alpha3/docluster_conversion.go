@@ -18,33 +18,38 @@ package v1alpha3
 
 import (
 	apiconversion "k8s.io/apimachinery/pkg/conversion"
-	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 	clusterv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	clusterv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
-// ConvertTo converts this DOCluster to the Hub version (v1alpha4).
+// ConvertTo converts this DOCluster to the Hub version (v1beta1).
 func (src *DOCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*infrav1alpha4.DOCluster)
-	if err := Convert_v1alpha3_DOCluster_To_v1alpha4_DOCluster(src, dst, nil); err != nil {
+	dst := dstRaw.(*infrav1beta1.DOCluster)
+	if err := Convert_v1alpha3_DOCluster_To_v1beta1_DOCluster(src, dst, nil); err != nil {
 		return err
 	}
 
 	// Manually restore data from annotations
-	restored := &infrav1alpha4.DOCluster{}
+	restored := &infrav1beta1.DOCluster{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
 
+	dst.Spec.FirewallRefs = restored.Spec.FirewallRefs
+	dst.Spec.Addons = restored.Spec.Addons
+	dst.Status.FailureDomains = restored.Status.FailureDomains
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
-// ConvertFrom converts from the Hub version (v1alpha4) to this version.
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *DOCluster) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*infrav1alpha4.DOCluster)
-	if err := Convert_v1alpha4_DOCluster_To_v1alpha3_DOCluster(src, dst, nil); err != nil {
+	src := srcRaw.(*infrav1beta1.DOCluster)
+	if err := Convert_v1beta1_DOCluster_To_v1alpha3_DOCluster(src, dst, nil); err != nil {
 		return err
 	}
 
@@ -56,16 +61,21 @@ func (dst *DOCluster) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 	return nil
 }
 
-// ConvertTo converts this DOClusterList to the Hub version (v1alpha4).
+// ConvertTo converts this DOClusterList to the Hub version (v1beta1).
 func (src *DOClusterList) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*infrav1alpha4.DOClusterList)
-	return Convert_v1alpha3_DOClusterList_To_v1alpha4_DOClusterList(src, dst, nil)
+	dst := dstRaw.(*infrav1beta1.DOClusterList)
+	return Convert_v1alpha3_DOClusterList_To_v1beta1_DOClusterList(src, dst, nil)
 }
 
-// ConvertFrom converts from the Hub version (v1alpha4) to this version.
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *DOClusterList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*infrav1alpha4.DOClusterList)
-	return Convert_v1alpha4_DOClusterList_To_v1alpha3_DOClusterList(src, dst, nil)
+	src := srcRaw.(*infrav1beta1.DOClusterList)
+	return Convert_v1beta1_DOClusterList_To_v1alpha3_DOClusterList(src, dst, nil)
+}
+
+// Convert_v1beta1_DOClusterSpec_To_v1alpha3_DOClusterSpec converts from the Hub version (v1beta1) of the DOClusterSpec to this version.
+func Convert_v1beta1_DOClusterSpec_To_v1alpha3_DOClusterSpec(in *infrav1beta1.DOClusterSpec, out *DOClusterSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_DOClusterSpec_To_v1alpha3_DOClusterSpec(in, out, s)
 }
 
 // Convert_v1alpha3_APIEndpoint_To_v1alpha4_APIEndpoint is an autogenerated conversion function.
@@ -77,3 +87,8 @@ func Convert_v1alpha3_APIEndpoint_To_v1alpha4_APIEndpoint(in *clusterv1alpha3.AP
 func Convert_v1alpha4_APIEndpoint_To_v1alpha3_APIEndpoint(in *clusterv1alpha4.APIEndpoint, out *clusterv1alpha3.APIEndpoint, s apiconversion.Scope) error {
 	return clusterv1alpha3.Convert_v1alpha4_APIEndpoint_To_v1alpha3_APIEndpoint(in, out, s)
 }
+
+// Convert_v1beta1_DOClusterStatus_To_v1alpha3_DOClusterStatus converts from the Hub version (v1beta1) of the DOClusterStatus to this version.
+func Convert_v1beta1_DOClusterStatus_To_v1alpha3_DOClusterStatus(in *infrav1beta1.DOClusterStatus, out *DOClusterStatus, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_DOClusterStatus_To_v1alpha3_DOClusterStatus(in, out, s)
+}
@@ -17,31 +17,37 @@ limitations under the License.
 package v1alpha3
 
 import (
-	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
-// ConvertTo converts this DOMachine to the Hub version (v1alpha4).
+// ConvertTo converts this DOMachine to the Hub version (v1beta1).
 func (src *DOMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*infrav1alpha4.DOMachine)
-	if err := Convert_v1alpha3_DOMachine_To_v1alpha4_DOMachine(src, dst, nil); err != nil {
+	dst := dstRaw.(*infrav1beta1.DOMachine)
+	if err := Convert_v1alpha3_DOMachine_To_v1beta1_DOMachine(src, dst, nil); err != nil {
 		return err
 	}
 
 	// Manually restore data from annotations
-	restored := &infrav1alpha4.DOMachine{}
+	restored := &infrav1beta1.DOMachine{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
 
+	dst.Spec.VolumeRefs = restored.Spec.VolumeRefs
+	dst.Spec.ImageRef = restored.Spec.ImageRef
+	dst.Spec.FirewallRefs = restored.Spec.FirewallRefs
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
-// ConvertFrom converts from the Hub version (v1alpha4) to this version.
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *DOMachine) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*infrav1alpha4.DOMachine)
-	if err := Convert_v1alpha4_DOMachine_To_v1alpha3_DOMachine(src, dst, nil); err != nil {
+	src := srcRaw.(*infrav1beta1.DOMachine)
+	if err := Convert_v1beta1_DOMachine_To_v1alpha3_DOMachine(src, dst, nil); err != nil {
 		return err
 	}
 
@@ -53,14 +59,24 @@ func (dst *DOMachine) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 	return nil
 }
 
-// ConvertTo converts this DOMachineList to the Hub version (v1alpha4).
+// ConvertTo converts this DOMachineList to the Hub version (v1beta1).
 func (src *DOMachineList) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*infrav1alpha4.DOMachineList)
-	return Convert_v1alpha3_DOMachineList_To_v1alpha4_DOMachineList(src, dst, nil)
+	dst := dstRaw.(*infrav1beta1.DOMachineList)
+	return Convert_v1alpha3_DOMachineList_To_v1beta1_DOMachineList(src, dst, nil)
 }
 
-// ConvertFrom converts from the Hub version (v1alpha4) to this version.
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *DOMachineList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*infrav1alpha4.DOMachineList)
-	return Convert_v1alpha4_DOMachineList_To_v1alpha3_DOMachineList(src, dst, nil)
+	src := srcRaw.(*infrav1beta1.DOMachineList)
+	return Convert_v1beta1_DOMachineList_To_v1alpha3_DOMachineList(src, dst, nil)
+}
+
+// Convert_v1beta1_DOMachineStatus_To_v1alpha3_DOMachineStatus converts from the Hub version (v1beta1) of the DOMachineStatus to this version.
+func Convert_v1beta1_DOMachineStatus_To_v1alpha3_DOMachineStatus(in *infrav1beta1.DOMachineStatus, out *DOMachineStatus, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_DOMachineStatus_To_v1alpha3_DOMachineStatus(in, out, s)
+}
+
+// Convert_v1beta1_DOMachineSpec_To_v1alpha3_DOMachineSpec converts from the Hub version (v1beta1) of the DOMachineSpec to this version.
+func Convert_v1beta1_DOMachineSpec_To_v1alpha3_DOMachineSpec(in *infrav1beta1.DOMachineSpec, out *DOMachineSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_DOMachineSpec_To_v1alpha3_DOMachineSpec(in, out, s)
 }
@@ -17,31 +17,37 @@ limitations under the License.
 package v1alpha3
 
 import (
-	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
-// ConvertTo converts this DOMachineTemplate to the Hub version (v1alpha4).
+// ConvertTo converts this DOMachineTemplate to the Hub version (v1beta1).
 func (src *DOMachineTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*infrav1alpha4.DOMachineTemplate)
-	if err := Convert_v1alpha3_DOMachineTemplate_To_v1alpha4_DOMachineTemplate(src, dst, nil); err != nil {
+	dst := dstRaw.(*infrav1beta1.DOMachineTemplate)
+	if err := Convert_v1alpha3_DOMachineTemplate_To_v1beta1_DOMachineTemplate(src, dst, nil); err != nil {
 		return err
 	}
 
 	// Manually restore data from annotations
-	restored := &infrav1alpha4.DOMachineTemplate{}
+	restored := &infrav1beta1.DOMachineTemplate{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
 
+	dst.Spec.Template.Spec.VolumeRefs = restored.Spec.Template.Spec.VolumeRefs
+	dst.Spec.Template.Spec.ImageRef = restored.Spec.Template.Spec.ImageRef
+	dst.Spec.Template.Spec.FirewallRefs = restored.Spec.Template.Spec.FirewallRefs
+	dst.Status = restored.Status
+
 	return nil
 }
 
-// ConvertFrom converts from the Hub version (v1alpha4) to this version.
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *DOMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*infrav1alpha4.DOMachineTemplate)
-	if err := Convert_v1alpha4_DOMachineTemplate_To_v1alpha3_DOMachineTemplate(src, dst, nil); err != nil {
+	src := srcRaw.(*infrav1beta1.DOMachineTemplate)
+	if err := Convert_v1beta1_DOMachineTemplate_To_v1alpha3_DOMachineTemplate(src, dst, nil); err != nil {
 		return err
 	}
 
@@ -53,14 +59,19 @@ func (dst *DOMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error { // noli
 	return nil
 }
 
-// ConvertTo converts this DOMachineTemplateList to the Hub version (v1alpha4).
+// ConvertTo converts this DOMachineTemplateList to the Hub version (v1beta1).
 func (src *DOMachineTemplateList) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*infrav1alpha4.DOMachineTemplateList)
-	return Convert_v1alpha3_DOMachineTemplateList_To_v1alpha4_DOMachineTemplateList(src, dst, nil)
+	dst := dstRaw.(*infrav1beta1.DOMachineTemplateList)
+	return Convert_v1alpha3_DOMachineTemplateList_To_v1beta1_DOMachineTemplateList(src, dst, nil)
 }
 
-// ConvertFrom converts from the Hub version (v1alpha4) to this version.
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *DOMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*infrav1alpha4.DOMachineTemplateList)
-	return Convert_v1alpha4_DOMachineTemplateList_To_v1alpha3_DOMachineTemplateList(src, dst, nil)
+	src := srcRaw.(*infrav1beta1.DOMachineTemplateList)
+	return Convert_v1beta1_DOMachineTemplateList_To_v1alpha3_DOMachineTemplateList(src, dst, nil)
+}
+
+// Convert_v1beta1_DOMachineTemplate_To_v1alpha3_DOMachineTemplate converts from the Hub version (v1beta1) of the DOMachineTemplate to this version.
+func Convert_v1beta1_DOMachineTemplate_To_v1alpha3_DOMachineTemplate(in *infrav1beta1.DOMachineTemplate, out *DOMachineTemplate, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_DOMachineTemplate_To_v1alpha3_DOMachineTemplate(in, out, s)
 }
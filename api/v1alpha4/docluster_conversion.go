@@ -16,8 +16,59 @@ limitations under the License.
 
 package v1alpha4
 
-// Hub marks DOCluster as a conversion hub.
-func (*DOCluster) Hub() {}
+import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
 
-// Hub marks DOClusterList as a conversion hub.
-func (*DOClusterList) Hub() {}
+// ConvertTo converts this DOCluster to the Hub version (v1beta1).
+func (src *DOCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1beta1.DOCluster)
+	if err := Convert_v1alpha4_DOCluster_To_v1beta1_DOCluster(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data from annotations
+	restored := &infrav1beta1.DOCluster{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Status.FailureDomains = restored.Status.FailureDomains
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DOCluster) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1beta1.DOCluster)
+	if err := Convert_v1beta1_DOCluster_To_v1alpha4_DOCluster(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConvertTo converts this DOClusterList to the Hub version (v1beta1).
+func (src *DOClusterList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1beta1.DOClusterList)
+	return Convert_v1alpha4_DOClusterList_To_v1beta1_DOClusterList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DOClusterList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1beta1.DOClusterList)
+	return Convert_v1beta1_DOClusterList_To_v1alpha4_DOClusterList(src, dst, nil)
+}
+
+// Convert_v1beta1_DOClusterStatus_To_v1alpha4_DOClusterStatus converts from the Hub version (v1beta1) of the DOClusterStatus to this version.
+func Convert_v1beta1_DOClusterStatus_To_v1alpha4_DOClusterStatus(in *infrav1beta1.DOClusterStatus, out *DOClusterStatus, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_DOClusterStatus_To_v1alpha4_DOClusterStatus(in, out, s)
+}
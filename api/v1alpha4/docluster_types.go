@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha4
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 )
@@ -32,6 +33,8 @@ type DOClusterSpec struct {
 	// The DigitalOcean Region the cluster lives in. It must be one of available
 	// region on DigitalOcean. See
 	// https://developers.digitalocean.com/documentation/v2/#list-all-regions
+	// Defaults to the --default-region of the manager.
+	// +optional
 	Region string `json:"region"`
 	// Network configurations
 	// +optional
@@ -45,6 +48,33 @@ type DOClusterSpec struct {
 	// IP used for the ControlPlaneEndpoint.
 	// +optional
 	ControlPlaneDNS *DOControlPlaneDNS `json:"controlPlaneDNS,omitempty"`
+	// FirewallRefs are DOFirewalls, in the namespace of the DOCluster, applied
+	// to all the droplets of the cluster.
+	// +optional
+	FirewallRefs []corev1.LocalObjectReference `json:"firewallRefs,omitempty"`
+	// Addons are DigitalOcean components installed in the workload cluster.
+	// +optional
+	Addons *DOClusterAddons `json:"addons,omitempty"`
+}
+
+// DOClusterAddons are the DigitalOcean components installed in the workload
+// cluster by a ClusterResourceSet, which requires the ClusterResourceSet
+// feature of Cluster API to be enabled.
+type DOClusterAddons struct {
+	// CloudControllerManager installs the DigitalOcean cloud controller
+	// manager, without which the nodes of clusters using the external cloud
+	// provider stay NotReady.
+	// +optional
+	CloudControllerManager bool `json:"cloudControllerManager,omitempty"`
+	// CSIDriver installs the DigitalOcean CSI driver and its default
+	// do-block-storage StorageClass.
+	// +optional
+	CSIDriver bool `json:"csiDriver,omitempty"`
+	// TokenSecretRef is a Secret, in the namespace of the DOCluster, whose
+	// access-token key holds the DigitalOcean token of the addons. Required as
+	// soon as an addon is enabled, the token of the manager is never used.
+	// +optional
+	TokenSecretRef *corev1.LocalObjectReference `json:"tokenSecretRef,omitempty"`
 }
 
 // DOClusterStatus defines the observed state of DOCluster.
@@ -59,11 +89,13 @@ type DOClusterStatus struct {
 	// Network encapsulates all things related to DigitalOcean network.
 	// +optional
 	Network DONetworkResource `json:"network,omitempty"`
+	// Conditions defines current service state of the DOCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=doclusters,scope=Namespaced,categories=cluster-api
-// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this DOCluster belongs"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Cluster infrastructure is ready for DigitalOcean droplet instances"
@@ -78,6 +110,16 @@ type DOCluster struct {
 	Status DOClusterStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the observations of the operational state of the DOCluster resource.
+func (r *DOCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOCluster to the predescribed clusterv1.Conditions.
+func (r *DOCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // DOClusterList contains a list of DOCluster.
@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this DOFirewall to the Hub version (v1beta1).
+func (src *DOFirewall) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1beta1.DOFirewall)
+	return Convert_v1alpha4_DOFirewall_To_v1beta1_DOFirewall(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DOFirewall) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1beta1.DOFirewall)
+	return Convert_v1beta1_DOFirewall_To_v1alpha4_DOFirewall(src, dst, nil)
+}
+
+// ConvertTo converts this DOFirewallList to the Hub version (v1beta1).
+func (src *DOFirewallList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1beta1.DOFirewallList)
+	return Convert_v1alpha4_DOFirewallList_To_v1beta1_DOFirewallList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DOFirewallList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1beta1.DOFirewallList)
+	return Convert_v1beta1_DOFirewallList_To_v1alpha4_DOFirewallList(src, dst, nil)
+}
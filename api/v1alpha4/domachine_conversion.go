@@ -16,8 +16,31 @@ limitations under the License.
 
 package v1alpha4
 
-// Hub marks DOMachine as a conversion hub.
-func (*DOMachine) Hub() {}
+import (
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
 
-// Hub marks DOMachineList as a conversion hub.
-func (*DOMachineList) Hub() {}
+// ConvertTo converts this DOMachine to the Hub version (v1beta1).
+func (src *DOMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1beta1.DOMachine)
+	return Convert_v1alpha4_DOMachine_To_v1beta1_DOMachine(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DOMachine) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1beta1.DOMachine)
+	return Convert_v1beta1_DOMachine_To_v1alpha4_DOMachine(src, dst, nil)
+}
+
+// ConvertTo converts this DOMachineList to the Hub version (v1beta1).
+func (src *DOMachineList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1beta1.DOMachineList)
+	return Convert_v1alpha4_DOMachineList_To_v1beta1_DOMachineList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *DOMachineList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1beta1.DOMachineList)
+	return Convert_v1beta1_DOMachineList_To_v1alpha4_DOMachineList(src, dst, nil)
+}
@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
@@ -35,11 +36,28 @@ type DOMachineSpec struct {
 	// +optional
 	ProviderID *string `json:"providerID,omitempty"`
 	// Droplet size. It must be known DigitalOcean droplet size. See https://developers.digitalocean.com/documentation/v2/#list-all-sizes
+	// Defaults to the --default-machine-size of the manager.
+	// +optional
 	Size string `json:"size"`
 	// Droplet image can be image id or slug. See https://developers.digitalocean.com/documentation/v2/#list-all-images
+	// Either Image or ImageRef must be set.
+	// +optional
 	Image intstr.IntOrString `json:"image"`
+	// ImageRef is a DOImage, in the namespace of the DOMachine, whose image is
+	// used instead of Image.
+	// +optional
+	ImageRef *corev1.LocalObjectReference `json:"imageRef,omitempty"`
 	// DataDisks specifies the parameters that are used to add one or more data disks to the machine
 	DataDisks []DataDisk `json:"dataDisks,omitempty"`
+	// VolumeRefs are DOVolumes, in the namespace of the DOMachine, attached to
+	// the droplet when it is created. Unlike data disks they are not deleted
+	// with the droplet.
+	// +optional
+	VolumeRefs []corev1.LocalObjectReference `json:"volumeRefs,omitempty"`
+	// FirewallRefs are DOFirewalls, in the namespace of the DOMachine,
+	// applied to its droplet.
+	// +optional
+	FirewallRefs []corev1.LocalObjectReference `json:"firewallRefs,omitempty"`
 	// SSHKeys is the ssh key id or fingerprint to attach in DigitalOcean droplet.
 	// It must be available on DigitalOcean account. See https://developers.digitalocean.com/documentation/v2/#list-all-keys
 	SSHKeys []intstr.IntOrString `json:"sshKeys"`
@@ -98,11 +116,14 @@ type DOMachineStatus struct {
 	// controller's output.
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the DOMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=domachines,scope=Namespaced,categories=cluster-api
-// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this DOMachine belongs"
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.instanceStatus",description="DigitalOcean droplet instance state"
@@ -119,6 +140,16 @@ type DOMachine struct {
 	Status DOMachineStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the observations of the operational state of the DOMachine resource.
+func (r *DOMachine) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOMachine to the predescribed clusterv1.Conditions.
+func (r *DOMachine) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // DOMachineList contains a list of DOMachine.
@@ -1,97 +0,0 @@
-/*
-Copyright 2021 The Kubernetes Authors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-package v1alpha4
-
-import (
-	"reflect"
-
-	"github.com/pkg/errors"
-
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	runtime "k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/validation/field"
-
-	ctrl "sigs.k8s.io/controller-runtime"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
-)
-
-// log is for logging in this package.
-var _ = logf.Log.WithName("domachine-resource")
-
-// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1alpha4-domachine,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachines,versions=v1alpha4,name=validation.domachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
-// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha4-domachine,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachines,versions=v1alpha4,name=default.domachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
-
-var (
-	_ webhook.Defaulter = &DOMachine{}
-	_ webhook.Validator = &DOMachine{}
-)
-
-func (r *DOMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
-}
-
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *DOMachine) Default() {}
-
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (r *DOMachine) ValidateCreate() error {
-	return nil
-}
-
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *DOMachine) ValidateUpdate(old runtime.Object) error {
-	var allErrs field.ErrorList
-
-	newDOMachine, err := runtime.DefaultUnstructuredConverter.ToUnstructured(r)
-	if err != nil {
-		return apierrors.NewInternalError(errors.Wrap(err, "failed to convert new DOMachine to unstructured object"))
-	}
-
-	oldDOMachine, err := runtime.DefaultUnstructuredConverter.ToUnstructured(old)
-	if err != nil {
-		return apierrors.NewInternalError(errors.Wrap(err, "failed to convert old DOMachine to unstructured object"))
-	}
-
-	newDOMachineSpec := newDOMachine["spec"].(map[string]interface{})
-	oldDOMachineSpec := oldDOMachine["spec"].(map[string]interface{})
-
-	// allow changes to providerID
-	delete(oldDOMachineSpec, "providerID")
-	delete(newDOMachineSpec, "providerID")
-
-	// allow changes to additionalTags
-	delete(oldDOMachineSpec, "additionalTags")
-	delete(newDOMachineSpec, "additionalTags")
-
-	if !reflect.DeepEqual(oldDOMachineSpec, newDOMachineSpec) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "cannot be modified"))
-	}
-
-	if len(allErrs) == 0 {
-		return nil
-	}
-
-	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
-}
-
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *DOMachine) ValidateDelete() error {
-	return nil
-}
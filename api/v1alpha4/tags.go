@@ -18,6 +18,7 @@ package v1alpha4
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Tags defines a slice of tags.
@@ -45,12 +46,36 @@ func ClusterNameRoleTag(clusterName, role string) string {
 	return fmt.Sprintf("%s:%s:%s", NameDigitalOceanProviderPrefix, clusterName, role)
 }
 
+// ClusterNameUIDTag generates the tag with prefix `NameDigitalOceanProviderPrefix` unique to a cluster,
+// as opposed to the name tags shared by clusters with the same name in different namespaces.
+// It will generated tag like `sigs-k8s-io:capdo:{clusterName}:{UID}`.
+func ClusterNameUIDTag(clusterName, clusterUID string) string {
+	return fmt.Sprintf("%s:%s:%s", NameDigitalOceanProviderPrefix, clusterName, clusterUID)
+}
+
 // ClusterNameUIDRoleTag generates the tag with prefix `NameDigitalOceanProviderPrefix` and `RoleValue` as suffix
 // It will generated tag like `sigs-k8s-io:capdo:{clusterName}:{UID}:{role}`.
 func ClusterNameUIDRoleTag(clusterName, clusterUID, role string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", NameDigitalOceanProviderPrefix, clusterName, clusterUID, role)
 }
 
+// ClusterUIDFromTags returns the cluster UID of the ClusterNameUIDTag among
+// the tags of a resource, and false when the resource carries none.
+func ClusterUIDFromTags(tags []string) (string, bool) {
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, NameDigitalOceanProviderPrefix+":") {
+			continue
+		}
+		// {clusterName}:{UID} as opposed to {clusterName}, {clusterName}:{role}
+		// and {clusterName}:{UID}:{role}.
+		parts := strings.Split(strings.TrimPrefix(tag, NameDigitalOceanProviderPrefix+":"), ":")
+		if len(parts) == 2 && parts[1] != APIServerRoleTagValue && parts[1] != NodeRoleTagValue {
+			return parts[1], true
+		}
+	}
+	return "", false
+}
+
 // NameTagFromName returns DigitalOcean safe name tag from name.
 func NameTagFromName(name string) string {
 	return fmt.Sprintf("name:%s", DOSafeName(name))
@@ -75,6 +100,7 @@ type BuildTagParams struct {
 func BuildTags(params BuildTagParams) Tags {
 	var tags Tags
 	tags = append(tags, ClusterNameTag(params.ClusterName))
+	tags = append(tags, ClusterNameUIDTag(params.ClusterName, params.ClusterUID))
 	tags = append(tags, ClusterNameRoleTag(params.ClusterName, params.Role))
 	tags = append(tags, ClusterNameUIDRoleTag(params.ClusterName, params.ClusterUID, params.Role))
 	tags = append(tags, NameTagFromName(params.Name))
@@ -42,6 +42,7 @@ func TestBuildTags(t *testing.T) {
 			},
 			want: Tags{
 				ClusterNameTag("foo"),
+				ClusterNameUIDTag("foo", "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42"),
 				ClusterNameRoleTag("foo", APIServerRoleTagValue),
 				ClusterNameUIDRoleTag("foo", "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42", APIServerRoleTagValue),
 				NameTagFromName("bar"),
@@ -56,3 +57,39 @@ func TestBuildTags(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterUIDFromTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		want   string
+		wantOK bool
+	}{
+		{
+			name: "built tags",
+			tags: BuildTags(BuildTagParams{
+				ClusterName: "foo",
+				ClusterUID:  "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42",
+				Name:        "bar",
+				Role:        NodeRoleTagValue,
+			}),
+			want:   "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42",
+			wantOK: true,
+		},
+		{
+			name: "tags of a cluster without UID tag",
+			tags: []string{ClusterNameTag("foo"), ClusterNameRoleTag("foo", APIServerRoleTagValue), NameTagFromName("bar")},
+		},
+		{
+			name: "no tags",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ClusterUIDFromTags(tt.tags)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ClusterUIDFromTags() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
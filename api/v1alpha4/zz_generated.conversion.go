@@ -0,0 +1,1649 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by conversion-gen-v0.20.4. DO NOT EDIT.
+
+package v1alpha4
+
+import (
+	unsafe "unsafe"
+
+	v1 "k8s.io/api/core/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	v1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	apiv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	errors "sigs.k8s.io/cluster-api/errors"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*BuildTagParams)(nil), (*v1beta1.BuildTagParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_BuildTagParams_To_v1beta1_BuildTagParams(a.(*BuildTagParams), b.(*v1beta1.BuildTagParams), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.BuildTagParams)(nil), (*BuildTagParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BuildTagParams_To_v1alpha4_BuildTagParams(a.(*v1beta1.BuildTagParams), b.(*BuildTagParams), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOCluster)(nil), (*v1beta1.DOCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOCluster_To_v1beta1_DOCluster(a.(*DOCluster), b.(*v1beta1.DOCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOCluster)(nil), (*DOCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOCluster_To_v1alpha4_DOCluster(a.(*v1beta1.DOCluster), b.(*DOCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOClusterAddons)(nil), (*v1beta1.DOClusterAddons)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOClusterAddons_To_v1beta1_DOClusterAddons(a.(*DOClusterAddons), b.(*v1beta1.DOClusterAddons), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOClusterAddons)(nil), (*DOClusterAddons)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOClusterAddons_To_v1alpha4_DOClusterAddons(a.(*v1beta1.DOClusterAddons), b.(*DOClusterAddons), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOClusterList)(nil), (*v1beta1.DOClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOClusterList_To_v1beta1_DOClusterList(a.(*DOClusterList), b.(*v1beta1.DOClusterList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOClusterList)(nil), (*DOClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOClusterList_To_v1alpha4_DOClusterList(a.(*v1beta1.DOClusterList), b.(*DOClusterList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOClusterSpec)(nil), (*v1beta1.DOClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOClusterSpec_To_v1beta1_DOClusterSpec(a.(*DOClusterSpec), b.(*v1beta1.DOClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOClusterSpec)(nil), (*DOClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOClusterSpec_To_v1alpha4_DOClusterSpec(a.(*v1beta1.DOClusterSpec), b.(*DOClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOClusterStatus)(nil), (*v1beta1.DOClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOClusterStatus_To_v1beta1_DOClusterStatus(a.(*DOClusterStatus), b.(*v1beta1.DOClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOControlPlaneDNS)(nil), (*v1beta1.DOControlPlaneDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOControlPlaneDNS_To_v1beta1_DOControlPlaneDNS(a.(*DOControlPlaneDNS), b.(*v1beta1.DOControlPlaneDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOControlPlaneDNS)(nil), (*DOControlPlaneDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOControlPlaneDNS_To_v1alpha4_DOControlPlaneDNS(a.(*v1beta1.DOControlPlaneDNS), b.(*DOControlPlaneDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewall)(nil), (*v1beta1.DOFirewall)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewall_To_v1beta1_DOFirewall(a.(*DOFirewall), b.(*v1beta1.DOFirewall), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewall)(nil), (*DOFirewall)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewall_To_v1alpha4_DOFirewall(a.(*v1beta1.DOFirewall), b.(*DOFirewall), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewallEndpoints)(nil), (*v1beta1.DOFirewallEndpoints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints(a.(*DOFirewallEndpoints), b.(*v1beta1.DOFirewallEndpoints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewallEndpoints)(nil), (*DOFirewallEndpoints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints(a.(*v1beta1.DOFirewallEndpoints), b.(*DOFirewallEndpoints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewallInboundRule)(nil), (*v1beta1.DOFirewallInboundRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewallInboundRule_To_v1beta1_DOFirewallInboundRule(a.(*DOFirewallInboundRule), b.(*v1beta1.DOFirewallInboundRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewallInboundRule)(nil), (*DOFirewallInboundRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewallInboundRule_To_v1alpha4_DOFirewallInboundRule(a.(*v1beta1.DOFirewallInboundRule), b.(*DOFirewallInboundRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewallList)(nil), (*v1beta1.DOFirewallList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewallList_To_v1beta1_DOFirewallList(a.(*DOFirewallList), b.(*v1beta1.DOFirewallList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewallList)(nil), (*DOFirewallList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewallList_To_v1alpha4_DOFirewallList(a.(*v1beta1.DOFirewallList), b.(*DOFirewallList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewallOutboundRule)(nil), (*v1beta1.DOFirewallOutboundRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewallOutboundRule_To_v1beta1_DOFirewallOutboundRule(a.(*DOFirewallOutboundRule), b.(*v1beta1.DOFirewallOutboundRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewallOutboundRule)(nil), (*DOFirewallOutboundRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewallOutboundRule_To_v1alpha4_DOFirewallOutboundRule(a.(*v1beta1.DOFirewallOutboundRule), b.(*DOFirewallOutboundRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewallSpec)(nil), (*v1beta1.DOFirewallSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewallSpec_To_v1beta1_DOFirewallSpec(a.(*DOFirewallSpec), b.(*v1beta1.DOFirewallSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewallSpec)(nil), (*DOFirewallSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewallSpec_To_v1alpha4_DOFirewallSpec(a.(*v1beta1.DOFirewallSpec), b.(*DOFirewallSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOFirewallStatus)(nil), (*v1beta1.DOFirewallStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOFirewallStatus_To_v1beta1_DOFirewallStatus(a.(*DOFirewallStatus), b.(*v1beta1.DOFirewallStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOFirewallStatus)(nil), (*DOFirewallStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOFirewallStatus_To_v1alpha4_DOFirewallStatus(a.(*v1beta1.DOFirewallStatus), b.(*DOFirewallStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOImage)(nil), (*v1beta1.DOImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOImage_To_v1beta1_DOImage(a.(*DOImage), b.(*v1beta1.DOImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOImage)(nil), (*DOImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOImage_To_v1alpha4_DOImage(a.(*v1beta1.DOImage), b.(*DOImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOImageBuild)(nil), (*v1beta1.DOImageBuild)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOImageBuild_To_v1beta1_DOImageBuild(a.(*DOImageBuild), b.(*v1beta1.DOImageBuild), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOImageBuild)(nil), (*DOImageBuild)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOImageBuild_To_v1alpha4_DOImageBuild(a.(*v1beta1.DOImageBuild), b.(*DOImageBuild), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOImageList)(nil), (*v1beta1.DOImageList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOImageList_To_v1beta1_DOImageList(a.(*DOImageList), b.(*v1beta1.DOImageList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOImageList)(nil), (*DOImageList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOImageList_To_v1alpha4_DOImageList(a.(*v1beta1.DOImageList), b.(*DOImageList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOImageSpec)(nil), (*v1beta1.DOImageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOImageSpec_To_v1beta1_DOImageSpec(a.(*DOImageSpec), b.(*v1beta1.DOImageSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOImageSpec)(nil), (*DOImageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOImageSpec_To_v1alpha4_DOImageSpec(a.(*v1beta1.DOImageSpec), b.(*DOImageSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOImageStatus)(nil), (*v1beta1.DOImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOImageStatus_To_v1beta1_DOImageStatus(a.(*DOImageStatus), b.(*v1beta1.DOImageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOImageStatus)(nil), (*DOImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOImageStatus_To_v1alpha4_DOImageStatus(a.(*v1beta1.DOImageStatus), b.(*DOImageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOLoadBalancer)(nil), (*v1beta1.DOLoadBalancer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOLoadBalancer_To_v1beta1_DOLoadBalancer(a.(*DOLoadBalancer), b.(*v1beta1.DOLoadBalancer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOLoadBalancer)(nil), (*DOLoadBalancer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOLoadBalancer_To_v1alpha4_DOLoadBalancer(a.(*v1beta1.DOLoadBalancer), b.(*DOLoadBalancer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOLoadBalancerHealthCheck)(nil), (*v1beta1.DOLoadBalancerHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOLoadBalancerHealthCheck_To_v1beta1_DOLoadBalancerHealthCheck(a.(*DOLoadBalancerHealthCheck), b.(*v1beta1.DOLoadBalancerHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOLoadBalancerHealthCheck)(nil), (*DOLoadBalancerHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOLoadBalancerHealthCheck_To_v1alpha4_DOLoadBalancerHealthCheck(a.(*v1beta1.DOLoadBalancerHealthCheck), b.(*DOLoadBalancerHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachine)(nil), (*v1beta1.DOMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachine_To_v1beta1_DOMachine(a.(*DOMachine), b.(*v1beta1.DOMachine), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachine)(nil), (*DOMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachine_To_v1alpha4_DOMachine(a.(*v1beta1.DOMachine), b.(*DOMachine), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineList)(nil), (*v1beta1.DOMachineList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineList_To_v1beta1_DOMachineList(a.(*DOMachineList), b.(*v1beta1.DOMachineList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachineList)(nil), (*DOMachineList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineList_To_v1alpha4_DOMachineList(a.(*v1beta1.DOMachineList), b.(*DOMachineList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineSpec)(nil), (*v1beta1.DOMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec(a.(*DOMachineSpec), b.(*v1beta1.DOMachineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachineSpec)(nil), (*DOMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec(a.(*v1beta1.DOMachineSpec), b.(*DOMachineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineStatus)(nil), (*v1beta1.DOMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineStatus_To_v1beta1_DOMachineStatus(a.(*DOMachineStatus), b.(*v1beta1.DOMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachineStatus)(nil), (*DOMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineStatus_To_v1alpha4_DOMachineStatus(a.(*v1beta1.DOMachineStatus), b.(*DOMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineTemplate)(nil), (*v1beta1.DOMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineTemplate_To_v1beta1_DOMachineTemplate(a.(*DOMachineTemplate), b.(*v1beta1.DOMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineTemplateList)(nil), (*v1beta1.DOMachineTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineTemplateList_To_v1beta1_DOMachineTemplateList(a.(*DOMachineTemplateList), b.(*v1beta1.DOMachineTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachineTemplateList)(nil), (*DOMachineTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineTemplateList_To_v1alpha4_DOMachineTemplateList(a.(*v1beta1.DOMachineTemplateList), b.(*DOMachineTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineTemplateResource)(nil), (*v1beta1.DOMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineTemplateResource_To_v1beta1_DOMachineTemplateResource(a.(*DOMachineTemplateResource), b.(*v1beta1.DOMachineTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachineTemplateResource)(nil), (*DOMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineTemplateResource_To_v1alpha4_DOMachineTemplateResource(a.(*v1beta1.DOMachineTemplateResource), b.(*DOMachineTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOMachineTemplateSpec)(nil), (*v1beta1.DOMachineTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOMachineTemplateSpec_To_v1beta1_DOMachineTemplateSpec(a.(*DOMachineTemplateSpec), b.(*v1beta1.DOMachineTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOMachineTemplateSpec)(nil), (*DOMachineTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineTemplateSpec_To_v1alpha4_DOMachineTemplateSpec(a.(*v1beta1.DOMachineTemplateSpec), b.(*DOMachineTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DONetwork)(nil), (*v1beta1.DONetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DONetwork_To_v1beta1_DONetwork(a.(*DONetwork), b.(*v1beta1.DONetwork), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DONetwork)(nil), (*DONetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DONetwork_To_v1alpha4_DONetwork(a.(*v1beta1.DONetwork), b.(*DONetwork), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DONetworkResource)(nil), (*v1beta1.DONetworkResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DONetworkResource_To_v1beta1_DONetworkResource(a.(*DONetworkResource), b.(*v1beta1.DONetworkResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DONetworkResource)(nil), (*DONetworkResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DONetworkResource_To_v1alpha4_DONetworkResource(a.(*v1beta1.DONetworkResource), b.(*DONetworkResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOReservedIP)(nil), (*v1beta1.DOReservedIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOReservedIP_To_v1beta1_DOReservedIP(a.(*DOReservedIP), b.(*v1beta1.DOReservedIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOReservedIP)(nil), (*DOReservedIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOReservedIP_To_v1alpha4_DOReservedIP(a.(*v1beta1.DOReservedIP), b.(*DOReservedIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOReservedIPList)(nil), (*v1beta1.DOReservedIPList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOReservedIPList_To_v1beta1_DOReservedIPList(a.(*DOReservedIPList), b.(*v1beta1.DOReservedIPList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOReservedIPList)(nil), (*DOReservedIPList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOReservedIPList_To_v1alpha4_DOReservedIPList(a.(*v1beta1.DOReservedIPList), b.(*DOReservedIPList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOReservedIPSpec)(nil), (*v1beta1.DOReservedIPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOReservedIPSpec_To_v1beta1_DOReservedIPSpec(a.(*DOReservedIPSpec), b.(*v1beta1.DOReservedIPSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOReservedIPSpec)(nil), (*DOReservedIPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOReservedIPSpec_To_v1alpha4_DOReservedIPSpec(a.(*v1beta1.DOReservedIPSpec), b.(*DOReservedIPSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOReservedIPStatus)(nil), (*v1beta1.DOReservedIPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOReservedIPStatus_To_v1beta1_DOReservedIPStatus(a.(*DOReservedIPStatus), b.(*v1beta1.DOReservedIPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOReservedIPStatus)(nil), (*DOReservedIPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOReservedIPStatus_To_v1alpha4_DOReservedIPStatus(a.(*v1beta1.DOReservedIPStatus), b.(*DOReservedIPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOReservedIPTarget)(nil), (*v1beta1.DOReservedIPTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOReservedIPTarget_To_v1beta1_DOReservedIPTarget(a.(*DOReservedIPTarget), b.(*v1beta1.DOReservedIPTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOReservedIPTarget)(nil), (*DOReservedIPTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOReservedIPTarget_To_v1alpha4_DOReservedIPTarget(a.(*v1beta1.DOReservedIPTarget), b.(*DOReservedIPTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOResourceReference)(nil), (*v1beta1.DOResourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOResourceReference_To_v1beta1_DOResourceReference(a.(*DOResourceReference), b.(*v1beta1.DOResourceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOResourceReference)(nil), (*DOResourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOResourceReference_To_v1alpha4_DOResourceReference(a.(*v1beta1.DOResourceReference), b.(*DOResourceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOVPC)(nil), (*v1beta1.DOVPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOVPC_To_v1beta1_DOVPC(a.(*DOVPC), b.(*v1beta1.DOVPC), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOVPC)(nil), (*DOVPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOVPC_To_v1alpha4_DOVPC(a.(*v1beta1.DOVPC), b.(*DOVPC), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOVolume)(nil), (*v1beta1.DOVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOVolume_To_v1beta1_DOVolume(a.(*DOVolume), b.(*v1beta1.DOVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOVolume)(nil), (*DOVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOVolume_To_v1alpha4_DOVolume(a.(*v1beta1.DOVolume), b.(*DOVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOVolumeList)(nil), (*v1beta1.DOVolumeList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOVolumeList_To_v1beta1_DOVolumeList(a.(*DOVolumeList), b.(*v1beta1.DOVolumeList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOVolumeList)(nil), (*DOVolumeList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOVolumeList_To_v1alpha4_DOVolumeList(a.(*v1beta1.DOVolumeList), b.(*DOVolumeList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOVolumeSpec)(nil), (*v1beta1.DOVolumeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOVolumeSpec_To_v1beta1_DOVolumeSpec(a.(*DOVolumeSpec), b.(*v1beta1.DOVolumeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOVolumeSpec)(nil), (*DOVolumeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOVolumeSpec_To_v1alpha4_DOVolumeSpec(a.(*v1beta1.DOVolumeSpec), b.(*DOVolumeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DOVolumeStatus)(nil), (*v1beta1.DOVolumeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DOVolumeStatus_To_v1beta1_DOVolumeStatus(a.(*DOVolumeStatus), b.(*v1beta1.DOVolumeStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DOVolumeStatus)(nil), (*DOVolumeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOVolumeStatus_To_v1alpha4_DOVolumeStatus(a.(*v1beta1.DOVolumeStatus), b.(*DOVolumeStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DataDisk)(nil), (*v1beta1.DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(a.(*DataDisk), b.(*v1beta1.DataDisk), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DataDisk)(nil), (*DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(a.(*v1beta1.DataDisk), b.(*DataDisk), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.DOClusterStatus)(nil), (*DOClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOClusterStatus_To_v1alpha4_DOClusterStatus(a.(*v1beta1.DOClusterStatus), b.(*DOClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.DOMachineTemplate)(nil), (*DOMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DOMachineTemplate_To_v1alpha4_DOMachineTemplate(a.(*v1beta1.DOMachineTemplate), b.(*DOMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha4_BuildTagParams_To_v1beta1_BuildTagParams(in *BuildTagParams, out *v1beta1.BuildTagParams, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.ClusterUID = in.ClusterUID
+	out.Name = in.Name
+	out.Role = in.Role
+	out.Additional = *(*v1beta1.Tags)(unsafe.Pointer(&in.Additional))
+	return nil
+}
+
+// Convert_v1alpha4_BuildTagParams_To_v1beta1_BuildTagParams is an autogenerated conversion function.
+func Convert_v1alpha4_BuildTagParams_To_v1beta1_BuildTagParams(in *BuildTagParams, out *v1beta1.BuildTagParams, s conversion.Scope) error {
+	return autoConvert_v1alpha4_BuildTagParams_To_v1beta1_BuildTagParams(in, out, s)
+}
+
+func autoConvert_v1beta1_BuildTagParams_To_v1alpha4_BuildTagParams(in *v1beta1.BuildTagParams, out *BuildTagParams, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.ClusterUID = in.ClusterUID
+	out.Name = in.Name
+	out.Role = in.Role
+	out.Additional = *(*Tags)(unsafe.Pointer(&in.Additional))
+	return nil
+}
+
+// Convert_v1beta1_BuildTagParams_To_v1alpha4_BuildTagParams is an autogenerated conversion function.
+func Convert_v1beta1_BuildTagParams_To_v1alpha4_BuildTagParams(in *v1beta1.BuildTagParams, out *BuildTagParams, s conversion.Scope) error {
+	return autoConvert_v1beta1_BuildTagParams_To_v1alpha4_BuildTagParams(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOCluster_To_v1beta1_DOCluster(in *DOCluster, out *v1beta1.DOCluster, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOClusterSpec_To_v1beta1_DOClusterSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOClusterStatus_To_v1beta1_DOClusterStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOCluster_To_v1beta1_DOCluster is an autogenerated conversion function.
+func Convert_v1alpha4_DOCluster_To_v1beta1_DOCluster(in *DOCluster, out *v1beta1.DOCluster, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOCluster_To_v1beta1_DOCluster(in, out, s)
+}
+
+func autoConvert_v1beta1_DOCluster_To_v1alpha4_DOCluster(in *v1beta1.DOCluster, out *DOCluster, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOClusterSpec_To_v1alpha4_DOClusterSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOClusterStatus_To_v1alpha4_DOClusterStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOCluster_To_v1alpha4_DOCluster is an autogenerated conversion function.
+func Convert_v1beta1_DOCluster_To_v1alpha4_DOCluster(in *v1beta1.DOCluster, out *DOCluster, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOCluster_To_v1alpha4_DOCluster(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOClusterAddons_To_v1beta1_DOClusterAddons(in *DOClusterAddons, out *v1beta1.DOClusterAddons, s conversion.Scope) error {
+	out.CloudControllerManager = in.CloudControllerManager
+	out.CSIDriver = in.CSIDriver
+	out.TokenSecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.TokenSecretRef))
+	return nil
+}
+
+// Convert_v1alpha4_DOClusterAddons_To_v1beta1_DOClusterAddons is an autogenerated conversion function.
+func Convert_v1alpha4_DOClusterAddons_To_v1beta1_DOClusterAddons(in *DOClusterAddons, out *v1beta1.DOClusterAddons, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOClusterAddons_To_v1beta1_DOClusterAddons(in, out, s)
+}
+
+func autoConvert_v1beta1_DOClusterAddons_To_v1alpha4_DOClusterAddons(in *v1beta1.DOClusterAddons, out *DOClusterAddons, s conversion.Scope) error {
+	out.CloudControllerManager = in.CloudControllerManager
+	out.CSIDriver = in.CSIDriver
+	out.TokenSecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.TokenSecretRef))
+	return nil
+}
+
+// Convert_v1beta1_DOClusterAddons_To_v1alpha4_DOClusterAddons is an autogenerated conversion function.
+func Convert_v1beta1_DOClusterAddons_To_v1alpha4_DOClusterAddons(in *v1beta1.DOClusterAddons, out *DOClusterAddons, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOClusterAddons_To_v1alpha4_DOClusterAddons(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOClusterList_To_v1beta1_DOClusterList(in *DOClusterList, out *v1beta1.DOClusterList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.DOCluster, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_DOCluster_To_v1beta1_DOCluster(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOClusterList_To_v1beta1_DOClusterList is an autogenerated conversion function.
+func Convert_v1alpha4_DOClusterList_To_v1beta1_DOClusterList(in *DOClusterList, out *v1beta1.DOClusterList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOClusterList_To_v1beta1_DOClusterList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOClusterList_To_v1alpha4_DOClusterList(in *v1beta1.DOClusterList, out *DOClusterList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOCluster, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_DOCluster_To_v1alpha4_DOCluster(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOClusterList_To_v1alpha4_DOClusterList is an autogenerated conversion function.
+func Convert_v1beta1_DOClusterList_To_v1alpha4_DOClusterList(in *v1beta1.DOClusterList, out *DOClusterList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOClusterList_To_v1alpha4_DOClusterList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOClusterSpec_To_v1beta1_DOClusterSpec(in *DOClusterSpec, out *v1beta1.DOClusterSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	if err := Convert_v1alpha4_DONetwork_To_v1beta1_DONetwork(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	out.ControlPlaneDNS = (*v1beta1.DOControlPlaneDNS)(unsafe.Pointer(in.ControlPlaneDNS))
+	out.FirewallRefs = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.FirewallRefs))
+	out.Addons = (*v1beta1.DOClusterAddons)(unsafe.Pointer(in.Addons))
+	return nil
+}
+
+// Convert_v1alpha4_DOClusterSpec_To_v1beta1_DOClusterSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOClusterSpec_To_v1beta1_DOClusterSpec(in *DOClusterSpec, out *v1beta1.DOClusterSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOClusterSpec_To_v1beta1_DOClusterSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOClusterSpec_To_v1alpha4_DOClusterSpec(in *v1beta1.DOClusterSpec, out *DOClusterSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	if err := Convert_v1beta1_DONetwork_To_v1alpha4_DONetwork(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	out.ControlPlaneDNS = (*DOControlPlaneDNS)(unsafe.Pointer(in.ControlPlaneDNS))
+	out.FirewallRefs = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.FirewallRefs))
+	out.Addons = (*DOClusterAddons)(unsafe.Pointer(in.Addons))
+	return nil
+}
+
+// Convert_v1beta1_DOClusterSpec_To_v1alpha4_DOClusterSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOClusterSpec_To_v1alpha4_DOClusterSpec(in *v1beta1.DOClusterSpec, out *DOClusterSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOClusterSpec_To_v1alpha4_DOClusterSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOClusterStatus_To_v1beta1_DOClusterStatus(in *DOClusterStatus, out *v1beta1.DOClusterStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.ControlPlaneDNSRecordReady = in.ControlPlaneDNSRecordReady
+	if err := Convert_v1alpha4_DONetworkResource_To_v1beta1_DONetworkResource(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha4_DOClusterStatus_To_v1beta1_DOClusterStatus is an autogenerated conversion function.
+func Convert_v1alpha4_DOClusterStatus_To_v1beta1_DOClusterStatus(in *DOClusterStatus, out *v1beta1.DOClusterStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOClusterStatus_To_v1beta1_DOClusterStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_DOClusterStatus_To_v1alpha4_DOClusterStatus(in *v1beta1.DOClusterStatus, out *DOClusterStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.ControlPlaneDNSRecordReady = in.ControlPlaneDNSRecordReady
+	if err := Convert_v1beta1_DONetworkResource_To_v1alpha4_DONetworkResource(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	// WARNING: in.FailureDomains requires manual conversion: does not exist in peer-type
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+func autoConvert_v1alpha4_DOControlPlaneDNS_To_v1beta1_DOControlPlaneDNS(in *DOControlPlaneDNS, out *v1beta1.DOControlPlaneDNS, s conversion.Scope) error {
+	out.Domain = in.Domain
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha4_DOControlPlaneDNS_To_v1beta1_DOControlPlaneDNS is an autogenerated conversion function.
+func Convert_v1alpha4_DOControlPlaneDNS_To_v1beta1_DOControlPlaneDNS(in *DOControlPlaneDNS, out *v1beta1.DOControlPlaneDNS, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOControlPlaneDNS_To_v1beta1_DOControlPlaneDNS(in, out, s)
+}
+
+func autoConvert_v1beta1_DOControlPlaneDNS_To_v1alpha4_DOControlPlaneDNS(in *v1beta1.DOControlPlaneDNS, out *DOControlPlaneDNS, s conversion.Scope) error {
+	out.Domain = in.Domain
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_DOControlPlaneDNS_To_v1alpha4_DOControlPlaneDNS is an autogenerated conversion function.
+func Convert_v1beta1_DOControlPlaneDNS_To_v1alpha4_DOControlPlaneDNS(in *v1beta1.DOControlPlaneDNS, out *DOControlPlaneDNS, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOControlPlaneDNS_To_v1alpha4_DOControlPlaneDNS(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewall_To_v1beta1_DOFirewall(in *DOFirewall, out *v1beta1.DOFirewall, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOFirewallSpec_To_v1beta1_DOFirewallSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOFirewallStatus_To_v1beta1_DOFirewallStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewall_To_v1beta1_DOFirewall is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewall_To_v1beta1_DOFirewall(in *DOFirewall, out *v1beta1.DOFirewall, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewall_To_v1beta1_DOFirewall(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewall_To_v1alpha4_DOFirewall(in *v1beta1.DOFirewall, out *DOFirewall, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOFirewallSpec_To_v1alpha4_DOFirewallSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOFirewallStatus_To_v1alpha4_DOFirewallStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOFirewall_To_v1alpha4_DOFirewall is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewall_To_v1alpha4_DOFirewall(in *v1beta1.DOFirewall, out *DOFirewall, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewall_To_v1alpha4_DOFirewall(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints(in *DOFirewallEndpoints, out *v1beta1.DOFirewallEndpoints, s conversion.Scope) error {
+	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints(in *DOFirewallEndpoints, out *v1beta1.DOFirewallEndpoints, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints(in *v1beta1.DOFirewallEndpoints, out *DOFirewallEndpoints, s conversion.Scope) error {
+	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+// Convert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints(in *v1beta1.DOFirewallEndpoints, out *DOFirewallEndpoints, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewallInboundRule_To_v1beta1_DOFirewallInboundRule(in *DOFirewallInboundRule, out *v1beta1.DOFirewallInboundRule, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Ports = in.Ports
+	if err := Convert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints(&in.Sources, &out.Sources, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewallInboundRule_To_v1beta1_DOFirewallInboundRule is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewallInboundRule_To_v1beta1_DOFirewallInboundRule(in *DOFirewallInboundRule, out *v1beta1.DOFirewallInboundRule, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewallInboundRule_To_v1beta1_DOFirewallInboundRule(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewallInboundRule_To_v1alpha4_DOFirewallInboundRule(in *v1beta1.DOFirewallInboundRule, out *DOFirewallInboundRule, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Ports = in.Ports
+	if err := Convert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints(&in.Sources, &out.Sources, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOFirewallInboundRule_To_v1alpha4_DOFirewallInboundRule is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewallInboundRule_To_v1alpha4_DOFirewallInboundRule(in *v1beta1.DOFirewallInboundRule, out *DOFirewallInboundRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewallInboundRule_To_v1alpha4_DOFirewallInboundRule(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewallList_To_v1beta1_DOFirewallList(in *DOFirewallList, out *v1beta1.DOFirewallList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1beta1.DOFirewall)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewallList_To_v1beta1_DOFirewallList is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewallList_To_v1beta1_DOFirewallList(in *DOFirewallList, out *v1beta1.DOFirewallList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewallList_To_v1beta1_DOFirewallList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewallList_To_v1alpha4_DOFirewallList(in *v1beta1.DOFirewallList, out *DOFirewallList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DOFirewall)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_DOFirewallList_To_v1alpha4_DOFirewallList is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewallList_To_v1alpha4_DOFirewallList(in *v1beta1.DOFirewallList, out *DOFirewallList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewallList_To_v1alpha4_DOFirewallList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewallOutboundRule_To_v1beta1_DOFirewallOutboundRule(in *DOFirewallOutboundRule, out *v1beta1.DOFirewallOutboundRule, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Ports = in.Ports
+	if err := Convert_v1alpha4_DOFirewallEndpoints_To_v1beta1_DOFirewallEndpoints(&in.Destinations, &out.Destinations, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewallOutboundRule_To_v1beta1_DOFirewallOutboundRule is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewallOutboundRule_To_v1beta1_DOFirewallOutboundRule(in *DOFirewallOutboundRule, out *v1beta1.DOFirewallOutboundRule, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewallOutboundRule_To_v1beta1_DOFirewallOutboundRule(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewallOutboundRule_To_v1alpha4_DOFirewallOutboundRule(in *v1beta1.DOFirewallOutboundRule, out *DOFirewallOutboundRule, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Ports = in.Ports
+	if err := Convert_v1beta1_DOFirewallEndpoints_To_v1alpha4_DOFirewallEndpoints(&in.Destinations, &out.Destinations, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOFirewallOutboundRule_To_v1alpha4_DOFirewallOutboundRule is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewallOutboundRule_To_v1alpha4_DOFirewallOutboundRule(in *v1beta1.DOFirewallOutboundRule, out *DOFirewallOutboundRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewallOutboundRule_To_v1alpha4_DOFirewallOutboundRule(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewallSpec_To_v1beta1_DOFirewallSpec(in *DOFirewallSpec, out *v1beta1.DOFirewallSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.InboundRules = *(*[]v1beta1.DOFirewallInboundRule)(unsafe.Pointer(&in.InboundRules))
+	out.OutboundRules = *(*[]v1beta1.DOFirewallOutboundRule)(unsafe.Pointer(&in.OutboundRules))
+	out.DropletTags = *(*[]string)(unsafe.Pointer(&in.DropletTags))
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewallSpec_To_v1beta1_DOFirewallSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewallSpec_To_v1beta1_DOFirewallSpec(in *DOFirewallSpec, out *v1beta1.DOFirewallSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewallSpec_To_v1beta1_DOFirewallSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewallSpec_To_v1alpha4_DOFirewallSpec(in *v1beta1.DOFirewallSpec, out *DOFirewallSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.InboundRules = *(*[]DOFirewallInboundRule)(unsafe.Pointer(&in.InboundRules))
+	out.OutboundRules = *(*[]DOFirewallOutboundRule)(unsafe.Pointer(&in.OutboundRules))
+	out.DropletTags = *(*[]string)(unsafe.Pointer(&in.DropletTags))
+	return nil
+}
+
+// Convert_v1beta1_DOFirewallSpec_To_v1alpha4_DOFirewallSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewallSpec_To_v1alpha4_DOFirewallSpec(in *v1beta1.DOFirewallSpec, out *DOFirewallSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewallSpec_To_v1alpha4_DOFirewallSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOFirewallStatus_To_v1beta1_DOFirewallStatus(in *DOFirewallStatus, out *v1beta1.DOFirewallStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.FirewallID = in.FirewallID
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.DropletIDs = *(*[]int)(unsafe.Pointer(&in.DropletIDs))
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha4_DOFirewallStatus_To_v1beta1_DOFirewallStatus is an autogenerated conversion function.
+func Convert_v1alpha4_DOFirewallStatus_To_v1beta1_DOFirewallStatus(in *DOFirewallStatus, out *v1beta1.DOFirewallStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOFirewallStatus_To_v1beta1_DOFirewallStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_DOFirewallStatus_To_v1alpha4_DOFirewallStatus(in *v1beta1.DOFirewallStatus, out *DOFirewallStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.FirewallID = in.FirewallID
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.DropletIDs = *(*[]int)(unsafe.Pointer(&in.DropletIDs))
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1beta1_DOFirewallStatus_To_v1alpha4_DOFirewallStatus is an autogenerated conversion function.
+func Convert_v1beta1_DOFirewallStatus_To_v1alpha4_DOFirewallStatus(in *v1beta1.DOFirewallStatus, out *DOFirewallStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOFirewallStatus_To_v1alpha4_DOFirewallStatus(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOImage_To_v1beta1_DOImage(in *DOImage, out *v1beta1.DOImage, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOImageSpec_To_v1beta1_DOImageSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOImageStatus_To_v1beta1_DOImageStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOImage_To_v1beta1_DOImage is an autogenerated conversion function.
+func Convert_v1alpha4_DOImage_To_v1beta1_DOImage(in *DOImage, out *v1beta1.DOImage, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOImage_To_v1beta1_DOImage(in, out, s)
+}
+
+func autoConvert_v1beta1_DOImage_To_v1alpha4_DOImage(in *v1beta1.DOImage, out *DOImage, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOImageSpec_To_v1alpha4_DOImageSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOImageStatus_To_v1alpha4_DOImageStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOImage_To_v1alpha4_DOImage is an autogenerated conversion function.
+func Convert_v1beta1_DOImage_To_v1alpha4_DOImage(in *v1beta1.DOImage, out *DOImage, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOImage_To_v1alpha4_DOImage(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOImageBuild_To_v1beta1_DOImageBuild(in *DOImageBuild, out *v1beta1.DOImageBuild, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.Env = *(*[]v1.EnvVar)(unsafe.Pointer(&in.Env))
+	return nil
+}
+
+// Convert_v1alpha4_DOImageBuild_To_v1beta1_DOImageBuild is an autogenerated conversion function.
+func Convert_v1alpha4_DOImageBuild_To_v1beta1_DOImageBuild(in *DOImageBuild, out *v1beta1.DOImageBuild, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOImageBuild_To_v1beta1_DOImageBuild(in, out, s)
+}
+
+func autoConvert_v1beta1_DOImageBuild_To_v1alpha4_DOImageBuild(in *v1beta1.DOImageBuild, out *DOImageBuild, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.Env = *(*[]v1.EnvVar)(unsafe.Pointer(&in.Env))
+	return nil
+}
+
+// Convert_v1beta1_DOImageBuild_To_v1alpha4_DOImageBuild is an autogenerated conversion function.
+func Convert_v1beta1_DOImageBuild_To_v1alpha4_DOImageBuild(in *v1beta1.DOImageBuild, out *DOImageBuild, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOImageBuild_To_v1alpha4_DOImageBuild(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOImageList_To_v1beta1_DOImageList(in *DOImageList, out *v1beta1.DOImageList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1beta1.DOImage)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha4_DOImageList_To_v1beta1_DOImageList is an autogenerated conversion function.
+func Convert_v1alpha4_DOImageList_To_v1beta1_DOImageList(in *DOImageList, out *v1beta1.DOImageList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOImageList_To_v1beta1_DOImageList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOImageList_To_v1alpha4_DOImageList(in *v1beta1.DOImageList, out *DOImageList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DOImage)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_DOImageList_To_v1alpha4_DOImageList is an autogenerated conversion function.
+func Convert_v1beta1_DOImageList_To_v1alpha4_DOImageList(in *v1beta1.DOImageList, out *DOImageList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOImageList_To_v1alpha4_DOImageList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOImageSpec_To_v1beta1_DOImageSpec(in *DOImageSpec, out *v1beta1.DOImageSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.SnapshotTag = in.SnapshotTag
+	out.Build = (*v1beta1.DOImageBuild)(unsafe.Pointer(in.Build))
+	return nil
+}
+
+// Convert_v1alpha4_DOImageSpec_To_v1beta1_DOImageSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOImageSpec_To_v1beta1_DOImageSpec(in *DOImageSpec, out *v1beta1.DOImageSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOImageSpec_To_v1beta1_DOImageSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOImageSpec_To_v1alpha4_DOImageSpec(in *v1beta1.DOImageSpec, out *DOImageSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.SnapshotTag = in.SnapshotTag
+	out.Build = (*DOImageBuild)(unsafe.Pointer(in.Build))
+	return nil
+}
+
+// Convert_v1beta1_DOImageSpec_To_v1alpha4_DOImageSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOImageSpec_To_v1alpha4_DOImageSpec(in *v1beta1.DOImageSpec, out *DOImageSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOImageSpec_To_v1alpha4_DOImageSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOImageStatus_To_v1beta1_DOImageStatus(in *DOImageStatus, out *v1beta1.DOImageStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.ImageID = in.ImageID
+	out.ImageName = in.ImageName
+	out.Regions = *(*[]string)(unsafe.Pointer(&in.Regions))
+	out.BuildJobName = in.BuildJobName
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha4_DOImageStatus_To_v1beta1_DOImageStatus is an autogenerated conversion function.
+func Convert_v1alpha4_DOImageStatus_To_v1beta1_DOImageStatus(in *DOImageStatus, out *v1beta1.DOImageStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOImageStatus_To_v1beta1_DOImageStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_DOImageStatus_To_v1alpha4_DOImageStatus(in *v1beta1.DOImageStatus, out *DOImageStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.ImageID = in.ImageID
+	out.ImageName = in.ImageName
+	out.Regions = *(*[]string)(unsafe.Pointer(&in.Regions))
+	out.BuildJobName = in.BuildJobName
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1beta1_DOImageStatus_To_v1alpha4_DOImageStatus is an autogenerated conversion function.
+func Convert_v1beta1_DOImageStatus_To_v1alpha4_DOImageStatus(in *v1beta1.DOImageStatus, out *DOImageStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOImageStatus_To_v1alpha4_DOImageStatus(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOLoadBalancer_To_v1beta1_DOLoadBalancer(in *DOLoadBalancer, out *v1beta1.DOLoadBalancer, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Algorithm = in.Algorithm
+	if err := Convert_v1alpha4_DOLoadBalancerHealthCheck_To_v1beta1_DOLoadBalancerHealthCheck(&in.HealthCheck, &out.HealthCheck, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOLoadBalancer_To_v1beta1_DOLoadBalancer is an autogenerated conversion function.
+func Convert_v1alpha4_DOLoadBalancer_To_v1beta1_DOLoadBalancer(in *DOLoadBalancer, out *v1beta1.DOLoadBalancer, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOLoadBalancer_To_v1beta1_DOLoadBalancer(in, out, s)
+}
+
+func autoConvert_v1beta1_DOLoadBalancer_To_v1alpha4_DOLoadBalancer(in *v1beta1.DOLoadBalancer, out *DOLoadBalancer, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Algorithm = in.Algorithm
+	if err := Convert_v1beta1_DOLoadBalancerHealthCheck_To_v1alpha4_DOLoadBalancerHealthCheck(&in.HealthCheck, &out.HealthCheck, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOLoadBalancer_To_v1alpha4_DOLoadBalancer is an autogenerated conversion function.
+func Convert_v1beta1_DOLoadBalancer_To_v1alpha4_DOLoadBalancer(in *v1beta1.DOLoadBalancer, out *DOLoadBalancer, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOLoadBalancer_To_v1alpha4_DOLoadBalancer(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOLoadBalancerHealthCheck_To_v1beta1_DOLoadBalancerHealthCheck(in *DOLoadBalancerHealthCheck, out *v1beta1.DOLoadBalancerHealthCheck, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.Timeout = in.Timeout
+	out.UnhealthyThreshold = in.UnhealthyThreshold
+	out.HealthyThreshold = in.HealthyThreshold
+	return nil
+}
+
+// Convert_v1alpha4_DOLoadBalancerHealthCheck_To_v1beta1_DOLoadBalancerHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha4_DOLoadBalancerHealthCheck_To_v1beta1_DOLoadBalancerHealthCheck(in *DOLoadBalancerHealthCheck, out *v1beta1.DOLoadBalancerHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOLoadBalancerHealthCheck_To_v1beta1_DOLoadBalancerHealthCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_DOLoadBalancerHealthCheck_To_v1alpha4_DOLoadBalancerHealthCheck(in *v1beta1.DOLoadBalancerHealthCheck, out *DOLoadBalancerHealthCheck, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.Timeout = in.Timeout
+	out.UnhealthyThreshold = in.UnhealthyThreshold
+	out.HealthyThreshold = in.HealthyThreshold
+	return nil
+}
+
+// Convert_v1beta1_DOLoadBalancerHealthCheck_To_v1alpha4_DOLoadBalancerHealthCheck is an autogenerated conversion function.
+func Convert_v1beta1_DOLoadBalancerHealthCheck_To_v1alpha4_DOLoadBalancerHealthCheck(in *v1beta1.DOLoadBalancerHealthCheck, out *DOLoadBalancerHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOLoadBalancerHealthCheck_To_v1alpha4_DOLoadBalancerHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachine_To_v1beta1_DOMachine(in *DOMachine, out *v1beta1.DOMachine, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOMachineStatus_To_v1beta1_DOMachineStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOMachine_To_v1beta1_DOMachine is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachine_To_v1beta1_DOMachine(in *DOMachine, out *v1beta1.DOMachine, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachine_To_v1beta1_DOMachine(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachine_To_v1alpha4_DOMachine(in *v1beta1.DOMachine, out *DOMachine, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOMachineStatus_To_v1alpha4_DOMachineStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOMachine_To_v1alpha4_DOMachine is an autogenerated conversion function.
+func Convert_v1beta1_DOMachine_To_v1alpha4_DOMachine(in *v1beta1.DOMachine, out *DOMachine, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachine_To_v1alpha4_DOMachine(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachineList_To_v1beta1_DOMachineList(in *DOMachineList, out *v1beta1.DOMachineList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1beta1.DOMachine)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineList_To_v1beta1_DOMachineList is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineList_To_v1beta1_DOMachineList(in *DOMachineList, out *v1beta1.DOMachineList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineList_To_v1beta1_DOMachineList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineList_To_v1alpha4_DOMachineList(in *v1beta1.DOMachineList, out *DOMachineList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DOMachine)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_DOMachineList_To_v1alpha4_DOMachineList is an autogenerated conversion function.
+func Convert_v1beta1_DOMachineList_To_v1alpha4_DOMachineList(in *v1beta1.DOMachineList, out *DOMachineList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachineList_To_v1alpha4_DOMachineList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec(in *DOMachineSpec, out *v1beta1.DOMachineSpec, s conversion.Scope) error {
+	out.ProviderID = (*string)(unsafe.Pointer(in.ProviderID))
+	out.Size = in.Size
+	out.Image = in.Image
+	out.ImageRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.ImageRef))
+	out.DataDisks = *(*[]v1beta1.DataDisk)(unsafe.Pointer(&in.DataDisks))
+	out.VolumeRefs = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.VolumeRefs))
+	out.FirewallRefs = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.FirewallRefs))
+	out.SSHKeys = *(*[]intstr.IntOrString)(unsafe.Pointer(&in.SSHKeys))
+	out.AdditionalTags = *(*v1beta1.Tags)(unsafe.Pointer(&in.AdditionalTags))
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec(in *DOMachineSpec, out *v1beta1.DOMachineSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec(in *v1beta1.DOMachineSpec, out *DOMachineSpec, s conversion.Scope) error {
+	out.ProviderID = (*string)(unsafe.Pointer(in.ProviderID))
+	out.Size = in.Size
+	out.Image = in.Image
+	out.ImageRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.ImageRef))
+	out.DataDisks = *(*[]DataDisk)(unsafe.Pointer(&in.DataDisks))
+	out.VolumeRefs = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.VolumeRefs))
+	out.FirewallRefs = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.FirewallRefs))
+	out.SSHKeys = *(*[]intstr.IntOrString)(unsafe.Pointer(&in.SSHKeys))
+	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
+	return nil
+}
+
+// Convert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec(in *v1beta1.DOMachineSpec, out *DOMachineSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachineStatus_To_v1beta1_DOMachineStatus(in *DOMachineStatus, out *v1beta1.DOMachineStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
+	out.InstanceStatus = (*v1beta1.DOResourceStatus)(unsafe.Pointer(in.InstanceStatus))
+	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
+	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineStatus_To_v1beta1_DOMachineStatus is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineStatus_To_v1beta1_DOMachineStatus(in *DOMachineStatus, out *v1beta1.DOMachineStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineStatus_To_v1beta1_DOMachineStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineStatus_To_v1alpha4_DOMachineStatus(in *v1beta1.DOMachineStatus, out *DOMachineStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
+	out.InstanceStatus = (*DOResourceStatus)(unsafe.Pointer(in.InstanceStatus))
+	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
+	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1beta1_DOMachineStatus_To_v1alpha4_DOMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_DOMachineStatus_To_v1alpha4_DOMachineStatus(in *v1beta1.DOMachineStatus, out *DOMachineStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachineStatus_To_v1alpha4_DOMachineStatus(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachineTemplate_To_v1beta1_DOMachineTemplate(in *DOMachineTemplate, out *v1beta1.DOMachineTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOMachineTemplateSpec_To_v1beta1_DOMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineTemplate_To_v1beta1_DOMachineTemplate is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineTemplate_To_v1beta1_DOMachineTemplate(in *DOMachineTemplate, out *v1beta1.DOMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineTemplate_To_v1beta1_DOMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineTemplate_To_v1alpha4_DOMachineTemplate(in *v1beta1.DOMachineTemplate, out *DOMachineTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOMachineTemplateSpec_To_v1alpha4_DOMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	// WARNING: in.Status requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha4_DOMachineTemplateList_To_v1beta1_DOMachineTemplateList(in *DOMachineTemplateList, out *v1beta1.DOMachineTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.DOMachineTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_DOMachineTemplate_To_v1beta1_DOMachineTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineTemplateList_To_v1beta1_DOMachineTemplateList is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineTemplateList_To_v1beta1_DOMachineTemplateList(in *DOMachineTemplateList, out *v1beta1.DOMachineTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineTemplateList_To_v1beta1_DOMachineTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineTemplateList_To_v1alpha4_DOMachineTemplateList(in *v1beta1.DOMachineTemplateList, out *DOMachineTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOMachineTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_DOMachineTemplate_To_v1alpha4_DOMachineTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOMachineTemplateList_To_v1alpha4_DOMachineTemplateList is an autogenerated conversion function.
+func Convert_v1beta1_DOMachineTemplateList_To_v1alpha4_DOMachineTemplateList(in *v1beta1.DOMachineTemplateList, out *DOMachineTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachineTemplateList_To_v1alpha4_DOMachineTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachineTemplateResource_To_v1beta1_DOMachineTemplateResource(in *DOMachineTemplateResource, out *v1beta1.DOMachineTemplateResource, s conversion.Scope) error {
+	if err := Convert_v1alpha4_DOMachineSpec_To_v1beta1_DOMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineTemplateResource_To_v1beta1_DOMachineTemplateResource is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineTemplateResource_To_v1beta1_DOMachineTemplateResource(in *DOMachineTemplateResource, out *v1beta1.DOMachineTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineTemplateResource_To_v1beta1_DOMachineTemplateResource(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineTemplateResource_To_v1alpha4_DOMachineTemplateResource(in *v1beta1.DOMachineTemplateResource, out *DOMachineTemplateResource, s conversion.Scope) error {
+	if err := Convert_v1beta1_DOMachineSpec_To_v1alpha4_DOMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOMachineTemplateResource_To_v1alpha4_DOMachineTemplateResource is an autogenerated conversion function.
+func Convert_v1beta1_DOMachineTemplateResource_To_v1alpha4_DOMachineTemplateResource(in *v1beta1.DOMachineTemplateResource, out *DOMachineTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachineTemplateResource_To_v1alpha4_DOMachineTemplateResource(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOMachineTemplateSpec_To_v1beta1_DOMachineTemplateSpec(in *DOMachineTemplateSpec, out *v1beta1.DOMachineTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha4_DOMachineTemplateResource_To_v1beta1_DOMachineTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOMachineTemplateSpec_To_v1beta1_DOMachineTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOMachineTemplateSpec_To_v1beta1_DOMachineTemplateSpec(in *DOMachineTemplateSpec, out *v1beta1.DOMachineTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOMachineTemplateSpec_To_v1beta1_DOMachineTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOMachineTemplateSpec_To_v1alpha4_DOMachineTemplateSpec(in *v1beta1.DOMachineTemplateSpec, out *DOMachineTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_DOMachineTemplateResource_To_v1alpha4_DOMachineTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOMachineTemplateSpec_To_v1alpha4_DOMachineTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOMachineTemplateSpec_To_v1alpha4_DOMachineTemplateSpec(in *v1beta1.DOMachineTemplateSpec, out *DOMachineTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOMachineTemplateSpec_To_v1alpha4_DOMachineTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DONetwork_To_v1beta1_DONetwork(in *DONetwork, out *v1beta1.DONetwork, s conversion.Scope) error {
+	if err := Convert_v1alpha4_DOLoadBalancer_To_v1beta1_DOLoadBalancer(&in.APIServerLoadbalancers, &out.APIServerLoadbalancers, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOVPC_To_v1beta1_DOVPC(&in.VPC, &out.VPC, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DONetwork_To_v1beta1_DONetwork is an autogenerated conversion function.
+func Convert_v1alpha4_DONetwork_To_v1beta1_DONetwork(in *DONetwork, out *v1beta1.DONetwork, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DONetwork_To_v1beta1_DONetwork(in, out, s)
+}
+
+func autoConvert_v1beta1_DONetwork_To_v1alpha4_DONetwork(in *v1beta1.DONetwork, out *DONetwork, s conversion.Scope) error {
+	if err := Convert_v1beta1_DOLoadBalancer_To_v1alpha4_DOLoadBalancer(&in.APIServerLoadbalancers, &out.APIServerLoadbalancers, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOVPC_To_v1alpha4_DOVPC(&in.VPC, &out.VPC, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DONetwork_To_v1alpha4_DONetwork is an autogenerated conversion function.
+func Convert_v1beta1_DONetwork_To_v1alpha4_DONetwork(in *v1beta1.DONetwork, out *DONetwork, s conversion.Scope) error {
+	return autoConvert_v1beta1_DONetwork_To_v1alpha4_DONetwork(in, out, s)
+}
+
+func autoConvert_v1alpha4_DONetworkResource_To_v1beta1_DONetworkResource(in *DONetworkResource, out *v1beta1.DONetworkResource, s conversion.Scope) error {
+	if err := Convert_v1alpha4_DOResourceReference_To_v1beta1_DOResourceReference(&in.APIServerLoadbalancersRef, &out.APIServerLoadbalancersRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DONetworkResource_To_v1beta1_DONetworkResource is an autogenerated conversion function.
+func Convert_v1alpha4_DONetworkResource_To_v1beta1_DONetworkResource(in *DONetworkResource, out *v1beta1.DONetworkResource, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DONetworkResource_To_v1beta1_DONetworkResource(in, out, s)
+}
+
+func autoConvert_v1beta1_DONetworkResource_To_v1alpha4_DONetworkResource(in *v1beta1.DONetworkResource, out *DONetworkResource, s conversion.Scope) error {
+	if err := Convert_v1beta1_DOResourceReference_To_v1alpha4_DOResourceReference(&in.APIServerLoadbalancersRef, &out.APIServerLoadbalancersRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DONetworkResource_To_v1alpha4_DONetworkResource is an autogenerated conversion function.
+func Convert_v1beta1_DONetworkResource_To_v1alpha4_DONetworkResource(in *v1beta1.DONetworkResource, out *DONetworkResource, s conversion.Scope) error {
+	return autoConvert_v1beta1_DONetworkResource_To_v1alpha4_DONetworkResource(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOReservedIP_To_v1beta1_DOReservedIP(in *DOReservedIP, out *v1beta1.DOReservedIP, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOReservedIPSpec_To_v1beta1_DOReservedIPSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOReservedIPStatus_To_v1beta1_DOReservedIPStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOReservedIP_To_v1beta1_DOReservedIP is an autogenerated conversion function.
+func Convert_v1alpha4_DOReservedIP_To_v1beta1_DOReservedIP(in *DOReservedIP, out *v1beta1.DOReservedIP, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOReservedIP_To_v1beta1_DOReservedIP(in, out, s)
+}
+
+func autoConvert_v1beta1_DOReservedIP_To_v1alpha4_DOReservedIP(in *v1beta1.DOReservedIP, out *DOReservedIP, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOReservedIPSpec_To_v1alpha4_DOReservedIPSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOReservedIPStatus_To_v1alpha4_DOReservedIPStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOReservedIP_To_v1alpha4_DOReservedIP is an autogenerated conversion function.
+func Convert_v1beta1_DOReservedIP_To_v1alpha4_DOReservedIP(in *v1beta1.DOReservedIP, out *DOReservedIP, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOReservedIP_To_v1alpha4_DOReservedIP(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOReservedIPList_To_v1beta1_DOReservedIPList(in *DOReservedIPList, out *v1beta1.DOReservedIPList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1beta1.DOReservedIP)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha4_DOReservedIPList_To_v1beta1_DOReservedIPList is an autogenerated conversion function.
+func Convert_v1alpha4_DOReservedIPList_To_v1beta1_DOReservedIPList(in *DOReservedIPList, out *v1beta1.DOReservedIPList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOReservedIPList_To_v1beta1_DOReservedIPList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOReservedIPList_To_v1alpha4_DOReservedIPList(in *v1beta1.DOReservedIPList, out *DOReservedIPList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DOReservedIP)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_DOReservedIPList_To_v1alpha4_DOReservedIPList is an autogenerated conversion function.
+func Convert_v1beta1_DOReservedIPList_To_v1alpha4_DOReservedIPList(in *v1beta1.DOReservedIPList, out *DOReservedIPList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOReservedIPList_To_v1alpha4_DOReservedIPList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOReservedIPSpec_To_v1beta1_DOReservedIPSpec(in *DOReservedIPSpec, out *v1beta1.DOReservedIPSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	out.Target = (*v1beta1.DOReservedIPTarget)(unsafe.Pointer(in.Target))
+	return nil
+}
+
+// Convert_v1alpha4_DOReservedIPSpec_To_v1beta1_DOReservedIPSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOReservedIPSpec_To_v1beta1_DOReservedIPSpec(in *DOReservedIPSpec, out *v1beta1.DOReservedIPSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOReservedIPSpec_To_v1beta1_DOReservedIPSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOReservedIPSpec_To_v1alpha4_DOReservedIPSpec(in *v1beta1.DOReservedIPSpec, out *DOReservedIPSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	out.Target = (*DOReservedIPTarget)(unsafe.Pointer(in.Target))
+	return nil
+}
+
+// Convert_v1beta1_DOReservedIPSpec_To_v1alpha4_DOReservedIPSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOReservedIPSpec_To_v1alpha4_DOReservedIPSpec(in *v1beta1.DOReservedIPSpec, out *DOReservedIPSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOReservedIPSpec_To_v1alpha4_DOReservedIPSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOReservedIPStatus_To_v1beta1_DOReservedIPStatus(in *DOReservedIPStatus, out *v1beta1.DOReservedIPStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.IP = in.IP
+	out.DropletID = in.DropletID
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha4_DOReservedIPStatus_To_v1beta1_DOReservedIPStatus is an autogenerated conversion function.
+func Convert_v1alpha4_DOReservedIPStatus_To_v1beta1_DOReservedIPStatus(in *DOReservedIPStatus, out *v1beta1.DOReservedIPStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOReservedIPStatus_To_v1beta1_DOReservedIPStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_DOReservedIPStatus_To_v1alpha4_DOReservedIPStatus(in *v1beta1.DOReservedIPStatus, out *DOReservedIPStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.IP = in.IP
+	out.DropletID = in.DropletID
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1beta1_DOReservedIPStatus_To_v1alpha4_DOReservedIPStatus is an autogenerated conversion function.
+func Convert_v1beta1_DOReservedIPStatus_To_v1alpha4_DOReservedIPStatus(in *v1beta1.DOReservedIPStatus, out *DOReservedIPStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOReservedIPStatus_To_v1alpha4_DOReservedIPStatus(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOReservedIPTarget_To_v1beta1_DOReservedIPTarget(in *DOReservedIPTarget, out *v1beta1.DOReservedIPTarget, s conversion.Scope) error {
+	out.MachineRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.MachineRef))
+	out.ControlPlaneRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.ControlPlaneRef))
+	return nil
+}
+
+// Convert_v1alpha4_DOReservedIPTarget_To_v1beta1_DOReservedIPTarget is an autogenerated conversion function.
+func Convert_v1alpha4_DOReservedIPTarget_To_v1beta1_DOReservedIPTarget(in *DOReservedIPTarget, out *v1beta1.DOReservedIPTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOReservedIPTarget_To_v1beta1_DOReservedIPTarget(in, out, s)
+}
+
+func autoConvert_v1beta1_DOReservedIPTarget_To_v1alpha4_DOReservedIPTarget(in *v1beta1.DOReservedIPTarget, out *DOReservedIPTarget, s conversion.Scope) error {
+	out.MachineRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.MachineRef))
+	out.ControlPlaneRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.ControlPlaneRef))
+	return nil
+}
+
+// Convert_v1beta1_DOReservedIPTarget_To_v1alpha4_DOReservedIPTarget is an autogenerated conversion function.
+func Convert_v1beta1_DOReservedIPTarget_To_v1alpha4_DOReservedIPTarget(in *v1beta1.DOReservedIPTarget, out *DOReservedIPTarget, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOReservedIPTarget_To_v1alpha4_DOReservedIPTarget(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOResourceReference_To_v1beta1_DOResourceReference(in *DOResourceReference, out *v1beta1.DOResourceReference, s conversion.Scope) error {
+	out.ResourceID = in.ResourceID
+	out.ResourceStatus = v1beta1.DOResourceStatus(in.ResourceStatus)
+	return nil
+}
+
+// Convert_v1alpha4_DOResourceReference_To_v1beta1_DOResourceReference is an autogenerated conversion function.
+func Convert_v1alpha4_DOResourceReference_To_v1beta1_DOResourceReference(in *DOResourceReference, out *v1beta1.DOResourceReference, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOResourceReference_To_v1beta1_DOResourceReference(in, out, s)
+}
+
+func autoConvert_v1beta1_DOResourceReference_To_v1alpha4_DOResourceReference(in *v1beta1.DOResourceReference, out *DOResourceReference, s conversion.Scope) error {
+	out.ResourceID = in.ResourceID
+	out.ResourceStatus = DOResourceStatus(in.ResourceStatus)
+	return nil
+}
+
+// Convert_v1beta1_DOResourceReference_To_v1alpha4_DOResourceReference is an autogenerated conversion function.
+func Convert_v1beta1_DOResourceReference_To_v1alpha4_DOResourceReference(in *v1beta1.DOResourceReference, out *DOResourceReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOResourceReference_To_v1alpha4_DOResourceReference(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOVPC_To_v1beta1_DOVPC(in *DOVPC, out *v1beta1.DOVPC, s conversion.Scope) error {
+	out.VPCUUID = in.VPCUUID
+	return nil
+}
+
+// Convert_v1alpha4_DOVPC_To_v1beta1_DOVPC is an autogenerated conversion function.
+func Convert_v1alpha4_DOVPC_To_v1beta1_DOVPC(in *DOVPC, out *v1beta1.DOVPC, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOVPC_To_v1beta1_DOVPC(in, out, s)
+}
+
+func autoConvert_v1beta1_DOVPC_To_v1alpha4_DOVPC(in *v1beta1.DOVPC, out *DOVPC, s conversion.Scope) error {
+	out.VPCUUID = in.VPCUUID
+	return nil
+}
+
+// Convert_v1beta1_DOVPC_To_v1alpha4_DOVPC is an autogenerated conversion function.
+func Convert_v1beta1_DOVPC_To_v1alpha4_DOVPC(in *v1beta1.DOVPC, out *DOVPC, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOVPC_To_v1alpha4_DOVPC(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOVolume_To_v1beta1_DOVolume(in *DOVolume, out *v1beta1.DOVolume, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha4_DOVolumeSpec_To_v1beta1_DOVolumeSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha4_DOVolumeStatus_To_v1beta1_DOVolumeStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha4_DOVolume_To_v1beta1_DOVolume is an autogenerated conversion function.
+func Convert_v1alpha4_DOVolume_To_v1beta1_DOVolume(in *DOVolume, out *v1beta1.DOVolume, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOVolume_To_v1beta1_DOVolume(in, out, s)
+}
+
+func autoConvert_v1beta1_DOVolume_To_v1alpha4_DOVolume(in *v1beta1.DOVolume, out *DOVolume, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DOVolumeSpec_To_v1alpha4_DOVolumeSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DOVolumeStatus_To_v1alpha4_DOVolumeStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DOVolume_To_v1alpha4_DOVolume is an autogenerated conversion function.
+func Convert_v1beta1_DOVolume_To_v1alpha4_DOVolume(in *v1beta1.DOVolume, out *DOVolume, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOVolume_To_v1alpha4_DOVolume(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOVolumeList_To_v1beta1_DOVolumeList(in *DOVolumeList, out *v1beta1.DOVolumeList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1beta1.DOVolume)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha4_DOVolumeList_To_v1beta1_DOVolumeList is an autogenerated conversion function.
+func Convert_v1alpha4_DOVolumeList_To_v1beta1_DOVolumeList(in *DOVolumeList, out *v1beta1.DOVolumeList, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOVolumeList_To_v1beta1_DOVolumeList(in, out, s)
+}
+
+func autoConvert_v1beta1_DOVolumeList_To_v1alpha4_DOVolumeList(in *v1beta1.DOVolumeList, out *DOVolumeList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DOVolume)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_DOVolumeList_To_v1alpha4_DOVolumeList is an autogenerated conversion function.
+func Convert_v1beta1_DOVolumeList_To_v1alpha4_DOVolumeList(in *v1beta1.DOVolumeList, out *DOVolumeList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOVolumeList_To_v1alpha4_DOVolumeList(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOVolumeSpec_To_v1beta1_DOVolumeSpec(in *DOVolumeSpec, out *v1beta1.DOVolumeSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	out.Name = in.Name
+	out.SizeGigaBytes = in.SizeGigaBytes
+	out.FilesystemType = in.FilesystemType
+	out.FilesystemLabel = in.FilesystemLabel
+	out.Tags = *(*v1beta1.Tags)(unsafe.Pointer(&in.Tags))
+	out.DeletionPolicy = v1beta1.VolumeDeletionPolicy(in.DeletionPolicy)
+	return nil
+}
+
+// Convert_v1alpha4_DOVolumeSpec_To_v1beta1_DOVolumeSpec is an autogenerated conversion function.
+func Convert_v1alpha4_DOVolumeSpec_To_v1beta1_DOVolumeSpec(in *DOVolumeSpec, out *v1beta1.DOVolumeSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOVolumeSpec_To_v1beta1_DOVolumeSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DOVolumeSpec_To_v1alpha4_DOVolumeSpec(in *v1beta1.DOVolumeSpec, out *DOVolumeSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	out.Name = in.Name
+	out.SizeGigaBytes = in.SizeGigaBytes
+	out.FilesystemType = in.FilesystemType
+	out.FilesystemLabel = in.FilesystemLabel
+	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	out.DeletionPolicy = VolumeDeletionPolicy(in.DeletionPolicy)
+	return nil
+}
+
+// Convert_v1beta1_DOVolumeSpec_To_v1alpha4_DOVolumeSpec is an autogenerated conversion function.
+func Convert_v1beta1_DOVolumeSpec_To_v1alpha4_DOVolumeSpec(in *v1beta1.DOVolumeSpec, out *DOVolumeSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOVolumeSpec_To_v1alpha4_DOVolumeSpec(in, out, s)
+}
+
+func autoConvert_v1alpha4_DOVolumeStatus_To_v1beta1_DOVolumeStatus(in *DOVolumeStatus, out *v1beta1.DOVolumeStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.VolumeID = in.VolumeID
+	out.SizeGigaBytes = in.SizeGigaBytes
+	out.DropletIDs = *(*[]int)(unsafe.Pointer(&in.DropletIDs))
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha4_DOVolumeStatus_To_v1beta1_DOVolumeStatus is an autogenerated conversion function.
+func Convert_v1alpha4_DOVolumeStatus_To_v1beta1_DOVolumeStatus(in *DOVolumeStatus, out *v1beta1.DOVolumeStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DOVolumeStatus_To_v1beta1_DOVolumeStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_DOVolumeStatus_To_v1alpha4_DOVolumeStatus(in *v1beta1.DOVolumeStatus, out *DOVolumeStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.VolumeID = in.VolumeID
+	out.SizeGigaBytes = in.SizeGigaBytes
+	out.DropletIDs = *(*[]int)(unsafe.Pointer(&in.DropletIDs))
+	out.Conditions = *(*apiv1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1beta1_DOVolumeStatus_To_v1alpha4_DOVolumeStatus is an autogenerated conversion function.
+func Convert_v1beta1_DOVolumeStatus_To_v1alpha4_DOVolumeStatus(in *v1beta1.DOVolumeStatus, out *DOVolumeStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_DOVolumeStatus_To_v1alpha4_DOVolumeStatus(in, out, s)
+}
+
+func autoConvert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.DataDisk, s conversion.Scope) error {
+	out.NameSuffix = in.NameSuffix
+	out.DiskSizeGB = in.DiskSizeGB
+	out.FilesystemType = in.FilesystemType
+	out.FilesystemLabel = in.FilesystemLabel
+	return nil
+}
+
+// Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk is an autogenerated conversion function.
+func Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.DataDisk, s conversion.Scope) error {
+	return autoConvert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in, out, s)
+}
+
+func autoConvert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *v1beta1.DataDisk, out *DataDisk, s conversion.Scope) error {
+	out.NameSuffix = in.NameSuffix
+	out.DiskSizeGB = in.DiskSizeGB
+	out.FilesystemType = in.FilesystemType
+	out.FilesystemLabel = in.FilesystemLabel
+	return nil
+}
+
+// Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk is an autogenerated conversion function.
+func Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *v1beta1.DataDisk, out *DataDisk, s conversion.Scope) error {
+	return autoConvert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in, out, s)
+}
@@ -22,8 +22,9 @@ package v1alpha4
 
 import (
 	"k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	apiv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
@@ -53,7 +54,7 @@ func (in *DOCluster) DeepCopyInto(out *DOCluster) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOCluster.
@@ -74,6 +75,26 @@ func (in *DOCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOClusterAddons) DeepCopyInto(out *DOClusterAddons) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOClusterAddons.
+func (in *DOClusterAddons) DeepCopy() *DOClusterAddons {
+	if in == nil {
+		return nil
+	}
+	out := new(DOClusterAddons)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DOClusterList) DeepCopyInto(out *DOClusterList) {
 	*out = *in
@@ -116,6 +137,16 @@ func (in *DOClusterSpec) DeepCopyInto(out *DOClusterSpec) {
 		*out = new(DOControlPlaneDNS)
 		**out = **in
 	}
+	if in.FirewallRefs != nil {
+		in, out := &in.FirewallRefs, &out.FirewallRefs
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(DOClusterAddons)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOClusterSpec.
@@ -132,6 +163,13 @@ func (in *DOClusterSpec) DeepCopy() *DOClusterSpec {
 func (in *DOClusterStatus) DeepCopyInto(out *DOClusterStatus) {
 	*out = *in
 	out.Network = in.Network
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1alpha4.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOClusterStatus.
@@ -159,6 +197,326 @@ func (in *DOControlPlaneDNS) DeepCopy() *DOControlPlaneDNS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewall) DeepCopyInto(out *DOFirewall) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewall.
+func (in *DOFirewall) DeepCopy() *DOFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOFirewall) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewallEndpoints) DeepCopyInto(out *DOFirewallEndpoints) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewallEndpoints.
+func (in *DOFirewallEndpoints) DeepCopy() *DOFirewallEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewallEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewallInboundRule) DeepCopyInto(out *DOFirewallInboundRule) {
+	*out = *in
+	in.Sources.DeepCopyInto(&out.Sources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewallInboundRule.
+func (in *DOFirewallInboundRule) DeepCopy() *DOFirewallInboundRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewallInboundRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewallList) DeepCopyInto(out *DOFirewallList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOFirewall, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewallList.
+func (in *DOFirewallList) DeepCopy() *DOFirewallList {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewallList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOFirewallList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewallOutboundRule) DeepCopyInto(out *DOFirewallOutboundRule) {
+	*out = *in
+	in.Destinations.DeepCopyInto(&out.Destinations)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewallOutboundRule.
+func (in *DOFirewallOutboundRule) DeepCopy() *DOFirewallOutboundRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewallOutboundRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewallSpec) DeepCopyInto(out *DOFirewallSpec) {
+	*out = *in
+	if in.InboundRules != nil {
+		in, out := &in.InboundRules, &out.InboundRules
+		*out = make([]DOFirewallInboundRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OutboundRules != nil {
+		in, out := &in.OutboundRules, &out.OutboundRules
+		*out = make([]DOFirewallOutboundRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DropletTags != nil {
+		in, out := &in.DropletTags, &out.DropletTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewallSpec.
+func (in *DOFirewallSpec) DeepCopy() *DOFirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOFirewallStatus) DeepCopyInto(out *DOFirewallStatus) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropletIDs != nil {
+		in, out := &in.DropletIDs, &out.DropletIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1alpha4.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOFirewallStatus.
+func (in *DOFirewallStatus) DeepCopy() *DOFirewallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DOFirewallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOImage) DeepCopyInto(out *DOImage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOImage.
+func (in *DOImage) DeepCopy() *DOImage {
+	if in == nil {
+		return nil
+	}
+	out := new(DOImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOImage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOImageBuild) DeepCopyInto(out *DOImageBuild) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOImageBuild.
+func (in *DOImageBuild) DeepCopy() *DOImageBuild {
+	if in == nil {
+		return nil
+	}
+	out := new(DOImageBuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOImageList) DeepCopyInto(out *DOImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOImageList.
+func (in *DOImageList) DeepCopy() *DOImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(DOImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOImageSpec) DeepCopyInto(out *DOImageSpec) {
+	*out = *in
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(DOImageBuild)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOImageSpec.
+func (in *DOImageSpec) DeepCopy() *DOImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DOImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOImageStatus) DeepCopyInto(out *DOImageStatus) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1alpha4.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOImageStatus.
+func (in *DOImageStatus) DeepCopy() *DOImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DOImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DOLoadBalancer) DeepCopyInto(out *DOLoadBalancer) {
 	*out = *in
@@ -258,11 +616,26 @@ func (in *DOMachineSpec) DeepCopyInto(out *DOMachineSpec) {
 		**out = **in
 	}
 	out.Image = in.Image
+	if in.ImageRef != nil {
+		in, out := &in.ImageRef, &out.ImageRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	if in.DataDisks != nil {
 		in, out := &in.DataDisks, &out.DataDisks
 		*out = make([]DataDisk, len(*in))
 		copy(*out, *in)
 	}
+	if in.VolumeRefs != nil {
+		in, out := &in.VolumeRefs, &out.VolumeRefs
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.FirewallRefs != nil {
+		in, out := &in.FirewallRefs, &out.FirewallRefs
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.SSHKeys != nil {
 		in, out := &in.SSHKeys, &out.SSHKeys
 		*out = make([]intstr.IntOrString, len(*in))
@@ -308,6 +681,13 @@ func (in *DOMachineStatus) DeepCopyInto(out *DOMachineStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1alpha4.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOMachineStatus.
@@ -443,6 +823,132 @@ func (in *DONetworkResource) DeepCopy() *DONetworkResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOReservedIP) DeepCopyInto(out *DOReservedIP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOReservedIP.
+func (in *DOReservedIP) DeepCopy() *DOReservedIP {
+	if in == nil {
+		return nil
+	}
+	out := new(DOReservedIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOReservedIP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOReservedIPList) DeepCopyInto(out *DOReservedIPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOReservedIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOReservedIPList.
+func (in *DOReservedIPList) DeepCopy() *DOReservedIPList {
+	if in == nil {
+		return nil
+	}
+	out := new(DOReservedIPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOReservedIPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOReservedIPSpec) DeepCopyInto(out *DOReservedIPSpec) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(DOReservedIPTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOReservedIPSpec.
+func (in *DOReservedIPSpec) DeepCopy() *DOReservedIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DOReservedIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOReservedIPStatus) DeepCopyInto(out *DOReservedIPStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1alpha4.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOReservedIPStatus.
+func (in *DOReservedIPStatus) DeepCopy() *DOReservedIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DOReservedIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOReservedIPTarget) DeepCopyInto(out *DOReservedIPTarget) {
+	*out = *in
+	if in.MachineRef != nil {
+		in, out := &in.MachineRef, &out.MachineRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ControlPlaneRef != nil {
+		in, out := &in.ControlPlaneRef, &out.ControlPlaneRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOReservedIPTarget.
+func (in *DOReservedIPTarget) DeepCopy() *DOReservedIPTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DOReservedIPTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DOResourceReference) DeepCopyInto(out *DOResourceReference) {
 	*out = *in
@@ -473,6 +979,112 @@ func (in *DOVPC) DeepCopy() *DOVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOVolume) DeepCopyInto(out *DOVolume) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOVolume.
+func (in *DOVolume) DeepCopy() *DOVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DOVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOVolume) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOVolumeList) DeepCopyInto(out *DOVolumeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOVolumeList.
+func (in *DOVolumeList) DeepCopy() *DOVolumeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DOVolumeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOVolumeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOVolumeSpec) DeepCopyInto(out *DOVolumeSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(Tags, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOVolumeSpec.
+func (in *DOVolumeSpec) DeepCopy() *DOVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DOVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOVolumeStatus) DeepCopyInto(out *DOVolumeStatus) {
+	*out = *in
+	if in.DropletIDs != nil {
+		in, out := &in.DropletIDs, &out.DropletIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1alpha4.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOVolumeStatus.
+func (in *DOVolumeStatus) DeepCopy() *DOVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DOVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 	*out = *in
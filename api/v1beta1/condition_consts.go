@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+
+const (
+	// DropletActionCondition reports on the last DigitalOcean action, e.g. a
+	// power off or a resize, submitted for the droplet of a DOMachine.
+	DropletActionCondition clusterv1.ConditionType = "DropletAction"
+
+	// ActionInProgressReason (Severity=Info) documents a DigitalOcean action
+	// that has been submitted and has not completed yet.
+	ActionInProgressReason = "ActionInProgress"
+	// ActionFailedReason (Severity=Error) documents a DigitalOcean action that
+	// completed with the errored status.
+	ActionFailedReason = "ActionFailed"
+	// ActionSubmitFailedReason (Severity=Warning) documents a DigitalOcean
+	// action that could not be submitted or polled.
+	ActionSubmitFailedReason = "ActionSubmitFailed"
+)
+
+const (
+	// DriftDetectedCondition reports on differences between the DigitalOcean
+	// resources of a DOCluster or DOMachine and their spec, e.g. after edits
+	// made in the DigitalOcean console. Unlike the other conditions it is True
+	// when something is wrong.
+	DriftDetectedCondition clusterv1.ConditionType = "DriftDetected"
+
+	// NoDriftReason (Severity=Info) documents resources matching their spec.
+	NoDriftReason = "NoDrift"
+	// DriftCorrectedReason (Severity=Info) documents drift that was found
+	// during the last check and reverted.
+	DriftCorrectedReason = "DriftCorrected"
+	// DriftNotCorrectableReason (Severity=Warning) documents drift that can not
+	// be reverted by the provider, e.g. a droplet resized in the console.
+	DriftNotCorrectableReason = "DriftNotCorrectable"
+)
+
+const (
+	// InstanceReadyCondition reports on the droplet of a DOMachine.
+	InstanceReadyCondition clusterv1.ConditionType = "InstanceReady"
+
+	// InstanceProvisioningReason (Severity=Info) documents a droplet that is
+	// being created.
+	InstanceProvisioningReason = "InstanceProvisioning"
+	// InstanceDeletedExternallyReason (Severity=Error) documents a droplet that
+	// was deleted outside of the provider, e.g. in the DigitalOcean console.
+	InstanceDeletedExternallyReason = "InstanceDeletedExternally"
+	// InstanceStateUnexpectedReason (Severity=Error) documents a droplet in a
+	// state it does not recover from on its own, e.g. powered off.
+	InstanceStateUnexpectedReason = "InstanceStateUnexpected"
+)
+
+const (
+	// LoadBalancerReadyCondition reports on the API server load balancer of a
+	// DOCluster.
+	LoadBalancerReadyCondition clusterv1.ConditionType = "LoadBalancerReady"
+
+	// LoadBalancerProvisioningReason (Severity=Info) documents a load balancer
+	// that is being created.
+	LoadBalancerProvisioningReason = "LoadBalancerProvisioning"
+	// LoadBalancerDegradedReason (Severity=Warning) documents a load balancer
+	// reported in the errored state by DigitalOcean.
+	LoadBalancerDegradedReason = "LoadBalancerDegraded"
+)
+
+const (
+	// ReservedIPAssignedCondition reports on the assignment of the reserved IP
+	// of a DOReservedIP to its target.
+	ReservedIPAssignedCondition clusterv1.ConditionType = "ReservedIPAssigned"
+
+	// WaitingForTargetReason (Severity=Info) documents a reserved IP whose
+	// target has no droplet yet, e.g. a DOMachine being provisioned.
+	WaitingForTargetReason = "WaitingForTarget"
+	// ReservedIPReleasedExternallyReason (Severity=Warning) documents a
+	// reserved IP that was released outside of the provider and allocated again.
+	ReservedIPReleasedExternallyReason = "ReservedIPReleasedExternally"
+)
+
+const (
+	// VolumeReadyCondition reports on the block storage volume of a DOVolume.
+	VolumeReadyCondition clusterv1.ConditionType = "VolumeReady"
+
+	// VolumeDeletedExternallyReason (Severity=Error) documents a volume that
+	// was deleted outside of the provider. It is not created again, as its
+	// data is lost.
+	VolumeDeletedExternallyReason = "VolumeDeletedExternally"
+	// VolumeResizingReason (Severity=Info) documents a volume being grown.
+	VolumeResizingReason = "VolumeResizing"
+	// WaitingForVolumeReason (Severity=Info) documents a DOMachine waiting for
+	// a DOVolume it references to be ready.
+	WaitingForVolumeReason = "WaitingForVolume"
+)
+
+const (
+	// ImageReadyCondition reports on the DigitalOcean image of a DOImage.
+	ImageReadyCondition clusterv1.ConditionType = "ImageReady"
+
+	// ImageNotFoundReason (Severity=Warning) documents a DOImage whose image
+	// or snapshot does not exist (yet). With Severity=Error, it documents a
+	// droplet whose image was rejected by the DigitalOcean API.
+	ImageNotFoundReason = "ImageNotFound"
+	// ImageBuildingReason (Severity=Info) documents a DOImage whose build Job
+	// is running.
+	ImageBuildingReason = "ImageBuilding"
+	// ImageBuildFailedReason (Severity=Error) documents a DOImage whose build
+	// Job failed.
+	ImageBuildFailedReason = "ImageBuildFailed"
+	// WaitingForImageReason (Severity=Info) documents a DOMachine waiting for
+	// the DOImage it references to be ready.
+	WaitingForImageReason = "WaitingForImage"
+)
+
+const (
+	// FirewallReadyCondition reports on the DigitalOcean firewall of a DOFirewall.
+	FirewallReadyCondition clusterv1.ConditionType = "FirewallReady"
+)
+
+// The reasons below document, on the ready condition of any object, a
+// DigitalOcean API error that retrying the same request does not fix.
+const (
+	// UnauthorizedReason (Severity=Error) documents a DigitalOcean token that is
+	// invalid, revoked or missing a scope.
+	UnauthorizedReason = "Unauthorized"
+	// QuotaExceededReason (Severity=Error) documents a request going over a
+	// limit of the DigitalOcean account, e.g. its droplet limit.
+	QuotaExceededReason = "QuotaExceeded"
+	// RegionOrSizeUnavailableReason (Severity=Error) documents a region or a
+	// size that is not available.
+	RegionOrSizeUnavailableReason = "RegionOrSizeUnavailable"
+	// InvalidRequestReason (Severity=Error) documents any other rejection of a
+	// request built from the spec.
+	InvalidRequestReason = "InvalidRequest"
+)
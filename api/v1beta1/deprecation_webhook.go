@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-docluster,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doclusters,versions=v1beta1,name=warning.docluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-domachine,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachines,versions=v1beta1,name=warning.domachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-domachinetemplate,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachinetemplates,versions=v1beta1,name=warning.domachinetemplate.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-doreservedip,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doreservedips,versions=v1beta1,name=warning.doreservedip.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-dovolume,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=dovolumes,versions=v1beta1,name=warning.dovolume.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-doimage,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doimages,versions=v1beta1,name=warning.doimage.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/warn-infrastructure-cluster-x-k8s-io-v1beta1-dofirewall,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=dofirewalls,versions=v1beta1,name=warning.dofirewall.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+// deprecatedFieldsWarner is implemented by the kinds having deprecated fields.
+type deprecatedFieldsWarner interface {
+	// deprecatedFieldsWarnings returns a warning for each deprecated field set.
+	deprecatedFieldsWarnings() []string
+}
+
+// registerDeprecationWebhook serves the webhook warning about the deprecated
+// API versions and fields used to create or update obj. It never rejects a
+// request, unlike the validation webhook.
+func registerDeprecationWebhook(mgr ctrl.Manager, obj runtime.Object, kind string) {
+	mgr.GetWebhookServer().Register(
+		"/warn-infrastructure-cluster-x-k8s-io-v1beta1-"+strings.ToLower(kind),
+		&webhook.Admission{Handler: &deprecationWarner{obj: obj}},
+	)
+}
+
+// deprecationWarner is the admission handler of the deprecation webhook.
+type deprecationWarner struct {
+	obj     runtime.Object
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &deprecationWarner{}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (w *deprecationWarner) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (w *deprecationWarner) Handle(_ context.Context, req admission.Request) admission.Response {
+	var warnings []string
+	// The request is converted to v1beta1 before reaching the webhook, the
+	// version used by the client is only known from the requested kind.
+	if kind := req.RequestKind; kind != nil && kind.Group == GroupVersion.Group && kind.Version != GroupVersion.Version {
+		warnings = append(warnings, fmt.Sprintf("%s/%s %s is deprecated; use %s %s", kind.Group, kind.Version, kind.Kind, GroupVersion, kind.Kind))
+	}
+
+	obj := w.obj.DeepCopyObject()
+	if err := w.decoder.Decode(req, obj); err == nil {
+		if d, ok := obj.(deprecatedFieldsWarner); ok {
+			warnings = append(warnings, d.deprecatedFieldsWarnings()...)
+		}
+	}
+
+	resp := admission.Allowed("")
+	resp.Warnings = warnings
+	return resp
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestDeprecationWarner(t *testing.T) {
+	testCases := []struct {
+		name         string
+		version      string
+		algorithm    string
+		wantWarnings []string
+	}{
+		{name: "current version", version: "v1beta1", algorithm: DefaultLBAlgorithm},
+		{
+			name:         "deprecated version",
+			version:      "v1alpha4",
+			algorithm:    DefaultLBAlgorithm,
+			wantWarnings: []string{"infrastructure.cluster.x-k8s.io/v1alpha4 DOCluster is deprecated; use infrastructure.cluster.x-k8s.io/v1beta1 DOCluster"},
+		},
+		{
+			name:         "deprecated field",
+			version:      "v1beta1",
+			algorithm:    "least_connections",
+			wantWarnings: []string{"spec.network.apiServerLoadbalancers.algorithm is deprecated and will be removed, DigitalOcean load balancers ignore it and always use round_robin; unset it"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			g.Expect(AddToScheme(scheme)).To(Succeed())
+			decoder, err := admission.NewDecoder(scheme)
+			g.Expect(err).NotTo(HaveOccurred())
+			w := &deprecationWarner{obj: &DOCluster{}}
+			g.Expect(w.InjectDecoder(decoder)).To(Succeed())
+
+			docluster := &DOCluster{
+				TypeMeta: metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "DOCluster"},
+				Spec:     DOClusterSpec{Region: "nyc1", Network: DONetwork{APIServerLoadbalancers: DOLoadBalancer{Algorithm: tc.algorithm}}},
+			}
+			raw, err := json.Marshal(docluster)
+			g.Expect(err).NotTo(HaveOccurred())
+			resp := w.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation:   admissionv1.Create,
+				RequestKind: &metav1.GroupVersionKind{Group: GroupVersion.Group, Version: tc.version, Kind: "DOCluster"},
+				Object:      runtime.RawExtension{Raw: raw},
+			}})
+
+			g.Expect(resp.Allowed).To(BeTrue())
+			g.Expect(resp.Warnings).To(Equal(tc.wantWarnings))
+		})
+	}
+}
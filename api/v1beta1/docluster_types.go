@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+)
+
+const (
+	// ClusterFinalizer allows ReconcileDOCluster to clean up DigitalOcean resources associated with DOCluster before
+	// removing it from the apiserver.
+	ClusterFinalizer = "docluster.infrastructure.cluster.x-k8s.io"
+)
+
+// DOClusterSpec defines the desired state of DOCluster.
+type DOClusterSpec struct {
+	// The DigitalOcean Region the cluster lives in. It must be one of available
+	// region on DigitalOcean. See
+	// https://developers.digitalocean.com/documentation/v2/#list-all-regions
+	// Defaults to the --default-region of the manager.
+	// +optional
+	Region string `json:"region"`
+	// Network configurations
+	// +optional
+	Network DONetwork `json:"network,omitempty"`
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the
+	// control plane. If ControlPlaneDNS is unset, the DO load-balancer IP
+	// of the Kubernetes API Server is used.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+	// ControlPlaneDNS is a managed DNS name that points to the load-balancer
+	// IP used for the ControlPlaneEndpoint.
+	// +optional
+	ControlPlaneDNS *DOControlPlaneDNS `json:"controlPlaneDNS,omitempty"`
+	// FirewallRefs are DOFirewalls, in the namespace of the DOCluster, applied
+	// to all the droplets of the cluster.
+	// +optional
+	FirewallRefs []corev1.LocalObjectReference `json:"firewallRefs,omitempty"`
+	// Addons are DigitalOcean components installed in the workload cluster.
+	// +optional
+	Addons *DOClusterAddons `json:"addons,omitempty"`
+}
+
+// DOClusterAddons are the DigitalOcean components installed in the workload
+// cluster by a ClusterResourceSet, which requires the ClusterResourceSet
+// feature of Cluster API to be enabled.
+type DOClusterAddons struct {
+	// CloudControllerManager installs the DigitalOcean cloud controller
+	// manager, without which the nodes of clusters using the external cloud
+	// provider stay NotReady.
+	// +optional
+	CloudControllerManager bool `json:"cloudControllerManager,omitempty"`
+	// CSIDriver installs the DigitalOcean CSI driver and its default
+	// do-block-storage StorageClass.
+	// +optional
+	CSIDriver bool `json:"csiDriver,omitempty"`
+	// TokenSecretRef is a Secret, in the namespace of the DOCluster, whose
+	// access-token key holds the DigitalOcean token of the addons. Required as
+	// soon as an addon is enabled, the token of the manager is never used.
+	// +optional
+	TokenSecretRef *corev1.LocalObjectReference `json:"tokenSecretRef,omitempty"`
+}
+
+// DOClusterStatus defines the observed state of DOCluster.
+type DOClusterStatus struct {
+	// Ready denotes that the cluster (infrastructure) is ready.
+	// +optional
+	Ready bool `json:"ready"`
+	// ControlPlaneDNSRecordReady denotes that the DNS record is ready and
+	// propagated to the DO DNS servers.
+	// +optional
+	ControlPlaneDNSRecordReady bool `json:"controlPlaneDNSRecordReady,omitempty"`
+	// Network encapsulates all things related to DigitalOcean network.
+	// +optional
+	Network DONetworkResource `json:"network,omitempty"`
+	// FailureDomains are the failure domains machines of the cluster may be
+	// spread across. DigitalOcean regions have no zones, so it is the region of
+	// the cluster.
+	// +optional
+	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
+	// Conditions defines current service state of the DOCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=doclusters,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this DOCluster belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Cluster infrastructure is ready for DigitalOcean droplet instances"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region",description="DigitalOcean region of the cluster"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.controlPlaneEndpoint.host",description="API Endpoint"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of DOCluster"
+
+// DOCluster is the Schema for the DOClusters API.
+type DOCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOClusterSpec   `json:"spec,omitempty"`
+	Status DOClusterStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the DOCluster resource.
+func (r *DOCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOCluster to the predescribed clusterv1.Conditions.
+func (r *DOCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// DOClusterList contains a list of DOCluster.
+type DOClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DOCluster{}, &DOClusterList{})
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var _ = logf.Log.WithName("docluster-resource")
+
+var (
+	// regionSlugPattern matches the DigitalOcean region slugs, e.g. nyc1.
+	regionSlugPattern = regexp.MustCompile(`^[a-z]{3}[0-9]+$`)
+	// uuidPattern matches the UUIDs of the DigitalOcean VPCs.
+	uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// DefaultRegion is the region of the DOClusters created without one, set from
+// the --default-region flag of the manager. The region is required when empty.
+var DefaultRegion string
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-docluster,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doclusters,versions=v1beta1,name=validation.docluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-docluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doclusters,versions=v1beta1,name=default.docluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var (
+	_ webhook.Defaulter = &DOCluster{}
+	_ webhook.Validator = &DOCluster{}
+)
+
+func (r *DOCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOCluster")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *DOCluster) Default() {
+	if r.Spec.Region == "" {
+		r.Spec.Region = DefaultRegion
+	}
+	r.Spec.Network.APIServerLoadbalancers.ApplyDefault()
+}
+
+// deprecatedFieldsWarnings implements deprecatedFieldsWarner.
+func (r *DOCluster) deprecatedFieldsWarnings() []string {
+	var warnings []string
+	if algorithm := r.Spec.Network.APIServerLoadbalancers.Algorithm; algorithm != "" && algorithm != DefaultLBAlgorithm {
+		warnings = append(warnings, fmt.Sprintf("spec.network.apiServerLoadbalancers.algorithm is deprecated and will be removed, "+
+			"DigitalOcean load balancers ignore it and always use %s; unset it", DefaultLBAlgorithm))
+	}
+	return warnings
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOCluster) ValidateCreate() error {
+	allErrs := validateClusterSpec(r.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateFirewallRefs(r.Spec.FirewallRefs, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateAddons(r.Spec.Addons, field.NewPath("spec", "addons"))...)
+	if len(allErrs) == 0 && Live != nil {
+		allErrs = Live.ValidateCluster(context.Background(), r)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DOCluster) ValidateUpdate(old runtime.Object) error {
+	var allErrs field.ErrorList
+
+	oldDOCluster, ok := old.(*DOCluster)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an DOCluster but got a %T", old))
+	}
+
+	if !reflect.DeepEqual(r.Spec.FirewallRefs, oldDOCluster.Spec.FirewallRefs) {
+		allErrs = append(allErrs, validateFirewallRefs(r.Spec.FirewallRefs, field.NewPath("spec"))...)
+	}
+
+	if !reflect.DeepEqual(r.Spec.Addons, oldDOCluster.Spec.Addons) {
+		allErrs = append(allErrs, validateAddons(r.Spec.Addons, field.NewPath("spec", "addons"))...)
+	}
+
+	// The other validated fields are immutable, objects created before a rule
+	// was added are still updated, e.g. to remove their finalizer.
+	// Objects created before the defaulting are defaulted on their next update.
+	oldLoadBalancer := oldDOCluster.Spec.Network.APIServerLoadbalancers
+	oldLoadBalancer.ApplyDefault()
+	if !reflect.DeepEqual(r.Spec.Network.APIServerLoadbalancers, oldLoadBalancer) {
+		allErrs = append(allErrs, validateClusterSpec(r.Spec, field.NewPath("spec"))...)
+	}
+
+	if r.Spec.Region != oldDOCluster.Spec.Region {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "region"), r.Spec.Region, "field is immutable"))
+	}
+
+	if r.Spec.Network.VPC != oldDOCluster.Spec.Network.VPC {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "network", "vpc", "vpc_uuid"), r.Spec.Network.VPC.VPCUUID, "field is immutable"))
+	}
+
+	// The control plane endpoint derives from the DNS record.
+	if !reflect.DeepEqual(r.Spec.ControlPlaneDNS, oldDOCluster.Spec.ControlPlaneDNS) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "controlPlaneDNS"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(clusterv1.APIEndpoint{}, oldDOCluster.Spec.ControlPlaneEndpoint) && !reflect.DeepEqual(r.Spec.ControlPlaneEndpoint, oldDOCluster.Spec.ControlPlaneEndpoint) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneEndpoint"), r.Spec.Region, "field is immutable"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DOCluster) ValidateDelete() error {
+	return nil
+}
+
+// validateClusterSpec checks the syntax of the DigitalOcean references of spec
+// and the consistency of its load balancer settings, which the DigitalOcean
+// API would otherwise only reject when the resources are created.
+func validateClusterSpec(spec DOClusterSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.Region == "" {
+		allErrs = append(allErrs, field.Required(path.Child("region"), "the DigitalOcean region is required"))
+	} else if !regionSlugPattern.MatchString(spec.Region) {
+		allErrs = append(allErrs, field.Invalid(path.Child("region"), spec.Region, "must be a DigitalOcean region slug, e.g. nyc1"))
+	}
+
+	if uuid := spec.Network.VPC.VPCUUID; uuid != "" && !uuidPattern.MatchString(uuid) {
+		allErrs = append(allErrs, field.Invalid(path.Child("network", "vpc", "vpc_uuid"), uuid, "must be the UUID of a DigitalOcean VPC"))
+	}
+
+	// The defaults are applied by the controller.
+	healthCheck := spec.Network.APIServerLoadbalancers.HealthCheck
+	interval, timeout := healthCheck.Interval, healthCheck.Timeout
+	if interval == 0 {
+		interval = DefaultLBHealthCheckInterval
+	}
+	if timeout == 0 {
+		timeout = DefaultLBHealthCheckTimeout
+	}
+	if timeout > interval {
+		allErrs = append(allErrs, field.Invalid(path.Child("network", "apiServerLoadbalancers", "healthCheck", "timeout"), timeout,
+			fmt.Sprintf("must not be greater than the health check interval (%d)", interval)))
+	}
+
+	if dns := spec.ControlPlaneDNS; dns != nil {
+		// The name may have several labels, each of them is limited.
+		for _, label := range strings.Split(dns.Name, ".") {
+			if len(label) > 63 {
+				allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneDNS", "name"), dns.Name, "must not have labels longer than 63 characters"))
+				break
+			}
+		}
+		if fqdn := dns.Name + "." + dns.Domain; len(fqdn) > 253 {
+			allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneDNS"), fqdn, "must not be longer than 253 characters"))
+		}
+	}
+	return allErrs
+}
+
+// validateAddons checks that the enabled addons have a token of their own:
+// the token of the manager is never copied to workload clusters.
+func validateAddons(addons *DOClusterAddons, path *field.Path) field.ErrorList {
+	if addons == nil || (!addons.CloudControllerManager && !addons.CSIDriver) {
+		return nil
+	}
+	if addons.TokenSecretRef == nil || addons.TokenSecretRef.Name == "" {
+		return field.ErrorList{field.Required(path.Child("tokenSecretRef"), "the addons require a Secret holding their DigitalOcean token")}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateClusterSpec(t *testing.T) {
+	testCases := []struct {
+		name       string
+		mutate     func(spec *DOClusterSpec)
+		wantFields []string
+	}{
+		{name: "valid", mutate: func(spec *DOClusterSpec) {}},
+		{name: "missing region", mutate: func(spec *DOClusterSpec) { spec.Region = "" }, wantFields: []string{"spec.region"}},
+		{name: "invalid region", mutate: func(spec *DOClusterSpec) { spec.Region = "New York 1" }, wantFields: []string{"spec.region"}},
+		{
+			name:       "invalid VPC",
+			mutate:     func(spec *DOClusterSpec) { spec.Network.VPC.VPCUUID = "default" },
+			wantFields: []string{"spec.network.vpc.vpc_uuid"},
+		},
+		{
+			name:       "health check timeout above the default interval",
+			mutate:     func(spec *DOClusterSpec) { spec.Network.APIServerLoadbalancers.HealthCheck.Timeout = 30 },
+			wantFields: []string{"spec.network.apiServerLoadbalancers.healthCheck.timeout"},
+		},
+		{
+			name: "health check timeout below the interval",
+			mutate: func(spec *DOClusterSpec) {
+				spec.Network.APIServerLoadbalancers.HealthCheck.Interval = 60
+				spec.Network.APIServerLoadbalancers.HealthCheck.Timeout = 30
+			},
+		},
+		{
+			name:       "DNS label too long",
+			mutate:     func(spec *DOClusterSpec) { spec.ControlPlaneDNS.Name = strings.Repeat("a", 64) },
+			wantFields: []string{"spec.controlPlaneDNS.name"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := DOClusterSpec{
+				Region:          "nyc1",
+				Network:         DONetwork{VPC: DOVPC{VPCUUID: "5a4981aa-9653-4bd1-bef5-d6bff52042e4"}},
+				ControlPlaneDNS: &DOControlPlaneDNS{Domain: "example.com", Name: "api.foo"},
+			}
+			tc.mutate(&spec)
+			var fields []string
+			for _, err := range validateClusterSpec(spec, field.NewPath("spec")) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tc.wantFields))
+		})
+	}
+}
+
+func TestValidateAddons(t *testing.T) {
+	testCases := []struct {
+		name       string
+		addons     *DOClusterAddons
+		wantFields []string
+	}{
+		{name: "no addons"},
+		{name: "no enabled addon", addons: &DOClusterAddons{}},
+		{
+			name:   "token Secret",
+			addons: &DOClusterAddons{CSIDriver: true, TokenSecretRef: &corev1.LocalObjectReference{Name: "addons-token"}},
+		},
+		{
+			name:       "missing token Secret",
+			addons:     &DOClusterAddons{CloudControllerManager: true},
+			wantFields: []string{"spec.addons.tokenSecretRef"},
+		},
+		{
+			name:       "unnamed token Secret",
+			addons:     &DOClusterAddons{CSIDriver: true, TokenSecretRef: &corev1.LocalObjectReference{}},
+			wantFields: []string{"spec.addons.tokenSecretRef"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var fields []string
+			for _, err := range validateAddons(tc.addons, field.NewPath("spec", "addons")) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tc.wantFields))
+		})
+	}
+}
+
+func TestDOClusterDefault(t *testing.T) {
+	g := NewWithT(t)
+	defer func(region string) { DefaultRegion = region }(DefaultRegion)
+	DefaultRegion = "fra1"
+
+	docluster := &DOCluster{}
+	docluster.Default()
+	g.Expect(docluster.Spec.Region).To(Equal("fra1"))
+	g.Expect(docluster.Spec.Network.APIServerLoadbalancers.Port).To(Equal(DefaultLBPort))
+	g.Expect(docluster.Spec.Network.APIServerLoadbalancers.HealthCheck.Interval).To(Equal(DefaultLBHealthCheckInterval))
+
+	docluster = &DOCluster{Spec: DOClusterSpec{Region: "nyc1"}}
+	docluster.Default()
+	g.Expect(docluster.Spec.Region).To(Equal("nyc1"))
+}
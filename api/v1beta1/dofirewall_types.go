@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+)
+
+const (
+	// FirewallFinalizer allows DOFirewallReconciler to delete the DigitalOcean
+	// firewall before removing the DOFirewall from the apiserver.
+	FirewallFinalizer = "dofirewall.infrastructure.cluster.x-k8s.io"
+)
+
+// DOFirewallSpec defines the desired state of DOFirewall.
+type DOFirewallSpec struct {
+	// Name is the name of the firewall in DigitalOcean. An existing firewall
+	// with this name is adopted rather than created. Defaults to the namespace
+	// and name of the DOFirewall.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// InboundRules is the traffic allowed to reach the droplets.
+	// +optional
+	InboundRules []DOFirewallInboundRule `json:"inboundRules,omitempty"`
+	// OutboundRules is the traffic allowed to leave the droplets.
+	// +optional
+	OutboundRules []DOFirewallOutboundRule `json:"outboundRules,omitempty"`
+	// DropletTags are the tags of droplets the firewall applies to, in addition
+	// to the droplets of the DOClusters and DOMachines referencing it.
+	// +optional
+	DropletTags []string `json:"dropletTags,omitempty"`
+}
+
+// DOFirewallInboundRule allows traffic from Sources.
+type DOFirewallInboundRule struct {
+	// Protocol of the traffic.
+	// +kubebuilder:validation:Enum=tcp;udp;icmp
+	Protocol string `json:"protocol"`
+	// Ports is a port, a range like 8000-9000, or "all". It is ignored for
+	// icmp.
+	// +optional
+	Ports string `json:"ports,omitempty"`
+	// Sources the traffic is allowed from.
+	Sources DOFirewallEndpoints `json:"sources"`
+}
+
+// DOFirewallOutboundRule allows traffic to Destinations.
+type DOFirewallOutboundRule struct {
+	// Protocol of the traffic.
+	// +kubebuilder:validation:Enum=tcp;udp;icmp
+	Protocol string `json:"protocol"`
+	// Ports is a port, a range like 8000-9000, or "all". It is ignored for
+	// icmp.
+	// +optional
+	Ports string `json:"ports,omitempty"`
+	// Destinations the traffic is allowed to.
+	Destinations DOFirewallEndpoints `json:"destinations"`
+}
+
+// DOFirewallEndpoints are the peers of the traffic of a firewall rule.
+type DOFirewallEndpoints struct {
+	// Addresses are IP addresses and CIDR blocks, e.g. 0.0.0.0/0 and ::/0
+	// for any address.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+	// Tags are the tags of droplets.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DOFirewallStatus defines the observed state of DOFirewall.
+type DOFirewallStatus struct {
+	// Ready is true when the rules of the firewall are applied.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// FirewallID is the ID of the DigitalOcean firewall.
+	// +optional
+	FirewallID string `json:"firewallID,omitempty"`
+
+	// Tags are the droplet tags the firewall applies to.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// DropletIDs are the droplets of the DOMachines the firewall applies to.
+	// +optional
+	DropletIDs []int `json:"dropletIDs,omitempty"`
+
+	// Conditions defines current service state of the DOFirewall.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=dofirewalls,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="FirewallID",type="string",JSONPath=".status.firewallID",description="DigitalOcean firewall ID"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Firewall ready status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of DOFirewall"
+
+// DOFirewall is the Schema for the dofirewalls API.
+type DOFirewall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOFirewallSpec   `json:"spec,omitempty"`
+	Status DOFirewallStatus `json:"status,omitempty"`
+}
+
+// FirewallName returns the name of the DigitalOcean firewall of the DOFirewall.
+func (r *DOFirewall) FirewallName() string {
+	if r.Spec.Name != "" {
+		return r.Spec.Name
+	}
+	return DOSafeName(fmt.Sprintf("%s-%s", r.Namespace, r.Name))
+}
+
+// GetConditions returns the observations of the operational state of the DOFirewall resource.
+func (r *DOFirewall) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOFirewall to the predescribed clusterv1.Conditions.
+func (r *DOFirewall) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// DOFirewallList contains a list of DOFirewall.
+type DOFirewallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOFirewall `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DOFirewall{}, &DOFirewallList{})
+}
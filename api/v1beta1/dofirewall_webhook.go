@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-dofirewall,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=dofirewalls,versions=v1beta1,name=validation.dofirewall.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DOFirewall{}
+
+func (r *DOFirewall) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOFirewall")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOFirewall) ValidateCreate() error {
+	if err := validateFeatureGate(r.GroupVersionKind().GroupKind(), r.Name, feature.Firewall); err != nil {
+		return err
+	}
+	return r.validate(nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOFirewall) ValidateUpdate(old runtime.Object) error {
+	oldDOFirewall, ok := old.(*DOFirewall)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an DOFirewall but got a %T", old))
+	}
+	return r.validate(oldDOFirewall)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOFirewall) ValidateDelete() error {
+	return nil
+}
+
+func (r *DOFirewall) validate(old *DOFirewall) error {
+	var allErrs field.ErrorList
+
+	if old != nil && r.Spec.Name != old.Spec.Name {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), r.Spec.Name, "field is immutable"))
+	}
+	for i, rule := range r.Spec.InboundRules {
+		path := field.NewPath("spec", "inboundRules").Index(i)
+		allErrs = append(allErrs, validateFirewallRule(path, rule.Protocol, rule.Ports)...)
+		if len(rule.Sources.Addresses) == 0 && len(rule.Sources.Tags) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("sources"), "at least one address or tag is required"))
+		}
+	}
+	for i, rule := range r.Spec.OutboundRules {
+		path := field.NewPath("spec", "outboundRules").Index(i)
+		allErrs = append(allErrs, validateFirewallRule(path, rule.Protocol, rule.Ports)...)
+		if len(rule.Destinations.Addresses) == 0 && len(rule.Destinations.Tags) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("destinations"), "at least one address or tag is required"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+func validateFirewallRule(path *field.Path, protocol, ports string) field.ErrorList {
+	if protocol != "icmp" && ports == "" {
+		return field.ErrorList{field.Required(path.Child("ports"), fmt.Sprintf("ports are required for %s", protocol))}
+	}
+	return nil
+}
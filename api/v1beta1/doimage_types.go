@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+)
+
+// SnapshotNameEnvVar is the environment variable holding the name the build
+// job of a DOImage must give to the snapshot it creates.
+const SnapshotNameEnvVar = "CAPDO_SNAPSHOT_NAME"
+
+// DOImageSpec defines the desired state of DOImage. Exactly one of its fields
+// must be set.
+type DOImageSpec struct {
+	// ID registers an existing image or snapshot.
+	// +optional
+	ID int `json:"id,omitempty"`
+	// SnapshotTag registers the most recent snapshot carrying this tag, e.g.
+	// the output of an image pipeline. A newer snapshot with this tag is
+	// picked up for the droplets created afterwards.
+	// +optional
+	SnapshotTag string `json:"snapshotTag,omitempty"`
+	// Build runs a Job building the image, e.g. with image-builder, and
+	// registers the snapshot it created. The image is built again when the
+	// spec changes.
+	// +optional
+	Build *DOImageBuild `json:"build,omitempty"`
+}
+
+// DOImageBuild is the Job building the image of a DOImage. Its container gets
+// the name of the snapshot to create in the CAPDO_SNAPSHOT_NAME environment
+// variable.
+type DOImageBuild struct {
+	// Image is the container image running the build.
+	Image string `json:"image"`
+	// Command overrides the entrypoint of Image.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments of the build.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env are environment variables of the build, e.g. the DigitalOcean
+	// token read from a Secret.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// DOImageStatus defines the observed state of DOImage.
+type DOImageStatus struct {
+	// Ready is true when the image can be used to create droplets.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// ImageID is the ID of the DigitalOcean image.
+	// +optional
+	ImageID int `json:"imageID,omitempty"`
+
+	// ImageName is the name of the DigitalOcean image.
+	// +optional
+	ImageName string `json:"imageName,omitempty"`
+
+	// Regions are the regions the image is available in.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// BuildJobName is the name of the Job building the image, if any.
+	// +optional
+	BuildJobName string `json:"buildJobName,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the image was resolved for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines current service state of the DOImage.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=doimages,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ImageID",type="string",JSONPath=".status.imageID",description="DigitalOcean image ID"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".status.imageName",description="DigitalOcean image name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Image ready status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of DOImage"
+
+// DOImage is the Schema for the doimages API.
+type DOImage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOImageSpec   `json:"spec,omitempty"`
+	Status DOImageStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the DOImage resource.
+func (r *DOImage) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOImage to the predescribed clusterv1.Conditions.
+func (r *DOImage) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// DOImageList contains a list of DOImage.
+type DOImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOImage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DOImage{}, &DOImageList{})
+}
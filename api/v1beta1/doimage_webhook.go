@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-doimage,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doimages,versions=v1beta1,name=validation.doimage.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DOImage{}
+
+func (r *DOImage) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOImage")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOImage) ValidateCreate() error {
+	if err := validateFeatureGate(r.GroupVersionKind().GroupKind(), r.Name, feature.GoldenImage); err != nil {
+		return err
+	}
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOImage) ValidateUpdate(old runtime.Object) error {
+	if _, ok := old.(*DOImage); !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an DOImage but got a %T", old))
+	}
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOImage) ValidateDelete() error {
+	return nil
+}
+
+func (r *DOImage) validate() error {
+	var allErrs field.ErrorList
+
+	set := 0
+	if r.Spec.ID != 0 {
+		set++
+	}
+	if r.Spec.SnapshotTag != "" {
+		set++
+	}
+	if r.Spec.Build != nil {
+		set++
+		if r.Spec.Build.Image == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "build", "image"), "the container image running the build is required"))
+		}
+	}
+	if set != 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), r.Spec, "exactly one of id, snapshotTag and build must be set"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var _ = logf.Log.WithName("domachine-resource")
+
+var (
+	// sizeSlugPattern matches the DigitalOcean size slugs, e.g. s-1vcpu-1gb
+	// or so1_5-2vcpu-16gb.
+	sizeSlugPattern = regexp.MustCompile(`^[a-z0-9]+([-_][a-z0-9]+)*$`)
+	// imageSlugPattern matches the DigitalOcean image slugs, e.g.
+	// ubuntu-20-04-x64.
+	imageSlugPattern = regexp.MustCompile(`^[a-z0-9]+([-._][a-z0-9]+)*$`)
+	// tagPattern matches the DigitalOcean tags, made of letters, numbers,
+	// colons, dashes and underscores.
+	tagPattern = regexp.MustCompile(`^[a-zA-Z0-9:_-]{1,255}$`)
+)
+
+// DefaultMachineSize is the droplet size of the DOMachines and
+// DOMachineTemplates created without one, set from the --default-machine-size
+// flag of the manager. It fits the kubeadm requirements.
+var DefaultMachineSize = "s-2vcpu-2gb"
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-domachine,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachines,versions=v1beta1,name=validation.domachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-domachine,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachines,versions=v1beta1,name=default.domachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var (
+	_ webhook.Defaulter = &DOMachine{}
+	_ webhook.Validator = &DOMachine{}
+)
+
+func (r *DOMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOMachine")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *DOMachine) Default() {
+	defaultMachineSpec(&r.Spec)
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOMachine) ValidateCreate() error {
+	allErrs := validateMachineSpec(r.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateMachineFeatureGates(r.Spec, field.NewPath("spec"))...)
+	if len(allErrs) == 0 && Live != nil {
+		allErrs = Live.ValidateMachineSpec(context.Background(), r, r.Spec, field.NewPath("spec"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DOMachine) ValidateUpdate(old runtime.Object) error {
+	var allErrs field.ErrorList
+
+	newDOMachine, err := runtime.DefaultUnstructuredConverter.ToUnstructured(r)
+	if err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to convert new DOMachine to unstructured object"))
+	}
+
+	oldDOMachine, err := runtime.DefaultUnstructuredConverter.ToUnstructured(old)
+	if err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to convert old DOMachine to unstructured object"))
+	}
+
+	if oldMachine, ok := old.(*DOMachine); ok {
+		// The other references are immutable.
+		if !reflect.DeepEqual(r.Spec.FirewallRefs, oldMachine.Spec.FirewallRefs) {
+			allErrs = append(allErrs, validateFirewallRefs(r.Spec.FirewallRefs, field.NewPath("spec"))...)
+		}
+		if !reflect.DeepEqual(r.Spec.AdditionalTags, oldMachine.Spec.AdditionalTags) {
+			allErrs = append(allErrs, validateTags(r.Spec.AdditionalTags, field.NewPath("spec", "additionalTags"))...)
+		}
+		// Report the fields most likely to be edited by name, the droplet
+		// is not resized nor rebuilt.
+		if r.Spec.Size != oldMachine.Spec.Size {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "size"), r.Spec.Size, "field is immutable"))
+		}
+		if r.Spec.Image != oldMachine.Spec.Image {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "image"), r.Spec.Image.String(), "field is immutable"))
+		}
+	}
+
+	newDOMachineSpec := newDOMachine["spec"].(map[string]interface{})
+	oldDOMachineSpec := oldDOMachine["spec"].(map[string]interface{})
+
+	// allow changes to providerID
+	delete(oldDOMachineSpec, "providerID")
+	delete(newDOMachineSpec, "providerID")
+
+	// allow changes to additionalTags
+	delete(oldDOMachineSpec, "additionalTags")
+	delete(newDOMachineSpec, "additionalTags")
+
+	// allow changes to firewallRefs
+	delete(oldDOMachineSpec, "firewallRefs")
+	delete(newDOMachineSpec, "firewallRefs")
+
+	// size and image are reported above
+	delete(oldDOMachineSpec, "size")
+	delete(newDOMachineSpec, "size")
+	delete(oldDOMachineSpec, "image")
+	delete(newDOMachineSpec, "image")
+
+	if !reflect.DeepEqual(oldDOMachineSpec, newDOMachineSpec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "cannot be modified"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DOMachine) ValidateDelete() error {
+	return nil
+}
+
+// defaultMachineSpec fills the unset fields of spec that have a default.
+func defaultMachineSpec(spec *DOMachineSpec) {
+	if spec.Size == "" {
+		spec.Size = DefaultMachineSize
+	}
+}
+
+// validateMachineSpec checks the syntax of the DigitalOcean references of
+// spec, which the DigitalOcean API would otherwise only reject when the
+// droplet is created.
+func validateMachineSpec(spec DOMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.Size == "" {
+		allErrs = append(allErrs, field.Required(path.Child("size"), "the droplet size is required"))
+	} else if !sizeSlugPattern.MatchString(spec.Size) {
+		allErrs = append(allErrs, field.Invalid(path.Child("size"), spec.Size, "must be a DigitalOcean size slug, e.g. s-1vcpu-1gb"))
+	}
+
+	allErrs = append(allErrs, validateImage(spec, path)...)
+	if image := spec.Image; (image.Type == intstr.Int && image.IntVal < 0) ||
+		(image.Type == intstr.String && image.StrVal != "" && !imageSlugPattern.MatchString(image.StrVal)) {
+		allErrs = append(allErrs, field.Invalid(path.Child("image"), image.String(), "must be a DigitalOcean image ID or slug, e.g. ubuntu-20-04-x64"))
+	}
+
+	// A string may be a fingerprint or a name, which only the live
+	// validation can resolve.
+	for i, key := range spec.SSHKeys {
+		if (key.Type == intstr.Int && key.IntVal > 0) || (key.Type == intstr.String && strings.TrimSpace(key.StrVal) != "") {
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(path.Child("sshKeys").Index(i), key.String(), "must be a DigitalOcean SSH key ID, fingerprint or name"))
+	}
+
+	allErrs = append(allErrs, validateTags(spec.AdditionalTags, path.Child("additionalTags"))...)
+	return allErrs
+}
+
+// validateTags checks that tags are valid DigitalOcean tags.
+func validateTags(tags Tags, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, tag := range tags {
+		if !tagPattern.MatchString(tag) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), tag, "must be at most 255 letters, numbers, colons, dashes and underscores"))
+		}
+	}
+	return allErrs
+}
+
+// validateImage checks that at most one of image and imageRef is set, and
+// that one of them is set when the image can not be looked up.
+func validateImage(spec DOMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	hasImage := spec.HasImage()
+	switch {
+	case hasImage && spec.ImageRef != nil:
+		allErrs = append(allErrs, field.Forbidden(path.Child("imageRef"), "cannot be set together with image"))
+	case !hasImage && spec.ImageRef == nil && ImageLookupFormat == "":
+		allErrs = append(allErrs, field.Required(path.Child("image"), "either image or imageRef is required"))
+	case spec.ImageRef != nil && spec.ImageRef.Name == "":
+		allErrs = append(allErrs, field.Required(path.Child("imageRef", "name"), "the name of the DOImage is required"))
+	}
+	return allErrs
+}
+
+// validateMachineFeatureGates checks that the references of spec are only set
+// when the feature handling them is enabled.
+func validateMachineFeatureGates(spec DOMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.ImageRef != nil && !feature.Gates.Enabled(feature.GoldenImage) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("imageRef"), "can be set only if the GoldenImage feature flag is enabled"))
+	}
+	if len(spec.VolumeRefs) > 0 && !feature.Gates.Enabled(feature.Volume) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("volumeRefs"), "can be set only if the Volume feature flag is enabled"))
+	}
+	allErrs = append(allErrs, validateFirewallRefs(spec.FirewallRefs, path)...)
+	return allErrs
+}
+
+// validateFirewallRefs checks that firewallRefs are only set when the Firewall
+// feature is enabled.
+func validateFirewallRefs(refs []corev1.LocalObjectReference, path *field.Path) field.ErrorList {
+	if len(refs) > 0 && !feature.Gates.Enabled(feature.Firewall) {
+		return field.ErrorList{field.Forbidden(path.Child("firewallRefs"), "can be set only if the Firewall feature flag is enabled")}
+	}
+	return nil
+}
+
+// validateFeatureGate rejects the creation of the objects of a kind whose
+// feature is disabled. Their webhooks are served regardless of the feature
+// gates, as the webhook configuration installs them all. The existing objects
+// may still be updated and deleted, e.g. to remove their finalizer.
+func validateFeatureGate(gk schema.GroupKind, name string, gate featuregate.Feature) error {
+	if feature.Gates.Enabled(gate) {
+		return nil
+	}
+	return apierrors.NewInvalid(gk, name, field.ErrorList{
+		field.Forbidden(field.NewPath("spec"), fmt.Sprintf("%s can be created only if the %s feature flag is enabled", gk.Kind, gate)),
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+)
+
+func TestValidateMachineFeatureGates(t *testing.T) {
+	spec := DOMachineSpec{
+		ImageRef:     &corev1.LocalObjectReference{Name: "golden"},
+		VolumeRefs:   []corev1.LocalObjectReference{{Name: "data"}},
+		FirewallRefs: []corev1.LocalObjectReference{{Name: "nodes"}},
+	}
+
+	// The features are disabled by default.
+	g := NewWithT(t)
+	var fields []string
+	for _, err := range validateMachineFeatureGates(spec, field.NewPath("spec")) {
+		fields = append(fields, err.Field)
+	}
+	g.Expect(fields).To(ConsistOf("spec.imageRef", "spec.volumeRefs", "spec.firewallRefs"))
+
+	defer featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.GoldenImage, true)()
+	defer featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.Volume, true)()
+	defer featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.Firewall, true)()
+	g.Expect(validateMachineFeatureGates(spec, field.NewPath("spec"))).To(BeEmpty())
+}
+
+func TestValidateFeatureGate(t *testing.T) {
+	g := NewWithT(t)
+
+	// The webhooks of the disabled kinds are served, they reject the creates.
+	volume := &DOVolume{ObjectMeta: metav1.ObjectMeta{Name: "data"}, Spec: DOVolumeSpec{Region: "nyc1", SizeGigaBytes: 10}}
+	g.Expect(volume.ValidateCreate()).To(MatchError(ContainSubstring("Volume feature flag")))
+	g.Expect((&DOImage{}).ValidateCreate()).To(MatchError(ContainSubstring("GoldenImage feature flag")))
+	g.Expect((&DOReservedIP{}).ValidateCreate()).To(MatchError(ContainSubstring("ReservedIP feature flag")))
+	g.Expect((&DOFirewall{}).ValidateCreate()).To(MatchError(ContainSubstring("Firewall feature flag")))
+	// The existing objects may still be updated, e.g. to remove their finalizer.
+	g.Expect(volume.ValidateUpdate(volume.DeepCopy())).To(Succeed())
+
+	defer featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.Volume, true)()
+	g.Expect(volume.ValidateCreate()).To(Succeed())
+}
+
+func TestValidateMachineSpec(t *testing.T) {
+	valid := DOMachineSpec{
+		Size:           "so1_5-2vcpu-16gb",
+		Image:          intstr.FromString("ubuntu-20-04-x64"),
+		SSHKeys:        []intstr.IntOrString{intstr.FromInt(1234), intstr.FromString("3b:16:bf:e4:8b:00:8b:b8:59:8c:a9:d3:f0:19:45:fa")},
+		AdditionalTags: Tags{"team:infra", "env_prod"},
+	}
+	testCases := []struct {
+		name       string
+		mutate     func(spec *DOMachineSpec)
+		wantFields []string
+	}{
+		{name: "valid", mutate: func(spec *DOMachineSpec) {}},
+		{name: "image ID", mutate: func(spec *DOMachineSpec) { spec.Image = intstr.FromInt(42) }},
+		{name: "missing size", mutate: func(spec *DOMachineSpec) { spec.Size = "" }, wantFields: []string{"spec.size"}},
+		{name: "invalid size", mutate: func(spec *DOMachineSpec) { spec.Size = "S 1vcpu" }, wantFields: []string{"spec.size"}},
+		{name: "invalid image", mutate: func(spec *DOMachineSpec) { spec.Image = intstr.FromString("Ubuntu 20.04") }, wantFields: []string{"spec.image"}},
+		{name: "named SSH key", mutate: func(spec *DOMachineSpec) { spec.SSHKeys[1] = intstr.FromString("capdo") }},
+		{name: "empty SSH key", mutate: func(spec *DOMachineSpec) { spec.SSHKeys[1] = intstr.FromString("") }, wantFields: []string{"spec.sshKeys[1]"}},
+		{name: "invalid SSH key ID", mutate: func(spec *DOMachineSpec) { spec.SSHKeys[0] = intstr.FromInt(0) }, wantFields: []string{"spec.sshKeys[0]"}},
+		{name: "invalid tag", mutate: func(spec *DOMachineSpec) { spec.AdditionalTags = Tags{"team=infra"} }, wantFields: []string{"spec.additionalTags[0]"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := *valid.DeepCopy()
+			tc.mutate(&spec)
+			var fields []string
+			for _, err := range validateMachineSpec(spec, field.NewPath("spec")) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tc.wantFields))
+		})
+	}
+}
+
+// recordingLive is a LiveValidator recording the machine specs it validates.
+type recordingLive struct {
+	specs []DOMachineSpec
+}
+
+func (l *recordingLive) ValidateCluster(context.Context, *DOCluster) field.ErrorList {
+	return nil
+}
+
+func (l *recordingLive) ValidateMachineSpec(_ context.Context, _ metav1.Object, spec DOMachineSpec, _ *field.Path) field.ErrorList {
+	l.specs = append(l.specs, spec)
+	return nil
+}
+
+func TestDOMachineValidateCreateSSHKeyNames(t *testing.T) {
+	g := NewWithT(t)
+	live := &recordingLive{}
+	defer func(old LiveValidator) { Live = old }(Live)
+	Live = live
+
+	// The names are resolved by the live validation.
+	machine := &DOMachine{Spec: DOMachineSpec{
+		Size:    "s-1vcpu-1gb",
+		Image:   intstr.FromString("ubuntu-20-04-x64"),
+		SSHKeys: []intstr.IntOrString{intstr.FromString("capdo")},
+	}}
+	g.Expect(machine.ValidateCreate()).To(Succeed())
+	g.Expect(live.specs).To(HaveLen(1))
+
+	machine.Spec.SSHKeys = []intstr.IntOrString{intstr.FromString("")}
+	g.Expect(machine.ValidateCreate()).NotTo(Succeed())
+	g.Expect(live.specs).To(HaveLen(1))
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DOMachineTemplateSpec defines the desired state of DOMachineTemplate.
+type DOMachineTemplateSpec struct {
+	Template DOMachineTemplateResource `json:"template"`
+}
+
+// DOMachineTemplateStatus defines the observed state of DOMachineTemplate.
+type DOMachineTemplateStatus struct {
+	// Capacity is the cpu, memory and ephemeral-storage of the droplet size
+	// of the template, which the cluster-autoscaler uses to scale the
+	// MachineDeployments of the template from zero.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=domachinetemplates,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.template.spec.size",description="DigitalOcean droplet size of the machines"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of DOMachineTemplate"
+
+// DOMachineTemplate is the Schema for the domachinetemplates API.
+type DOMachineTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOMachineTemplateSpec   `json:"spec,omitempty"`
+	Status DOMachineTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DOMachineTemplateList contains a list of DOMachineTemplate.
+type DOMachineTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOMachineTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DOMachineTemplate{}, &DOMachineTemplateList{})
+}
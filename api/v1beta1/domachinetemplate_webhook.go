@@ -14,9 +14,11 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package v1alpha4
+package v1beta1
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,18 +33,26 @@ import (
 // log is for logging in this package.
 var _ = logf.Log.WithName("domachinetemplate-resource")
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1alpha4-domachinetemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachinetemplates,versions=v1alpha4,name=validation.domachinetemplate.infrastructure.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-domachinetemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachinetemplates,versions=v1beta1,name=validation.domachinetemplate.infrastructure.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-domachinetemplate,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=domachinetemplates,versions=v1beta1,name=default.domachinetemplate.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
 
 var (
+	_ webhook.Defaulter = &DOMachineTemplate{}
 	_ webhook.Validator = &DOMachineTemplate{}
 )
 
 func (r *DOMachineTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOMachineTemplate")
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *DOMachineTemplate) Default() {
+	defaultMachineSpec(&r.Spec.Template.Spec)
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *DOMachineTemplate) ValidateCreate() error {
 	var allErrs field.ErrorList
@@ -51,6 +61,11 @@ func (r *DOMachineTemplate) ValidateCreate() error {
 	if spec.ProviderID != nil {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "providerID"), "cannot be set in templates"))
 	}
+	allErrs = append(allErrs, validateMachineSpec(spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateMachineFeatureGates(spec, field.NewPath("spec", "template", "spec"))...)
+	if len(allErrs) == 0 && Live != nil {
+		allErrs = Live.ValidateMachineSpec(context.Background(), r, spec, field.NewPath("spec", "template", "spec"))
+	}
 
 	if len(allErrs) == 0 {
 		return nil
@@ -63,9 +78,13 @@ func (r *DOMachineTemplate) ValidateCreate() error {
 func (r *DOMachineTemplate) ValidateUpdate(old runtime.Object) error {
 	var allErrs field.ErrorList
 
-	oldDOMachineTemplate := old.(*DOMachineTemplate)
+	oldDOMachineTemplate, ok := old.(*DOMachineTemplate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a DOMachineTemplate but got a %T", old))
+	}
 	if !reflect.DeepEqual(r.Spec, oldDOMachineTemplate.Spec) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "DOMachineTemplateSpec is immutable"))
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec"),
+			"DOMachineTemplates are immutable, create a new DOMachineTemplate and point the MachineDeployment or the control plane to it to roll out the change"))
 	}
 
 	if len(allErrs) == 0 {
@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDOMachineTemplateValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	old := &DOMachineTemplate{
+		Spec: DOMachineTemplateSpec{Template: DOMachineTemplateResource{Spec: DOMachineSpec{
+			Size:  "s-2vcpu-2gb",
+			Image: intstr.FromString("ubuntu-20-04-x64"),
+		}}},
+	}
+
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"team": "infra"}
+	g.Expect(updated.ValidateUpdate(old)).To(Succeed())
+
+	updated.Spec.Template.Spec.Size = "s-4vcpu-8gb"
+	err := updated.ValidateUpdate(old)
+	g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("spec.template.spec"))
+	g.Expect(err.Error()).To(ContainSubstring("create a new DOMachineTemplate"))
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+)
+
+const (
+	// ReservedIPFinalizer allows DOReservedIPReconciler to release the
+	// DigitalOcean reserved IP before removing the DOReservedIP from the apiserver.
+	ReservedIPFinalizer = "doreservedip.infrastructure.cluster.x-k8s.io"
+)
+
+// DOReservedIPSpec defines the desired state of DOReservedIP.
+type DOReservedIPSpec struct {
+	// Region is the DigitalOcean region the reserved IP is allocated in. It
+	// can only be assigned to droplets of this region.
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+	// Target is what the reserved IP is assigned to. The reserved IP is
+	// allocated but left unassigned when not set.
+	// +optional
+	Target *DOReservedIPTarget `json:"target,omitempty"`
+}
+
+// DOReservedIPTarget is what a reserved IP is assigned to. Exactly one of its
+// fields must be set.
+type DOReservedIPTarget struct {
+	// MachineRef is the DOMachine, in the namespace of the DOReservedIP, whose
+	// droplet the reserved IP is assigned to.
+	// +optional
+	MachineRef *corev1.LocalObjectReference `json:"machineRef,omitempty"`
+	// ControlPlaneRef is the Cluster, in the namespace of the DOReservedIP,
+	// to one of whose ready control plane droplets the reserved IP is
+	// assigned. The reserved IP is moved to another control plane droplet
+	// when its droplet goes away.
+	// +optional
+	ControlPlaneRef *corev1.LocalObjectReference `json:"controlPlaneRef,omitempty"`
+}
+
+// DOReservedIPStatus defines the observed state of DOReservedIP.
+type DOReservedIPStatus struct {
+	// Ready is true when the reserved IP is allocated and assigned to its target.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// IP is the allocated reserved IP.
+	// +optional
+	IP string `json:"ip,omitempty"`
+
+	// DropletID is the ID of the droplet the reserved IP is assigned to.
+	// +optional
+	DropletID int `json:"dropletID,omitempty"`
+
+	// Conditions defines current service state of the DOReservedIP.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=doreservedips,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.ip",description="Allocated reserved IP"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region",description="DigitalOcean region of the reserved IP"
+// +kubebuilder:printcolumn:name="Droplet",type="string",JSONPath=".status.dropletID",description="DigitalOcean droplet the reserved IP is assigned to"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Reserved IP ready status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of DOReservedIP"
+
+// DOReservedIP is the Schema for the doreservedips API.
+type DOReservedIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOReservedIPSpec   `json:"spec,omitempty"`
+	Status DOReservedIPStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the DOReservedIP resource.
+func (r *DOReservedIP) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOReservedIP to the predescribed clusterv1.Conditions.
+func (r *DOReservedIP) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// DOReservedIPList contains a list of DOReservedIP.
+type DOReservedIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOReservedIP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DOReservedIP{}, &DOReservedIPList{})
+}
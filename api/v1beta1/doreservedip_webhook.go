@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-doreservedip,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=doreservedips,versions=v1beta1,name=validation.doreservedip.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DOReservedIP{}
+
+func (r *DOReservedIP) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOReservedIP")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOReservedIP) ValidateCreate() error {
+	if err := validateFeatureGate(r.GroupVersionKind().GroupKind(), r.Name, feature.ReservedIP); err != nil {
+		return err
+	}
+	return r.validate(nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOReservedIP) ValidateUpdate(old runtime.Object) error {
+	oldDOReservedIP, ok := old.(*DOReservedIP)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an DOReservedIP but got a %T", old))
+	}
+	return r.validate(oldDOReservedIP)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOReservedIP) ValidateDelete() error {
+	return nil
+}
+
+func (r *DOReservedIP) validate(old *DOReservedIP) error {
+	var allErrs field.ErrorList
+
+	if old != nil && r.Spec.Region != old.Spec.Region {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "region"), r.Spec.Region, "field is immutable"))
+	}
+
+	if t := r.Spec.Target; t != nil && (t.MachineRef == nil) == (t.ControlPlaneRef == nil) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "target"), t, "exactly one of machineRef and controlPlaneRef must be set"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+)
+
+const (
+	// VolumeFinalizer allows DOVolumeReconciler to delete the DigitalOcean
+	// block storage volume before removing the DOVolume from the apiserver.
+	VolumeFinalizer = "dovolume.infrastructure.cluster.x-k8s.io"
+)
+
+// VolumeDeletionPolicy is what happens to the block storage volume of a
+// DOVolume when the DOVolume is deleted.
+type VolumeDeletionPolicy string
+
+const (
+	// VolumeDeletionPolicyDelete deletes the volume with the DOVolume.
+	VolumeDeletionPolicyDelete VolumeDeletionPolicy = "Delete"
+	// VolumeDeletionPolicyRetain keeps the volume in the DigitalOcean account.
+	VolumeDeletionPolicyRetain VolumeDeletionPolicy = "Retain"
+)
+
+// DOVolumeSpec defines the desired state of DOVolume.
+type DOVolumeSpec struct {
+	// Region is the DigitalOcean region of the volume. It can only be attached
+	// to droplets of this region.
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+	// Name is the name of the volume in DigitalOcean. An existing volume with
+	// this name in Region is adopted rather than created. Defaults to the
+	// name of the DOVolume.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// SizeGigaBytes is the size of the volume. It can only be grown.
+	// +kubebuilder:validation:Minimum=1
+	SizeGigaBytes int64 `json:"sizeGigaBytes"`
+	// FilesystemType to be used on the volume. When provided the volume will
+	// be automatically formatted when created.
+	// +optional
+	FilesystemType string `json:"filesystemType,omitempty"`
+	// FilesystemLabel is the label that is applied to the created filesystem.
+	// Character limits apply: 16 for ext4; 12 for xfs.
+	// May only be used in conjunction with filesystemType.
+	// +optional
+	FilesystemLabel string `json:"filesystemLabel,omitempty"`
+	// Tags is an optional set of tags added to the volume when it is created.
+	// +optional
+	Tags Tags `json:"tags,omitempty"`
+	// DeletionPolicy is what happens to the volume when the DOVolume is
+	// deleted, Delete or Retain. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy VolumeDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DOVolumeStatus defines the observed state of DOVolume.
+type DOVolumeStatus struct {
+	// Ready is true when the volume exists and can be attached.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// VolumeID is the ID of the DigitalOcean volume.
+	// +optional
+	VolumeID string `json:"volumeID,omitempty"`
+
+	// SizeGigaBytes is the current size of the volume.
+	// +optional
+	SizeGigaBytes int64 `json:"sizeGigaBytes,omitempty"`
+
+	// DropletIDs are the IDs of the droplets the volume is attached to.
+	// +optional
+	DropletIDs []int `json:"dropletIDs,omitempty"`
+
+	// Conditions defines current service state of the DOVolume.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=dovolumes,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VolumeID",type="string",JSONPath=".status.volumeID",description="DigitalOcean volume ID"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region",description="DigitalOcean region of the volume"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.sizeGigaBytes",description="Size of the volume in GB"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Volume ready status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of DOVolume"
+
+// DOVolume is the Schema for the dovolumes API.
+type DOVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOVolumeSpec   `json:"spec,omitempty"`
+	Status DOVolumeStatus `json:"status,omitempty"`
+}
+
+// VolumeName returns the name of the DigitalOcean volume of the DOVolume.
+func (r *DOVolume) VolumeName() string {
+	if r.Spec.Name != "" {
+		return r.Spec.Name
+	}
+	return DOSafeName(r.Name)
+}
+
+// GetConditions returns the observations of the operational state of the DOVolume resource.
+func (r *DOVolume) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the DOVolume to the predescribed clusterv1.Conditions.
+func (r *DOVolume) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// DOVolumeList contains a list of DOVolume.
+type DOVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOVolume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DOVolume{}, &DOVolumeList{})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-dovolume,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=dovolumes,versions=v1beta1,name=validation.dovolume.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DOVolume{}
+
+func (r *DOVolume) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	registerDeprecationWebhook(mgr, r, "DOVolume")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOVolume) ValidateCreate() error {
+	if err := validateFeatureGate(r.GroupVersionKind().GroupKind(), r.Name, feature.Volume); err != nil {
+		return err
+	}
+
+	var allErrs field.ErrorList
+
+	if r.Spec.FilesystemLabel != "" && r.Spec.FilesystemType == "" {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "filesystemLabel"), r.Spec.FilesystemLabel, "can only be set with filesystemType"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOVolume) ValidateUpdate(old runtime.Object) error {
+	var allErrs field.ErrorList
+
+	oldDOVolume, ok := old.(*DOVolume)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an DOVolume but got a %T", old))
+	}
+
+	if r.Spec.Region != oldDOVolume.Spec.Region {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "region"), r.Spec.Region, "field is immutable"))
+	}
+	if r.VolumeName() != oldDOVolume.VolumeName() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), r.Spec.Name, "field is immutable"))
+	}
+	if r.Spec.SizeGigaBytes < oldDOVolume.Spec.SizeGigaBytes {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "sizeGigaBytes"), r.Spec.SizeGigaBytes, "volumes can only be grown"))
+	}
+	if r.Spec.FilesystemType != oldDOVolume.Spec.FilesystemType {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "filesystemType"), r.Spec.FilesystemType, "field is immutable"))
+	}
+	if r.Spec.FilesystemLabel != oldDOVolume.Spec.FilesystemLabel {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "filesystemLabel"), r.Spec.FilesystemLabel, "field is immutable"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *DOVolume) ValidateDelete() error {
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Reasons of the Events recorded on the objects of the provider. They are part
+// of the API of the provider, alerts and tooling may match on them rather than
+// on the messages. The condition reasons, e.g. InstanceDeletedExternallyReason
+// or ActionFailedReason, are also used for the Events reporting the same thing.
+const (
+	// Droplets.
+	InstanceCreatedReason       = "InstanceCreated"
+	InstanceCreatingErrorReason = "InstanceCreatingError"
+	InstanceDeletedReason       = "InstanceDeleted"
+	InstanceDeletingErrorReason = "InstanceDeletingError"
+	NoInstanceFoundReason       = "NoInstanceFound"
+	DOMachineReadyReason        = "DOMachineReady"
+
+	// Droplets tagged for a cluster but referenced by no DOMachine.
+	InstanceAdoptedReason         = "InstanceAdopted"
+	OrphanedInstanceReason        = "OrphanedInstance"
+	OrphanedInstanceDeletedReason = "OrphanedInstanceDeleted"
+
+	// Block storage volumes.
+	VolumeCreatedReason       = "VolumeCreated"
+	VolumeCreatingErrorReason = "VolumeCreatingError"
+	VolumeDeletedReason       = "VolumeDeleted"
+	VolumeDeletingErrorReason = "VolumeDeletingError"
+	VolumeAdoptedReason       = "VolumeAdopted"
+	VolumeResizedReason       = "VolumeResized"
+	VolumeResizingErrorReason = "VolumeResizingError"
+
+	// Firewalls.
+	FirewallCreatedReason       = "FirewallCreated"
+	FirewallCreatingErrorReason = "FirewallCreatingError"
+	FirewallAdoptedReason       = "FirewallAdopted"
+	FirewallUpdatedReason       = "FirewallUpdated"
+	FirewallUpdatingErrorReason = "FirewallUpdatingError"
+	FirewallDeletedReason       = "FirewallDeleted"
+	FirewallDeletingErrorReason = "FirewallDeletingError"
+
+	// ClusterResourceSets installing the DigitalOcean addons.
+	AddonsConfiguredReason       = "AddonsConfigured"
+	AddonsConfiguringErrorReason = "AddonsConfiguringError"
+
+	// Golden images.
+	ImageResolvedReason      = "ImageResolved"
+	ImageBuildStartedReason  = "ImageBuildStarted"
+	ImageBuildingErrorReason = "ImageBuildingError"
+
+	// Load balancers.
+	LoadBalancerCreatedReason           = "LoadBalancerCreated"
+	LoadBalancerCreatingErrorReason     = "LoadBalancerCreatingError"
+	LoadBalancerReadyReason             = "LoadBalancerReady"
+	LoadBalancerDeletedReason           = "LoadBalancerDeleted"
+	LoadBalancerDeletingErrorReason     = "LoadBalancerDeletingError"
+	NoLoadBalancerFoundReason           = "NoLoadBalancerFound"
+	LoadBalancerDeletedExternallyReason = "LoadBalancerDeletedExternally"
+
+	// Reserved IPs.
+	ReservedIPCreatedReason        = "ReservedIPCreated"
+	ReservedIPCreatingErrorReason  = "ReservedIPCreatingError"
+	ReservedIPAssignedReason       = "ReservedIPAssigned"
+	ReservedIPUnassignedReason     = "ReservedIPUnassigned"
+	ReservedIPAssigningErrorReason = "ReservedIPAssigningError"
+	ReservedIPReleasedReason       = "ReservedIPReleased"
+	ReservedIPReleasingErrorReason = "ReservedIPReleasingError"
+
+	// Control plane DNS records.
+	DomainRecordUpdatedReason       = "DomainRecordUpdated"
+	DomainRecordUpdatingErrorReason = "DomainRecordUpdatingError"
+	DomainRecordReadyReason         = "DomainRecordReady"
+	DomainRecordDeletedReason       = "DomainRecordDeleted"
+	DOClusterReadyReason            = "DOClusterReady"
+
+	// Drift between the DigitalOcean resources and their spec.
+	DriftDetectedReason = "DriftDetected"
+
+	// Rotation of the DigitalOcean token.
+	CredentialsRotatedReason = "CredentialsRotated"
+	InvalidCredentialsReason = "InvalidCredentials"
+
+	// Reconciles requested with ReconcileAnnotation.
+	ReconcileRequestedReason = "ReconcileRequested"
+
+	// DigitalOcean API.
+	RateLimitedReason = "RateLimited"
+	APITimeoutReason  = "APITimeout"
+)
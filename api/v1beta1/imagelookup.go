@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ImageLookupFormat is the template of the name of the image looked up
+	// for the DOMachines created with neither image nor imageRef, rendered
+	// with ImageLookupParams. It matches the names of the snapshots built by
+	// the Kubernetes image-builder, e.g. ubuntu-2004-kube-v1.21.2. Set from the
+	// --image-lookup-format flag of the manager, the image is required when
+	// empty.
+	ImageLookupFormat = "{{.BaseOS}}-kube-{{.K8sVersion}}"
+	// ImageLookupBaseOS is the BaseOS ImageLookupFormat is rendered with. Set
+	// from the --image-lookup-base-os flag of the manager.
+	ImageLookupBaseOS = "ubuntu-2004"
+)
+
+// ImageLookupParams are the values ImageLookupFormat is rendered with.
+// +kubebuilder:object:generate=false
+type ImageLookupParams struct {
+	// BaseOS is ImageLookupBaseOS.
+	BaseOS string
+	// K8sVersion is the Kubernetes version of the Machine, e.g. v1.21.2.
+	K8sVersion string
+}
+
+// ImageLookupName returns the name of the image looked up for a Machine of
+// the given Kubernetes version.
+func ImageLookupName(version string) (string, error) {
+	if ImageLookupFormat == "" {
+		return "", errors.New("image lookup is disabled")
+	}
+	tmpl, err := template.New("image").Option("missingkey=error").Parse(ImageLookupFormat)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid image lookup format")
+	}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, ImageLookupParams{BaseOS: ImageLookupBaseOS, K8sVersion: version}); err != nil {
+		return "", errors.Wrap(err, "invalid image lookup format")
+	}
+	return name.String(), nil
+}
+
+// HasImage returns whether the image of the droplet is set, either as an ID
+// or as a slug.
+func (in *DOMachineSpec) HasImage() bool {
+	image := in.Image.String()
+	return image != "" && image != "0"
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// LiveValidator checks the resources against the DigitalOcean API at
+// admission, e.g. that a droplet size is offered in the region of the cluster,
+// so that such mistakes are not only found once provisioning fails.
+// +kubebuilder:object:generate=false
+type LiveValidator interface {
+	// ValidateCluster validates the region and VPC of a DOCluster.
+	ValidateCluster(ctx context.Context, cluster *DOCluster) field.ErrorList
+	// ValidateMachineSpec validates the size, image and SSH keys of the
+	// machine spec at path, in the region of the DOCluster obj belongs to
+	// when it is known.
+	ValidateMachineSpec(ctx context.Context, obj metav1.Object, spec DOMachineSpec, path *field.Path) field.ErrorList
+}
+
+// Live, when set, is called by the validation webhooks of the DOClusters,
+// DOMachines and DOMachineTemplates being created once their static
+// validation passed. It is set from the --webhook-live-validation flag of the
+// manager.
+var Live LiveValidator
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildTags(t *testing.T) {
+	type args struct {
+		params BuildTagParams
+	}
+	tests := []struct {
+		name string
+		args args
+		want Tags
+	}{
+		{
+			name: "test tags",
+			args: args{
+				params: BuildTagParams{
+					ClusterName: "foo",
+					ClusterUID:  "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42",
+					Name:        "bar",
+					Role:        APIServerRoleTagValue,
+				},
+			},
+			want: Tags{
+				ClusterNameTag("foo"),
+				ClusterNameUIDTag("foo", "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42"),
+				ClusterNameRoleTag("foo", APIServerRoleTagValue),
+				ClusterNameUIDRoleTag("foo", "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42", APIServerRoleTagValue),
+				NameTagFromName("bar"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildTags(tt.args.params); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterUIDsFromTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "built tags",
+			tags: BuildTags(BuildTagParams{
+				ClusterName: "foo",
+				ClusterUID:  "155bd6ca-c6a9-45a8-8c9c-05e09b36bc42",
+				Name:        "bar",
+				Role:        NodeRoleTagValue,
+			}),
+			want: []string{"155bd6ca-c6a9-45a8-8c9c-05e09b36bc42"},
+		},
+		{
+			name: "tags of a moved cluster",
+			tags: []string{ClusterNameUIDTag("foo", "old"), ClusterNameUIDRoleTag("foo", "old", NodeRoleTagValue), ClusterNameUIDTag("foo", "new")},
+			want: []string{"old", "new"},
+		},
+		{
+			name: "tags of a cluster without UID tag",
+			tags: []string{ClusterNameTag("foo"), ClusterNameRoleTag("foo", APIServerRoleTagValue), NameTagFromName("bar")},
+		},
+		{
+			name: "no tags",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClusterUIDsFromTags(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClusterUIDsFromTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaleClusterUIDTags(t *testing.T) {
+	tags := append(BuildTags(BuildTagParams{ClusterName: "foo", ClusterUID: "old", Name: "bar", Role: NodeRoleTagValue}),
+		ClusterNameUIDTag("foo", "new"), ClusterNameUIDRoleTag("foo", "new", NodeRoleTagValue), ClusterNameUIDTag("foobar", "other"))
+	want := []string{ClusterNameUIDTag("foo", "old"), ClusterNameUIDRoleTag("foo", "old", NodeRoleTagValue)}
+	if got := StaleClusterUIDTags(tags, "foo", "new"); !reflect.DeepEqual(got, want) {
+		t.Errorf("StaleClusterUIDTags() = %v, want %v", got, want)
+	}
+}
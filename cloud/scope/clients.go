@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -17,14 +17,26 @@ package scope
 
 import (
 	"github.com/digitalocean/godo"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
 )
 
 type DOClients struct {
-	Actions       godo.ActionsService
-	Droplets      godo.DropletsService
-	Storage       godo.StorageService
-	Images        godo.ImagesService
-	Keys          godo.KeysService
-	LoadBalancers godo.LoadBalancersService
-	Domains       godo.DomainsService
+	Account           godo.AccountService
+	Actions           godo.ActionsService
+	Droplets          godo.DropletsService
+	DropletActions    godo.DropletActionsService
+	Storage           godo.StorageService
+	StorageActions    godo.StorageActionsService
+	Firewalls         godo.FirewallsService
+	FloatingIPs       godo.FloatingIPsService
+	FloatingIPActions godo.FloatingIPActionsService
+	Images            godo.ImagesService
+	Keys              godo.KeysService
+	LoadBalancers     godo.LoadBalancersService
+	Domains           godo.DomainsService
+	Regions           godo.RegionsService
+	Sizes             godo.SizesService
+	Tags              godo.TagsService
+	Catalog           *doclient.Catalog
 }
@@ -22,12 +22,12 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
-
-	"k8s.io/klog/v2/klogr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -50,13 +50,18 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		return nil, errors.New("DOCluster is required when creating a ClusterScope")
 	}
 	if params.Logger == nil {
-		params.Logger = klogr.New()
+		params.Logger = ctrl.Log
 	}
 
-	session, err := params.DOClients.Session()
+	cached, err := getSession(AccessToken())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create DO session")
 	}
+	session := cached.client
+
+	if params.DOClients.Account == nil {
+		params.DOClients.Account = session.Account
+	}
 
 	if params.DOClients.Actions == nil {
 		params.DOClients.Actions = session.Actions
@@ -66,10 +71,30 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		params.DOClients.Droplets = session.Droplets
 	}
 
+	if params.DOClients.DropletActions == nil {
+		params.DOClients.DropletActions = session.DropletActions
+	}
+
 	if params.DOClients.Storage == nil {
 		params.DOClients.Storage = session.Storage
 	}
 
+	if params.DOClients.StorageActions == nil {
+		params.DOClients.StorageActions = session.StorageActions
+	}
+
+	if params.DOClients.Firewalls == nil {
+		params.DOClients.Firewalls = session.Firewalls
+	}
+
+	if params.DOClients.FloatingIPs == nil {
+		params.DOClients.FloatingIPs = session.FloatingIPs
+	}
+
+	if params.DOClients.FloatingIPActions == nil {
+		params.DOClients.FloatingIPActions = session.FloatingIPActions
+	}
+
 	if params.DOClients.Images == nil {
 		params.DOClients.Images = session.Images
 	}
@@ -86,6 +111,22 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		params.DOClients.Domains = session.Domains
 	}
 
+	if params.DOClients.Regions == nil {
+		params.DOClients.Regions = session.Regions
+	}
+
+	if params.DOClients.Sizes == nil {
+		params.DOClients.Sizes = session.Sizes
+	}
+
+	if params.DOClients.Tags == nil {
+		params.DOClients.Tags = session.Tags
+	}
+
+	if params.DOClients.Catalog == nil {
+		params.DOClients.Catalog = cached.catalog
+	}
+
 	helper, err := patch.NewHelper(params.DOCluster, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
@@ -113,8 +154,13 @@ type ClusterScope struct {
 }
 
 // Close closes the current scope persisting the cluster configuration and status.
+// The Ready condition is set to the summary of the other conditions first.
 func (s *ClusterScope) Close() error {
-	return s.patchHelper.Patch(context.TODO(), s.DOCluster)
+	conditions.SetSummary(s.DOCluster, conditions.WithConditions(infrav1.LoadBalancerReadyCondition))
+	return s.patchHelper.Patch(context.TODO(), s.DOCluster, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		infrav1.LoadBalancerReadyCondition,
+	}})
 }
 
 // Name returns the cluster name.
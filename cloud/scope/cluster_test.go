@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClusterScopeCloseSetsReady(t *testing.T) {
+	g := NewWithT(t)
+	defer SetAccessToken("")
+	SetAccessToken("token")
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	docluster := &infrav1.DOCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster).Build()
+	newScope := func() *ClusterScope {
+		s, err := NewClusterScope(ClusterScopeParams{
+			Client:    c,
+			Cluster:   &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}},
+			DOCluster: docluster,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+	get := func() *infrav1.DOCluster {
+		got := &infrav1.DOCluster{}
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(docluster), got)).To(Succeed())
+		return got
+	}
+
+	s := newScope()
+	conditions.MarkFalse(docluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerProvisioningReason, clusterv1.ConditionSeverityInfo, "")
+	g.Expect(s.Close()).To(Succeed())
+	g.Expect(conditions.IsFalse(get(), clusterv1.ReadyCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(get(), clusterv1.ReadyCondition)).To(Equal(infrav1.LoadBalancerProvisioningReason))
+
+	docluster = get()
+	s = newScope()
+	conditions.MarkTrue(docluster, infrav1.LoadBalancerReadyCondition)
+	g.Expect(s.Close()).To(Succeed())
+	g.Expect(conditions.IsTrue(get(), clusterv1.ReadyCondition)).To(BeTrue())
+}
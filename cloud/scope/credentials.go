@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// DefaultCredentialsCheckInterval is how often CredentialsChecker validates
+// the manager token again.
+const DefaultCredentialsCheckInterval = 5 * time.Minute
+
+var (
+	credentialsMu sync.Mutex
+	// credentialsErr is the result of the last validation of the manager token.
+	credentialsErr       error
+	credentialsCheckedAt time.Time
+)
+
+var (
+	tokenScopesMu sync.Mutex
+	// tokenScopes is the result of the last probe of the scopes of the manager
+	// token, nil until it was probed.
+	tokenScopes map[doclient.Scope]doclient.ScopeStatus
+	// probedScopes caches the conclusive probes of each token, keyed by the
+	// token hash, so that a token is probed once per process, however often it
+	// is loaded again from its file or Secret.
+	probedScopes = map[string]map[doclient.Scope]doclient.ScopeStatus{}
+)
+
+func recordCredentials(err error) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	credentialsErr = err
+	credentialsCheckedAt = time.Now()
+}
+
+func recordScopes(statuses map[doclient.Scope]doclient.ScopeStatus) {
+	tokenScopesMu.Lock()
+	defer tokenScopesMu.Unlock()
+	tokenScopes = statuses
+}
+
+// ProbeScopes probes which scopes the manager token has, see
+// doclient.ProbeScopes, and returns the missing ones and the ones the probe
+// could not tell about. The result is used by CheckScopes until the token
+// changes. A token is only probed again when a probe could not tell about
+// some of its scopes.
+func ProbeScopes(ctx context.Context) (missing, unknown []doclient.Scope, err error) {
+	token := AccessToken()
+	key := sessionKey(token)
+	tokenScopesMu.Lock()
+	statuses, ok := probedScopes[key]
+	tokenScopesMu.Unlock()
+
+	if !ok {
+		s, err := getSession(token)
+		if err != nil {
+			return nil, nil, err
+		}
+		statuses, err = doclient.ProbeScopes(ctx, s.client)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for scope, status := range statuses {
+		switch status {
+		case doclient.ScopeMissing:
+			missing = append(missing, scope)
+		case doclient.ScopeUnknown:
+			unknown = append(unknown, scope)
+		}
+	}
+	recordScopes(statuses)
+	if len(unknown) == 0 {
+		tokenScopesMu.Lock()
+		probedScopes[key] = statuses
+		tokenScopesMu.Unlock()
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+	return missing, unknown, nil
+}
+
+// CheckScopes returns a doclient.MissingScopesError when the last probe found
+// that the manager token lacks some of scopes, so that the requests needing
+// them are not sent to fail with a 403 Forbidden. Scopes are assumed granted
+// until they are probed, and when the probe could not tell about them.
+func CheckScopes(scopes ...doclient.Scope) error {
+	tokenScopesMu.Lock()
+	defer tokenScopesMu.Unlock()
+	var missing []doclient.Scope
+	for _, scope := range scopes {
+		if tokenScopes[scope] == doclient.ScopeMissing {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return &doclient.MissingScopesError{Scopes: missing}
+	}
+	return nil
+}
+
+// CredentialsChecker returns a check failing while the manager token is
+// invalid. The token is validated again at most once per interval so that
+// probes do not use up its rate limit budget. Other failures, e.g. a
+// DigitalOcean API outage, do not fail the check.
+func CredentialsChecker(interval time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		credentialsMu.Lock()
+		stale := time.Since(credentialsCheckedAt) >= interval
+		err := credentialsErr
+		credentialsMu.Unlock()
+
+		if stale {
+			_, err = ValidateCredentials(req.Context())
+		}
+		if doclient.IsInvalidCredentials(err) {
+			return err
+		}
+		return nil
+	}
+}
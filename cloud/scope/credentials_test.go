@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+func TestCredentialsChecker(t *testing.T) {
+	g := NewWithT(t)
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer func() { sessionOptions = SessionOptions{} }()
+	g.Expect(InitSessions(SessionOptions{APIURL: s.URL})).To(Succeed())
+	defer SetAccessToken("")
+	SetAccessToken("token")
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	g.Expect(CredentialsChecker(0)(req)).To(Succeed())
+
+	s.FailNext(http.MethodGet, "/v2/account", http.StatusUnauthorized, 1)
+	g.Expect(CredentialsChecker(0)(req)).NotTo(Succeed())
+
+	// The failure is cached until the interval elapsed.
+	requests := len(s.Requests())
+	g.Expect(CredentialsChecker(time.Hour)(req)).NotTo(Succeed())
+	g.Expect(s.Requests()).To(HaveLen(requests))
+
+	// Failures saying nothing about the token do not make the manager unready.
+	s.FailNext(http.MethodGet, "/v2/account", http.StatusNotFound, 1)
+	g.Expect(CredentialsChecker(0)(req)).To(Succeed())
+
+	// Switching to a new token clears the failure.
+	s.FailNext(http.MethodGet, "/v2/account", http.StatusUnauthorized, 1)
+	g.Expect(CredentialsChecker(0)(req)).NotTo(Succeed())
+	SetAccessToken("rotated")
+	g.Expect(CredentialsChecker(time.Hour)(req)).To(Succeed())
+}
+
+func TestProbeScopes(t *testing.T) {
+	g := NewWithT(t)
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer func() { sessionOptions = SessionOptions{} }()
+	g.Expect(InitSessions(SessionOptions{APIURL: s.URL})).To(Succeed())
+	defer SetAccessToken("")
+	defer func() { probedScopes = map[string]map[doclient.Scope]doclient.ScopeStatus{} }()
+	SetAccessToken("token")
+
+	// Scopes are assumed granted until they are probed.
+	g.Expect(CheckScopes(doclient.ScopeDropletCreate)).To(Succeed())
+
+	// A token restricted to droplets.
+	s.FailNext(http.MethodPost, "/v2/load_balancers", http.StatusForbidden, 1)
+	s.FailNext(http.MethodPost, "/v2/firewalls", http.StatusForbidden, 1)
+	missing, unknown, err := ProbeScopes(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(missing).To(Equal([]doclient.Scope{doclient.ScopeFirewallCreate, doclient.ScopeLoadBalancerCreate}))
+	g.Expect(unknown).To(BeEmpty())
+	g.Expect(CheckScopes(doclient.ScopeDropletCreate, doclient.ScopeTagCreate)).To(Succeed())
+	err = CheckScopes(doclient.ScopeLoadBalancerCreate)
+	g.Expect(doclient.Classify(err)).To(Equal(doclient.ErrorClassUnauthorized))
+
+	// A token loaded again, e.g. on a reload of its file, is not probed again.
+	requests := len(s.Requests())
+	missing, _, err = ProbeScopes(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(missing).To(Equal([]doclient.Scope{doclient.ScopeFirewallCreate, doclient.ScopeLoadBalancerCreate}))
+	g.Expect(s.Requests()).To(HaveLen(requests))
+
+	// A rotated token is probed again, the scopes the probe can not tell
+	// about do not block their resources.
+	SetAccessToken("rotated")
+	g.Expect(CheckScopes(doclient.ScopeLoadBalancerCreate)).To(Succeed())
+	s.FailNext(http.MethodPost, "/v2/load_balancers", http.StatusServiceUnavailable, 1)
+	missing, unknown, err = ProbeScopes(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(missing).To(BeEmpty())
+	g.Expect(unknown).To(Equal([]doclient.Scope{doclient.ScopeLoadBalancerCreate}))
+	g.Expect(CheckScopes(doclient.ScopeLoadBalancerCreate)).To(Succeed())
+
+	// An inconclusive probe is not cached.
+	missing, unknown, err = ProbeScopes(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(missing).To(BeEmpty())
+	g.Expect(unknown).To(BeEmpty())
+
+	// Switching back to a probed token reuses its probe.
+	requests = len(s.Requests())
+	SetAccessToken("token")
+	g.Expect(CheckScopes(doclient.ScopeLoadBalancerCreate)).To(Succeed())
+	_, _, err = ProbeScopes(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Requests()).To(HaveLen(requests))
+	g.Expect(CheckScopes(doclient.ScopeLoadBalancerCreate)).NotTo(Succeed())
+}
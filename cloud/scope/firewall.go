@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FirewallScopeParams defines the input parameters used to create a new FirewallScope.
+type FirewallScopeParams struct {
+	DOClients
+	Client     client.Client
+	Logger     logr.Logger
+	DOFirewall *infrav1.DOFirewall
+}
+
+// NewFirewallScope creates a new FirewallScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration only on DOFirewallReconciler.
+func NewFirewallScope(params FirewallScopeParams) (*FirewallScope, error) {
+	if params.DOFirewall == nil {
+		return nil, errors.New("DOFirewall is required when creating a FirewallScope")
+	}
+	if params.Logger == nil {
+		params.Logger = ctrl.Log
+	}
+
+	cached, err := getSession(AccessToken())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DO session")
+	}
+	session := cached.client
+
+	if params.DOClients.Firewalls == nil {
+		params.DOClients.Firewalls = session.Firewalls
+	}
+
+	if params.DOClients.Tags == nil {
+		params.DOClients.Tags = session.Tags
+	}
+
+	if params.DOClients.Catalog == nil {
+		params.DOClients.Catalog = cached.catalog
+	}
+
+	helper, err := patch.NewHelper(params.DOFirewall, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &FirewallScope{
+		Logger:      params.Logger,
+		client:      params.Client,
+		DOClients:   params.DOClients,
+		DOFirewall:  params.DOFirewall,
+		patchHelper: helper,
+	}, nil
+}
+
+// FirewallScope defines the basic context for an actuator to operate upon.
+type FirewallScope struct {
+	logr.Logger
+	client      client.Client
+	patchHelper *patch.Helper
+
+	DOClients
+	DOFirewall *infrav1.DOFirewall
+}
+
+// Close closes the current scope persisting the firewall status.
+// The Ready condition is set to the summary of the other conditions first.
+func (s *FirewallScope) Close() error {
+	conditions.SetSummary(s.DOFirewall, conditions.WithConditions(infrav1.FirewallReadyCondition))
+	return s.patchHelper.Patch(context.TODO(), s.DOFirewall, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		infrav1.FirewallReadyCondition,
+	}})
+}
+
+// FirewallID returns the ID of the DigitalOcean firewall, if any.
+func (s *FirewallScope) FirewallID() string {
+	return s.DOFirewall.Status.FirewallID
+}
+
+// SetFirewall records the observed state of the DigitalOcean firewall.
+func (s *FirewallScope) SetFirewall(fw *godo.Firewall) {
+	s.DOFirewall.Status.FirewallID = fw.ID
+	s.DOFirewall.Status.Tags = fw.Tags
+	s.DOFirewall.Status.DropletIDs = fw.DropletIDs
+}
+
+// SetReady sets the DOFirewall Ready Status.
+func (s *FirewallScope) SetReady(ready bool) {
+	s.DOFirewall.Status.Ready = ready
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageScopeParams defines the input parameters used to create a new ImageScope.
+type ImageScopeParams struct {
+	DOClients
+	Client  client.Client
+	Logger  logr.Logger
+	DOImage *infrav1.DOImage
+}
+
+// NewImageScope creates a new ImageScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration only on DOImageReconciler.
+func NewImageScope(params ImageScopeParams) (*ImageScope, error) {
+	if params.DOImage == nil {
+		return nil, errors.New("DOImage is required when creating an ImageScope")
+	}
+	if params.Logger == nil {
+		params.Logger = ctrl.Log
+	}
+
+	cached, err := getSession(AccessToken())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DO session")
+	}
+	session := cached.client
+
+	if params.DOClients.Images == nil {
+		params.DOClients.Images = session.Images
+	}
+
+	helper, err := patch.NewHelper(params.DOImage, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &ImageScope{
+		Logger:      params.Logger,
+		client:      params.Client,
+		DOClients:   params.DOClients,
+		DOImage:     params.DOImage,
+		patchHelper: helper,
+	}, nil
+}
+
+// ImageScope defines the basic context for an actuator to operate upon.
+type ImageScope struct {
+	logr.Logger
+	client      client.Client
+	patchHelper *patch.Helper
+
+	DOClients
+	DOImage *infrav1.DOImage
+}
+
+// Close closes the current scope persisting the image status.
+// The Ready condition is set to the summary of the other conditions first.
+func (s *ImageScope) Close() error {
+	conditions.SetSummary(s.DOImage, conditions.WithConditions(infrav1.ImageReadyCondition))
+	return s.patchHelper.Patch(context.TODO(), s.DOImage, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		infrav1.ImageReadyCondition,
+	}})
+}
+
+// ImageID returns the ID of the DigitalOcean image, if any.
+func (s *ImageScope) ImageID() int {
+	return s.DOImage.Status.ImageID
+}
+
+// SnapshotName returns the name the build job gives to the snapshot of the
+// current generation of the DOImage.
+func (s *ImageScope) SnapshotName() string {
+	return infrav1.DOSafeName(fmt.Sprintf("%s-%s-%d", s.DOImage.Namespace, s.DOImage.Name, s.DOImage.Generation))
+}
+
+// SetImage records the DigitalOcean image of the DOImage.
+func (s *ImageScope) SetImage(image *godo.Image) {
+	s.DOImage.Status.ImageID = image.ID
+	s.DOImage.Status.ImageName = image.Name
+	s.DOImage.Status.Regions = image.Regions
+}
+
+// SetReady sets the DOImage Ready Status.
+func (s *ImageScope) SetReady(ready bool) {
+	s.DOImage.Status.Ready = ready
+}
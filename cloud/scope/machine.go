@@ -22,18 +22,19 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/klog/v2/klogr"
 	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -69,7 +70,7 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 	}
 
 	if params.Logger == nil {
-		params.Logger = klogr.New()
+		params.Logger = ctrl.Log
 	}
 
 	helper, err := patch.NewHelper(params.DOMachine, params.Client)
@@ -99,9 +100,14 @@ type MachineScope struct {
 	DOMachine *infrav1.DOMachine
 }
 
-// Close the MachineScope by updating the machine spec, machine status.
+// Close the MachineScope by updating the machine spec, machine status and
+// the Ready condition summarizing the other conditions.
 func (m *MachineScope) Close() error {
-	return m.patchHelper.Patch(context.TODO(), m.DOMachine)
+	conditions.SetSummary(m.DOMachine, conditions.WithConditions(infrav1.InstanceReadyCondition))
+	return m.patchHelper.Patch(context.TODO(), m.DOMachine, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		infrav1.InstanceReadyCondition,
+	}})
 }
 
 // Name returns the DOMachine name.
@@ -165,6 +171,11 @@ func (m *MachineScope) SetReady() {
 	m.DOMachine.Status.Ready = true
 }
 
+// SetNotReady sets the DOMachine Ready Status to false.
+func (m *MachineScope) SetNotReady() {
+	m.DOMachine.Status.Ready = false
+}
+
 // SetFailureMessage sets the DOMachine status error message.
 func (m *MachineScope) SetFailureMessage(v error) {
 	m.DOMachine.Status.FailureMessage = pointer.StringPtr(v.Error())
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReservedIPScopeParams defines the input parameters used to create a new ReservedIPScope.
+type ReservedIPScopeParams struct {
+	DOClients
+	Client       client.Client
+	Logger       logr.Logger
+	DOReservedIP *infrav1.DOReservedIP
+}
+
+// NewReservedIPScope creates a new ReservedIPScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration only on DOReservedIPReconciler.
+func NewReservedIPScope(params ReservedIPScopeParams) (*ReservedIPScope, error) {
+	if params.DOReservedIP == nil {
+		return nil, errors.New("DOReservedIP is required when creating a ReservedIPScope")
+	}
+	if params.Logger == nil {
+		params.Logger = ctrl.Log
+	}
+
+	cached, err := getSession(AccessToken())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DO session")
+	}
+	session := cached.client
+
+	if params.DOClients.Actions == nil {
+		params.DOClients.Actions = session.Actions
+	}
+
+	if params.DOClients.FloatingIPs == nil {
+		params.DOClients.FloatingIPs = session.FloatingIPs
+	}
+
+	if params.DOClients.FloatingIPActions == nil {
+		params.DOClients.FloatingIPActions = session.FloatingIPActions
+	}
+
+	helper, err := patch.NewHelper(params.DOReservedIP, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &ReservedIPScope{
+		Logger:       params.Logger,
+		client:       params.Client,
+		DOClients:    params.DOClients,
+		DOReservedIP: params.DOReservedIP,
+		patchHelper:  helper,
+	}, nil
+}
+
+// ReservedIPScope defines the basic context for an actuator to operate upon.
+type ReservedIPScope struct {
+	logr.Logger
+	client      client.Client
+	patchHelper *patch.Helper
+
+	DOClients
+	DOReservedIP *infrav1.DOReservedIP
+}
+
+// Close closes the current scope persisting the reserved IP configuration and status.
+// The Ready condition is set to the summary of the other conditions first.
+func (s *ReservedIPScope) Close() error {
+	conditions.SetSummary(s.DOReservedIP, conditions.WithConditions(infrav1.ReservedIPAssignedCondition))
+	return s.patchHelper.Patch(context.TODO(), s.DOReservedIP, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		infrav1.ReservedIPAssignedCondition,
+	}})
+}
+
+// Region returns the region of the reserved IP.
+func (s *ReservedIPScope) Region() string {
+	return s.DOReservedIP.Spec.Region
+}
+
+// IP returns the allocated reserved IP, if any.
+func (s *ReservedIPScope) IP() string {
+	return s.DOReservedIP.Status.IP
+}
+
+// SetIP records the allocated reserved IP.
+func (s *ReservedIPScope) SetIP(ip string) {
+	s.DOReservedIP.Status.IP = ip
+}
+
+// SetDropletID records the droplet the reserved IP is assigned to, 0 when unassigned.
+func (s *ReservedIPScope) SetDropletID(id int) {
+	s.DOReservedIP.Status.DropletID = id
+}
+
+// SetReady sets the DOReservedIP Ready Status.
+func (s *ReservedIPScope) SetReady(ready bool) {
+	s.DOReservedIP.Status.Ready = ready
+}
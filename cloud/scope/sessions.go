@@ -18,13 +18,193 @@ package scope
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// baseTransport holds the connection pool shared by the sessions of every token.
+var baseTransport http.RoundTripper = http.DefaultTransport
+
+// newTransport layers the DigitalOcean API client behaviors on top of base.
+// Each retry goes through the rate limiter again.
+func newTransport(base http.RoundTripper) http.RoundTripper {
+	rateLimit := doclient.NewRateLimitTransport(base)
+	rateLimit.ClusterRate = sessionOptions.ClusterRequestRate
+	rateLimit.ClusterBurst = sessionOptions.ClusterRequestBurst
+	return doclient.NewRetryTransport(rateLimit)
+}
+
+// cachedSession is the client of a token along with the state that has to be
+// shared by every reconcile using that token: the rate limiter tracking its
+// budget and the catalogs of its account.
+type cachedSession struct {
+	client  *godo.Client
+	catalog *doclient.Catalog
+}
+
+var (
+	sessionsMu sync.Mutex
+	// sessions caches a session per token, keyed by the token hash, so that
+	// reconciles do not each build their own HTTP client.
+	sessions = map[string]*cachedSession{}
 )
 
+func sessionKey(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// getSession returns the cached session of accessToken, creating it on first use.
+func getSession(accessToken string) (*cachedSession, error) {
+	key := sessionKey(accessToken)
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	if s, ok := sessions[key]; ok {
+		return s, nil
+	}
+
+	client, err := newSession(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	ttl := sessionOptions.CatalogTTL
+	if ttl <= 0 {
+		ttl = doclient.DefaultCatalogTTL
+	}
+	s := &cachedSession{client: client, catalog: doclient.NewCatalog(ttl)}
+	sessions[key] = s
+	return s, nil
+}
+
+// EvictSession drops the cached session of accessToken, e.g. once the token
+// was rotated or the identity holding it was deleted. Reconciles still holding
+// the session keep working until they complete.
+func EvictSession(accessToken string) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	delete(sessions, sessionKey(accessToken))
+}
+
+// SessionOptions configures the DigitalOcean API clients returned by Session.
+type SessionOptions struct {
+	// APIURL overrides the DigitalOcean API base URL. When empty the
+	// DIGITALOCEAN_API_URL env var is used, then the godo default.
+	APIURL string
+	// CABundle is the path to a PEM encoded CA bundle trusted in addition to
+	// the system roots. When empty the DIGITALOCEAN_CA_BUNDLE env var is used.
+	CABundle string
+	// Debug logs every DigitalOcean API call at doclient.DebugLogLevel.
+	Debug bool
+	// CatalogTTL is how long the regions, sizes, images and SSH keys catalogs,
+	// and the existing tags, are cached. Defaults to doclient.DefaultCatalogTTL.
+	CatalogTTL time.Duration
+	// RequestTimeout bounds every attempt of a DigitalOcean API request.
+	// Defaults to doclient.DefaultRequestTimeout.
+	RequestTimeout time.Duration
+	// ClusterRequestRate and ClusterRequestBurst bound the requests per hour
+	// of each cluster once the rate limit budget of its token is contended.
+	// They default to doclient.DefaultClusterRequestRate and
+	// doclient.DefaultClusterRequestBurst.
+	ClusterRequestRate  int
+	ClusterRequestBurst int
+}
+
+var sessionOptions SessionOptions
+
+var (
+	accessTokenMu sync.RWMutex
+	// accessToken is the token set by SetAccessToken. It takes precedence over
+	// the DIGITALOCEAN_ACCESS_TOKEN env var, which is only read at pod start.
+	accessToken string
+)
+
+// AccessToken returns the DigitalOcean token used by the sessions.
+func AccessToken() string {
+	accessTokenMu.RLock()
+	defer accessTokenMu.RUnlock()
+	if accessToken != "" {
+		return accessToken
+	}
+	return os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
+}
+
+// SetAccessToken replaces the DigitalOcean token used by every following
+// Session call, e.g. after the token was rotated. The session of the previous
+// token is evicted.
+func SetAccessToken(token string) {
+	accessTokenMu.Lock()
+	previous := accessToken
+	if previous == "" {
+		previous = os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
+	}
+	accessToken = token
+	accessTokenMu.Unlock()
+
+	if previous != token {
+		EvictSession(previous)
+		// Callers validate the new token before switching to it, its scopes
+		// are probed again.
+		recordCredentials(nil)
+		recordScopes(nil)
+	}
+}
+
+// InitSessions sets the options used by every following Session call.
+func InitSessions(opts SessionOptions) error {
+	if opts.APIURL != "" {
+		u, err := url.Parse(opts.APIURL)
+		if err != nil {
+			return errors.Wrap(err, "invalid DigitalOcean API URL")
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid DigitalOcean API URL %q: must be an absolute http(s) URL", opts.APIURL)
+		}
+	}
+
+	caBundle := opts.CABundle
+	if caBundle == "" {
+		caBundle = os.Getenv("DIGITALOCEAN_CA_BUNDLE")
+	}
+	base, err := doclient.NewBaseTransport(caBundle)
+	if err != nil {
+		return err
+	}
+
+	var rt http.RoundTripper = base
+	if opts.Debug {
+		rt = doclient.NewLoggingTransport(base, ctrl.Log.WithName("digitalocean-api"))
+	}
+	requestTimeout := opts.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = doclient.DefaultRequestTimeout
+	}
+	rt = doclient.NewTimeoutTransport(rt, requestTimeout)
+	rt = doclient.NewMetricsTransport(rt)
+	// Every attempt of a request, including its retries, gets its own span.
+	rt = doclient.NewTracingTransport(rt)
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	baseTransport = rt
+	sessions = map[string]*cachedSession{}
+	sessionOptions = opts
+	return nil
+}
+
 type TokenSource struct {
 	AccessToken string
 }
@@ -36,16 +216,69 @@ func (t *TokenSource) Token() (*oauth2.Token, error) {
 	return token, nil
 }
 
+// Session returns the cached client of the manager token.
 func (c *DOClients) Session() (*godo.Client, error) {
-	accessToken := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
+	s, err := getSession(AccessToken())
+	if err != nil {
+		return nil, err
+	}
+	return s.client, nil
+}
+
+// SessionCatalog returns the cached catalogs of the account of the manager token.
+func (c *DOClients) SessionCatalog() (*doclient.Catalog, error) {
+	s, err := getSession(AccessToken())
+	if err != nil {
+		return nil, err
+	}
+	return s.catalog, nil
+}
+
+// newSession builds an uncached client for accessToken with its own rate limiter.
+func newSession(accessToken string) (*godo.Client, error) {
 	if accessToken == "" {
 		return nil, errors.New("env var DIGITALOCEAN_ACCESS_TOKEN is required")
 	}
 
-	oc := oauth2.NewClient(context.Background(), &TokenSource{
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: newTransport(baseTransport)})
+	oc := oauth2.NewClient(ctx, &TokenSource{
 		AccessToken: accessToken,
 	})
 
-	client := godo.NewClient(oc)
+	opts := []godo.ClientOpt{}
+	apiURL := sessionOptions.APIURL
+	if apiURL == "" {
+		apiURL = os.Getenv("DIGITALOCEAN_API_URL")
+	}
+	if apiURL != "" {
+		// godo resolves request paths relative to the base URL, so it must end with a slash.
+		if !strings.HasSuffix(apiURL, "/") {
+			apiURL += "/"
+		}
+		opts = append(opts, godo.SetBaseURL(apiURL))
+	}
+
+	client, err := godo.New(oc, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DigitalOcean client")
+	}
 	return client, nil
 }
+
+// ValidateCredentials checks that the DigitalOcean token of the manager can be
+// used, see doclient.ValidateCredentials.
+func ValidateCredentials(ctx context.Context) (*godo.Account, error) {
+	account, err := ValidateAccessToken(ctx, AccessToken())
+	recordCredentials(err)
+	return account, err
+}
+
+// ValidateAccessToken checks that accessToken can be used, e.g. before
+// switching to it with SetAccessToken. It does not cache a session for it.
+func ValidateAccessToken(ctx context.Context, accessToken string) (*godo.Account, error) {
+	session, err := newSession(accessToken)
+	if err != nil {
+		return nil, &doclient.InvalidCredentialsError{Message: err.Error()}
+	}
+	return doclient.ValidateCredentials(ctx, session.Account)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestInitSessions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		apiURL  string
+		wantErr bool
+	}{
+		{name: "default", apiURL: ""},
+		{name: "https", apiURL: "https://api.example.com/"},
+		{name: "http without trailing slash", apiURL: "http://127.0.0.1:8080"},
+		{name: "relative", apiURL: "api.example.com", wantErr: true},
+		{name: "unsupported scheme", apiURL: "ftp://api.example.com/", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer func() { sessionOptions = SessionOptions{} }()
+
+			err := InitSessions(SessionOptions{APIURL: tc.apiURL})
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestSessionAPIURL(t *testing.T) {
+	g := NewWithT(t)
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+
+	defer os.Setenv("DIGITALOCEAN_ACCESS_TOKEN", os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"))
+	os.Setenv("DIGITALOCEAN_ACCESS_TOKEN", "token")
+	defer func() { sessionOptions = SessionOptions{} }()
+	g.Expect(InitSessions(SessionOptions{APIURL: s.URL})).To(Succeed())
+
+	client, err := (&DOClients{}).Session()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.BaseURL.String()).To(Equal(s.URL + "/"))
+
+	_, _, err = client.Account.Get(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Requests()).To(ConsistOf("GET /v2/account"))
+}
+
+func TestSessionCache(t *testing.T) {
+	g := NewWithT(t)
+	defer func() { sessionOptions = SessionOptions{} }()
+	g.Expect(InitSessions(SessionOptions{})).To(Succeed())
+
+	a, err := getSession("token-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	again, err := getSession("token-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(again).To(BeIdenticalTo(a))
+
+	b, err := getSession("token-b")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b).NotTo(BeIdenticalTo(a))
+	g.Expect(b.catalog).NotTo(BeIdenticalTo(a.catalog))
+
+	EvictSession("token-a")
+	evicted, err := getSession("token-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(evicted).NotTo(BeIdenticalTo(a))
+
+	defer SetAccessToken("")
+	SetAccessToken("token-b")
+	SetAccessToken("token-c")
+	rotated, err := getSession("token-b")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rotated).NotTo(BeIdenticalTo(b))
+
+	_, err = getSession("")
+	g.Expect(err).To(HaveOccurred())
+}
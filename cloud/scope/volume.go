@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VolumeScopeParams defines the input parameters used to create a new VolumeScope.
+type VolumeScopeParams struct {
+	DOClients
+	Client   client.Client
+	Logger   logr.Logger
+	DOVolume *infrav1.DOVolume
+}
+
+// NewVolumeScope creates a new VolumeScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration only on DOVolumeReconciler.
+func NewVolumeScope(params VolumeScopeParams) (*VolumeScope, error) {
+	if params.DOVolume == nil {
+		return nil, errors.New("DOVolume is required when creating a VolumeScope")
+	}
+	if params.Logger == nil {
+		params.Logger = ctrl.Log
+	}
+
+	cached, err := getSession(AccessToken())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DO session")
+	}
+	session := cached.client
+
+	if params.DOClients.Actions == nil {
+		params.DOClients.Actions = session.Actions
+	}
+
+	if params.DOClients.Storage == nil {
+		params.DOClients.Storage = session.Storage
+	}
+
+	if params.DOClients.StorageActions == nil {
+		params.DOClients.StorageActions = session.StorageActions
+	}
+
+	helper, err := patch.NewHelper(params.DOVolume, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &VolumeScope{
+		Logger:      params.Logger,
+		client:      params.Client,
+		DOClients:   params.DOClients,
+		DOVolume:    params.DOVolume,
+		patchHelper: helper,
+	}, nil
+}
+
+// VolumeScope defines the basic context for an actuator to operate upon.
+type VolumeScope struct {
+	logr.Logger
+	client      client.Client
+	patchHelper *patch.Helper
+
+	DOClients
+	DOVolume *infrav1.DOVolume
+}
+
+// Close closes the current scope persisting the volume configuration and status.
+// The Ready condition is set to the summary of the other conditions first.
+func (s *VolumeScope) Close() error {
+	conditions.SetSummary(s.DOVolume, conditions.WithConditions(infrav1.VolumeReadyCondition))
+	return s.patchHelper.Patch(context.TODO(), s.DOVolume, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		infrav1.VolumeReadyCondition,
+	}})
+}
+
+// Region returns the region of the volume.
+func (s *VolumeScope) Region() string {
+	return s.DOVolume.Spec.Region
+}
+
+// VolumeID returns the ID of the DigitalOcean volume, if any.
+func (s *VolumeScope) VolumeID() string {
+	return s.DOVolume.Status.VolumeID
+}
+
+// SetVolume records the observed state of the DigitalOcean volume.
+func (s *VolumeScope) SetVolume(vol *godo.Volume) {
+	s.DOVolume.Status.VolumeID = vol.ID
+	s.DOVolume.Status.SizeGigaBytes = vol.SizeGigaBytes
+	s.DOVolume.Status.DropletIDs = vol.DropletIDs
+}
+
+// SetReady sets the DOVolume Ready Status.
+func (s *VolumeScope) SetReady(ready bool) {
+	s.DOVolume.Status.Ready = ready
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computes
+
+import (
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// DefaultActionTimeout is how long a reconcile waits for a droplet action
+// before requeueing.
+const DefaultActionTimeout = 30 * time.Second
+
+// RunDropletAction submits a droplet action, e.g. a power off or a resize,
+// waits up to timeout for it to complete and reflects its state on the
+// DropletActionCondition of the DOMachine.
+func (s *Service) RunDropletAction(machineScope *scope.MachineScope, submit doclient.ActionSubmitter, timeout time.Duration) (*godo.Action, error) {
+	action, err := doclient.RunAction(s.ctx, s.scope.Actions, submit, timeout)
+	SetActionCondition(machineScope.DOMachine, infrav1.DropletActionCondition, action, err)
+	return action, err
+}
+
+// WaitForDropletAction waits up to timeout for a droplet action submitted by
+// an earlier reconcile and reflects its state on the DropletActionCondition.
+func (s *Service) WaitForDropletAction(machineScope *scope.MachineScope, actionID int, timeout time.Duration) (*godo.Action, error) {
+	action, _, err := s.scope.Actions.Get(s.ctx, actionID)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get action %d", actionID)
+		SetActionCondition(machineScope.DOMachine, infrav1.DropletActionCondition, nil, err)
+		return nil, err
+	}
+	action, err = doclient.WaitForAction(s.ctx, s.scope.Actions, action, timeout)
+	SetActionCondition(machineScope.DOMachine, infrav1.DropletActionCondition, action, err)
+	return action, err
+}
+
+// SetActionCondition reflects the outcome of a DigitalOcean action, as returned
+// by doclient.RunAction or doclient.WaitForAction, on the condition t of obj.
+func SetActionCondition(obj conditions.Setter, t clusterv1.ConditionType, action *godo.Action, err error) {
+	var inProgress *doclient.ActionInProgressError
+	var failed *doclient.ActionFailedError
+	switch {
+	case err == nil:
+		conditions.MarkTrue(obj, t)
+	case errors.As(err, &inProgress):
+		conditions.MarkFalse(obj, t, infrav1.ActionInProgressReason, clusterv1.ConditionSeverityInfo,
+			"%s action %d is in progress", action.Type, action.ID)
+	case errors.As(err, &failed):
+		conditions.MarkFalse(obj, t, infrav1.ActionFailedReason, clusterv1.ConditionSeverityError,
+			"%s action %d errored", action.Type, action.ID)
+	default:
+		conditions.MarkFalse(obj, t, infrav1.ActionSubmitFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	}
+}
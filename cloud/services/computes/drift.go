@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
+)
+
+// RemoveStaleDropletTags removes from droplet the tags of the previous UIDs of
+// the cluster, see tags.Service.RemoveStale. It returns the tags that were
+// removed.
+func (s *Service) RemoveStaleDropletTags(droplet *godo.Droplet) (infrav1.Tags, error) {
+	resource := godo.Resource{ID: strconv.Itoa(droplet.ID), Type: godo.DropletResourceType}
+	return tags.NewService(s.ctx, s.scope).RemoveStale(resource, droplet.Tags)
+}
+
+// ReconcileDropletDrift compares the droplet of a DOMachine with its spec.
+// Missing tags are restored, as the cluster relies on them to find its
+// droplets, a different size is only reported since resizing requires a
+// power off. It returns the drift that was corrected and the drift that was
+// not.
+func (s *Service) ReconcileDropletDrift(machineScope *scope.MachineScope, droplet *godo.Droplet) (corrected, uncorrected []string, err error) {
+	tagsvc := tags.NewService(s.ctx, s.scope)
+	want := tagsvc.Build(infrav1.DOSafeName(machineScope.Name()), machineScope.Role(), machineScope.AdditionalTags())
+	resource := godo.Resource{ID: strconv.Itoa(droplet.ID), Type: godo.DropletResourceType}
+	added, err := tagsvc.EnsureOnResource(resource, want, droplet.Tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(added) > 0 {
+		corrected = append(corrected, fmt.Sprintf("restored tags %s", strings.Join(added, ", ")))
+	}
+	removed, err := s.RemoveStaleDropletTags(droplet)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(removed) > 0 {
+		corrected = append(corrected, fmt.Sprintf("removed stale tags %s", strings.Join(removed, ", ")))
+	}
+
+	if size := machineScope.DOMachine.Spec.Size; droplet.SizeSlug != size {
+		uncorrected = append(uncorrected, fmt.Sprintf("size is %q instead of %q", droplet.SizeSlug, size))
+	}
+	return corrected, uncorrected, nil
+}
@@ -24,12 +24,20 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/pkg/errors"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
+// maxUserDataSize is the largest user data DigitalOcean accepts for a droplet.
+// The bootstrap data is passed as is, be it cloud-init or e.g. the machine
+// config of Talos, which embeds its manifests and grows faster.
+const maxUserDataSize = 64 * 1024
+
 // GetDroplet get a droplet instance.
 func (s *Service) GetDroplet(id string) (*godo.Droplet, error) {
 	if id == "" {
@@ -54,21 +62,62 @@ func (s *Service) GetDroplet(id string) (*godo.Droplet, error) {
 	return droplet, nil
 }
 
+// CreateDropletOptions are the resources resolved by the controller that the
+// droplet of a DOMachine is created with.
+type CreateDropletOptions struct {
+	// ImageID, when set, is used instead of the image of the DOMachine spec,
+	// e.g. the image of the DOImage it references.
+	ImageID int
+	// VolumeIDs are attached to the droplet in addition to its data disks.
+	VolumeIDs []string
+}
+
+// lookupMachineImageID returns the ID of the image named after the Kubernetes
+// version of the Machine, for the DOMachines created without image.
+func (s *Service) lookupMachineImageID(scope *scope.MachineScope) (int, error) {
+	version := scope.Machine.Spec.Version
+	if version == nil || *version == "" {
+		return 0, errors.New("the DOMachine has no image and its Machine has no Kubernetes version to look one up for")
+	}
+	name, err := infrav1.ImageLookupName(*version)
+	if err != nil {
+		return 0, err
+	}
+	return s.LookupImageID(name)
+}
+
 // CreateDroplet create a droplet instance.
-func (s *Service) CreateDroplet(scope *scope.MachineScope) (*godo.Droplet, error) {
+func (s *Service) CreateDroplet(scope *scope.MachineScope, opts CreateDropletOptions) (_ *godo.Droplet, reterr error) {
+	s, span := s.trace("computes.CreateDroplet")
+	defer func() { tracing.End(span, reterr) }()
+
 	s.scope.V(2).Info("Creating an instance for a machine")
 
 	bootstrapData, err := scope.GetBootstrapData()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode bootstrap data")
 	}
+	if len(bootstrapData) > maxUserDataSize {
+		return nil, &doclient.InvalidSpecError{
+			Message: fmt.Sprintf("the bootstrap data is %d bytes, more than the %d bytes of user data DigitalOcean accepts", len(bootstrapData), maxUserDataSize),
+		}
+	}
 
-	clusterName := infrav1.DOSafeName(s.scope.Name())
 	instanceName := infrav1.DOSafeName(scope.Name())
 
-	imageID, err := s.GetImageID(scope.DOMachine.Spec.Image)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed getting image")
+	imageID := opts.ImageID
+	switch {
+	case imageID != 0:
+	case scope.DOMachine.Spec.HasImage():
+		imageID, err = s.GetImageID(scope.DOMachine.Spec.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed getting image")
+		}
+	default:
+		imageID, err = s.lookupMachineImageID(scope)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	sshkeys := []godo.DropletCreateSSHKey{}
@@ -95,6 +144,9 @@ func (s *Service) CreateDroplet(scope *scope.MachineScope) (*godo.Droplet, error
 		}
 		volumes = append(volumes, godo.DropletCreateVolume{ID: vol.ID})
 	}
+	for _, id := range opts.VolumeIDs {
+		volumes = append(volumes, godo.DropletCreateVolume{ID: id})
+	}
 
 	request := &godo.DropletCreateRequest{
 		Name:    instanceName,
@@ -110,13 +162,11 @@ func (s *Service) CreateDroplet(scope *scope.MachineScope) (*godo.Droplet, error
 		VPCUUID:           s.scope.VPC().VPCUUID,
 	}
 
-	request.Tags = infrav1.BuildTags(infrav1.BuildTagParams{
-		ClusterName: clusterName,
-		ClusterUID:  s.scope.UID(),
-		Name:        instanceName,
-		Role:        scope.Role(),
-		Additional:  scope.AdditionalTags(),
-	})
+	tagsvc := tags.NewService(s.ctx, s.scope)
+	request.Tags = tagsvc.Build(instanceName, scope.Role(), scope.AdditionalTags())
+	if err := tagsvc.Ensure(request.Tags); err != nil {
+		return nil, err
+	}
 
 	droplet, _, err := s.scope.Droplets.Create(s.ctx, request)
 	if err != nil {
@@ -128,7 +178,10 @@ func (s *Service) CreateDroplet(scope *scope.MachineScope) (*godo.Droplet, error
 
 // DeleteDroplet delete a droplet instance.
 // Returns nil on success, error in all other cases.
-func (s *Service) DeleteDroplet(id string) error {
+func (s *Service) DeleteDroplet(id string) (reterr error) {
+	s, span := s.trace("computes.DeleteDroplet")
+	defer func() { tracing.End(span, reterr) }()
+
 	s.scope.V(2).Info("Attempting to delete instance", "instance-id", id)
 	if id == "" {
 		s.scope.Info("Instance does not have an instance id")
@@ -148,6 +201,14 @@ func (s *Service) DeleteDroplet(id string) error {
 	return nil
 }
 
+// ForgetDropletTags drops the name tag of the deleted droplet name from the
+// catalog, which would otherwise record a tag per droplet ever created.
+func (s *Service) ForgetDropletTags(name string) {
+	if s.scope.Catalog != nil {
+		s.scope.Catalog.ForgetTag(infrav1.NameTagFromName(name))
+	}
+}
+
 // GetDropletAddress convert droplet IPs to corev1.NodeAddresses.
 func (s *Service) GetDropletAddress(droplet *godo.Droplet) ([]corev1.NodeAddress, error) {
 	addresses := []corev1.NodeAddress{}
@@ -22,12 +22,13 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/pkg/errors"
 
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func (s *Service) GetImageID(imageSpec intstr.IntOrString) (int, error) {
-	var image *godo.Image
-
 	if imageSpec.IntValue() != 0 { // nolint
 		return imageSpec.IntValue(), nil
 	}
@@ -37,10 +38,41 @@ func (s *Service) GetImageID(imageSpec intstr.IntOrString) (int, error) {
 		return 0, fmt.Errorf("invalid image spec string %q", imageSpecStr)
 	}
 
-	image, _, err := s.scope.Images.GetBySlug(s.ctx, imageSpecStr)
+	image, err := s.scope.Catalog.ImageBySlug(s.ctx, s.scope.Images, imageSpecStr)
 	if err != nil {
 		return 0, errors.Wrap(err, "Unable to get image")
 	}
 
 	return image.ID, nil
 }
+
+// LookupImageID returns the ID of the most recent image of the account with
+// the given name that is available in the region of the cluster.
+func (s *Service) LookupImageID(name string) (int, error) {
+	var images []godo.Image
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := s.scope.Images.ListUser(s.ctx, opt)
+		images = append(images, page...)
+		return resp, err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list images")
+	}
+
+	region := s.scope.Region()
+	var found *godo.Image
+	for i := range images {
+		image := &images[i]
+		if image.Name != name || (image.Status != "" && image.Status != "available") || !sets.NewString(image.Regions...).Has(region) {
+			continue
+		}
+		// The creation times are RFC 3339 timestamps in UTC, which sort lexically.
+		if found == nil || image.Created > found.Created {
+			found = image
+		}
+	}
+	if found == nil {
+		return 0, errors.Errorf("no image named %q is available in region %s", name, region)
+	}
+	return found.ID, nil
+}
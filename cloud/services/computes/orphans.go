@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computes
+
+import (
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// ListClusterNameDroplets returns the droplets tagged with the name of the
+// cluster. They include the droplets of the clusters with the same name in
+// other namespaces, and the droplets of the cluster created before it was
+// moved, which do not carry its UID tag yet.
+func (s *Service) ListClusterNameDroplets() ([]godo.Droplet, error) {
+	return s.listDropletsByTag(infrav1.ClusterNameTag(infrav1.DOSafeName(s.scope.Name())))
+}
+
+// IsClusterDroplet returns whether droplet carries the UID tag of the cluster.
+func (s *Service) IsClusterDroplet(droplet *godo.Droplet) bool {
+	return hasTag(droplet.Tags, infrav1.ClusterNameUIDTag(infrav1.DOSafeName(s.scope.Name()), s.scope.UID()))
+}
+
+// ReconcileClusterDropletTags tags droplets, the droplets of the DOMachines of
+// the cluster, with the UID tag of the cluster and removes the tags of its
+// previous UIDs, e.g. after a clusterctl move, with a single request per tag
+// for all of them.
+func (s *Service) ReconcileClusterDropletTags(droplets []godo.Droplet) error {
+	tagsvc := tags.NewService(s.ctx, s.scope)
+	want := infrav1.Tags{infrav1.ClusterNameUIDTag(infrav1.DOSafeName(s.scope.Name()), s.scope.UID())}
+	resources := make([]tags.ResourceTags, 0, len(droplets))
+	for _, d := range droplets {
+		resources = append(resources, tags.ResourceTags{
+			Resource: godo.Resource{ID: strconv.Itoa(d.ID), Type: godo.DropletResourceType},
+			Want:     want,
+			Have:     d.Tags,
+		})
+	}
+	if _, err := tagsvc.EnsureOnResources(resources); err != nil {
+		return err
+	}
+	_, err := tagsvc.RemoveStaleFromResources(resources)
+	return err
+}
+
+// GetDropletByName returns the droplet of the cluster with the given name,
+// e.g. one created for a DOMachine whose status was never updated with its
+// ID. It returns nil when there is none.
+func (s *Service) GetDropletByName(name string) (*godo.Droplet, error) {
+	droplets, err := s.listDropletsByTag(infrav1.NameTagFromName(name))
+	if err != nil {
+		return nil, err
+	}
+	// The name tag is shared by the clusters with the same name.
+	uidTag := infrav1.ClusterNameUIDTag(infrav1.DOSafeName(s.scope.Name()), s.scope.UID())
+	for i := range droplets {
+		if droplets[i].Name == name && hasTag(droplets[i].Tags, uidTag) {
+			return &droplets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Service) listDropletsByTag(tag string) ([]godo.Droplet, error) {
+	var all []godo.Droplet
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		droplets, resp, err := s.scope.Droplets.ListByTag(s.ctx, tag, opt)
+		all = append(all, droplets...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list droplets tagged %q", tag)
+	}
+	return all, nil
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
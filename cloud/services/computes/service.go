@@ -19,7 +19,10 @@ package computes
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 )
 
 // Service holds a collection of interfaces.
@@ -35,3 +38,10 @@ func NewService(ctx context.Context, scope *scope.ClusterScope) *Service {
 		ctx:   ctx,
 	}
 }
+
+// trace starts the span of the service call name and returns the service
+// whose DigitalOcean API requests are recorded as its children.
+func (s *Service) trace(name string) (*Service, trace.Span) {
+	ctx, span := tracing.Start(s.ctx, name)
+	return &Service{scope: s.scope, ctx: ctx}, span
+}
@@ -20,24 +20,35 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/pkg/errors"
 
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// GetSSHKey returns the SSH key of the account with the ID, fingerprint or name
+// of sshkey.
 func (s *Service) GetSSHKey(sshkey intstr.IntOrString) (*godo.Key, error) {
-	var keys *godo.Key
-	var reterr error
-
-	if sshkey.IntValue() != 0 { // nolint
-		keys, _, reterr = s.scope.Keys.GetByID(s.ctx, sshkey.IntValue())
-	} else if sshkey.String() != "" && sshkey.String() != "0" {
-		keys, _, reterr = s.scope.Keys.GetByFingerprint(s.ctx, sshkey.String())
-	} else {
-		reterr = errors.New("Missing key id or fingerprint")
+	id, fingerprint := sshkey.IntValue(), sshkey.String()
+	if id == 0 && (fingerprint == "" || fingerprint == "0") { // nolint
+		return nil, errors.New("Missing key id or fingerprint")
 	}
 
-	if reterr != nil {
-		return nil, reterr
+	keys, err := s.scope.Catalog.SSHKeys(s.ctx, s.scope.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if key := doclient.FindSSHKey(keys, id, fingerprint); key != nil {
+		return key, nil
 	}
 
-	return keys, nil
+	// The key may have been added since the catalog was cached, list them again.
+	s.scope.Catalog.Invalidate()
+	keys, err = s.scope.Catalog.SSHKeys(s.ctx, s.scope.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if key := doclient.FindSSHKey(keys, id, fingerprint); key != nil {
+		return key, nil
+	}
+	return nil, errors.Errorf("SSH key %s not found", sshkey.String())
 }
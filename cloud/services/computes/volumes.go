@@ -22,14 +22,21 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/pkg/errors"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
 )
 
 // GetVolumeByName takes a volume name and returns a Volume if found.
 func (s *Service) GetVolumeByName(name string) (*godo.Volume, error) {
-	vols, _, err := s.scope.Storage.ListVolumes(s.ctx, &godo.ListVolumeParams{
-		Name:   name,
-		Region: s.scope.Region(),
+	var vols []godo.Volume
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := s.scope.Storage.ListVolumes(s.ctx, &godo.ListVolumeParams{
+			Name:        name,
+			Region:      s.scope.Region(),
+			ListOptions: opt,
+		})
+		vols = append(vols, page...)
+		return resp, err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list volumes: %w", err)
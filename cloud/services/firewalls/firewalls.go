@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewalls
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// GetFirewall returns the firewall id, or nil if it does not exist.
+func (s *Service) GetFirewall(id string) (*godo.Firewall, error) {
+	fw, res, err := s.scope.Firewalls.Get(s.ctx, id)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get firewall %s", id)
+	}
+	return fw, nil
+}
+
+// GetFirewallByName returns the firewall named name, or nil if there is none.
+func (s *Service) GetFirewallByName(name string) (*godo.Firewall, error) {
+	var fws []godo.Firewall
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := s.scope.Firewalls.List(s.ctx, opt)
+		fws = append(fws, page...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list firewalls")
+	}
+	for i := range fws {
+		if fws[i].Name == name {
+			return &fws[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Request returns the firewall of the DOFirewall, applied to the droplets
+// carrying dropletTags and to the droplets dropletIDs.
+func (s *Service) Request(dropletTags []string, dropletIDs []int) *godo.FirewallRequest {
+	dofirewall := s.scope.DOFirewall
+	req := &godo.FirewallRequest{
+		Name:          dofirewall.FirewallName(),
+		InboundRules:  []godo.InboundRule{},
+		OutboundRules: []godo.OutboundRule{},
+		DropletIDs:    append([]int{}, dropletIDs...),
+		Tags:          append([]string{}, dropletTags...),
+	}
+	for _, rule := range dofirewall.Spec.InboundRules {
+		req.InboundRules = append(req.InboundRules, godo.InboundRule{
+			Protocol:  rule.Protocol,
+			PortRange: portRange(rule.Protocol, rule.Ports),
+			Sources:   &godo.Sources{Addresses: rule.Sources.Addresses, Tags: rule.Sources.Tags},
+		})
+	}
+	for _, rule := range dofirewall.Spec.OutboundRules {
+		req.OutboundRules = append(req.OutboundRules, godo.OutboundRule{
+			Protocol:     rule.Protocol,
+			PortRange:    portRange(rule.Protocol, rule.Ports),
+			Destinations: &godo.Destinations{Addresses: rule.Destinations.Addresses, Tags: rule.Destinations.Tags},
+		})
+	}
+	sort.Strings(req.Tags)
+	sort.Ints(req.DropletIDs)
+	return req
+}
+
+// UpToDate returns whether the firewall fw already applies req.
+func UpToDate(fw *godo.Firewall, req *godo.FirewallRequest) bool {
+	current := &godo.FirewallRequest{
+		Name:          fw.Name,
+		InboundRules:  []godo.InboundRule{},
+		OutboundRules: []godo.OutboundRule{},
+		DropletIDs:    append([]int{}, fw.DropletIDs...),
+		Tags:          append([]string{}, fw.Tags...),
+	}
+	for _, rule := range fw.InboundRules {
+		rule.PortRange = portRange(rule.Protocol, rule.PortRange)
+		current.InboundRules = append(current.InboundRules, rule)
+	}
+	for _, rule := range fw.OutboundRules {
+		rule.PortRange = portRange(rule.Protocol, rule.PortRange)
+		current.OutboundRules = append(current.OutboundRules, rule)
+	}
+	sort.Strings(current.Tags)
+	sort.Ints(current.DropletIDs)
+	return reflect.DeepEqual(current, req)
+}
+
+// portRange returns the ports of a rule the way the API reports them.
+func portRange(protocol, ports string) string {
+	if protocol == "icmp" {
+		return ""
+	}
+	if ports == "all" {
+		return "0"
+	}
+	return ports
+}
+
+// CreateFirewall creates the firewall req.
+func (s *Service) CreateFirewall(req *godo.FirewallRequest) (*godo.Firewall, error) {
+	if err := s.ensureTags(req); err != nil {
+		return nil, err
+	}
+	fw, _, err := s.scope.Firewalls.Create(s.ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create firewall %s", req.Name)
+	}
+	return fw, nil
+}
+
+// UpdateFirewall replaces the rules and targets of the firewall id with req.
+func (s *Service) UpdateFirewall(id string, req *godo.FirewallRequest) (*godo.Firewall, error) {
+	if err := s.ensureTags(req); err != nil {
+		return nil, err
+	}
+	fw, _, err := s.scope.Firewalls.Update(s.ctx, id, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update firewall %s", id)
+	}
+	return fw, nil
+}
+
+// ensureTags creates the tags targeted by req, firewalls can only reference
+// existing tags, e.g. before the first droplet of a cluster is created.
+func (s *Service) ensureTags(req *godo.FirewallRequest) error {
+	all := append(infrav1.Tags{}, req.Tags...)
+	for _, rule := range req.InboundRules {
+		all = append(all, rule.Sources.Tags...)
+	}
+	for _, rule := range req.OutboundRules {
+		all = append(all, rule.Destinations.Tags...)
+	}
+	return tags.EnsureTags(s.ctx, s.scope.Tags, s.scope.Catalog, all)
+}
+
+// DeleteFirewall deletes the firewall id. Deleting a firewall that does not
+// exist is not an error.
+func (s *Service) DeleteFirewall(id string) error {
+	if res, err := s.scope.Firewalls.Delete(s.ctx, id); err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete firewall %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// GetImage returns the image id, or nil if it does not exist.
+func (s *Service) GetImage(id int) (*godo.Image, error) {
+	image, res, err := s.scope.Images.GetByID(s.ctx, id)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get image %d", id)
+	}
+	return image, nil
+}
+
+// GetLatestImageByTag returns the most recently created available image
+// carrying tag, or nil if there is none.
+func (s *Service) GetLatestImageByTag(tag string) (*godo.Image, error) {
+	var images []godo.Image
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := s.scope.Images.ListByTag(s.ctx, tag, opt)
+		images = append(images, page...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list images tagged %s", tag)
+	}
+	var latest *godo.Image
+	for i := range images {
+		if !available(&images[i]) {
+			continue
+		}
+		// Creation times are RFC 3339 timestamps in UTC, which sort lexically.
+		if latest == nil || images[i].Created > latest.Created {
+			latest = &images[i]
+		}
+	}
+	return latest, nil
+}
+
+// GetUserImageByName returns the available user image named name, or nil if
+// there is none.
+func (s *Service) GetUserImageByName(name string) (*godo.Image, error) {
+	var images []godo.Image
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := s.scope.Images.ListUser(s.ctx, opt)
+		images = append(images, page...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user images")
+	}
+	for i := range images {
+		if images[i].Name == name && available(&images[i]) {
+			return &images[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// available returns whether droplets can be created from the image. Images
+// without a status are snapshots, which are only listed once available.
+func available(image *godo.Image) bool {
+	return image.Status == "" || image.Status == "available"
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+type Service struct {
+	scope *scope.ImageScope
+	ctx   context.Context
+}
+
+// NewService returns a new service given the digitalocean api client.
+func NewService(ctx context.Context, scope *scope.ImageScope) *Service {
+	return &Service{
+		scope: scope,
+		ctx:   ctx,
+	}
+}
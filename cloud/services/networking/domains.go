@@ -21,12 +21,23 @@ import (
 	"net/http"
 
 	"github.com/digitalocean/godo"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 )
 
 // GetDomainRecord retrieves a single domain record from DO.
 func (s *Service) GetDomainRecord(domain, name, rType string) (*godo.DomainRecord, error) {
 	fqdn := fmt.Sprintf("%s.%s", name, domain)
-	records, resp, err := s.scope.Domains.RecordsByTypeAndName(s.ctx, domain, rType, fqdn, nil)
+	var records []godo.DomainRecord
+	var resp *godo.Response
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		var page []godo.DomainRecord
+		var err error
+		page, resp, err = s.scope.Domains.RecordsByTypeAndName(s.ctx, domain, rType, fqdn, opt)
+		records = append(records, page...)
+		return resp, err
+	})
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil, nil
@@ -45,7 +56,10 @@ func (s *Service) GetDomainRecord(domain, name, rType string) (*godo.DomainRecor
 }
 
 // UpsertDomainRecord creates or updates a DO domain record.
-func (s *Service) UpsertDomainRecord(domain, name, rType, data string) error {
+func (s *Service) UpsertDomainRecord(domain, name, rType, data string) (reterr error) {
+	s, span := s.trace("networking.UpsertDomainRecord")
+	defer func() { tracing.End(span, reterr) }()
+
 	record, err := s.GetDomainRecord(domain, name, rType)
 	if err != nil {
 		return fmt.Errorf("unable to get current DNS record from API: %s", err)
@@ -56,6 +70,7 @@ func (s *Service) UpsertDomainRecord(domain, name, rType, data string) error {
 		Data: data,
 		TTL:  30,
 	}
+	s.scope.V(2).Info("Upserting DNS record", "domain", domain, "record-name", name, "record-type", rType)
 	if record == nil {
 		_, _, err = s.scope.Domains.CreateRecord(s.ctx, domain, recordReq)
 	} else {
@@ -65,7 +80,10 @@ func (s *Service) UpsertDomainRecord(domain, name, rType, data string) error {
 }
 
 // DeleteDomainRecord removes a DO domain record.
-func (s *Service) DeleteDomainRecord(domain, name, rType string) error {
+func (s *Service) DeleteDomainRecord(domain, name, rType string) (reterr error) {
+	s, span := s.trace("networking.DeleteDomainRecord")
+	defer func() { tracing.End(span, reterr) }()
+
 	record, err := s.GetDomainRecord(domain, name, rType)
 	if err != nil {
 		return fmt.Errorf("unable to get current DNS record from API: %s", err)
@@ -73,6 +91,7 @@ func (s *Service) DeleteDomainRecord(domain, name, rType string) error {
 	if record == nil {
 		return nil
 	}
+	s.scope.V(2).Info("Deleting DNS record", "domain", domain, "record-name", name, "record-type", rType)
 	_, err = s.scope.Domains.DeleteRecord(s.ctx, domain, record.ID)
 	return err
 }
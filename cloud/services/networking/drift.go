@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
+)
+
+// ReconcileLoadBalancerDrift compares the API server load balancer with its
+// spec and reverts the settings, e.g. health check thresholds changed in the
+// DigitalOcean console, as well as missing tags. It returns the drift that
+// was corrected.
+func (s *Service) ReconcileLoadBalancerDrift(spec *infrav1.DOLoadBalancer, lb *godo.LoadBalancer) ([]string, error) {
+	want := s.loadBalancerRequest(spec)
+
+	var drift []string
+	if lb.Algorithm != want.Algorithm {
+		drift = append(drift, fmt.Sprintf("algorithm was %q", lb.Algorithm))
+	}
+	if !reflect.DeepEqual(lb.ForwardingRules, want.ForwardingRules) {
+		drift = append(drift, "forwarding rules were changed")
+	}
+	if lb.HealthCheck == nil || *lb.HealthCheck != *want.HealthCheck {
+		drift = append(drift, "health check was changed")
+	}
+	if lb.Tag != want.Tag {
+		drift = append(drift, fmt.Sprintf("droplet tag was %q", lb.Tag))
+	}
+	if len(drift) > 0 {
+		s.scope.V(2).Info("Reverting load balancer drift", "load-balancer-id", lb.ID, "drift", drift)
+		// The name of the load balancer is not part of the spec, keep a renamed one as is.
+		want.Name = lb.Name
+		if _, _, err := s.scope.LoadBalancers.Update(s.ctx, lb.ID, want); err != nil {
+			return nil, errors.Wrapf(err, "failed to update load balancer %s", lb.ID)
+		}
+	}
+
+	resource := godo.Resource{ID: lb.ID, Type: godo.LoadBalancerResourceType}
+	added, err := tags.NewService(s.ctx, s.scope).EnsureOnResource(resource, want.Tags, lb.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(added) > 0 {
+		drift = append(drift, fmt.Sprintf("tags %s were missing", strings.Join(added, ", ")))
+	}
+	removed, err := tags.NewService(s.ctx, s.scope).RemoveStale(resource, lb.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(removed) > 0 {
+		drift = append(drift, fmt.Sprintf("tags %s were stale", strings.Join(removed, ", ")))
+	}
+	return drift, nil
+}
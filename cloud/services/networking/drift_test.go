@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileLoadBalancerDrift(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+		Spec:       infrav1.DOClusterSpec{Region: "nyc1"},
+	}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		DOClients: scope.DOClients{LoadBalancers: c.LoadBalancers, Tags: c.Tags},
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster).Build(),
+		Cluster:   &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "uid"}},
+		DOCluster: docluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	svc := NewService(ctx, clusterScope)
+
+	spec := &infrav1.DOLoadBalancer{}
+	spec.ApplyDefault()
+	lb, err := svc.CreateLoadBalancer(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	drift, err := svc.ReconcileLoadBalancerDrift(spec, lb)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drift).To(BeEmpty())
+
+	// Edits made in the DigitalOcean console.
+	edited := svc.loadBalancerRequest(spec)
+	edited.HealthCheck.UnhealthyThreshold = 10
+	edited.Tags = nil
+	lb, _, err = c.LoadBalancers.Update(ctx, lb.ID, edited)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	drift, err = svc.ReconcileLoadBalancerDrift(spec, lb)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drift).To(HaveLen(2))
+
+	lb, _, err = c.LoadBalancers.Get(ctx, lb.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lb.HealthCheck.UnhealthyThreshold).To(Equal(infrav1.DefaultLBHealthCheckUnhealthyThreshold))
+	g.Expect(lb.Tags).To(ContainElement(infrav1.ClusterNameUIDTag("foo", "uid")))
+
+	drift, err = svc.ReconcileLoadBalancerDrift(spec, lb)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drift).To(BeEmpty())
+}
@@ -21,7 +21,9 @@ import (
 
 	"github.com/digitalocean/godo"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 )
 
 func (s *Service) GetLoadBalancer(id string) (*godo.LoadBalancer, error) {
@@ -40,10 +42,31 @@ func (s *Service) GetLoadBalancer(id string) (*godo.LoadBalancer, error) {
 	return lb, nil
 }
 
-func (s *Service) CreateLoadBalancer(spec *infrav1.DOLoadBalancer) (*godo.LoadBalancer, error) {
+func (s *Service) CreateLoadBalancer(spec *infrav1.DOLoadBalancer) (_ *godo.LoadBalancer, reterr error) {
+	s, span := s.trace("networking.CreateLoadBalancer")
+	defer func() { tracing.End(span, reterr) }()
+
+	request := s.loadBalancerRequest(spec)
+	if err := tags.NewService(s.ctx, s.scope).Ensure(request.Tags); err != nil {
+		return nil, err
+	}
+
+	s.scope.V(2).Info("Creating load balancer", "load-balancer-name", request.Name)
+	lb, _, err := s.scope.LoadBalancers.Create(s.ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.scope.V(2).Info("Created load balancer", "load-balancer-id", lb.ID)
+
+	return lb, nil
+}
+
+// loadBalancerRequest returns the request creating the API server load
+// balancer described by spec.
+func (s *Service) loadBalancerRequest(spec *infrav1.DOLoadBalancer) *godo.LoadBalancerRequest {
 	clusterName := infrav1.DOSafeName(s.scope.Name())
 	name := clusterName + "-" + infrav1.APIServerRoleTagValue + "-" + s.scope.UID()
-	request := &godo.LoadBalancerRequest{
+	return &godo.LoadBalancerRequest{
 		Name:      name,
 		Algorithm: spec.Algorithm,
 		Region:    s.scope.Region(),
@@ -64,21 +87,20 @@ func (s *Service) CreateLoadBalancer(spec *infrav1.DOLoadBalancer) (*godo.LoadBa
 			HealthyThreshold:       spec.HealthCheck.HealthyThreshold,
 		},
 		Tag:     infrav1.ClusterNameUIDRoleTag(clusterName, s.scope.UID(), infrav1.APIServerRoleTagValue),
+		Tags:    tags.NewService(s.ctx, s.scope).Build(name, infrav1.APIServerRoleTagValue, nil),
 		VPCUUID: s.scope.VPC().VPCUUID,
 	}
-
-	lb, _, err := s.scope.LoadBalancers.Create(s.ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return lb, nil
 }
 
-func (s *Service) DeleteLoadBalancer(id string) error {
+func (s *Service) DeleteLoadBalancer(id string) (reterr error) {
+	s, span := s.trace("networking.DeleteLoadBalancer")
+	defer func() { tracing.End(span, reterr) }()
+
+	s.scope.V(2).Info("Attempting to delete load balancer", "load-balancer-id", id)
 	if _, err := s.scope.LoadBalancers.Delete(s.ctx, id); err != nil {
 		return err
 	}
+	s.scope.V(2).Info("Deleted load balancer", "load-balancer-id", id)
 
 	return nil
 }
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservedips
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// DefaultActionTimeout is how long a reconcile waits for a reserved IP
+// assignment before requeueing.
+const DefaultActionTimeout = 30 * time.Second
+
+// GetReservedIP returns the reserved IP ip, or nil if it does not exist.
+func (s *Service) GetReservedIP(ip string) (*godo.FloatingIP, error) {
+	fip, res, err := s.scope.FloatingIPs.Get(s.ctx, ip)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get reserved IP %s", ip)
+	}
+	return fip, nil
+}
+
+// CreateReservedIP allocates a reserved IP in the region of the DOReservedIP.
+func (s *Service) CreateReservedIP() (*godo.FloatingIP, error) {
+	fip, _, err := s.scope.FloatingIPs.Create(s.ctx, &godo.FloatingIPCreateRequest{
+		Region: s.scope.Region(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to allocate reserved IP in region %s", s.scope.Region())
+	}
+	return fip, nil
+}
+
+// DeleteReservedIP releases the reserved IP ip. Releasing a reserved IP that
+// does not exist is not an error.
+func (s *Service) DeleteReservedIP(ip string) error {
+	if res, err := s.scope.FloatingIPs.Delete(s.ctx, ip); err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to release reserved IP %s", ip)
+	}
+	return nil
+}
+
+// AssignReservedIP assigns the reserved IP ip to the droplet dropletID, moving
+// it from the droplet it is assigned to if any, and waits up to timeout for
+// the assignment to complete.
+func (s *Service) AssignReservedIP(ip string, dropletID int, timeout time.Duration) (*godo.Action, error) {
+	return doclient.RunAction(s.ctx, s.scope.Actions, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return s.scope.FloatingIPActions.Assign(ctx, ip, dropletID)
+	}, timeout)
+}
+
+// UnassignReservedIP unassigns the reserved IP ip from its droplet and waits
+// up to timeout for it to complete.
+func (s *Service) UnassignReservedIP(ip string, timeout time.Duration) (*godo.Action, error) {
+	return doclient.RunAction(s.ctx, s.scope.Actions, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return s.scope.FloatingIPActions.Unassign(ctx, ip)
+	}, timeout)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tags
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// Build returns the standard tag set of a cluster resource with the given
+// name and role.
+func (s *Service) Build(name, role string, additional infrav1.Tags) infrav1.Tags {
+	return infrav1.BuildTags(infrav1.BuildTagParams{
+		ClusterName: infrav1.DOSafeName(s.scope.Name()),
+		ClusterUID:  s.scope.UID(),
+		Name:        name,
+		Role:        role,
+		Additional:  additional,
+	})
+}
+
+// Ensure creates the tags that do not exist yet. It is called before creating
+// a resource carrying them, so that a failure surfaces before the resource
+// exists rather than leaving it untagged and undiscoverable.
+func (s *Service) Ensure(tags infrav1.Tags) error {
+	return EnsureTags(s.ctx, s.scope.Tags, s.scope.Catalog, tags)
+}
+
+// EnsureTags creates the tags that do not exist yet with client, for the
+// services of resources not owned by a cluster. The tags recorded in catalog
+// are not looked up again, the ones shared by all the droplets of a cluster
+// would otherwise cost a request per droplet. catalog may be nil.
+func EnsureTags(ctx context.Context, client godo.TagsService, catalog *doclient.Catalog, tags infrav1.Tags) error {
+	for _, tag := range tags {
+		if catalog != nil && catalog.HasTag(tag) {
+			continue
+		}
+		_, res, err := client.Get(ctx, tag)
+		if err != nil {
+			if res == nil || res.StatusCode != http.StatusNotFound {
+				return errors.Wrapf(err, "failed to get tag %q", tag)
+			}
+			if _, _, err := client.Create(ctx, &godo.TagCreateRequest{Name: tag}); err != nil {
+				return errors.Wrapf(err, "failed to create tag %q", tag)
+			}
+		}
+		if catalog != nil {
+			catalog.AddTag(tag)
+		}
+	}
+	return nil
+}
+
+// ResourceTags is a resource along with the tags it should carry and the
+// ones it carries.
+type ResourceTags struct {
+	Resource godo.Resource
+	Want     infrav1.Tags
+	Have     []string
+}
+
+// EnsureOnResource adds the tags of want missing from have, the current tags
+// of the resource, to the resource. It returns the tags that were added.
+func (s *Service) EnsureOnResource(resource godo.Resource, want infrav1.Tags, have []string) (infrav1.Tags, error) {
+	added, err := s.EnsureOnResources([]ResourceTags{{Resource: resource, Want: want, Have: have}})
+	return added[resource], err
+}
+
+// EnsureOnResources adds to each resource the tags it is missing. The
+// DigitalOcean API tags resources one tag at a time, the resources missing
+// the same tag, e.g. the droplets of a cluster missing its UID tag after a
+// clusterctl move, are tagged with a single request. It returns the tags
+// that were added to each resource.
+func (s *Service) EnsureOnResources(resources []ResourceTags) (map[godo.Resource]infrav1.Tags, error) {
+	var tags infrav1.Tags
+	missing := map[string][]godo.Resource{}
+	for _, r := range resources {
+		current := make(map[string]bool, len(r.Have))
+		for _, tag := range r.Have {
+			current[tag] = true
+		}
+		for _, tag := range r.Want {
+			if current[tag] {
+				continue
+			}
+			if _, ok := missing[tag]; !ok {
+				tags = append(tags, tag)
+			}
+			missing[tag] = append(missing[tag], r.Resource)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	if err := s.Ensure(tags); err != nil {
+		return nil, err
+	}
+	added := map[godo.Resource]infrav1.Tags{}
+	for _, tag := range tags {
+		s.scope.V(2).Info("Tagging resources", "tag", tag, "resources", len(missing[tag]))
+		req := &godo.TagResourcesRequest{Resources: missing[tag]}
+		if res, err := s.scope.Tags.TagResources(s.ctx, tag, req); err != nil {
+			// The tag was deleted since it was recorded, create it again on
+			// the next reconcile.
+			if res != nil && res.StatusCode == http.StatusNotFound && s.scope.Catalog != nil {
+				s.scope.Catalog.ForgetTag(tag)
+			}
+			return added, errors.Wrapf(err, "failed to tag %d resources with %q", len(missing[tag]), tag)
+		}
+		for _, r := range missing[tag] {
+			added[r] = append(added[r], tag)
+		}
+	}
+	return added, nil
+}
+
+// RemoveStale removes from the resource the tags of the previous UIDs of the
+// cluster among have, its current tags. A Cluster gets a new UID when it is
+// moved to another management cluster, e.g. with clusterctl move, and the
+// garbage collector would otherwise still see the resource as belonging to
+// the Cluster of the previous UID. It returns the tags that were removed.
+func (s *Service) RemoveStale(resource godo.Resource, have []string) (infrav1.Tags, error) {
+	removed, err := s.RemoveStaleFromResources([]ResourceTags{{Resource: resource, Have: have}})
+	return removed[resource], err
+}
+
+// RemoveStaleFromResources removes from each resource the tags of the
+// previous UIDs of the cluster, see RemoveStale, with a single request per
+// tag. It returns the tags that were removed from each resource.
+func (s *Service) RemoveStaleFromResources(resources []ResourceTags) (map[godo.Resource]infrav1.Tags, error) {
+	var tags []string
+	stale := map[string][]godo.Resource{}
+	for _, r := range resources {
+		for _, tag := range infrav1.StaleClusterUIDTags(r.Have, infrav1.DOSafeName(s.scope.Name()), s.scope.UID()) {
+			if _, ok := stale[tag]; !ok {
+				tags = append(tags, tag)
+			}
+			stale[tag] = append(stale[tag], r.Resource)
+		}
+	}
+
+	removed := map[godo.Resource]infrav1.Tags{}
+	for _, tag := range tags {
+		s.scope.V(2).Info("Untagging resources", "tag", tag, "resources", len(stale[tag]))
+		req := &godo.UntagResourcesRequest{Resources: stale[tag]}
+		if res, err := s.scope.Tags.UntagResources(s.ctx, tag, req); err != nil && (res == nil || res.StatusCode != http.StatusNotFound) {
+			return removed, errors.Wrapf(err, "failed to untag %d resources with %q", len(stale[tag]), tag)
+		}
+		for _, r := range stale[tag] {
+			removed[r] = append(removed[r], tag)
+		}
+	}
+	return removed, nil
+}
+
+// DeleteClusterTags deletes the tags of the cluster once its resources are
+// gone. Tags unique to the cluster are always deleted, the tags derived from
+// the cluster name only are kept while resources of another cluster with the
+// same name still carry them.
+func (s *Service) DeleteClusterTags() error {
+	clusterName := infrav1.DOSafeName(s.scope.Name())
+	uidTag := infrav1.ClusterNameUIDTag(clusterName, s.scope.UID())
+	shared := map[string]bool{
+		infrav1.ClusterNameTag(clusterName):                                    true,
+		infrav1.ClusterNameRoleTag(clusterName, infrav1.APIServerRoleTagValue): true,
+		infrav1.ClusterNameRoleTag(clusterName, infrav1.NodeRoleTagValue):      true,
+	}
+
+	var all []godo.Tag
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		tags, resp, err := s.scope.Tags.List(s.ctx, opt)
+		all = append(all, tags...)
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list tags")
+	}
+
+	for _, tag := range all {
+		unique := tag.Name == uidTag || strings.HasPrefix(tag.Name, uidTag+":")
+		unused := tag.Resources == nil || tag.Resources.Count == 0
+		if !unique && !(shared[tag.Name] && unused) {
+			continue
+		}
+		s.scope.V(2).Info("Deleting tag", "tag", tag.Name)
+		if s.scope.Catalog != nil {
+			s.scope.Catalog.ForgetTag(tag.Name)
+		}
+		if res, err := s.scope.Tags.Delete(s.ctx, tag.Name); err != nil {
+			if res != nil && res.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return errors.Wrapf(err, "failed to delete tag %q", tag.Name)
+		}
+	}
+	return nil
+}
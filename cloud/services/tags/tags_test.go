@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tags
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestService(t *testing.T, s *fakedo.Server, uid string) *Service {
+	g := NewWithT(t)
+
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	docluster := &infrav1.DOCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-" + uid, Name: "foo"}}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		DOClients: scope.DOClients{Account: c.Account, Actions: c.Actions, Droplets: c.Droplets, DropletActions: c.DropletActions, Storage: c.Storage,
+			Images: c.Images, Keys: c.Keys, LoadBalancers: c.LoadBalancers, Domains: c.Domains, Regions: c.Regions, Sizes: c.Sizes, Tags: c.Tags},
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster).Build(),
+		Cluster:   &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-" + uid, Name: "foo", UID: types.UID(uid)}},
+		DOCluster: docluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	return NewService(context.Background(), clusterScope)
+}
+
+func TestTagLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	a := newTestService(t, s, "uid-a")
+	b := newTestService(t, s, "uid-b")
+
+	tagsA := a.Build("foo-node-0", infrav1.NodeRoleTagValue, infrav1.Tags{"team:infra"})
+	g.Expect(tagsA).To(ContainElement(infrav1.ClusterNameUIDTag("foo", "uid-a")))
+	g.Expect(a.Ensure(tagsA)).To(Succeed())
+	g.Expect(a.Ensure(tagsA)).To(Succeed())
+	g.Expect(s.Tags()).To(ContainElements([]string(tagsA)))
+
+	// Cluster b shares the name of cluster a and still has a droplet.
+	tagsB := b.Build("foo-node-0", infrav1.NodeRoleTagValue, nil)
+	g.Expect(b.Ensure(tagsB)).To(Succeed())
+	_, _, err := a.scope.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name: "foo-node-0", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42}, Tags: tagsB,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(a.DeleteClusterTags()).To(Succeed())
+	g.Expect(s.Tags()).NotTo(ContainElement(infrav1.ClusterNameUIDTag("foo", "uid-a")))
+	g.Expect(s.Tags()).NotTo(ContainElement(infrav1.ClusterNameUIDRoleTag("foo", "uid-a", infrav1.NodeRoleTagValue)))
+	g.Expect(s.Tags()).To(ContainElements(
+		infrav1.ClusterNameTag("foo"),
+		infrav1.ClusterNameRoleTag("foo", infrav1.NodeRoleTagValue),
+		infrav1.ClusterNameUIDTag("foo", "uid-b"),
+		"team:infra",
+	))
+}
+
+func TestEnsureRecordsExistingTags(t *testing.T) {
+	g := NewWithT(t)
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	svc := newTestService(t, s, "uid-c")
+	svc.scope.Catalog = doclient.NewCatalog(time.Minute)
+
+	tags := svc.Build("foo-node-0", infrav1.NodeRoleTagValue, nil)
+	g.Expect(svc.Ensure(tags)).To(Succeed())
+	g.Expect(s.Tags()).To(ContainElements([]string(tags)))
+	requests := len(s.Requests())
+
+	// The tags shared by the droplets of the cluster are not looked up again.
+	g.Expect(svc.Ensure(svc.Build("foo-node-1", infrav1.NodeRoleTagValue, nil))).To(Succeed())
+	g.Expect(s.Requests()[requests:]).To(ConsistOf(
+		"GET /v2/tags/"+infrav1.NameTagFromName("foo-node-1"),
+		"POST /v2/tags",
+	))
+
+	// Deleted tags are created again.
+	g.Expect(svc.DeleteClusterTags()).To(Succeed())
+	g.Expect(svc.Ensure(tags)).To(Succeed())
+	g.Expect(s.Tags()).To(ContainElements([]string(tags)))
+}
+
+func TestRemoveStale(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	before := newTestService(t, s, "uid-before")
+	after := newTestService(t, s, "uid-after")
+
+	// A droplet created before the Cluster was moved, then tagged again by
+	// the drift check after it.
+	tags := append(before.Build("foo-node-0", infrav1.NodeRoleTagValue, nil), after.Build("foo-node-0", infrav1.NodeRoleTagValue, nil)...)
+	g.Expect(after.Ensure(tags)).To(Succeed())
+	droplet, _, err := after.scope.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name: "foo-node-0", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42}, Tags: tags,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	resource := godo.Resource{ID: strconv.Itoa(droplet.ID), Type: godo.DropletResourceType}
+	removed, err := after.RemoveStale(resource, droplet.Tags)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(ConsistOf(
+		infrav1.ClusterNameUIDTag("foo", "uid-before"),
+		infrav1.ClusterNameUIDRoleTag("foo", "uid-before", infrav1.NodeRoleTagValue),
+	))
+	droplet, _, err = after.scope.Droplets.Get(ctx, droplet.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(infrav1.ClusterUIDsFromTags(droplet.Tags)).To(ConsistOf("uid-after"))
+}
+
+func TestEnsureOnResourcesBatchesByTag(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	svc := newTestService(t, s, "uid-d")
+
+	var resources []ResourceTags
+	for _, name := range []string{"foo-node-0", "foo-node-1", "foo-node-2"} {
+		d, _, err := svc.scope.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: name, Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		resources = append(resources, ResourceTags{
+			Resource: godo.Resource{ID: strconv.Itoa(d.ID), Type: godo.DropletResourceType},
+			Want:     infrav1.Tags{"team:infra", "env:prod"},
+			Have:     d.Tags,
+		})
+	}
+	g.Expect(svc.Ensure(infrav1.Tags{"team:infra", "env:prod"})).To(Succeed())
+	requests := len(s.Requests())
+
+	added, err := svc.EnsureOnResources(resources)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(added).To(HaveLen(3))
+	g.Expect(s.Requests()[requests:]).To(ConsistOf(
+		"POST /v2/tags/team:infra/resources",
+		"POST /v2/tags/env:prod/resources",
+	))
+	for _, d := range s.Droplets() {
+		g.Expect(d.Tags).To(ConsistOf("team:infra", "env:prod"))
+	}
+}
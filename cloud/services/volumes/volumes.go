@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumes
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// DefaultActionTimeout is how long a reconcile waits for a volume resize
+// before requeueing.
+const DefaultActionTimeout = 30 * time.Second
+
+// GetVolume returns the volume id, or nil if it does not exist.
+func (s *Service) GetVolume(id string) (*godo.Volume, error) {
+	vol, res, err := s.scope.Storage.GetVolume(s.ctx, id)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get volume %s", id)
+	}
+	return vol, nil
+}
+
+// GetVolumeByName returns the volume named name in the region of the
+// DOVolume, or nil if there is none.
+func (s *Service) GetVolumeByName(name string) (*godo.Volume, error) {
+	var vols []godo.Volume
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := s.scope.Storage.ListVolumes(s.ctx, &godo.ListVolumeParams{
+			Name:        name,
+			Region:      s.scope.Region(),
+			ListOptions: opt,
+		})
+		vols = append(vols, page...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list volumes")
+	}
+	if len(vols) == 0 {
+		return nil, nil
+	}
+	if len(vols) > 1 {
+		return nil, errors.New("volume names are not unique per region")
+	}
+	return &vols[0], nil
+}
+
+// CreateVolume creates the block storage volume of the DOVolume.
+func (s *Service) CreateVolume(name string) (*godo.Volume, error) {
+	spec := s.scope.DOVolume.Spec
+	vol, _, err := s.scope.Storage.CreateVolume(s.ctx, &godo.VolumeCreateRequest{
+		Region:          spec.Region,
+		Name:            name,
+		SizeGigaBytes:   spec.SizeGigaBytes,
+		FilesystemType:  spec.FilesystemType,
+		FilesystemLabel: spec.FilesystemLabel,
+		Tags:            spec.Tags,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create volume %s", name)
+	}
+	return vol, nil
+}
+
+// ResizeVolume grows the volume id to sizeGigaBytes and waits up to timeout
+// for the resize to complete.
+func (s *Service) ResizeVolume(id string, sizeGigaBytes int64, timeout time.Duration) (*godo.Action, error) {
+	return doclient.RunAction(s.ctx, s.scope.Actions, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return s.scope.StorageActions.Resize(ctx, id, int(sizeGigaBytes), s.scope.Region())
+	}, timeout)
+}
+
+// DeleteVolume deletes the volume id. Deleting a volume that does not exist
+// is not an error.
+func (s *Service) DeleteVolume(id string) error {
+	if res, err := s.scope.Storage.DeleteVolume(s.ctx, id); err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete volume %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates the resources against the DigitalOcean API at
+// admission.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultTimeout bounds the DigitalOcean API calls of a validation, below the
+// timeout of the webhooks.
+const DefaultTimeout = 5 * time.Second
+
+// LiveValidator implements infrav1.LiveValidator with the cached catalogs of
+// the manager token. It is best effort: the checks that can not reach the
+// DigitalOcean API are skipped rather than rejecting the request.
+type LiveValidator struct {
+	// Client reads the Clusters and DOClusters of the validated DOMachines.
+	Client client.Reader
+	Log    logr.Logger
+	// Timeout bounds the DigitalOcean API calls of a validation. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+
+	// session returns the DigitalOcean client and catalogs. Defaults to the
+	// session of the manager token.
+	session func() (*godo.Client, *doclient.Catalog, error)
+}
+
+var _ infrav1.LiveValidator = &LiveValidator{}
+
+// ValidateCluster checks that the region of cluster is available and that its
+// VPC exists in that region.
+func (v *LiveValidator) ValidateCluster(ctx context.Context, cluster *infrav1.DOCluster) field.ErrorList {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout())
+	defer cancel()
+	c, catalog, err := v.getSession()
+	if err != nil {
+		v.skip(err, "session")
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	region := cluster.Spec.Region
+	regions, err := catalog.Regions(ctx, c.Regions)
+	if err != nil {
+		v.skip(err, "region")
+	} else {
+		allErrs = append(allErrs, validateRegion(regions, region, field.NewPath("spec", "region"))...)
+	}
+
+	if uuid := cluster.Spec.Network.VPC.VPCUUID; uuid != "" {
+		path := field.NewPath("spec", "network", "vpc", "vpc_uuid")
+		vpc, _, err := c.VPCs.Get(ctx, uuid)
+		switch {
+		case doclient.IsNotFound(err):
+			allErrs = append(allErrs, field.NotFound(path, uuid))
+		case err != nil:
+			v.skip(err, "vpc")
+		case vpc.RegionSlug != region:
+			allErrs = append(allErrs, field.Invalid(path, uuid, fmt.Sprintf("VPC is in region %s, not %s", vpc.RegionSlug, region)))
+		}
+	}
+	return allErrs
+}
+
+// ValidateMachineSpec checks that the size of spec is offered, that its image
+// and SSH keys exist and, when the DOCluster of obj is known, that the size
+// and image are available in its region.
+func (v *LiveValidator) ValidateMachineSpec(ctx context.Context, obj metav1.Object, spec infrav1.DOMachineSpec, path *field.Path) field.ErrorList {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout())
+	defer cancel()
+	c, catalog, err := v.getSession()
+	if err != nil {
+		v.skip(err, "session")
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	region := v.clusterRegion(ctx, obj)
+
+	sizes, err := catalog.Sizes(ctx, c.Sizes)
+	if err != nil {
+		v.skip(err, "size")
+	} else {
+		allErrs = append(allErrs, validateSize(sizes, spec.Size, region, path.Child("size"))...)
+	}
+
+	// Golden images are validated by the DOImage controller, and the images
+	// looked up by Kubernetes version once the Machine is known.
+	if spec.HasImage() {
+		allErrs = append(allErrs, v.validateImage(ctx, c, catalog, spec.Image, region, path.Child("image"))...)
+	}
+
+	if len(spec.SSHKeys) > 0 {
+		allErrs = append(allErrs, v.validateSSHKeys(ctx, c, catalog, spec.SSHKeys, path.Child("sshKeys"))...)
+	}
+	return allErrs
+}
+
+func (v *LiveValidator) validateImage(ctx context.Context, c *godo.Client, catalog *doclient.Catalog, spec intstr.IntOrString, region string, path *field.Path) field.ErrorList {
+	var (
+		image *godo.Image
+		err   error
+	)
+	if id := spec.IntValue(); id != 0 {
+		image, _, err = c.Images.GetByID(ctx, id)
+	} else {
+		image, err = catalog.ImageBySlug(ctx, c.Images, spec.String())
+	}
+	switch {
+	case doclient.IsNotFound(err):
+		return field.ErrorList{field.NotFound(path, spec.String())}
+	case err != nil:
+		v.skip(err, "image")
+		return nil
+	case image.Status != "" && image.Status != "available":
+		return field.ErrorList{field.Invalid(path, spec.String(), fmt.Sprintf("image is %s", image.Status))}
+	case region != "" && len(image.Regions) > 0 && !contains(image.Regions, region):
+		return field.ErrorList{field.Invalid(path, spec.String(), fmt.Sprintf("image is not available in region %s", region))}
+	}
+	return nil
+}
+
+func (v *LiveValidator) validateSSHKeys(ctx context.Context, c *godo.Client, catalog *doclient.Catalog, sshKeys []intstr.IntOrString, path *field.Path) field.ErrorList {
+	keys, err := catalog.SSHKeys(ctx, c.Keys)
+	if err != nil {
+		v.skip(err, "ssh-keys")
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	refreshed := false
+	for i, sshKey := range sshKeys {
+		id, ref := sshKey.IntValue(), sshKey.String()
+		if doclient.FindSSHKey(keys, id, ref) != nil {
+			continue
+		}
+
+		// The key may have been added since the catalog was cached.
+		if !refreshed {
+			refreshed = true
+			catalog.Invalidate()
+			if keys, err = catalog.SSHKeys(ctx, c.Keys); err != nil {
+				v.skip(err, "ssh-keys")
+				return allErrs
+			}
+			if doclient.FindSSHKey(keys, id, ref) != nil {
+				continue
+			}
+		}
+		allErrs = append(allErrs, field.Invalid(path.Index(i), ref, "SSH key not found in the DigitalOcean account, available keys: "+sshKeyNames(keys)))
+	}
+	return allErrs
+}
+
+// sshKeyNames lists the names of keys, with their ID, for error messages.
+func sshKeyNames(keys []godo.Key) string {
+	if len(keys) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, fmt.Sprintf("%s (%d)", key.Name, key.ID))
+	}
+	return strings.Join(names, ", ")
+}
+
+// clusterRegion returns the region of the DOCluster of obj, or an empty
+// string when it is not known yet, e.g. for DOMachineTemplates created before
+// their Cluster.
+func (v *LiveValidator) clusterRegion(ctx context.Context, obj metav1.Object) string {
+	name, ok := obj.GetLabels()[clusterv1.ClusterLabelName]
+	if !ok || v.Client == nil {
+		return ""
+	}
+	cluster := &clusterv1.Cluster{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}, cluster); err != nil {
+		return ""
+	}
+	if cluster.Spec.InfrastructureRef == nil {
+		return ""
+	}
+	docluster := &infrav1.DOCluster{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: cluster.Spec.InfrastructureRef.Name}, docluster); err != nil {
+		return ""
+	}
+	return docluster.Spec.Region
+}
+
+func (v *LiveValidator) getSession() (*godo.Client, *doclient.Catalog, error) {
+	if v.session != nil {
+		return v.session()
+	}
+	return managerSession()
+}
+
+// managerSession returns the cached client and catalogs of the manager token.
+func managerSession() (*godo.Client, *doclient.Catalog, error) {
+	clients := &scope.DOClients{}
+	c, err := clients.Session()
+	if err != nil {
+		return nil, nil, err
+	}
+	catalog, err := clients.SessionCatalog()
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, catalog, nil
+}
+
+func (v *LiveValidator) timeout() time.Duration {
+	if v.Timeout > 0 {
+		return v.Timeout
+	}
+	return DefaultTimeout
+}
+
+// skip logs a check that could not be made.
+func (v *LiveValidator) skip(err error, check string) {
+	if v.Log != nil {
+		v.Log.Info("Skipping live validation, the DigitalOcean API could not be reached", "check", check, "error", err.Error())
+	}
+}
+
+func validateRegion(regions []godo.Region, slug string, path *field.Path) field.ErrorList {
+	for _, region := range regions {
+		if region.Slug != slug {
+			continue
+		}
+		if !region.Available {
+			return field.ErrorList{field.Invalid(path, slug, "region is not available")}
+		}
+		return nil
+	}
+	return field.ErrorList{field.NotFound(path, slug)}
+}
+
+func validateSize(sizes []godo.Size, slug, region string, path *field.Path) field.ErrorList {
+	for _, size := range sizes {
+		if size.Slug != slug {
+			continue
+		}
+		switch {
+		case !size.Available:
+			return field.ErrorList{field.Invalid(path, slug, "size is not available")}
+		case region != "" && !contains(size.Regions, region):
+			return field.ErrorList{field.Invalid(path, slug, fmt.Sprintf("size is not available in region %s", region))}
+		}
+		return nil
+	}
+	return field.ErrorList{field.NotFound(path, slug)}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newValidator(g *WithT, s *fakedo.Server, objs ...runtime.Object) *LiveValidator {
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	catalog := doclient.NewCatalog(doclient.DefaultCatalogTTL)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	return &LiveValidator{
+		Client:  fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+		session: func() (*godo.Client, *doclient.Catalog, error) { return c, catalog, nil },
+	}
+}
+
+func fields(errs field.ErrorList) []string {
+	var out []string
+	for _, err := range errs {
+		out = append(out, err.Field)
+	}
+	return out
+}
+
+func TestValidateMachineSpec(t *testing.T) {
+	testCases := []struct {
+		name       string
+		mutate     func(spec *infrav1.DOMachineSpec)
+		wantFields []string
+	}{
+		{name: "valid", mutate: func(spec *infrav1.DOMachineSpec) {}},
+		{
+			name:       "size not offered in the region",
+			mutate:     func(spec *infrav1.DOMachineSpec) { spec.Size = "c-4" },
+			wantFields: []string{"spec.size"},
+		},
+		{
+			name:       "unknown size",
+			mutate:     func(spec *infrav1.DOMachineSpec) { spec.Size = "s-64vcpu-1gb" },
+			wantFields: []string{"spec.size"},
+		},
+		{
+			name:       "unknown image",
+			mutate:     func(spec *infrav1.DOMachineSpec) { spec.Image = intstr.FromString("ubuntu-10-04-x64") },
+			wantFields: []string{"spec.image"},
+		},
+		{
+			name:       "image not available in the region",
+			mutate:     func(spec *infrav1.DOMachineSpec) { spec.Image = intstr.FromString("capi-ubuntu-nyc1") },
+			wantFields: []string{"spec.image"},
+		},
+		{
+			name: "unknown SSH key",
+			mutate: func(spec *infrav1.DOMachineSpec) {
+				spec.SSHKeys = append(spec.SSHKeys, intstr.FromString("00:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff"))
+			},
+			wantFields: []string{"spec.sshKeys[1]"},
+		},
+		{
+			name:   "SSH key by name",
+			mutate: func(spec *infrav1.DOMachineSpec) { spec.SSHKeys = append(spec.SSHKeys, intstr.FromString("capdo")) },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			s.AddSize(godo.Size{Slug: "s-2vcpu-2gb", Available: true, Regions: []string{"nyc1", "ams3"}})
+			s.AddSize(godo.Size{Slug: "c-4", Available: true, Regions: []string{"nyc1"}})
+			s.AddImage(godo.Image{Slug: "ubuntu-20-04-x64", Regions: []string{"nyc1", "ams3"}})
+			s.AddImage(godo.Image{Slug: "capi-ubuntu-nyc1", Regions: []string{"nyc1"}})
+			key := s.AddSSHKey(godo.Key{Name: "capdo"})
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+				Spec:       clusterv1.ClusterSpec{InfrastructureRef: &corev1.ObjectReference{Name: "foo"}},
+			}
+			docluster := &infrav1.DOCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+				Spec:       infrav1.DOClusterSpec{Region: "ams3"},
+			}
+			v := newValidator(g, s, cluster, docluster)
+
+			domachine := &infrav1.DOMachine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-md-0", Labels: map[string]string{clusterv1.ClusterLabelName: "foo"}},
+				Spec: infrav1.DOMachineSpec{
+					Size:    "s-2vcpu-2gb",
+					Image:   intstr.FromString("ubuntu-20-04-x64"),
+					SSHKeys: []intstr.IntOrString{intstr.FromInt(key.ID)},
+				},
+			}
+			tc.mutate(&domachine.Spec)
+			errs := v.ValidateMachineSpec(context.Background(), domachine, domachine.Spec, field.NewPath("spec"))
+			g.Expect(fields(errs)).To(Equal(tc.wantFields))
+		})
+	}
+}
+
+func TestValidateCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	s.AddRegion(godo.Region{Slug: "nyc1", Available: true})
+	s.AddRegion(godo.Region{Slug: "ams3", Available: true})
+	s.AddRegion(godo.Region{Slug: "sfo1", Available: false})
+	v := newValidator(g, s)
+
+	c, _, err := v.session()
+	g.Expect(err).NotTo(HaveOccurred())
+	vpc, _, err := c.VPCs.Create(ctx, &godo.VPCCreateRequest{Name: "nyc1", RegionSlug: "nyc1"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	docluster := &infrav1.DOCluster{Spec: infrav1.DOClusterSpec{Region: "nyc1"}}
+	docluster.Spec.Network.VPC.VPCUUID = vpc.ID
+	g.Expect(v.ValidateCluster(ctx, docluster)).To(BeEmpty())
+
+	docluster.Spec.Region = "ams3"
+	g.Expect(fields(v.ValidateCluster(ctx, docluster))).To(Equal([]string{"spec.network.vpc.vpc_uuid"}))
+
+	docluster.Spec.Region = "sfo1"
+	docluster.Spec.Network.VPC.VPCUUID = ""
+	g.Expect(fields(v.ValidateCluster(ctx, docluster))).To(Equal([]string{"spec.region"}))
+
+	// The checks are skipped when the API can not be reached.
+	docluster.Spec.Region = "nyc3"
+	s.FailNext(http.MethodGet, "/v2/regions", http.StatusInternalServerError, 10)
+	v.session = newValidator(g, s).session
+	g.Expect(v.ValidateCluster(ctx, docluster)).To(BeEmpty())
+}
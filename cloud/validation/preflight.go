@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PreflightTag is the tag created and deleted by the preflight checks to
+// find out whether the token can write.
+const PreflightTag = "capdo-preflight"
+
+// PreflightStatus is the outcome of a preflight check.
+type PreflightStatus string
+
+const (
+	// PreflightPass is a check that passed.
+	PreflightPass PreflightStatus = "PASS"
+	// PreflightWarn is a check that passed but points at a likely problem.
+	PreflightWarn PreflightStatus = "WARN"
+	// PreflightFail is a check that failed, the cluster would not come up.
+	PreflightFail PreflightStatus = "FAIL"
+)
+
+// PreflightCheck is the outcome of a check of the account.
+type PreflightCheck struct {
+	Name    string
+	Status  PreflightStatus
+	Message string
+}
+
+// PreflightOptions are what a cluster needs from the account.
+type PreflightOptions struct {
+	// Region is the region of the cluster.
+	Region string
+	// Sizes are the droplet sizes of the machines.
+	Sizes []string
+	// Images are the IDs or slugs of the images of the machines.
+	Images []string
+	// SSHKeys are the IDs, fingerprints or names of the SSH keys of the machines.
+	SSHKeys []string
+	// Droplets is the number of droplets the cluster creates at its peak.
+	Droplets int
+	// Volumes is the number of volumes the cluster creates.
+	Volumes int
+	// SkipWriteCheck skips the creation of the PreflightTag.
+	SkipWriteCheck bool
+}
+
+// Preflight checks that the account of c is ready for a cluster: that the
+// token is valid and can write, that the droplet and volume limits leave room
+// for it and that its region, sizes, images and SSH keys are available. It
+// returns every check, passed or not, in the order they were made. The
+// checks needing the account are not made when the token is not valid.
+func Preflight(ctx context.Context, c *godo.Client, opts PreflightOptions) []PreflightCheck {
+	var checks []PreflightCheck
+	add := func(name string, status PreflightStatus, format string, args ...interface{}) {
+		checks = append(checks, newPreflightCheck(name, status, format, args...))
+	}
+
+	account, err := doclient.ValidateCredentials(ctx, c.Account)
+	if err != nil {
+		add("token", PreflightFail, "%v", err)
+		return checks
+	}
+	add("token", PreflightPass, "account %s (%s)", account.Email, account.UUID)
+	switch {
+	case account.Status != "active":
+		add("account", PreflightWarn, "account is %s: %s", account.Status, account.StatusMessage)
+	case !account.EmailVerified:
+		add("account", PreflightFail, "the email of the account is not verified, droplets can not be created")
+	default:
+		add("account", PreflightPass, "account is active")
+	}
+
+	if !opts.SkipWriteCheck {
+		checks = append(checks, preflightWrite(ctx, c))
+	}
+
+	_, resp, err := c.Droplets.List(ctx, &godo.ListOptions{PerPage: 1})
+	checks = append(checks, preflightLimit("droplets", account.DropletLimit, opts.Droplets, resp, err))
+	_, resp, err = c.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: &godo.ListOptions{PerPage: 1}})
+	checks = append(checks, preflightLimit("volumes", account.VolumeLimit, opts.Volumes, resp, err))
+
+	catalog := doclient.NewCatalog(doclient.DefaultCatalogTTL)
+	if opts.Region != "" {
+		regions, err := catalog.Regions(ctx, c.Regions)
+		checks = append(checks, preflightField("region "+opts.Region, err, func() field.ErrorList {
+			return validateRegion(regions, opts.Region, field.NewPath("region"))
+		}))
+	}
+
+	if len(opts.Sizes) > 0 {
+		sizes, err := catalog.Sizes(ctx, c.Sizes)
+		for _, slug := range opts.Sizes {
+			checks = append(checks, preflightField("size "+slug, err, func() field.ErrorList {
+				return validateSize(sizes, slug, opts.Region, field.NewPath("size"))
+			}))
+		}
+	}
+
+	for _, ref := range opts.Images {
+		var image *godo.Image
+		if id, convErr := strconv.Atoi(ref); convErr == nil {
+			image, _, err = c.Images.GetByID(ctx, id)
+		} else {
+			image, err = catalog.ImageBySlug(ctx, c.Images, ref)
+		}
+		name := "image " + ref
+		switch {
+		case doclient.IsNotFound(err):
+			add(name, PreflightFail, "image not found")
+		case err != nil:
+			add(name, PreflightFail, "failed to get the image: %v", err)
+		case image.Status != "" && image.Status != "available":
+			add(name, PreflightFail, "image %s is %s", image.Name, image.Status)
+		case opts.Region != "" && len(image.Regions) > 0 && !contains(image.Regions, opts.Region):
+			add(name, PreflightFail, "image %s is not available in region %s, only in %s", image.Name, opts.Region, strings.Join(image.Regions, ", "))
+		default:
+			add(name, PreflightPass, "image %s (%d)", image.Name, image.ID)
+		}
+	}
+
+	if len(opts.SSHKeys) > 0 {
+		keys, err := catalog.SSHKeys(ctx, c.Keys)
+		for _, ref := range opts.SSHKeys {
+			name := "ssh key " + ref
+			id, _ := strconv.Atoi(ref)
+			switch key := doclient.FindSSHKey(keys, id, ref); {
+			case err != nil:
+				add(name, PreflightFail, "failed to list the SSH keys: %v", err)
+			case key == nil:
+				add(name, PreflightFail, "SSH key not found, available keys: %s", sshKeyNames(keys))
+			default:
+				add(name, PreflightPass, "SSH key %s (%d)", key.Name, key.ID)
+			}
+		}
+	}
+	return checks
+}
+
+func newPreflightCheck(name string, status PreflightStatus, format string, args ...interface{}) PreflightCheck {
+	return PreflightCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+// preflightWrite creates and deletes the PreflightTag, which read-only tokens
+// are not allowed to.
+func preflightWrite(ctx context.Context, c *godo.Client) PreflightCheck {
+	const name = "token scope"
+	_, resp, err := c.Tags.Create(ctx, &godo.TagCreateRequest{Name: PreflightTag})
+	switch {
+	case err != nil && resp != nil && resp.StatusCode == http.StatusForbidden:
+		return newPreflightCheck(name, PreflightFail, "token is read-only, the provider needs a token with the write scope")
+	case err != nil:
+		return newPreflightCheck(name, PreflightFail, "failed to create the %s tag: %v", PreflightTag, err)
+	}
+	if _, err := c.Tags.Delete(ctx, PreflightTag); err != nil {
+		return newPreflightCheck(name, PreflightWarn, "token can write, but the %s tag could not be deleted: %v", PreflightTag, err)
+	}
+	return newPreflightCheck(name, PreflightPass, "token can write")
+}
+
+// preflightLimit checks that limit leaves room for needed resources on top of
+// the total of the listing resp.
+func preflightLimit(kind string, limit, needed int, resp *godo.Response, err error) PreflightCheck {
+	name := kind + " limit"
+	switch {
+	case err != nil:
+		return newPreflightCheck(name, PreflightFail, "failed to list the %s: %v", kind, err)
+	case resp == nil || resp.Meta == nil:
+		return newPreflightCheck(name, PreflightWarn, "the %s listing has no total", kind)
+	case limit == 0:
+		return newPreflightCheck(name, PreflightPass, "%d %s, no limit", resp.Meta.Total, kind)
+	case resp.Meta.Total+needed > limit:
+		return newPreflightCheck(name, PreflightFail, "%d %s of %d, no room for %d more", resp.Meta.Total, kind, limit, needed)
+	}
+	return newPreflightCheck(name, PreflightPass, "%d %s of %d, room for %d more", resp.Meta.Total, kind, limit, limit-resp.Meta.Total)
+}
+
+// preflightField turns the field errors of validate into a check, failed
+// when the catalog validate reads could not be fetched.
+func preflightField(name string, err error, validate func() field.ErrorList) PreflightCheck {
+	if err != nil {
+		return newPreflightCheck(name, PreflightFail, "failed to list the catalog: %v", err)
+	}
+	if errs := validate(); len(errs) > 0 {
+		return newPreflightCheck(name, PreflightFail, "%s", errs[0].ErrorBody())
+	}
+	return newPreflightCheck(name, PreflightPass, "available")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestPreflight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mutate   func(s *fakedo.Server, opts *PreflightOptions)
+		wantFail []string
+	}{
+		{name: "ready", mutate: func(s *fakedo.Server, opts *PreflightOptions) {}},
+		{
+			name: "read-only token",
+			mutate: func(s *fakedo.Server, opts *PreflightOptions) {
+				s.FailNext(http.MethodPost, "/v2/tags", http.StatusForbidden, 1)
+			},
+			wantFail: []string{"token scope"},
+		},
+		{
+			name: "droplet limit reached",
+			mutate: func(s *fakedo.Server, opts *PreflightOptions) {
+				s.SetAccount(godo.Account{DropletLimit: 2, VolumeLimit: 10, Status: "active", EmailVerified: true})
+			},
+			wantFail: []string{"droplets limit"},
+		},
+		{
+			name: "unavailable region, size, image and key",
+			mutate: func(s *fakedo.Server, opts *PreflightOptions) {
+				opts.Region = "sfo2"
+				opts.Images = append(opts.Images, "ubuntu-10-04-x64")
+				opts.SSHKeys = append(opts.SSHKeys, "unknown")
+			},
+			wantFail: []string{"region sfo2", "size s-2vcpu-2gb", "image ubuntu-20-04-x64", "image ubuntu-10-04-x64", "ssh key unknown"},
+		},
+		{
+			name: "invalid token",
+			mutate: func(s *fakedo.Server, opts *PreflightOptions) {
+				s.FailNext(http.MethodGet, "/v2/account", http.StatusUnauthorized, 1)
+			},
+			wantFail: []string{"token"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			s.AddRegion(godo.Region{Slug: "nyc1", Available: true})
+			s.AddRegion(godo.Region{Slug: "sfo2", Available: false})
+			s.AddSize(godo.Size{Slug: "s-2vcpu-2gb", Available: true, Regions: []string{"nyc1"}})
+			s.AddImage(godo.Image{Slug: "ubuntu-20-04-x64", Regions: []string{"nyc1"}})
+			key := s.AddSSHKey(godo.Key{Name: "capdo"})
+			c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			opts := PreflightOptions{
+				Region:   "nyc1",
+				Sizes:    []string{"s-2vcpu-2gb"},
+				Images:   []string{"ubuntu-20-04-x64"},
+				SSHKeys:  []string{key.Fingerprint},
+				Droplets: 3,
+			}
+			tc.mutate(s, &opts)
+
+			var failed []string
+			for _, check := range Preflight(context.Background(), c, opts) {
+				if check.Status == PreflightFail {
+					failed = append(failed, check.Name)
+				}
+			}
+			g.Expect(failed).To(Equal(tc.wantFail))
+			g.Expect(s.Tags()).NotTo(ContainElement(PreflightTag))
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// QuotaGuardMode is what the quota guard does with a MachineDeployment that
+// would go over the droplet limit of the account.
+type QuotaGuardMode string
+
+const (
+	// QuotaGuardOff admits every MachineDeployment without checking it.
+	QuotaGuardOff QuotaGuardMode = "off"
+	// QuotaGuardWarn admits the MachineDeployment with an admission warning.
+	QuotaGuardWarn QuotaGuardMode = "warn"
+	// QuotaGuardDeny rejects the MachineDeployment.
+	QuotaGuardDeny QuotaGuardMode = "deny"
+)
+
+// QuotaGuardPath is the path of the quota guard webhook.
+const QuotaGuardPath = "/validate-cluster-x-k8s-io-v1alpha4-machinedeployment-droplet-quota"
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1alpha4-machinedeployment-droplet-quota,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=machinedeployments,versions=v1alpha4,name=dropletquota.machinedeployment.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+// QuotaGuard is the admission handler checking that the droplets added by a
+// MachineDeployment of DOMachines, including the ones surged during its
+// rollout, fit in the droplet limit of the account. The droplets that other
+// MachineDeployments are about to create are not accounted for.
+type QuotaGuard struct {
+	Mode QuotaGuardMode
+	Log  logr.Logger
+	// Timeout bounds the DigitalOcean API calls of a check. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+
+	decoder *admission.Decoder
+	// session returns the DigitalOcean client and catalogs. Defaults to the
+	// session of the manager token.
+	session func() (*godo.Client, *doclient.Catalog, error)
+}
+
+// IsValid returns whether m is a known mode.
+func (m QuotaGuardMode) IsValid() bool {
+	return m == QuotaGuardOff || m == QuotaGuardWarn || m == QuotaGuardDeny
+}
+
+var _ admission.DecoderInjector = &QuotaGuard{}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (q *QuotaGuard) InjectDecoder(d *admission.Decoder) error {
+	q.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (q *QuotaGuard) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if q.Mode == QuotaGuardOff || q.Mode == "" {
+		return admission.Allowed("")
+	}
+
+	md := &clusterv1.MachineDeployment{}
+	if err := q.decoder.Decode(req, md); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	ref := md.Spec.Template.Spec.InfrastructureRef
+	if ref.Kind != "DOMachineTemplate" || ref.GroupVersionKind().Group != infrav1.GroupVersion.Group {
+		return admission.Allowed("")
+	}
+
+	added := replicas(md)
+	if req.Operation == admissionv1.Update {
+		old := &clusterv1.MachineDeployment{}
+		if err := q.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		added -= replicas(old)
+		if !reflect.DeepEqual(md.Spec.Template, old.Spec.Template) {
+			added += surge(md)
+		}
+	}
+	if added <= 0 {
+		return admission.Allowed("")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, q.timeout())
+	defer cancel()
+	limit, used, err := q.dropletUsage(ctx)
+	if err != nil {
+		if q.Log != nil {
+			q.Log.Info("Skipping droplet quota check, the DigitalOcean API could not be reached", "error", err.Error())
+		}
+		return admission.Allowed("")
+	}
+	if limit == 0 || used+added <= limit {
+		return admission.Allowed("")
+	}
+
+	message := fmt.Sprintf("MachineDeployment %s would add up to %d droplets to the %d of the DigitalOcean account, over its droplet limit of %d",
+		md.Name, added, used, limit)
+	if q.Mode == QuotaGuardDeny {
+		return admission.Denied(message)
+	}
+	resp := admission.Allowed("")
+	resp.Warnings = []string{message}
+	return resp
+}
+
+// dropletUsage returns the droplet limit of the account, cached with the
+// catalogs, and its current number of droplets.
+func (q *QuotaGuard) dropletUsage(ctx context.Context) (int, int, error) {
+	session := q.session
+	if session == nil {
+		session = managerSession
+	}
+	c, catalog, err := session()
+	if err != nil {
+		return 0, 0, err
+	}
+	account, err := catalog.Account(ctx, c.Account)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Only the total of the listing is needed.
+	_, resp, err := c.Droplets.List(ctx, &godo.ListOptions{PerPage: 1})
+	if err != nil {
+		return 0, 0, err
+	}
+	if resp.Meta == nil {
+		return 0, 0, errors.New("droplets listing has no total")
+	}
+	return account.DropletLimit, resp.Meta.Total, nil
+}
+
+func (q *QuotaGuard) timeout() time.Duration {
+	if q.Timeout > 0 {
+		return q.Timeout
+	}
+	return DefaultTimeout
+}
+
+func replicas(md *clusterv1.MachineDeployment) int {
+	if md.Spec.Replicas == nil {
+		return 1
+	}
+	return int(*md.Spec.Replicas)
+}
+
+// surge returns how many machines a rollout of md creates on top of its replicas.
+func surge(md *clusterv1.MachineDeployment) int {
+	strategy := md.Spec.Strategy
+	if strategy != nil && strategy.Type == clusterv1.OnDeleteMachineDeploymentStrategyType {
+		return 0
+	}
+	if strategy == nil || strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxSurge == nil {
+		return 1
+	}
+	v, err := intstr.GetScaledValueFromIntOrPercent(strategy.RollingUpdate.MaxSurge, replicas(md), true)
+	if err != nil {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestQuotaGuard(t *testing.T) {
+	machineDeployment := func(replicas int32, kind, version string) *clusterv1.MachineDeployment {
+		md := &clusterv1.MachineDeployment{}
+		md.Name = "foo-md-0"
+		md.Spec.Replicas = pointer.Int32Ptr(replicas)
+		md.Spec.Template.Spec.Version = pointer.StringPtr(version)
+		md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{APIVersion: infrav1.GroupVersion.String(), Kind: kind}
+		return md
+	}
+	testCases := []struct {
+		name        string
+		mode        QuotaGuardMode
+		old, new    *clusterv1.MachineDeployment
+		wantAllowed bool
+		wantWarning bool
+	}{
+		{name: "within the limit", mode: QuotaGuardDeny, new: machineDeployment(2, "DOMachineTemplate", "v1.21.2"), wantAllowed: true},
+		{name: "over the limit", mode: QuotaGuardWarn, new: machineDeployment(3, "DOMachineTemplate", "v1.21.2"), wantAllowed: true, wantWarning: true},
+		{name: "over the limit denied", mode: QuotaGuardDeny, new: machineDeployment(3, "DOMachineTemplate", "v1.21.2")},
+		{name: "off", mode: QuotaGuardOff, new: machineDeployment(3, "DOMachineTemplate", "v1.21.2"), wantAllowed: true},
+		{name: "other provider", mode: QuotaGuardDeny, new: machineDeployment(3, "AWSMachineTemplate", "v1.21.2"), wantAllowed: true},
+		{
+			name:        "scale down",
+			mode:        QuotaGuardDeny,
+			old:         machineDeployment(5, "DOMachineTemplate", "v1.21.2"),
+			new:         machineDeployment(4, "DOMachineTemplate", "v1.21.2"),
+			wantAllowed: true,
+		},
+		{
+			name: "rollout surge",
+			mode: QuotaGuardDeny,
+			old:  machineDeployment(2, "DOMachineTemplate", "v1.21.2"),
+			new: func() *clusterv1.MachineDeployment {
+				md := machineDeployment(3, "DOMachineTemplate", "v1.22.0")
+				maxSurge := intstr.FromInt(2)
+				md.Spec.Strategy = &clusterv1.MachineDeploymentStrategy{
+					Type:          clusterv1.RollingUpdateMachineDeploymentStrategyType,
+					RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{MaxSurge: &maxSurge},
+				}
+				return md
+			}(),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			s.SetAccount(godo.Account{DropletLimit: 3, Status: "active"})
+			c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+			g.Expect(err).NotTo(HaveOccurred())
+			_, _, err = c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+				Name: "other", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			scheme := runtime.NewScheme()
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			decoder, err := admission.NewDecoder(scheme)
+			g.Expect(err).NotTo(HaveOccurred())
+			catalog := doclient.NewCatalog(doclient.DefaultCatalogTTL)
+			q := &QuotaGuard{
+				Mode:    tc.mode,
+				session: func() (*godo.Client, *doclient.Catalog, error) { return c, catalog, nil },
+			}
+			g.Expect(q.InjectDecoder(decoder)).To(Succeed())
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create}}
+			req.Object.Raw, err = json.Marshal(tc.new)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.old != nil {
+				req.Operation = admissionv1.Update
+				req.OldObject.Raw, err = json.Marshal(tc.old)
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			resp := q.Handle(ctx, req)
+
+			g.Expect(resp.Allowed).To(Equal(tc.wantAllowed))
+			g.Expect(resp.Warnings != nil).To(Equal(tc.wantWarning))
+		})
+	}
+}
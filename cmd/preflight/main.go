@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// preflight checks that a DigitalOcean account is ready for the clusters of
+// the provider: that the token of DIGITALOCEAN_ACCESS_TOKEN is valid and can
+// write, that the droplet and volume limits leave room for the cluster and
+// that its region, droplet sizes, images and SSH keys are available. It
+// prints a report and exits with 1 when a check failed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/digitalocean/godo"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/validation"
+)
+
+var (
+	region         = flag.String("region", os.Getenv("DO_REGION"), "Region of the cluster, defaults to DO_REGION")
+	sizes          = flag.String("sizes", "", "Comma separated droplet sizes of the machines, e.g. s-2vcpu-2gb")
+	images         = flag.String("images", "", "Comma separated IDs or slugs of the images of the machines")
+	sshKeys        = flag.String("ssh-keys", os.Getenv("DO_SSH_KEY_FINGERPRINT"), "Comma separated IDs, fingerprints or names of the SSH keys of the machines, defaults to DO_SSH_KEY_FINGERPRINT")
+	droplets       = flag.Int("droplets", 2, "Number of droplets the cluster creates at its peak, including the ones surged during upgrades")
+	volumes        = flag.Int("volumes", 0, "Number of volumes the cluster creates, e.g. its data disks")
+	skipWriteCheck = flag.Bool("skip-write-check", false, fmt.Sprintf("Do not create and delete the %s tag to check that the token can write", validation.PreflightTag))
+	timeout        = flag.Duration("timeout", time.Minute, "Timeout of the checks")
+)
+
+func main() {
+	flag.Parse()
+	token := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "DIGITALOCEAN_ACCESS_TOKEN is not set")
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	checks := validation.Preflight(ctx, godo.NewFromToken(token), validation.PreflightOptions{
+		Region:         *region,
+		Sizes:          split(*sizes),
+		Images:         split(*images),
+		SSHKeys:        split(*sshKeys),
+		Droplets:       *droplets,
+		Volumes:        *volumes,
+		SkipWriteCheck: *skipWriteCheck,
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	failed := false
+	for _, check := range checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Status, check.Name, check.Message)
+		failed = failed || check.Status == validation.PreflightFail
+	}
+	w.Flush()
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func split(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/addons"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AddonsClusterLabel is the label of the Clusters selected by the
+// ClusterResourceSet of the addons of their DOCluster. Its value is the name
+// of the ClusterResourceSet, so that each one only selects its Cluster.
+const AddonsClusterLabel = "infrastructure.cluster.x-k8s.io/digitalocean-addons"
+
+// pushedTokens remembers the hash of the addons token last pushed to the
+// workload cluster of each DOCluster, so that a client of the workload cluster
+// is only built once the token changed. The zero value is ready to use.
+type pushedTokens struct {
+	mu     sync.Mutex
+	hashes map[types.NamespacedName]string
+}
+
+func (p *pushedTokens) pushed(key types.NamespacedName, hash string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hashes[key] == hash
+}
+
+func (p *pushedTokens) set(key types.NamespacedName, hash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.hashes == nil {
+		p.hashes = map[types.NamespacedName]string{}
+	}
+	p.hashes[key] = hash
+}
+
+func (p *pushedTokens) forget(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.hashes, key)
+}
+
+// +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=patch
+
+// reconcileAddons renders the ClusterResourceSet installing the addons
+// enabled in the DOCluster spec in the workload cluster. The resources are
+// applied once, later changes only apply to new clusters, except for the token
+// whose rotations are pushed to the workload cluster.
+func (r *DOClusterReconciler) reconcileAddons(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	docluster := clusterScope.DOCluster
+	spec := docluster.Spec.Addons
+	if spec == nil || (!spec.CloudControllerManager && !spec.CSIDriver) {
+		return nil
+	}
+
+	// The token of the manager is never copied to workload clusters, the
+	// webhook requires a Secret of its own.
+	ref := spec.TokenSecretRef
+	if ref == nil || ref.Name == "" {
+		return errors.New("addons require a tokenSecretRef")
+	}
+	tokenSecret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: docluster.Namespace, Name: ref.Name}
+	if err := r.Get(ctx, key, tokenSecret); err != nil {
+		return errors.Wrapf(err, "failed to get the addons token Secret %s", key)
+	}
+	token := string(tokenSecret.Data[addons.TokenSecretKey])
+	if token == "" {
+		return errors.Errorf("addons token Secret %s has no %s key", key, addons.TokenSecretKey)
+	}
+	params := addons.Params{
+		ClusterName:            clusterScope.Name(),
+		VPCID:                  clusterScope.VPC().VPCUUID,
+		Token:                  token,
+		CloudControllerManager: spec.CloudControllerManager,
+		CSIDriver:              spec.CSIDriver,
+	}
+	data, err := addons.Render(params)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-digitalocean-addons", docluster.Name)
+	meta := metav1.ObjectMeta{Namespace: docluster.Namespace, Name: name}
+
+	secret := &corev1.Secret{ObjectMeta: meta}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Type = addonsv1.ClusterResourceSetSecretType
+		secret.Data = data
+		return controllerutil.SetControllerReference(docluster, secret, r.Scheme())
+	}); err != nil {
+		return errors.Wrapf(err, "failed to reconcile the addons Secret %s", name)
+	}
+
+	crs := &addonsv1.ClusterResourceSet{ObjectMeta: meta}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, crs, func() error {
+		crs.Spec.SetTypedStrategy(addonsv1.ClusterResourceSetStrategyApplyOnce)
+		crs.Spec.ClusterSelector = metav1.LabelSelector{MatchLabels: map[string]string{AddonsClusterLabel: name}}
+		crs.Spec.Resources = []addonsv1.ResourceRef{{Name: name, Kind: string(addonsv1.SecretClusterResourceSetResourceKind)}}
+		return controllerutil.SetControllerReference(docluster, crs, r.Scheme())
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to reconcile the addons ClusterResourceSet %s", name)
+	}
+	if op == controllerutil.OperationResultCreated {
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.AddonsConfiguredReason, "Created ClusterResourceSet %s installing the DigitalOcean addons", name)
+	}
+
+	cluster := clusterScope.Cluster
+	if cluster.Labels[AddonsClusterLabel] != name {
+		patch := client.MergeFrom(cluster.DeepCopy())
+		if cluster.Labels == nil {
+			cluster.Labels = map[string]string{}
+		}
+		cluster.Labels[AddonsClusterLabel] = name
+		if err := r.Patch(ctx, cluster, patch); err != nil {
+			return errors.Wrapf(err, "failed to label Cluster %s for its addons", cluster.Name)
+		}
+	}
+
+	// The ClusterResourceSets of Cluster API v1alpha4 only support ApplyOnce
+	// and never update what they created, the token is rotated directly in
+	// the workload cluster once its API server is up. The token pushed last
+	// is remembered, a rotation made in the workload cluster meanwhile is
+	// only undone by the next rotation or a restart of the manager.
+	if !conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return nil
+	}
+	clusterKey := client.ObjectKeyFromObject(docluster)
+	hash := addons.TokenHash(token)
+	if r.addonsTokens.pushed(clusterKey, hash) {
+		return nil
+	}
+	pushed, err := r.rotateAddonsToken(ctx, clusterScope, params)
+	if err != nil {
+		return err
+	}
+	if pushed {
+		r.addonsTokens.set(clusterKey, hash)
+	}
+	return nil
+}
+
+// rotateAddonsToken updates the token Secret of the addons in the workload
+// cluster and restarts the addons reading it. It returns whether the token
+// was pushed, it is not while the ClusterResourceSet has yet to create the
+// Secret.
+func (r *DOClusterReconciler) rotateAddonsToken(ctx context.Context, clusterScope *scope.ClusterScope, params addons.Params) (bool, error) {
+	newClient := r.RemoteClient
+	if newClient == nil {
+		newClient = func(ctx context.Context, cluster client.ObjectKey) (client.Client, error) {
+			return remote.NewClusterClient(ctx, "digitalocean-addons", r.Client, cluster)
+		}
+	}
+	c, err := newClient(ctx, client.ObjectKeyFromObject(clusterScope.Cluster))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create a client of the workload cluster")
+	}
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: metav1.NamespaceSystem, Name: addons.TokenSecretName}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return false, errors.Wrapf(client.IgnoreNotFound(err), "failed to get the addons token Secret %s of the workload cluster", key)
+	}
+	rotated := false
+	if string(secret.Data[addons.TokenSecretKey]) != params.Token {
+		patch := client.MergeFrom(secret.DeepCopy())
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[addons.TokenSecretKey] = []byte(params.Token)
+		if err := c.Patch(ctx, secret, patch); err != nil {
+			return false, errors.Wrapf(err, "failed to update the addons token Secret %s of the workload cluster", key)
+		}
+		rotated = true
+	}
+
+	// The restarts are not tied to the Secret update, so that they are
+	// retried when they fail.
+	hash := addons.TokenHash(params.Token)
+	for _, obj := range addons.TokenConsumers(params) {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, errors.Wrapf(err, "failed to get %s of the workload cluster", obj.GetName())
+		}
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		var template *corev1.PodTemplateSpec
+		switch o := obj.(type) {
+		case *appsv1.DaemonSet:
+			template = &o.Spec.Template
+		case *appsv1.StatefulSet:
+			template = &o.Spec.Template
+		}
+		if template.Annotations[addons.TokenHashAnnotation] == hash {
+			continue
+		}
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[addons.TokenHashAnnotation] = hash
+		if err := c.Patch(ctx, obj, patch); err != nil {
+			return false, errors.Wrapf(err, "failed to restart %s of the workload cluster", obj.GetName())
+		}
+	}
+	if rotated {
+		r.Recorder.Eventf(clusterScope.DOCluster, corev1.EventTypeNormal, infrav1.AddonsConfiguredReason, "Rotated the DigitalOcean token of the addons of the workload cluster")
+	}
+	return true, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/addons"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileAddons(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("manager-token")
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(addonsv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+	cluster := newCluster("foo")
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"},
+		Spec: infrav1.DOClusterSpec{
+			Network: infrav1.DONetwork{VPC: infrav1.DOVPC{VPCUUID: "vpc-uuid"}},
+			Addons: &infrav1.DOClusterAddons{
+				CloudControllerManager: true,
+				TokenSecretRef:         &corev1.LocalObjectReference{Name: "addons-token"},
+			},
+		},
+	}
+	token := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "addons-token"},
+		Data:       map[string][]byte{"access-token": []byte("addons-token")},
+	}
+	kc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, docluster, token).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{Client: kc, Cluster: cluster, DOCluster: docluster})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	recorder := record.NewFakeRecorder(10)
+	r := &DOClusterReconciler{Client: kc, Recorder: recorder}
+	g.Expect(r.reconcileAddons(ctx, clusterScope)).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.AddonsConfiguredReason)))
+
+	key := client.ObjectKey{Namespace: namespace, Name: "foo-digitalocean-addons"}
+	secret := &corev1.Secret{}
+	g.Expect(kc.Get(ctx, key, secret)).To(Succeed())
+	g.Expect(secret.Type).To(Equal(addonsv1.ClusterResourceSetSecretType))
+	g.Expect(secret.Data).To(HaveKey("digitalocean-cloud-controller-manager.yaml"))
+	g.Expect(secret.Data).NotTo(HaveKey("digitalocean-csi.yaml"))
+	g.Expect(string(secret.Data["digitalocean-cloud-controller-manager.yaml"])).To(ContainSubstring(`value: "vpc-uuid"`))
+	g.Expect(string(secret.Data["digitalocean-token.yaml"])).To(ContainSubstring("access-token: addons-token"))
+
+	crs := &addonsv1.ClusterResourceSet{}
+	g.Expect(kc.Get(ctx, key, crs)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(crs, docluster)).To(BeTrue())
+	g.Expect(crs.Spec.ClusterSelector.MatchLabels).To(Equal(map[string]string{AddonsClusterLabel: key.Name}))
+
+	labeled := &clusterv1.Cluster{}
+	g.Expect(kc.Get(ctx, client.ObjectKeyFromObject(cluster), labeled)).To(Succeed())
+	g.Expect(labeled.Labels).To(HaveKeyWithValue(AddonsClusterLabel, key.Name))
+
+	// Reconciling again changes nothing.
+	g.Expect(r.reconcileAddons(ctx, clusterScope)).To(Succeed())
+	g.Expect(recorder.Events).NotTo(Receive())
+
+	// Once the workload cluster is up, the rotations of the token are pushed
+	// to it and the addons reading it are restarted.
+	remoteToken := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: addons.TokenSecretName},
+		Data:       map[string][]byte{addons.TokenSecretKey: []byte("addons-token")},
+	}
+	ccm := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "digitalocean-cloud-controller-manager"}}
+	ccm.Spec.Template.Annotations = map[string]string{addons.TokenHashAnnotation: addons.TokenHash("addons-token")}
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(remoteToken, ccm).Build()
+	remoteClients := 0
+	r.RemoteClient = func(_ context.Context, key client.ObjectKey) (client.Client, error) {
+		g.Expect(key).To(Equal(client.ObjectKeyFromObject(cluster)))
+		remoteClients++
+		return remoteClient, nil
+	}
+	conditions.MarkTrue(clusterScope.Cluster, clusterv1.ControlPlaneInitializedCondition)
+
+	g.Expect(r.reconcileAddons(ctx, clusterScope)).To(Succeed())
+	g.Expect(recorder.Events).NotTo(Receive())
+	g.Expect(remoteClients).To(Equal(1))
+
+	// The workload cluster is not reached again until the token changes.
+	g.Expect(r.reconcileAddons(ctx, clusterScope)).To(Succeed())
+	g.Expect(remoteClients).To(Equal(1))
+
+	token.Data["access-token"] = []byte("rotated-token")
+	g.Expect(kc.Update(ctx, token)).To(Succeed())
+	g.Expect(r.reconcileAddons(ctx, clusterScope)).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("Rotated")))
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(remoteToken), remoteToken)).To(Succeed())
+	g.Expect(string(remoteToken.Data[addons.TokenSecretKey])).To(Equal("rotated-token"))
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(ccm), ccm)).To(Succeed())
+	g.Expect(ccm.Spec.Template.Annotations).To(HaveKeyWithValue(addons.TokenHashAnnotation, addons.TokenHash("rotated-token")))
+
+	g.Expect(r.reconcileAddons(ctx, clusterScope)).To(Succeed())
+	g.Expect(recorder.Events).NotTo(Receive())
+	g.Expect(remoteClients).To(Equal(2))
+}
+
+func TestReconcileAddonsRequiresTokenSecret(t *testing.T) {
+	g := NewWithT(t)
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("manager-token")
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	cluster := newCluster("foo")
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"},
+		Spec:       infrav1.DOClusterSpec{Addons: &infrav1.DOClusterAddons{CSIDriver: true}},
+	}
+	kc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, docluster).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{Client: kc, Cluster: cluster, DOCluster: docluster})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &DOClusterReconciler{Client: kc, Recorder: record.NewFakeRecorder(10)}
+	g.Expect(r.reconcileAddons(context.Background(), clusterScope)).To(MatchError(ContainSubstring("tokenSecretRef")))
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultCapacityMonitorInterval is how often the capacity of the DigitalOcean
+// account is polled.
+const DefaultCapacityMonitorInterval = 5 * time.Minute
+
+var (
+	accountDropletLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_account_droplet_limit",
+		Help: "Droplet limit of the DigitalOcean account, by account UUID.",
+	}, []string{"account"})
+	accountDroplets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_account_droplets",
+		Help: "Number of droplets of the DigitalOcean account, by account UUID.",
+	}, []string{"account"})
+	accountVolumeLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_account_volume_limit",
+		Help: "Block storage volume limit of the DigitalOcean account, by account UUID.",
+	}, []string{"account"})
+	accountVolumes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_account_volumes",
+		Help: "Number of block storage volumes of the DigitalOcean account, by account UUID.",
+	}, []string{"account"})
+	accountReservedIPLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_account_reserved_ip_limit",
+		Help: "Reserved IP limit of the DigitalOcean account, by account UUID.",
+	}, []string{"account"})
+	accountReservedIPs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_account_reserved_ips",
+		Help: "Number of reserved IPs of the DigitalOcean account, by account UUID.",
+	}, []string{"account"})
+	capacityPollErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "capdo_account_capacity_poll_errors_total",
+		Help: "Number of failed polls of the capacity of the DigitalOcean account.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(accountDropletLimit, accountDroplets, accountVolumeLimit, accountVolumes,
+		accountReservedIPLimit, accountReservedIPs, capacityPollErrors)
+}
+
+// CapacityMonitor periodically exports the limits of the DigitalOcean account
+// of the manager token and how much of them is used, the resources of the
+// whole account being counted, not only the ones of the provider. It
+// implements the manager Runnable interface.
+type CapacityMonitor struct {
+	Log logr.Logger
+	// Interval is the delay between two polls. Defaults to
+	// DefaultCapacityMonitorInterval.
+	Interval time.Duration
+
+	// session returns the DigitalOcean client. Defaults to the session of the
+	// manager token.
+	session func() (*godo.Client, error)
+}
+
+// Start polls every Interval until ctx is done.
+func (m *CapacityMonitor) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.Poll(ctx); err != nil {
+			capacityPollErrors.Inc()
+			m.Log.Error(err, "Polling the capacity of the DigitalOcean account failed")
+		}
+	}, orDefault(m.Interval, DefaultCapacityMonitorInterval))
+	return nil
+}
+
+// NeedLeaderElection implements the manager LeaderElectionRunnable interface,
+// so that the replicas do not all spend API budget on the same numbers.
+func (m *CapacityMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Poll updates the capacity gauges once.
+func (m *CapacityMonitor) Poll(ctx context.Context) error {
+	session := m.session
+	if session == nil {
+		session = (&scope.DOClients{}).Session
+	}
+	c, err := session()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := c.Account.Get(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get account")
+	}
+	// Only the totals of the listings are needed.
+	opt := &godo.ListOptions{PerPage: 1}
+	_, resp, err := c.Droplets.List(ctx, opt)
+	droplets, err := listTotal(resp, err, "droplets")
+	if err != nil {
+		return err
+	}
+	_, resp, err = c.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+	volumes, err := listTotal(resp, err, "volumes")
+	if err != nil {
+		return err
+	}
+	_, resp, err = c.FloatingIPs.List(ctx, opt)
+	reservedIPs, err := listTotal(resp, err, "reserved IPs")
+	if err != nil {
+		return err
+	}
+
+	// The token may have been rotated to another account.
+	for _, g := range []*prometheus.GaugeVec{accountDropletLimit, accountDroplets, accountVolumeLimit, accountVolumes, accountReservedIPLimit, accountReservedIPs} {
+		g.Reset()
+	}
+	accountDropletLimit.WithLabelValues(account.UUID).Set(float64(account.DropletLimit))
+	accountDroplets.WithLabelValues(account.UUID).Set(float64(droplets))
+	accountVolumeLimit.WithLabelValues(account.UUID).Set(float64(account.VolumeLimit))
+	accountVolumes.WithLabelValues(account.UUID).Set(float64(volumes))
+	accountReservedIPLimit.WithLabelValues(account.UUID).Set(float64(account.FloatingIPLimit))
+	accountReservedIPs.WithLabelValues(account.UUID).Set(float64(reservedIPs))
+	return nil
+}
+
+// listTotal returns the total number of resources of a listing.
+func listTotal(resp *godo.Response, err error, resources string) (int, error) {
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list %s", resources)
+	}
+	if resp.Meta == nil {
+		return 0, errors.Errorf("%s listing has no total", resources)
+	}
+	return resp.Meta.Total, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestCapacityMonitorPoll(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, name := range []string{"foo-md-0", "foo-md-1"} {
+		_, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: name, Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	m := &CapacityMonitor{Log: ctrl.Log, session: func() (*godo.Client, error) { return c, nil }}
+	g.Expect(m.Poll(ctx)).To(Succeed())
+
+	value := func(gauge *prometheus.GaugeVec) float64 {
+		metric := &dto.Metric{}
+		g.Expect(gauge.WithLabelValues("fake-account").Write(metric)).To(Succeed())
+		return metric.GetGauge().GetValue()
+	}
+	g.Expect(value(accountDropletLimit)).To(Equal(25.0))
+	g.Expect(value(accountDroplets)).To(Equal(2.0))
+	g.Expect(value(accountVolumeLimit)).To(Equal(100.0))
+	g.Expect(value(accountVolumes)).To(Equal(0.0))
+	g.Expect(value(accountReservedIPLimit)).To(Equal(3.0))
+	g.Expect(value(accountReservedIPs)).To(Equal(0.0))
+}
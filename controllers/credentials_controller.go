@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// DefaultCredentialsSecretKey is the key of the DigitalOcean token in the
+// credentials Secret.
+const DefaultCredentialsSecretKey = "credentials"
+
+// CredentialsReconciler watches the Secret holding the DigitalOcean token of
+// the manager and switches the sessions to the new token when it is rotated,
+// so that a rotation does not require a restart of the manager. Like the
+// TokenFileWatcher, it runs on every replica rather than only on the leader.
+type CredentialsReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+
+	// Secret is the Secret holding the DigitalOcean token.
+	Secret types.NamespacedName
+	// Key is the key of the token in the Secret. Defaults to DefaultCredentialsSecretKey.
+	Key string
+
+	// reader reads the Secret from the cache set up by SetupWithManager.
+	// Defaults to Client.
+	reader client.Reader
+	// validate checks a token before switching to it. Defaults to scope.ValidateAccessToken.
+	validate func(ctx context.Context, accessToken string) error
+	// probeScopes probes the scopes of a token once switched to. Defaults to
+	// ProbeTokenScopes.
+	probeScopes func(ctx context.Context, log logr.Logger)
+}
+
+func (r *CredentialsReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	// The Secret lives in the namespace of the manager, which may not be
+	// watched by the manager cache, e.g. when running with --namespace.
+	secretCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: r.Secret.Namespace,
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.Secret{}: {Field: fields.OneTermEqualSelector("metadata.name", r.Secret.Name)},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error creating credentials Secret cache")
+	}
+	if err := mgr.Add(secretCache); err != nil {
+		return errors.Wrapf(err, "error adding credentials Secret cache")
+	}
+	r.reader = secretCache
+
+	options.Reconciler = r
+	c, err := controller.NewUnmanaged("credentials", mgr, options)
+	if err != nil {
+		return errors.Wrapf(err, "error creating controller")
+	}
+	if err := c.Watch(source.NewKindWithCache(&corev1.Secret{}, secretCache), &handler.EnqueueRequestForObject{}); err != nil {
+		return errors.Wrapf(err, "failed adding a watch for the credentials Secret")
+	}
+	if err := mgr.Add(credentialsController{c}); err != nil {
+		return errors.Wrapf(err, "error adding controller")
+	}
+	return nil
+}
+
+// credentialsController runs the credentials controller on every replica,
+// the manager only starts the controllers on the leader otherwise.
+type credentialsController struct {
+	controller.Controller
+}
+
+// NeedLeaderElection implements the manager LeaderElectionRunnable interface.
+// Every replica uses the token, e.g. to serve the webhooks.
+func (credentialsController) NeedLeaderElection() bool {
+	return false
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *CredentialsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	reader := r.reader
+	if reader == nil {
+		reader = r.Client
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, req.NamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("DigitalOcean credentials Secret not found, keeping the current token")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	key := r.Key
+	if key == "" {
+		key = DefaultCredentialsSecretKey
+	}
+	token := strings.TrimSpace(string(secret.Data[key]))
+	if token == "" {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, infrav1.InvalidCredentialsReason, "Key %q is missing or empty, keeping the current DigitalOcean token", key)
+		return ctrl.Result{}, nil
+	}
+	if token == scope.AccessToken() {
+		return ctrl.Result{}, nil
+	}
+
+	validate := r.validate
+	if validate == nil {
+		validate = func(ctx context.Context, accessToken string) error {
+			_, err := scope.ValidateAccessToken(ctx, accessToken)
+			return err
+		}
+	}
+	if err := validate(ctx, token); err != nil {
+		if doclient.IsInvalidCredentials(err) {
+			// Retrying does not help, the next update of the Secret triggers a new attempt.
+			r.Recorder.Eventf(secret, corev1.EventTypeWarning, infrav1.InvalidCredentialsReason, "Keeping the current DigitalOcean token: %s", doclient.ErrorMessage(err))
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "failed to validate rotated DigitalOcean token")
+	}
+
+	scope.SetAccessToken(token)
+	log.Info("Switched to rotated DigitalOcean token")
+	r.Recorder.Event(secret, corev1.EventTypeNormal, infrav1.CredentialsRotatedReason, "Switched to rotated DigitalOcean token")
+	probeScopes := r.probeScopes
+	if probeScopes == nil {
+		probeScopes = ProbeTokenScopes
+	}
+	probeScopes(ctx, log)
+	return ctrl.Result{}, nil
+}
+
+// ProbeTokenScopes probes the scopes of the manager token once it was loaded,
+// see scope.ProbeScopes, and logs the missing ones. The DOClusters and
+// DOMachines needing a missing scope report it on their conditions rather than
+// failing mid-provisioning. A failed probe is only logged, the scopes it could
+// not tell about are then assumed granted.
+func ProbeTokenScopes(ctx context.Context, log logr.Logger) {
+	missing, unknown, err := scope.ProbeScopes(ctx)
+	if err != nil {
+		log.Error(err, "Unable to probe the scopes of the DigitalOcean token")
+		return
+	}
+	if len(unknown) > 0 {
+		log.Info("Unable to tell whether the DigitalOcean token has some scopes, assuming it does", "unknown", unknown)
+	}
+	if len(missing) > 0 {
+		log.Info("DigitalOcean token is missing scopes, the resources needing them are not provisioned", "missing", missing)
+	} else if len(unknown) == 0 {
+		log.Info("DigitalOcean token has every scope the provider relies on")
+	}
+}
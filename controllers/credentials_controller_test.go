@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func TestCredentialsReconciler(t *testing.T) {
+	secretName := types.NamespacedName{Namespace: "capdo-system", Name: "capdo-manager-bootstrap-credentials"}
+
+	testCases := []struct {
+		name          string
+		data          map[string][]byte
+		validateErr   error
+		wantValidated string
+		wantToken     string
+		wantErr       bool
+	}{
+		{name: "unchanged", data: map[string][]byte{"credentials": []byte("old")}, wantToken: "old"},
+		{name: "rotated", data: map[string][]byte{"credentials": []byte("new\n")}, wantValidated: "new", wantToken: "new"},
+		{name: "missing key", data: map[string][]byte{"token": []byte("new")}, wantToken: "old"},
+		{name: "invalid token", data: map[string][]byte{"credentials": []byte("new")}, validateErr: &doclient.InvalidCredentialsError{Message: "revoked"}, wantValidated: "new", wantToken: "old"},
+		{name: "validation failed", data: map[string][]byte{"credentials": []byte("new")}, validateErr: errors.New("connection refused"), wantValidated: "new", wantToken: "old", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer scope.SetAccessToken("")
+			scope.SetAccessToken("old")
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+				Data:       tc.data,
+			}
+			validated := ""
+			r := &CredentialsReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build(),
+				Recorder: record.NewFakeRecorder(10),
+				Secret:   secretName,
+				validate: func(ctx context.Context, accessToken string) error {
+					validated = accessToken
+					return tc.validateErr
+				},
+				probeScopes: func(context.Context, logr.Logger) {},
+			}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: secretName})
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(validated).To(Equal(tc.wantValidated))
+			g.Expect(scope.AccessToken()).To(Equal(tc.wantToken))
+		})
+	}
+}
+
+func TestCredentialsReconcilerSetupWithManager(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "http://127.0.0.1:1"}, ctrl.Options{
+		Scheme:             scheme,
+		MetricsBindAddress: "0",
+		MapperProvider: func(*rest.Config) (meta.RESTMapper, error) {
+			return meta.NewDefaultRESTMapper(nil), nil
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &CredentialsReconciler{Secret: types.NamespacedName{Namespace: "capdo-system", Name: "capdo-manager-bootstrap-credentials"}}
+	g.Expect(r.SetupWithManager(context.Background(), mgr, controller.Options{})).To(Succeed())
+
+	// Every replica follows the rotations, not only the leader.
+	var runnable manager.LeaderElectionRunnable = credentialsController{}
+	g.Expect(runnable.NeedLeaderElection()).To(BeFalse())
+}
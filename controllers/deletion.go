@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// keyLockedRequeueAfter is the delay before reconciling again an object that
+// the other controller of its kind was reconciling.
+const keyLockedRequeueAfter = time.Second
+
+type deletionReconcileKey struct{}
+
+// keyLocks serializes the reconciles of an object by the main and the deletion
+// controllers of its kind, which each only serialize their own. Otherwise the
+// deletion controller could remove the finalizer of an object while the main
+// controller is still creating its droplet or load balancer, which would then
+// leak. The zero value is ready to use.
+type keyLocks struct {
+	mu   sync.Mutex
+	held map[types.NamespacedName]struct{}
+}
+
+// tryLock locks key unless it is already locked. It returns whether it did,
+// and the function unlocking it.
+func (l *keyLocks) tryLock(key types.NamespacedName) (func(), bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.held[key]; ok {
+		return nil, false
+	}
+	if l.held == nil {
+		l.held = map[types.NamespacedName]struct{}{}
+	}
+	l.held[key] = struct{}{}
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, key)
+	}, true
+}
+
+// isDeletionReconcile returns whether ctx is the one of a reconcile of a
+// deletion controller.
+func isDeletionReconcile(ctx context.Context) bool {
+	ok, _ := ctx.Value(deletionReconcileKey{}).(bool)
+	return ok
+}
+
+// setupDeletionController builds the controller name reconciling with r the
+// objects of the type of obj being deleted. It has its own queue and workers,
+// so that their teardown does not wait behind the creations and retries queued
+// in the main controller of the kind. It returns the channel the main
+// controller hands the objects being deleted over with, see forwardDeletion.
+func setupDeletionController(ctx context.Context, mgr ctrl.Manager, name string, obj client.Object, r reconcile.Reconciler, options controller.Options, watchFilterValue string) (chan<- event.GenericEvent, error) {
+	options.Reconciler = reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return r.Reconcile(context.WithValue(ctx, deletionReconcileKey{}, true), req)
+	})
+	c, err := controller.New(name, mgr, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating controller %s", name)
+	}
+
+	deleting := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return !o.GetDeletionTimestamp().IsZero()
+	})
+	if err := c.Watch(&source.Kind{Type: obj}, &handler.EnqueueRequestForObject{},
+		deleting, predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), watchFilterValue)); err != nil {
+		return nil, errors.Wrapf(err, "failed adding a watch for the objects deleted by controller %s", name)
+	}
+	events := make(chan event.GenericEvent)
+	if err := c.Watch(&source.Channel{Source: events}, &handler.EnqueueRequestForObject{}); err != nil {
+		return nil, errors.Wrapf(err, "failed adding a watch for the objects handed over to controller %s", name)
+	}
+	return events, nil
+}
+
+// forwardDeletion hands obj over to the deletion controller of events when it
+// is being deleted, e.g. when a retry of its creation was still queued in the
+// main controller. It returns whether it did, and the main controller is then
+// done with obj. events is nil when there is no deletion controller.
+func forwardDeletion(ctx context.Context, events chan<- event.GenericEvent, obj client.Object) bool {
+	if events == nil || obj.GetDeletionTimestamp().IsZero() || isDeletionReconcile(ctx) {
+		return false
+	}
+	select {
+	case events <- event.GenericEvent{Object: obj}:
+	case <-ctx.Done():
+		// The deletion controller is also triggered by the update that
+		// deleted obj.
+	}
+	return true
+}
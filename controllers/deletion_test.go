@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestForwardDeletion(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+	events := make(chan event.GenericEvent, 1)
+	machine := &infrav1.DOMachine{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	g.Expect(forwardDeletion(ctx, events, machine)).To(BeFalse())
+
+	now := metav1.Now()
+	machine.DeletionTimestamp = &now
+	g.Expect(forwardDeletion(ctx, nil, machine)).To(BeFalse())
+	g.Expect(forwardDeletion(ctx, events, machine)).To(BeTrue())
+	g.Expect(events).To(Receive(Equal(event.GenericEvent{Object: machine})))
+
+	// The deletion controller reconciles the objects it is handed over.
+	ctx = context.WithValue(ctx, deletionReconcileKey{}, true)
+	g.Expect(forwardDeletion(ctx, events, machine)).To(BeFalse())
+	g.Expect(events).NotTo(Receive())
+}
+
+func TestKeyLocks(t *testing.T) {
+	g := NewWithT(t)
+
+	var locks keyLocks
+	key := types.NamespacedName{Namespace: "default", Name: "foo"}
+	unlock, ok := locks.tryLock(key)
+	g.Expect(ok).To(BeTrue())
+
+	// The deletion controller waits for the main one to be done.
+	_, ok = locks.tryLock(key)
+	g.Expect(ok).To(BeFalse())
+	_, ok = locks.tryLock(types.NamespacedName{Namespace: "default", Name: "bar"})
+	g.Expect(ok).To(BeTrue())
+
+	unlock()
+	_, ok = locks.tryLock(key)
+	g.Expect(ok).To(BeTrue())
+}
@@ -21,12 +21,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/digitalocean/godo"
 	"github.com/pkg/errors"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/networking"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/tags"
 	dnsutil "sigs.k8s.io/cluster-api-provider-digitalocean/util/dns"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,11 +38,14 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -48,22 +55,86 @@ import (
 type DOClusterReconciler struct {
 	client.Client
 	Recorder record.EventRecorder
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// ShutdownGracePeriod is how long the DigitalOcean API calls in flight may
+	// complete once the manager is stopped. Defaults to DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+	// LoadBalancerRequeueAfter is the delay between two checks of a new load
+	// balancer. Defaults to DefaultLoadBalancerRequeueAfter.
+	LoadBalancerRequeueAfter time.Duration
+	// DNSRequeueAfter is the delay between two checks of the control plane DNS
+	// record propagation. Defaults to DefaultDNSRequeueAfter.
+	DNSRequeueAfter time.Duration
+	// TimeoutRequeueAfter is the delay before retrying a reconcile that ran out
+	// of time. Defaults to DefaultTimeoutRequeueAfter.
+	TimeoutRequeueAfter time.Duration
+	// BlockedRequeueAfter is the delay before retrying a reconcile blocked by
+	// a DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota. Defaults to DefaultBlockedRequeueAfter.
+	BlockedRequeueAfter time.Duration
+	// DriftCheckInterval is the delay between two comparisons of the
+	// DigitalOcean resources with their spec. Defaults to DefaultDriftCheckInterval.
+	DriftCheckInterval time.Duration
+	// OrphanDropletPolicy is what to do with droplets tagged for the cluster
+	// that are not referenced by a DOMachine. Defaults to OrphanDropletPolicyAdopt.
+	OrphanDropletPolicy OrphanDropletPolicy
+	// OrphanGracePeriod is how old a droplet referenced by no DOMachine must be
+	// to be considered orphaned. Defaults to DefaultOrphanGracePeriod.
+	OrphanGracePeriod time.Duration
+	// ResyncEvents, when set, receives the DOClusters whose DigitalOcean
+	// resources changed, see StatusResyncer.
+	ResyncEvents <-chan event.GenericEvent
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+	// DeletionOptions, when MaxConcurrentReconciles is set, configure a
+	// controller of its own reconciling the DOClusters being deleted.
+	DeletionOptions controller.Options
+	// RemoteClient returns a client of the workload cluster, used to rotate
+	// the token of its addons. Defaults to remote.NewClusterClient.
+	RemoteClient func(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+
+	deletionEvents chan<- event.GenericEvent
+	// reconciling locks the objects being reconciled by either controller.
+	reconciling keyLocks
+	// addonsTokens remembers the addons tokens pushed to the workload clusters.
+	addonsTokens pushedTokens
 }
 
 func (r *DOClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.DeletionOptions.MaxConcurrentReconciles > 0 {
+		events, err := setupDeletionController(ctx, mgr, DOClusterControllerName+"-deletion", &infrav1.DOCluster{}, r, r.DeletionOptions, r.WatchFilterValue)
+		if err != nil {
+			return err
+		}
+		r.deletionEvents = events
+	}
+
 	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.DOCluster{}).
-		WithEventFilter(predicates.ResourceNotPaused(ctrl.LoggerFrom(ctx))). // don't queue reconcile if resource is paused
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
 		Build(r)
 	if err != nil {
 		return errors.Wrapf(err, "error creating controller")
 	}
 
+	if r.ResyncEvents != nil {
+		if err := c.Watch(&source.Channel{Source: r.ResyncEvents}, &handler.EnqueueRequestForObject{}); err != nil {
+			return errors.Wrapf(err, "failed adding a watch for resynced DOClusters")
+		}
+	}
+
 	// Add a watch on clusterv1.Cluster object for unpause notifications.
 	if err = c.Watch(
 		&source.Kind{Type: &clusterv1.Cluster{}},
 		handler.EnqueueRequestsFromMapFunc(util.ClusterToInfrastructureMapFunc(infrav1.GroupVersion.WithKind("DOCluster"))),
-		predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+		predicates.All(ctrl.LoggerFrom(ctx),
+			predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+			predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
+		),
 	); err != nil {
 		return errors.Wrapf(err, "failed adding a watch for ready clusters")
 	}
@@ -78,6 +149,21 @@ func (r *DOClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 func (r *DOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	// Do not start new work once the manager is stopping, the reconciles in
+	// flight are still given ShutdownGracePeriod to complete.
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOCluster", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	// Held until the DOCluster is patched.
+	unlock, ok := r.reconciling.tryLock(req.NamespacedName)
+	if !ok {
+		return reconcile.Result{RequeueAfter: keyLockedRequeueAfter}, nil
+	}
+	defer unlock()
+
 	docluster := &infrav1.DOCluster{}
 	if err := r.Get(ctx, req.NamespacedName, docluster); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -85,6 +171,9 @@ func (r *DOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 		return reconcile.Result{}, err
 	}
+	if forwardDeletion(ctx, r.deletionEvents, docluster) {
+		return reconcile.Result{}, nil
+	}
 
 	// Fetch the Cluster.
 	cluster, err := util.GetOwnerCluster(ctx, r.Client, docluster.ObjectMeta)
@@ -95,6 +184,13 @@ func (r *DOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Info("Cluster Controller has not yet set OwnerRef")
 		return reconcile.Result{}, nil
 	}
+	log = log.WithValues("cluster", cluster.Name)
+	ctx = withClusterAccounting(ctx, cluster)
+
+	if annotations.IsPaused(cluster, docluster) {
+		log.Info("DOCluster or linked Cluster is marked as paused. Won't reconcile")
+		return reconcile.Result{}, nil
+	}
 
 	// Create the cluster scope.
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
@@ -114,12 +210,23 @@ func (r *DOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}()
 
-	// Handle deleted clusters
+	handleReconcileAnnotation(r.Recorder, docluster, clusterScope.Catalog)
+
+	// Only the reconcile itself is bounded, the scope is still closed once it
+	// ran out of time so that its progress is persisted.
+	reconcileCtx, cancel := withReconcileTimeout(ctx, r.ReconcileTimeout, r.ShutdownGracePeriod)
+	defer cancel()
+
+	var result reconcile.Result
 	if !docluster.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, clusterScope)
+		// Handle deleted clusters
+		result, err = r.reconcileDelete(reconcileCtx, clusterScope)
+	} else {
+		result, err = r.reconcile(reconcileCtx, clusterScope)
 	}
-
-	return r.reconcile(ctx, clusterScope)
+	recordThrottling(r.Recorder, docluster, err)
+	observeReconcile(DOClusterControllerName, result, err)
+	return requeueOnTimeout(r.TimeoutRequeueAfter)(requeueOnRateLimit(requeueOnErrorClass(docluster, r.BlockedRequeueAfter)(result, err)))
 }
 
 func (r *DOClusterReconciler) reconcile(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
@@ -128,22 +235,123 @@ func (r *DOClusterReconciler) reconcile(ctx context.Context, clusterScope *scope
 	// If the DOCluster doesn't have our finalizer, add it.
 	controllerutil.AddFinalizer(docluster, infrav1.ClusterFinalizer)
 
+	// The orphaned droplets do not depend on the load balancer, they are
+	// reconciled meanwhile rather than once it is ready. The sweep only reads
+	// the cluster, so it gets copies of its own and the endpoint reconcile
+	// can update the DOCluster status and conditions without racing with it.
+	// It is best effort: its failures are retried without holding back the
+	// cluster.
+	orphansScope := *clusterScope
+	orphansScope.Cluster = clusterScope.Cluster.DeepCopy()
+	orphansScope.DOCluster = clusterScope.DOCluster.DeepCopy()
+	var (
+		result    reconcile.Result
+		orphanErr error
+	)
+	err := runConcurrently(clusterScope.Logger,
+		func() (err error) {
+			result, err = r.reconcileControlPlaneEndpoint(ctx, clusterScope)
+			return err
+		},
+		func() error {
+			orphanErr = r.reconcileOrphans(ctx, &orphansScope)
+			return nil
+		},
+	)
+	requeueAfter := orDefault(r.DriftCheckInterval, DefaultDriftCheckInterval)
+	if orphanErr != nil {
+		clusterScope.Error(orphanErr, "Failed to reconcile orphaned droplets, retrying")
+		if requeueAfter > orphanSweepRetryAfter {
+			requeueAfter = orphanSweepRetryAfter
+		}
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !result.IsZero() {
+		return result, nil
+	}
+
+	// Droplets are placed within a single region, which is the only failure
+	// domain of the cluster.
+	docluster.Status.FailureDomains = clusterv1.FailureDomains{
+		docluster.Spec.Region: clusterv1.FailureDomainSpec{ControlPlane: true},
+	}
+
+	clusterScope.Info("Set DOCluster status to ready")
+	if !docluster.Status.Ready {
+		observeClusterReady(docluster)
+	}
+	clusterScope.SetReady()
+	r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.DOClusterReadyReason, "DOCluster %s - has ready status", clusterScope.Name())
+
+	// The cluster does not wait for its addons, they are only applied once
+	// its control plane is up.
+	if err := r.reconcileAddons(ctx, clusterScope); err != nil {
+		recordFailure(r.Recorder, docluster, infrav1.AddonsConfiguringErrorReason, err)
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile addons")
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileControlPlaneEndpoint reconciles the API server load balancer and
+// the DNS record pointing to it, and sets the control plane endpoint once
+// they are ready. It returns a result to requeue while they are not.
+func (r *DOClusterReconciler) reconcileControlPlaneEndpoint(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	docluster := clusterScope.DOCluster
 	networkingsvc := networking.NewService(ctx, clusterScope)
 	apiServerLoadbalancer := clusterScope.APIServerLoadbalancers()
 	apiServerLoadbalancer.ApplyDefault()
 
 	apiServerLoadbalancerRef := clusterScope.APIServerLoadbalancersRef()
-	loadbalancer, err := networkingsvc.GetLoadBalancer(apiServerLoadbalancerRef.ResourceID)
+
+	// The DNS record is looked up along with a load balancer that was ready,
+	// e.g. on drift checks, rather than while a new one is polled.
+	recordSpec := docluster.Spec.ControlPlaneDNS
+	var (
+		loadbalancer *godo.LoadBalancer
+		dRecord      *godo.DomainRecord
+		recordLooked bool
+	)
+	lookupRecord := func() (err error) {
+		recordLooked = true
+		dRecord, err = networkingsvc.GetDomainRecord(recordSpec.Domain, recordSpec.Name, "A")
+		return errors.Wrapf(err, "failed verify DNS record for LB Name %s.%s", recordSpec.Name, recordSpec.Domain)
+	}
+	err := runConcurrently(clusterScope.Logger,
+		func() (err error) {
+			loadbalancer, err = networkingsvc.GetLoadBalancer(apiServerLoadbalancerRef.ResourceID)
+			return err
+		},
+		func() error {
+			if recordSpec == nil || apiServerLoadbalancerRef.ResourceStatus != infrav1.DOResourceStatusRunning {
+				return nil
+			}
+			return lookupRecord()
+		},
+	)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if loadbalancer == nil && apiServerLoadbalancerRef.ResourceID != "" {
+		r.Recorder.Eventf(docluster, corev1.EventTypeWarning, infrav1.LoadBalancerDeletedExternallyReason,
+			"Load balancer %s was deleted outside of the provider, creating a new one", apiServerLoadbalancerRef.ResourceID)
+	}
 	if loadbalancer == nil {
+		// A token known to lack a scope would only get a 403 Forbidden.
+		if err := scope.CheckScopes(doclient.ScopeLoadBalancerCreate); err != nil {
+			recordFailure(r.Recorder, docluster, infrav1.LoadBalancerCreatingErrorReason, err)
+			setErrorCondition(docluster, infrav1.LoadBalancerReadyCondition, err)
+			return reconcile.Result{}, err
+		}
 		loadbalancer, err = networkingsvc.CreateLoadBalancer(apiServerLoadbalancer)
 		if err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "failed to create load balancers for DOCluster %s/%s", docluster.Namespace, docluster.Name)
+			err = errors.Wrapf(err, "failed to create load balancers for DOCluster %s/%s", docluster.Namespace, docluster.Name)
+			recordFailure(r.Recorder, docluster, infrav1.LoadBalancerCreatingErrorReason, err)
+			return reconcile.Result{}, err
 		}
 
-		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, "LoadBalancerCreated", "Created new load balancers - %s", loadbalancer.Name)
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.LoadBalancerCreatedReason, "Created new load balancers - %s", loadbalancer.Name)
 	}
 
 	apiServerLoadbalancerRef.ResourceID = loadbalancer.ID
@@ -151,40 +359,71 @@ func (r *DOClusterReconciler) reconcile(ctx context.Context, clusterScope *scope
 
 	if apiServerLoadbalancerRef.ResourceStatus != infrav1.DOResourceStatusRunning && loadbalancer.IP == "" {
 		clusterScope.Info("Waiting on API server Global IP Address")
-		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+		conditions.MarkFalse(docluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerProvisioningReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{RequeueAfter: orDefault(r.LoadBalancerRequeueAfter, DefaultLoadBalancerRequeueAfter)}, nil
 	}
 
-	r.Recorder.Eventf(docluster, corev1.EventTypeNormal, "LoadBalancerReady", "LoadBalancer got an IP Address - %s", loadbalancer.IP)
+	// The control plane may still be reachable through a degraded load
+	// balancer, the DOCluster stays ready.
+	if apiServerLoadbalancerRef.ResourceStatus == infrav1.DOResourceStatusErrored {
+		if conditions.GetReason(docluster, infrav1.LoadBalancerReadyCondition) != infrav1.LoadBalancerDegradedReason {
+			r.Recorder.Eventf(docluster, corev1.EventTypeWarning, infrav1.LoadBalancerDegradedReason, "Load balancer %s is in the errored state", loadbalancer.ID)
+		}
+		conditions.MarkFalse(docluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerDegradedReason, clusterv1.ConditionSeverityWarning,
+			"load balancer %s is in the errored state", loadbalancer.ID)
+	} else {
+		conditions.MarkTrue(docluster, infrav1.LoadBalancerReadyCondition)
+	}
 
-	var controlPlaneEndpoint = loadbalancer.IP
-	if docluster.Spec.ControlPlaneDNS != nil {
-		clusterScope.Info("Verifying LB DNS Record")
-		// ensure DNS record is created and use it as control plane endpoint
-		recordSpec := docluster.Spec.ControlPlaneDNS
-		controlPlaneEndpoint = fmt.Sprintf("%s.%s", recordSpec.Name, recordSpec.Domain)
-		dRecord, err := networkingsvc.GetDomainRecord(
-			recordSpec.Domain,
-			recordSpec.Name,
-			"A",
-		)
+	r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.LoadBalancerReadyReason, "LoadBalancer got an IP Address - %s", loadbalancer.IP)
 
-		if err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "failed verify DNS record for LB Name %s.%s",
-				recordSpec.Name, recordSpec.Domain)
+	if recordSpec != nil && !recordLooked {
+		if err := lookupRecord(); err != nil {
+			return reconcile.Result{}, err
 		}
+	}
 
-		if dRecord == nil || dRecord.Data != loadbalancer.IP {
+	// The drift of the load balancer and the DNS record only depend on the
+	// load balancer, they are reconciled concurrently. The steps do not
+	// update the DOCluster, their outcome is recorded once both are done.
+	var (
+		corrected     []string
+		recordUpdated bool
+	)
+	recordStale := recordSpec != nil && (dRecord == nil || dRecord.Data != loadbalancer.IP)
+	err = runConcurrently(clusterScope.Logger,
+		func() (err error) {
+			corrected, err = networkingsvc.ReconcileLoadBalancerDrift(apiServerLoadbalancer, loadbalancer)
+			return errors.Wrap(err, "failed to reconcile load balancer drift")
+		},
+		func() error {
+			if !recordStale {
+				return nil
+			}
 			clusterScope.Info("Ensuring LB DNS Record is in place")
-			clusterScope.SetControlPlaneDNSRecordReady(false)
-			if err := networkingsvc.UpsertDomainRecord(
-				recordSpec.Domain,
-				recordSpec.Name,
-				"A",
-				loadbalancer.IP,
-			); err != nil {
-				return reconcile.Result{}, errors.Wrap(err, "failed to reconcile LB DNS record")
+			if err := networkingsvc.UpsertDomainRecord(recordSpec.Domain, recordSpec.Name, "A", loadbalancer.IP); err != nil {
+				err = errors.Wrap(err, "failed to reconcile LB DNS record")
+				recordFailure(r.Recorder, docluster, infrav1.DomainRecordUpdatingErrorReason, err)
+				return err
 			}
-		}
+			recordUpdated = true
+			return nil
+		},
+	)
+	if recordStale {
+		clusterScope.SetControlPlaneDNSRecordReady(false)
+	}
+	if recordUpdated {
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.DomainRecordUpdatedReason, "Pointed DNS Record '%s.%s' to IP '%s'", recordSpec.Name, recordSpec.Domain, loadbalancer.IP)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	setDriftCondition(r.Recorder, docluster, corrected, nil)
+
+	var controlPlaneEndpoint = loadbalancer.IP
+	if recordSpec != nil {
+		controlPlaneEndpoint = fmt.Sprintf("%s.%s", recordSpec.Name, recordSpec.Domain)
 
 		// If the record has never been ready we need to check whether it has
 		// been propagated or not. Updating the record in the DNS API does not
@@ -202,7 +441,7 @@ func (r *DOClusterReconciler) reconcile(ctx context.Context, clusterScope *scope
 
 			if !propagated {
 				clusterScope.Info("Waiting for DNS record to be propagated")
-				return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+				return reconcile.Result{RequeueAfter: orDefault(r.DNSRequeueAfter, DefaultDNSRequeueAfter)}, nil
 			}
 
 			clusterScope.Info("DNS record is propagated - set DOCluster ControlPlaneDNSRecordReady status to ready")
@@ -210,17 +449,13 @@ func (r *DOClusterReconciler) reconcile(ctx context.Context, clusterScope *scope
 		}
 
 		clusterScope.Info("LB DNS Record is already ready")
-		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, "DomainRecordReady", "DNS Record '%s.%s' with IP '%s'", recordSpec.Name, recordSpec.Domain, loadbalancer.IP)
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.DomainRecordReadyReason, "DNS Record '%s.%s' with IP '%s'", recordSpec.Name, recordSpec.Domain, loadbalancer.IP)
 	}
 
 	clusterScope.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 		Host: controlPlaneEndpoint,
 		Port: int32(apiServerLoadbalancer.Port),
 	})
-
-	clusterScope.Info("Set DOCluster status to ready")
-	clusterScope.SetReady()
-	r.Recorder.Eventf(docluster, corev1.EventTypeNormal, "DOClusterReady", "DOCluster %s - has ready status", clusterScope.Name())
 	return reconcile.Result{}, nil
 }
 
@@ -235,6 +470,7 @@ func (r *DOClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		if err := networkingsvc.DeleteDomainRecord(recordSpec.Domain, recordSpec.Name, "A"); err != nil {
 			return reconcile.Result{}, err
 		}
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.DomainRecordDeletedReason, "Deleted DNS Record '%s.%s'", recordSpec.Name, recordSpec.Domain)
 	}
 
 	loadbalancer, err := networkingsvc.GetLoadBalancer(apiServerLoadbalancerRef.ResourceID)
@@ -244,17 +480,24 @@ func (r *DOClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 
 	if loadbalancer == nil {
 		clusterScope.V(2).Info("Unable to locate load balancer")
-		r.Recorder.Eventf(docluster, corev1.EventTypeWarning, "NoLoadBalancerFound", "Unable to find matching load balancer")
-		controllerutil.RemoveFinalizer(docluster, infrav1.ClusterFinalizer)
-		return reconcile.Result{}, nil
+		r.Recorder.Eventf(docluster, corev1.EventTypeWarning, infrav1.NoLoadBalancerFoundReason, "Unable to find matching load balancer")
+	} else {
+		if err := networkingsvc.DeleteLoadBalancer(loadbalancer.ID); err != nil {
+			err = errors.Wrapf(err, "error deleting load balancer for DOCluster %s/%s", docluster.Namespace, docluster.Name)
+			recordFailure(r.Recorder, docluster, infrav1.LoadBalancerDeletingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.LoadBalancerDeletedReason, "Deleted an LoadBalancer - %s", loadbalancer.Name)
 	}
 
-	if err := networkingsvc.DeleteLoadBalancer(loadbalancer.ID); err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "error deleting load balancer for DOCluster %s/%s", docluster.Namespace, docluster.Name)
+	// Machines are deleted before the cluster, so its tags are no longer in use.
+	if err := tags.NewService(ctx, clusterScope).DeleteClusterTags(); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "error deleting tags for DOCluster %s/%s", docluster.Namespace, docluster.Name)
 	}
 
-	r.Recorder.Eventf(docluster, corev1.EventTypeNormal, "LoadBalancerDeleted", "Deleted an LoadBalancer - %s", loadbalancer.Name)
 	// Cluster is deleted so remove the finalizer.
+	r.addonsTokens.forget(client.ObjectKeyFromObject(docluster))
 	controllerutil.RemoveFinalizer(docluster, infrav1.ClusterFinalizer)
 	return reconcile.Result{}, nil
 }
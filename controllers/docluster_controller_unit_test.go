@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDOClusterReconcileOrphanSweepFailure(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	cluster := newCluster("foo")
+	cluster.UID = "uid"
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"},
+		Spec:       infrav1.DOClusterSpec{Region: "nyc1"},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		DOClients: scope.DOClients{Droplets: c.Droplets, LoadBalancers: c.LoadBalancers, Tags: c.Tags},
+		Client:    client,
+		Cluster:   cluster,
+		DOCluster: docluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &DOClusterReconciler{Client: client, Recorder: record.NewFakeRecorder(100)}
+	// The sweep of the orphaned droplets fails while the load balancer is
+	// created and once it is ready.
+	s.FailNext(http.MethodGet, "/v2/droplets", http.StatusBadRequest, 2)
+	result, err := r.reconcile(ctx, clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(DefaultLoadBalancerRequeueAfter))
+
+	// The cluster gets ready anyway, the sweep is retried sooner than the
+	// drift checks.
+	result, err = r.reconcile(ctx, clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(docluster.Status.Ready).To(BeTrue())
+	g.Expect(docluster.Spec.ControlPlaneEndpoint.Host).NotTo(BeEmpty())
+	g.Expect(result.RequeueAfter).To(Equal(orphanSweepRetryAfter))
+
+	result, err = r.reconcile(ctx, clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(DefaultDriftCheckInterval))
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/firewalls"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// DOFirewallReconciler reconciles a DOFirewall object.
+type DOFirewallReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// ShutdownGracePeriod is how long the DigitalOcean API calls in flight may
+	// complete once the manager is stopped. Defaults to DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+	// TimeoutRequeueAfter is the delay before retrying a reconcile that ran out
+	// of time. Defaults to DefaultTimeoutRequeueAfter.
+	TimeoutRequeueAfter time.Duration
+	// BlockedRequeueAfter is the delay before retrying a reconcile blocked by
+	// a DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota. Defaults to DefaultBlockedRequeueAfter.
+	BlockedRequeueAfter time.Duration
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+}
+
+func (r *DOFirewallReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.DOFirewall{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
+		Watches(
+			&source.Kind{Type: &infrav1.DOCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.DOClusterToDOFirewalls(ctx)),
+		).
+		Watches(
+			&source.Kind{Type: &infrav1.DOMachine{}},
+			handler.EnqueueRequestsFromMapFunc(r.DOMachineToDOFirewalls(ctx)),
+		).
+		WithOptions(options).
+		Build(r)
+	if err != nil {
+		return errors.Wrapf(err, "error creating controller")
+	}
+	return nil
+}
+
+// DOClusterToDOFirewalls is a handler.ToRequestsFunc to be used to enqueue
+// the DOFirewalls referenced by a DOCluster, or applied to it before its
+// reference was removed.
+func (r *DOFirewallReconciler) DOClusterToDOFirewalls(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(o client.Object) []ctrl.Request {
+		c, ok := o.(*infrav1.DOCluster)
+		if !ok {
+			log.Error(errors.Errorf("expected a DOCluster but got a %T", o), "failed to get DOFirewall for DOCluster")
+			return nil
+		}
+		tag, _ := clusterTagOf(c)
+		return r.firewallRequests(ctx, c.Namespace, c.Spec.FirewallRefs, func(fw *infrav1.DOFirewall) bool {
+			return tag != "" && sets.NewString(fw.Status.Tags...).Has(tag)
+		})
+	}
+}
+
+// DOMachineToDOFirewalls is a handler.ToRequestsFunc to be used to enqueue
+// the DOFirewalls referenced by a DOMachine, or applied to its droplet before
+// its reference was removed.
+func (r *DOFirewallReconciler) DOMachineToDOFirewalls(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(o client.Object) []ctrl.Request {
+		m, ok := o.(*infrav1.DOMachine)
+		if !ok {
+			log.Error(errors.Errorf("expected a DOMachine but got a %T", o), "failed to get DOFirewall for DOMachine")
+			return nil
+		}
+		id := dropletIDOf(m)
+		return r.firewallRequests(ctx, m.Namespace, m.Spec.FirewallRefs, func(fw *infrav1.DOFirewall) bool {
+			for _, applied := range fw.Status.DropletIDs {
+				if id != 0 && applied == id {
+					return true
+				}
+			}
+			return false
+		})
+	}
+}
+
+// firewallRequests returns the requests of the DOFirewalls of namespace that
+// are in refs or for which applied returns true.
+func (r *DOFirewallReconciler) firewallRequests(ctx context.Context, namespace string, refs []corev1.LocalObjectReference, applied func(*infrav1.DOFirewall) bool) []ctrl.Request {
+	list := &infrav1.DOFirewallList{}
+	if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list DOFirewalls")
+		return nil
+	}
+	result := []ctrl.Request{}
+	for i := range list.Items {
+		fw := &list.Items[i]
+		if hasRef(refs, fw.Name) || applied(fw) {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(fw)})
+		}
+	}
+	return result
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dofirewalls,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dofirewalls/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=doclusters;domachines,verbs=get;list;watch
+
+func (r *DOFirewallReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Do not start new work once the manager is stopping, the reconciles in
+	// flight are still given ShutdownGracePeriod to complete.
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOFirewall", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	dofirewall := &infrav1.DOFirewall{}
+	if err := r.Get(ctx, req.NamespacedName, dofirewall); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	firewallScope, err := scope.NewFirewallScope(scope.FirewallScopeParams{
+		Client:     r.Client,
+		Logger:     log,
+		DOFirewall: dofirewall,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any changes.
+	defer func() {
+		if err := firewallScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	reconcileCtx, cancel := withReconcileTimeout(ctx, r.ReconcileTimeout, r.ShutdownGracePeriod)
+	defer cancel()
+
+	var result reconcile.Result
+	if !dofirewall.DeletionTimestamp.IsZero() {
+		result, err = r.reconcileDelete(reconcileCtx, firewallScope)
+	} else {
+		result, err = r.reconcile(reconcileCtx, firewallScope)
+	}
+	recordThrottling(r.Recorder, dofirewall, err)
+	observeReconcile(DOFirewallControllerName, result, err)
+	setErrorCondition(dofirewall, infrav1.FirewallReadyCondition, err)
+	return requeueOnTimeout(r.TimeoutRequeueAfter)(requeueOnRateLimit(requeueOnErrorClass(dofirewall, r.BlockedRequeueAfter)(result, err)))
+}
+
+func (r *DOFirewallReconciler) reconcile(ctx context.Context, firewallScope *scope.FirewallScope) (reconcile.Result, error) {
+	firewallScope.Info("Reconciling DOFirewall")
+	dofirewall := firewallScope.DOFirewall
+
+	// If the DOFirewall doesn't have our finalizer, add it.
+	controllerutil.AddFinalizer(dofirewall, infrav1.FirewallFinalizer)
+
+	dropletTags, dropletIDs, err := r.targets(ctx, dofirewall)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	svc := firewalls.NewService(ctx, firewallScope)
+	request := svc.Request(dropletTags, dropletIDs)
+
+	var fw *godo.Firewall
+	if id := firewallScope.FirewallID(); id != "" {
+		// A firewall deleted outside of the provider is created again.
+		fw, err = svc.GetFirewall(id)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if fw == nil {
+		fw, err = svc.GetFirewallByName(request.Name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if fw != nil {
+			r.Recorder.Eventf(dofirewall, corev1.EventTypeNormal, infrav1.FirewallAdoptedReason, "Adopted existing firewall - %s", fw.Name)
+		}
+	}
+	switch {
+	case fw == nil:
+		fw, err = svc.CreateFirewall(request)
+		if err != nil {
+			recordFailure(r.Recorder, dofirewall, infrav1.FirewallCreatingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Eventf(dofirewall, corev1.EventTypeNormal, infrav1.FirewallCreatedReason, "Created new firewall - %s", fw.Name)
+	case !firewalls.UpToDate(fw, request):
+		fw, err = svc.UpdateFirewall(fw.ID, request)
+		if err != nil {
+			recordFailure(r.Recorder, dofirewall, infrav1.FirewallUpdatingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Eventf(dofirewall, corev1.EventTypeNormal, infrav1.FirewallUpdatedReason, "Updated the rules and droplets of firewall - %s", fw.Name)
+	}
+	firewallScope.SetFirewall(fw)
+
+	conditions.MarkTrue(dofirewall, infrav1.FirewallReadyCondition)
+	firewallScope.SetReady(true)
+	return reconcile.Result{}, nil
+}
+
+// targets returns the droplet tags and the droplet IDs the DOFirewall applies
+// to: its own droplet tags, the tag of the droplets of the clusters of the
+// DOClusters referencing it and the droplets of the DOMachines referencing it.
+func (r *DOFirewallReconciler) targets(ctx context.Context, dofirewall *infrav1.DOFirewall) ([]string, []int, error) {
+	dropletTags := sets.NewString(dofirewall.Spec.DropletTags...)
+
+	doclusters := &infrav1.DOClusterList{}
+	if err := r.List(ctx, doclusters, client.InNamespace(dofirewall.Namespace)); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list DOClusters")
+	}
+	for i := range doclusters.Items {
+		c := &doclusters.Items[i]
+		if !hasRef(c.Spec.FirewallRefs, dofirewall.Name) || !c.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if tag, ok := clusterTagOf(c); ok {
+			dropletTags.Insert(tag)
+		}
+	}
+
+	domachines := &infrav1.DOMachineList{}
+	if err := r.List(ctx, domachines, client.InNamespace(dofirewall.Namespace)); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list DOMachines")
+	}
+	var dropletIDs []int
+	for i := range domachines.Items {
+		m := &domachines.Items[i]
+		if !hasRef(m.Spec.FirewallRefs, dofirewall.Name) || !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if id := dropletIDOf(m); id != 0 {
+			dropletIDs = append(dropletIDs, id)
+		}
+	}
+	return dropletTags.List(), dropletIDs, nil
+}
+
+func (r *DOFirewallReconciler) reconcileDelete(ctx context.Context, firewallScope *scope.FirewallScope) (reconcile.Result, error) {
+	firewallScope.Info("Reconciling delete DOFirewall")
+	dofirewall := firewallScope.DOFirewall
+
+	if id := firewallScope.FirewallID(); id != "" {
+		if err := firewalls.NewService(ctx, firewallScope).DeleteFirewall(id); err != nil {
+			recordFailure(r.Recorder, dofirewall, infrav1.FirewallDeletingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Eventf(dofirewall, corev1.EventTypeNormal, infrav1.FirewallDeletedReason, "Deleted the firewall - %s", dofirewall.FirewallName())
+	}
+
+	controllerutil.RemoveFinalizer(dofirewall, infrav1.FirewallFinalizer)
+	return reconcile.Result{}, nil
+}
+
+// clusterTagOf returns the tag carried by all the droplets of the Cluster
+// owning docluster, or false when the owner is not set yet.
+func clusterTagOf(docluster *infrav1.DOCluster) (string, bool) {
+	for _, ref := range docluster.OwnerReferences {
+		if ref.Kind == "Cluster" && strings.HasPrefix(ref.APIVersion, clusterv1.GroupVersion.Group+"/") {
+			return infrav1.ClusterNameUIDTag(infrav1.DOSafeName(ref.Name), string(ref.UID)), true
+		}
+	}
+	return "", false
+}
+
+func hasRef(refs []corev1.LocalObjectReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestDOFirewallReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	droplet, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name: "bastion", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	refs := []corev1.LocalObjectReference{{Name: "ssh"}}
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            "foo",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Name: "foo", UID: "uid"}},
+		},
+		Spec: infrav1.DOClusterSpec{FirewallRefs: refs},
+	}
+	domachine := &infrav1.DOMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "bastion"},
+		Spec: infrav1.DOMachineSpec{
+			ProviderID:   pointer.StringPtr(fmt.Sprintf("digitalocean://%d", droplet.ID)),
+			FirewallRefs: refs,
+		},
+	}
+	dofirewall := &infrav1.DOFirewall{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ssh"},
+		Spec: infrav1.DOFirewallSpec{
+			InboundRules: []infrav1.DOFirewallInboundRule{
+				{Protocol: "tcp", Ports: "22", Sources: infrav1.DOFirewallEndpoints{Addresses: []string{"0.0.0.0/0"}}},
+			},
+			OutboundRules: []infrav1.DOFirewallOutboundRule{
+				{Protocol: "tcp", Ports: "all", Destinations: infrav1.DOFirewallEndpoints{Addresses: []string{"0.0.0.0/0", "::/0"}}},
+			},
+			DropletTags: []string{"bastion"},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster, domachine, dofirewall).Build()
+	newScope := func() *scope.FirewallScope {
+		firewallScope, err := scope.NewFirewallScope(scope.FirewallScopeParams{
+			DOClients:  scope.DOClients{Firewalls: c.Firewalls, Tags: c.Tags},
+			Client:     client,
+			DOFirewall: dofirewall,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return firewallScope
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &DOFirewallReconciler{Client: client, Recorder: recorder}
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.FirewallCreatedReason)))
+	g.Expect(dofirewall.Status.Ready).To(BeTrue())
+	g.Expect(s.Firewalls()).To(HaveLen(1))
+	fw := s.Firewalls()[0]
+	g.Expect(fw.Tags).To(ConsistOf("bastion", infrav1.ClusterNameUIDTag("foo", "uid")))
+	g.Expect(fw.DropletIDs).To(ConsistOf(droplet.ID))
+
+	// Reconciling again leaves the firewall alone.
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).NotTo(Receive())
+
+	// The droplet of a DOMachine no longer referencing the firewall is removed.
+	domachine.Spec.FirewallRefs = nil
+	g.Expect(client.Update(ctx, domachine)).To(Succeed())
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.FirewallUpdatedReason)))
+	g.Expect(s.Firewalls()[0].DropletIDs).To(BeEmpty())
+
+	_, err = r.reconcileDelete(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(controllerutil.ContainsFinalizer(dofirewall, infrav1.FirewallFinalizer)).To(BeFalse())
+	g.Expect(s.Firewalls()).To(BeEmpty())
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/images"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultImageResyncPeriod is how often a DOImage looks again for its image,
+// e.g. for a newer snapshot carrying its tag.
+const DefaultImageResyncPeriod = 10 * time.Minute
+
+// DOImageReconciler reconciles a DOImage object.
+type DOImageReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// ShutdownGracePeriod is how long the DigitalOcean API calls in flight may
+	// complete once the manager is stopped. Defaults to DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+	// TimeoutRequeueAfter is the delay before retrying a reconcile that ran out
+	// of time. Defaults to DefaultTimeoutRequeueAfter.
+	TimeoutRequeueAfter time.Duration
+	// BlockedRequeueAfter is the delay before retrying a reconcile blocked by
+	// a DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota. Defaults to DefaultBlockedRequeueAfter.
+	BlockedRequeueAfter time.Duration
+	// ResyncPeriod is how often the images registered by ID or tag are looked
+	// up again. Defaults to DefaultImageResyncPeriod.
+	ResyncPeriod time.Duration
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+}
+
+func (r *DOImageReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.DOImage{}).
+		Owns(&batchv1.Job{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
+		WithOptions(options).
+		Build(r)
+	if err != nil {
+		return errors.Wrapf(err, "error creating controller")
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=doimages,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=doimages/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+
+func (r *DOImageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Do not start new work once the manager is stopping, the reconciles in
+	// flight are still given ShutdownGracePeriod to complete.
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOImage", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	doimage := &infrav1.DOImage{}
+	if err := r.Get(ctx, req.NamespacedName, doimage); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The images are not deleted with their DOImage, droplets may still use
+	// them. The build Jobs are garbage collected through their owner reference.
+	if !doimage.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	imageScope, err := scope.NewImageScope(scope.ImageScopeParams{
+		Client:  r.Client,
+		Logger:  log,
+		DOImage: doimage,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any changes.
+	defer func() {
+		if err := imageScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	reconcileCtx, cancel := withReconcileTimeout(ctx, r.ReconcileTimeout, r.ShutdownGracePeriod)
+	defer cancel()
+
+	result, err := r.reconcile(reconcileCtx, imageScope)
+	recordThrottling(r.Recorder, doimage, err)
+	observeReconcile(DOImageControllerName, result, err)
+	setErrorCondition(doimage, infrav1.ImageReadyCondition, err)
+	return requeueOnTimeout(r.TimeoutRequeueAfter)(requeueOnRateLimit(requeueOnErrorClass(doimage, r.BlockedRequeueAfter)(result, err)))
+}
+
+func (r *DOImageReconciler) reconcile(ctx context.Context, imageScope *scope.ImageScope) (reconcile.Result, error) {
+	imageScope.Info("Reconciling DOImage")
+	doimage := imageScope.DOImage
+
+	if doimage.Spec.Build != nil {
+		return r.reconcileBuild(ctx, imageScope)
+	}
+
+	svc := images.NewService(ctx, imageScope)
+	var image *godo.Image
+	var err error
+	if doimage.Spec.ID != 0 {
+		image, err = svc.GetImage(doimage.Spec.ID)
+	} else {
+		image, err = svc.GetLatestImageByTag(doimage.Spec.SnapshotTag)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Look again later for a newer snapshot, or for an image that was deleted.
+	result := reconcile.Result{RequeueAfter: orDefault(r.ResyncPeriod, DefaultImageResyncPeriod)}
+	if image == nil {
+		imageScope.SetReady(false)
+		conditions.MarkFalse(doimage, infrav1.ImageReadyCondition, infrav1.ImageNotFoundReason, clusterv1.ConditionSeverityWarning,
+			"no available image matches the DOImage")
+		r.Recorder.Event(doimage, corev1.EventTypeWarning, infrav1.ImageNotFoundReason, "No available image matches the DOImage")
+		return result, nil
+	}
+	r.setImage(imageScope, image)
+	return result, nil
+}
+
+// reconcileBuild runs the Job building the snapshot of the current generation
+// of the DOImage. Droplets keep being created from the previous snapshot, if
+// any, until the new one is built.
+func (r *DOImageReconciler) reconcileBuild(ctx context.Context, imageScope *scope.ImageScope) (reconcile.Result, error) {
+	doimage := imageScope.DOImage
+	if doimage.Status.ObservedGeneration == doimage.Generation && imageScope.ImageID() != 0 {
+		return reconcile.Result{}, nil
+	}
+
+	// The snapshot may already have been built, e.g. by a Job whose completion
+	// was not recorded.
+	snapshotName := imageScope.SnapshotName()
+	image, err := images.NewService(ctx, imageScope).GetUserImageByName(snapshotName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if image != nil {
+		r.setImage(imageScope, image)
+		return reconcile.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+	key := client.ObjectKey{Namespace: doimage.Namespace, Name: fmt.Sprintf("%s-build-%d", doimage.Name, doimage.Generation)}
+	if err := r.Get(ctx, key, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to get Job %s", key)
+		}
+		job = buildJob(key, doimage.Spec.Build, snapshotName)
+		if err := controllerutil.SetControllerReference(doimage, job, r.Scheme()); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to set the owner of the build Job")
+		}
+		if err := r.Create(ctx, job); err != nil {
+			err = errors.Wrapf(err, "failed to create Job %s", key)
+			recordFailure(r.Recorder, doimage, infrav1.ImageBuildingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Eventf(doimage, corev1.EventTypeNormal, infrav1.ImageBuildStartedReason, "Started Job %s building snapshot %s", job.Name, snapshotName)
+	}
+	doimage.Status.BuildJobName = job.Name
+
+	// Updates of the Job trigger a new reconcile through its owner reference.
+	switch {
+	case jobFinished(job, batchv1.JobFailed):
+		err := errors.Errorf("Job %s building snapshot %s failed", job.Name, snapshotName)
+		conditions.MarkFalse(doimage, infrav1.ImageReadyCondition, infrav1.ImageBuildFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		r.Recorder.Event(doimage, corev1.EventTypeWarning, infrav1.ImageBuildFailedReason, err.Error())
+		return reconcile.Result{}, nil
+	case jobFinished(job, batchv1.JobComplete):
+		// The snapshot was not found above, it may not be listed yet.
+		err := errors.Errorf("Job %s completed without creating snapshot %s", job.Name, snapshotName)
+		conditions.MarkFalse(doimage, infrav1.ImageReadyCondition, infrav1.ImageBuildFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		r.Recorder.Event(doimage, corev1.EventTypeWarning, infrav1.ImageBuildFailedReason, err.Error())
+		return reconcile.Result{RequeueAfter: orDefault(r.ResyncPeriod, DefaultImageResyncPeriod)}, nil
+	default:
+		conditions.MarkFalse(doimage, infrav1.ImageReadyCondition, infrav1.ImageBuildingReason, clusterv1.ConditionSeverityInfo,
+			"Job %s is building snapshot %s", job.Name, snapshotName)
+		return reconcile.Result{}, nil
+	}
+}
+
+func (r *DOImageReconciler) setImage(imageScope *scope.ImageScope, image *godo.Image) {
+	doimage := imageScope.DOImage
+	if imageScope.ImageID() != image.ID {
+		r.Recorder.Eventf(doimage, corev1.EventTypeNormal, infrav1.ImageResolvedReason, "Using image %s (%d)", image.Name, image.ID)
+	}
+	imageScope.SetImage(image)
+	imageScope.SetReady(true)
+	doimage.Status.ObservedGeneration = doimage.Generation
+	conditions.MarkTrue(doimage, infrav1.ImageReadyCondition)
+}
+
+// buildJob returns the Job running build to create the snapshot snapshotName.
+func buildJob(key client.ObjectKey, build *infrav1.DOImageBuild, snapshotName string) *batchv1.Job {
+	env := append([]corev1.EnvVar{{Name: infrav1.SnapshotNameEnvVar, Value: snapshotName}}, build.Env...)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "build",
+						Image:   build.Image,
+						Command: build.Command,
+						Args:    build.Args,
+						Env:     env,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// jobFinished returns whether the Job has the finished condition t.
+func jobFinished(job *batchv1.Job, t batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == t && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
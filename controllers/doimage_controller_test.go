@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDOImageReconcileSnapshotTag(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	s.AddImage(godo.Image{Name: "golden-1", Type: "snapshot", Tags: []string{"golden"}, Created: "2021-07-01T00:00:00Z", Regions: []string{"nyc1"}})
+	s.AddImage(godo.Image{Name: "other", Type: "snapshot", Tags: []string{"other"}, Created: "2021-07-03T00:00:00Z"})
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	doimage := &infrav1.DOImage{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "golden"},
+		Spec:       infrav1.DOImageSpec{SnapshotTag: "golden"},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(doimage).Build()
+	newScope := func() *scope.ImageScope {
+		imageScope, err := scope.NewImageScope(scope.ImageScopeParams{
+			DOClients: scope.DOClients{Images: c.Images},
+			Client:    client,
+			DOImage:   doimage,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return imageScope
+	}
+
+	r := &DOImageReconciler{Client: client, Recorder: record.NewFakeRecorder(10)}
+	result, err := r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(DefaultImageResyncPeriod))
+	g.Expect(doimage.Status.Ready).To(BeTrue())
+	g.Expect(doimage.Status.ImageName).To(Equal("golden-1"))
+	g.Expect(doimage.Status.Regions).To(ConsistOf("nyc1"))
+
+	// A newer snapshot carrying the tag is picked up on the next resync.
+	newer := s.AddImage(godo.Image{Name: "golden-2", Type: "snapshot", Tags: []string{"golden"}, Created: "2021-07-02T00:00:00Z"})
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(doimage.Status.ImageID).To(Equal(newer.ID))
+}
+
+func TestDOImageReconcileBuild(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+	doimage := &infrav1.DOImage{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "golden", Generation: 1},
+		Spec:       infrav1.DOImageSpec{Build: &infrav1.DOImageBuild{Image: "image-builder"}},
+	}
+	kc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(doimage).Build()
+	newScope := func() *scope.ImageScope {
+		imageScope, err := scope.NewImageScope(scope.ImageScopeParams{
+			DOClients: scope.DOClients{Images: c.Images},
+			Client:    kc,
+			DOImage:   doimage,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return imageScope
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &DOImageReconciler{Client: kc, Recorder: recorder}
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.ImageBuildStartedReason)))
+	g.Expect(doimage.Status.Ready).To(BeFalse())
+	g.Expect(conditions.GetReason(doimage, infrav1.ImageReadyCondition)).To(Equal(infrav1.ImageBuildingReason))
+
+	job := &batchv1.Job{}
+	g.Expect(kc.Get(ctx, client.ObjectKey{Namespace: namespace, Name: doimage.Status.BuildJobName}, job)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(job, doimage)).To(BeTrue())
+	env := job.Spec.Template.Spec.Containers[0].Env
+	g.Expect(env).To(ContainElement(corev1.EnvVar{Name: infrav1.SnapshotNameEnvVar, Value: namespace + "-golden-1"}))
+
+	// The Job creates the snapshot and succeeds.
+	snapshot := s.AddImage(godo.Image{Name: namespace + "-golden-1", Type: "snapshot"})
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	g.Expect(kc.Status().Update(ctx, job)).To(Succeed())
+
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(doimage.Status.Ready).To(BeTrue())
+	g.Expect(doimage.Status.ImageID).To(Equal(snapshot.ID))
+	g.Expect(conditions.IsTrue(doimage, infrav1.ImageReadyCondition)).To(BeTrue())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.ImageResolvedReason)))
+}
@@ -24,22 +24,29 @@ import (
 
 	"github.com/pkg/errors"
 
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/computes"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -49,12 +56,56 @@ import (
 type DOMachineReconciler struct {
 	client.Client
 	Recorder record.EventRecorder
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// ShutdownGracePeriod is how long the DigitalOcean API calls in flight may
+	// complete once the manager is stopped. Defaults to DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+	// DropletRequeueAfter is the delay between two checks of a new droplet.
+	// Defaults to DefaultDropletRequeueAfter.
+	DropletRequeueAfter time.Duration
+	// TimeoutRequeueAfter is the delay before retrying a reconcile that ran out
+	// of time. Defaults to DefaultTimeoutRequeueAfter.
+	TimeoutRequeueAfter time.Duration
+	// BlockedRequeueAfter is the delay before retrying a reconcile blocked by
+	// a DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota. Defaults to DefaultBlockedRequeueAfter.
+	BlockedRequeueAfter time.Duration
+	// DriftCheckInterval is the delay between two comparisons of the
+	// DigitalOcean resources with their spec. Defaults to DefaultDriftCheckInterval.
+	DriftCheckInterval time.Duration
+	// OrphanDropletPolicy is what to do with droplets tagged for the cluster
+	// that are not referenced by a DOMachine. Defaults to OrphanDropletPolicyAdopt.
+	OrphanDropletPolicy OrphanDropletPolicy
+	// ResyncEvents, when set, receives the DOMachines whose DigitalOcean
+	// resources changed, see StatusResyncer.
+	ResyncEvents <-chan event.GenericEvent
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+	// DeletionOptions, when MaxConcurrentReconciles is set, configure a
+	// controller of its own reconciling the DOMachines being deleted.
+	DeletionOptions controller.Options
+
+	deletionEvents chan<- event.GenericEvent
+	// reconciling locks the objects being reconciled by either controller.
+	reconciling keyLocks
 }
 
 func (r *DOMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
-	c, err := ctrl.NewControllerManagedBy(mgr).
+	if r.DeletionOptions.MaxConcurrentReconciles > 0 {
+		events, err := setupDeletionController(ctx, mgr, DOMachineControllerName+"-deletion", &infrav1.DOMachine{}, r, r.DeletionOptions, r.WatchFilterValue)
+		if err != nil {
+			return err
+		}
+		r.deletionEvents = events
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.DOMachine{}).
-		WithEventFilter(predicates.ResourceNotPaused(ctrl.LoggerFrom(ctx))). // don't queue reconcile if resource is paused
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
 		Watches(
 			&source.Kind{Type: &clusterv1.Machine{}},
 			handler.EnqueueRequestsFromMapFunc(util.MachineToInfrastructureMapFunc(infrav1.GroupVersion.WithKind("DOMachine"))),
@@ -62,8 +113,24 @@ func (r *DOMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 		Watches(
 			&source.Kind{Type: &infrav1.DOCluster{}},
 			handler.EnqueueRequestsFromMapFunc(r.DOClusterToDOMachines(ctx)),
-		).
-		Build(r)
+		)
+	// The CRDs of the disabled features may not be installed.
+	if feature.Gates.Enabled(feature.Volume) {
+		b = b.Watches(
+			&source.Kind{Type: &infrav1.DOVolume{}},
+			handler.EnqueueRequestsFromMapFunc(r.DOVolumeToDOMachines(ctx)),
+		)
+	}
+	if feature.Gates.Enabled(feature.GoldenImage) {
+		b = b.Watches(
+			&source.Kind{Type: &infrav1.DOImage{}},
+			handler.EnqueueRequestsFromMapFunc(r.DOImageToDOMachines(ctx)),
+		)
+	}
+	if r.ResyncEvents != nil {
+		b = b.Watches(&source.Channel{Source: r.ResyncEvents}, &handler.EnqueueRequestForObject{})
+	}
+	c, err := b.Build(r)
 	if err != nil {
 		return errors.Wrapf(err, "error creating controller")
 	}
@@ -77,7 +144,10 @@ func (r *DOMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 	if err := c.Watch(
 		&source.Kind{Type: &clusterv1.Cluster{}},
 		handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
-		predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx)),
+		predicates.All(ctrl.LoggerFrom(ctx),
+			predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx)),
+			predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
+		),
 	); err != nil {
 		return errors.Wrapf(err, "failed adding a watch for ready clusters")
 	}
@@ -123,15 +193,87 @@ func (r *DOMachineReconciler) DOClusterToDOMachines(ctx context.Context) handler
 	}
 }
 
+// DOVolumeToDOMachines is a handler.ToRequestsFunc to be used to enqueue
+// the DOMachines referencing a DOVolume, e.g. once it is ready.
+func (r *DOMachineReconciler) DOVolumeToDOMachines(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(o client.Object) []ctrl.Request {
+		v, ok := o.(*infrav1.DOVolume)
+		if !ok {
+			log.Error(errors.Errorf("expected a DOVolume but got a %T", o), "failed to get DOMachine for DOVolume")
+			return nil
+		}
+
+		list := &infrav1.DOMachineList{}
+		if err := r.List(ctx, list, client.InNamespace(v.Namespace)); err != nil {
+			log.Error(err, "failed to list DOMachines")
+			return nil
+		}
+		result := []ctrl.Request{}
+		for _, m := range list.Items {
+			for _, ref := range m.Spec.VolumeRefs {
+				if ref.Name == v.Name {
+					result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&m)})
+					break
+				}
+			}
+		}
+		return result
+	}
+}
+
+// DOImageToDOMachines is a handler.ToRequestsFunc to be used to enqueue
+// the DOMachines referencing a DOImage, e.g. once it is ready.
+func (r *DOMachineReconciler) DOImageToDOMachines(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(o client.Object) []ctrl.Request {
+		i, ok := o.(*infrav1.DOImage)
+		if !ok {
+			log.Error(errors.Errorf("expected a DOImage but got a %T", o), "failed to get DOMachine for DOImage")
+			return nil
+		}
+
+		list := &infrav1.DOMachineList{}
+		if err := r.List(ctx, list, client.InNamespace(i.Namespace)); err != nil {
+			log.Error(err, "failed to list DOMachines")
+			return nil
+		}
+		result := []ctrl.Request{}
+		for _, m := range list.Items {
+			if m.Spec.ImageRef != nil && m.Spec.ImageRef.Name == i.Name {
+				result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&m)})
+			}
+		}
+		return result
+	}
+}
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=domachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=domachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dovolumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=doimages,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 
 func (r *DOMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	// Do not start new work once the manager is stopping, the reconciles in
+	// flight are still given ShutdownGracePeriod to complete.
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOMachine", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	// Held until the DOMachine is patched.
+	unlock, ok := r.reconciling.tryLock(req.NamespacedName)
+	if !ok {
+		return reconcile.Result{RequeueAfter: keyLockedRequeueAfter}, nil
+	}
+	defer unlock()
+
 	domachine := &infrav1.DOMachine{}
 	if err := r.Get(ctx, req.NamespacedName, domachine); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -139,6 +281,9 @@ func (r *DOMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 		return reconcile.Result{}, err
 	}
+	if forwardDeletion(ctx, r.deletionEvents, domachine) {
+		return reconcile.Result{}, nil
+	}
 
 	// Fetch the Machine.
 	machine, err := util.GetOwnerMachine(ctx, r.Client, domachine.ObjectMeta)
@@ -149,6 +294,7 @@ func (r *DOMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Info("Machine Controller has not yet set OwnerRef")
 		return reconcile.Result{}, nil
 	}
+	log = log.WithValues("machine", machine.Name)
 
 	// Fetch the Cluster.
 	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machine.ObjectMeta)
@@ -156,6 +302,13 @@ func (r *DOMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Info("Machine is missing cluster label or cluster does not exist")
 		return reconcile.Result{}, nil
 	}
+	log = log.WithValues("cluster", cluster.Name)
+	ctx = withClusterAccounting(ctx, cluster)
+
+	if annotations.IsPaused(cluster, domachine) {
+		log.Info("DOMachine or linked Cluster is marked as paused. Won't reconcile")
+		return reconcile.Result{}, nil
+	}
 
 	docluster := &infrav1.DOCluster{}
 	doclusterNamespacedName := client.ObjectKey{
@@ -198,12 +351,24 @@ func (r *DOMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}()
 
-	// Handle deleted machines
+	handleReconcileAnnotation(r.Recorder, domachine, clusterScope.Catalog)
+
+	// Only the reconcile itself is bounded, the scope is still closed once it
+	// ran out of time so that its progress is persisted.
+	reconcileCtx, cancel := withReconcileTimeout(ctx, r.ReconcileTimeout, r.ShutdownGracePeriod)
+	defer cancel()
+
+	var result reconcile.Result
 	if !domachine.ObjectMeta.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, machineScope, clusterScope)
+		// Handle deleted machines
+		result, err = r.reconcileDelete(reconcileCtx, machineScope, clusterScope)
+	} else {
+		result, err = r.reconcile(reconcileCtx, machineScope, clusterScope)
 	}
-
-	return r.reconcile(ctx, machineScope, clusterScope)
+	recordThrottling(r.Recorder, domachine, err)
+	observeReconcile(DOMachineControllerName, result, err)
+	setErrorCondition(domachine, infrav1.InstanceReadyCondition, err)
+	return requeueOnTimeout(r.TimeoutRequeueAfter)(requeueOnRateLimit(requeueOnErrorClass(domachine, r.BlockedRequeueAfter)(result, err)))
 }
 
 func (r *DOMachineReconciler) reconcileVolumes(ctx context.Context, mscope *scope.MachineScope, cscope *scope.ClusterScope) (reconcile.Result, error) {
@@ -217,16 +382,70 @@ func (r *DOMachineReconciler) reconcileVolumes(ctx context.Context, mscope *scop
 			return reconcile.Result{}, err
 		}
 		if vol == nil {
-			_, err = computesvc.CreateVolume(disk, volName)
+			vol, err = computesvc.CreateVolume(disk, volName)
 			if err != nil {
+				recordFailure(r.Recorder, domachine, infrav1.VolumeCreatingErrorReason, errors.Wrapf(err, "failed to create storage volume %s", volName))
 				return reconcile.Result{}, err
 			}
+			r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.VolumeCreatedReason, "Created new storage volume - %s", vol.Name)
 		}
 		// TODO(gottwald): reconcile disk resizes here (at least grow)
 	}
 	return reconcile.Result{}, nil
 }
 
+// volumeRefIDs returns the IDs of the volumes of the DOVolumes referenced by
+// the DOMachine, or the name of the first one that is not ready yet.
+func (r *DOMachineReconciler) volumeRefIDs(ctx context.Context, mscope *scope.MachineScope) ([]string, string, error) {
+	domachine := mscope.DOMachine
+	var ids []string
+	for _, ref := range domachine.Spec.VolumeRefs {
+		dovolume := &infrav1.DOVolume{}
+		key := client.ObjectKey{Namespace: domachine.Namespace, Name: ref.Name}
+		if err := r.Get(ctx, key, dovolume); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, ref.Name, nil
+			}
+			return nil, "", errors.Wrapf(err, "failed to get DOVolume %s", key)
+		}
+		if !dovolume.Status.Ready || !dovolume.DeletionTimestamp.IsZero() {
+			return nil, ref.Name, nil
+		}
+		if dovolume.Spec.Region != mscope.DOCluster.Spec.Region {
+			return nil, "", errors.Errorf("DOVolume %s is in region %s, not in the region %s of the cluster", ref.Name, dovolume.Spec.Region, mscope.DOCluster.Spec.Region)
+		}
+		ids = append(ids, dovolume.Status.VolumeID)
+	}
+	return ids, "", nil
+}
+
+// imageRefID returns the ID of the image of the DOImage referenced by the
+// DOMachine, or its name if it is not ready yet. The ID is 0 when the
+// DOMachine does not reference a DOImage.
+func (r *DOMachineReconciler) imageRefID(ctx context.Context, mscope *scope.MachineScope) (int, string, error) {
+	domachine := mscope.DOMachine
+	ref := domachine.Spec.ImageRef
+	if ref == nil {
+		return 0, "", nil
+	}
+	doimage := &infrav1.DOImage{}
+	key := client.ObjectKey{Namespace: domachine.Namespace, Name: ref.Name}
+	if err := r.Get(ctx, key, doimage); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, ref.Name, nil
+		}
+		return 0, "", errors.Wrapf(err, "failed to get DOImage %s", key)
+	}
+	if !doimage.Status.Ready || doimage.Status.ImageID == 0 {
+		return 0, ref.Name, nil
+	}
+	region := mscope.DOCluster.Spec.Region
+	if len(doimage.Status.Regions) > 0 && !sets.NewString(doimage.Status.Regions...).Has(region) {
+		return 0, "", errors.Errorf("image of DOImage %s is not available in the region %s of the cluster", ref.Name, region)
+	}
+	return doimage.Status.ImageID, "", nil
+}
+
 func (r *DOMachineReconciler) reconcile(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	machineScope.Info("Reconciling DOMachine")
 	domachine := machineScope.DOMachine
@@ -260,15 +479,71 @@ func (r *DOMachineReconciler) reconcile(ctx context.Context, machineScope *scope
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if droplet == nil && machineScope.GetInstanceID() != "" {
+		// The droplet was deleted behind our back. Machines are immutable, so
+		// report the failure for a MachineHealthCheck to replace the Machine
+		// rather than creating a new droplet.
+		err := errors.Errorf("droplet %s was deleted outside of the provider", machineScope.GetInstanceID())
+		machineScope.SetNotReady()
+		conditions.MarkFalse(domachine, infrav1.InstanceReadyCondition, infrav1.InstanceDeletedExternallyReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		machineScope.SetFailureReason(capierrors.UpdateMachineError)
+		machineScope.SetFailureMessage(err)
+		r.Recorder.Event(domachine, corev1.EventTypeWarning, infrav1.InstanceDeletedExternallyReason, err.Error())
+		return reconcile.Result{}, nil
+	}
+	if droplet == nil && machineScope.GetInstanceID() == "" {
+		// A previous reconcile may have created the droplet without recording it.
+		droplet, err = r.adoptDroplet(computesvc, machineScope)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 	if droplet == nil {
-		droplet, err = computesvc.CreateDroplet(machineScope)
+		volumeIDs, waitingFor, err := r.volumeRefIDs(ctx, machineScope)
 		if err != nil {
-			err = errors.Errorf("Failed to create droplet instance for DOMachine %s/%s: %v", domachine.Namespace, domachine.Name, err)
-			r.Recorder.Event(domachine, corev1.EventTypeWarning, "InstanceCreatingError", err.Error())
+			return reconcile.Result{}, err
+		}
+		if waitingFor != "" {
+			// The DOVolume watch triggers a new reconcile once it is ready.
+			machineScope.Info("Waiting for DOVolume to be ready", "volume", waitingFor)
+			conditions.MarkFalse(domachine, infrav1.InstanceReadyCondition, infrav1.WaitingForVolumeReason, clusterv1.ConditionSeverityInfo,
+				"waiting for DOVolume %s to be ready", waitingFor)
+			return reconcile.Result{}, nil
+		}
+		imageID, waitingFor, err := r.imageRefID(ctx, machineScope)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if waitingFor != "" {
+			// The DOImage watch triggers a new reconcile once it is ready.
+			machineScope.Info("Waiting for DOImage to be ready", "image", waitingFor)
+			conditions.MarkFalse(domachine, infrav1.InstanceReadyCondition, infrav1.WaitingForImageReason, clusterv1.ConditionSeverityInfo,
+				"waiting for DOImage %s to be ready", waitingFor)
+			return reconcile.Result{}, nil
+		}
+		// A token known to lack a scope would only get a 403 Forbidden.
+		if err := scope.CheckScopes(doclient.ScopeDropletCreate, doclient.ScopeTagCreate); err != nil {
+			recordFailure(r.Recorder, domachine, infrav1.InstanceCreatingErrorReason, err)
+			setErrorCondition(domachine, infrav1.InstanceReadyCondition, err)
+			return reconcile.Result{}, err
+		}
+		droplet, err = computesvc.CreateDroplet(machineScope, computes.CreateDropletOptions{ImageID: imageID, VolumeIDs: volumeIDs})
+		if err != nil {
+			err = errors.Wrapf(err, "Failed to create droplet instance for DOMachine %s/%s", domachine.Namespace, domachine.Name)
+			recordFailure(r.Recorder, domachine, infrav1.InstanceCreatingErrorReason, err)
 			machineScope.SetInstanceStatus(infrav1.DOResourceStatusErrored)
+			// The API rejected the droplet spec itself, retrying will not help.
+			if class := doclient.Classify(err); class.Terminal() {
+				// The spec may reference a catalog item that changed, e.g. a deleted image.
+				clusterScope.Catalog.Invalidate()
+				setErrorCondition(domachine, infrav1.InstanceReadyCondition, err)
+				machineScope.SetFailureReason(machineStatusError(class))
+				machineScope.SetFailureMessage(errors.New(doclient.ErrorMessage(err)))
+				return reconcile.Result{}, nil
+			}
 			return reconcile.Result{}, err
 		}
-		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, "InstanceCreated", "Created new droplet instance - %s", droplet.Name)
+		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.InstanceCreatedReason, "Created new droplet instance - %s", droplet.Name)
 	}
 
 	machineScope.SetProviderID(strconv.Itoa(droplet.ID))
@@ -285,15 +560,30 @@ func (r *DOMachineReconciler) reconcile(ctx context.Context, machineScope *scope
 	switch infrav1.DOResourceStatus(droplet.Status) {
 	case infrav1.DOResourceStatusNew:
 		machineScope.Info("Machine instance is pending", "instance-id", machineScope.GetInstanceID())
-		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		conditions.MarkFalse(domachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisioningReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{RequeueAfter: orDefault(r.DropletRequeueAfter, DefaultDropletRequeueAfter)}, nil
 	case infrav1.DOResourceStatusRunning:
 		machineScope.Info("Machine instance is active", "instance-id", machineScope.GetInstanceID())
+		corrected, uncorrected, err := computesvc.ReconcileDropletDrift(machineScope, droplet)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to reconcile droplet drift")
+		}
+		setDriftCondition(r.Recorder, domachine, corrected, uncorrected)
+		conditions.MarkTrue(domachine, infrav1.InstanceReadyCondition)
+		if !domachine.Status.Ready {
+			observeMachineActive(clusterScope.Region(), domachine)
+		}
+		observeMachineNodeReady(clusterScope.Region(), domachine, machineScope.Machine)
 		machineScope.SetReady()
-		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, "DOMachineReady", "DOMachine %s - has ready status", droplet.Name)
-		return reconcile.Result{}, nil
+		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.DOMachineReadyReason, "DOMachine %s - has ready status", droplet.Name)
+		return reconcile.Result{RequeueAfter: orDefault(r.DriftCheckInterval, DefaultDriftCheckInterval)}, nil
 	default:
+		err := errors.Errorf("Instance status %q is unexpected", droplet.Status)
+		machineScope.SetNotReady()
+		conditions.MarkFalse(domachine, infrav1.InstanceReadyCondition, infrav1.InstanceStateUnexpectedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
-		machineScope.SetFailureMessage(errors.Errorf("Instance status %q is unexpected", droplet.Status))
+		machineScope.SetFailureMessage(err)
+		r.Recorder.Event(domachine, corev1.EventTypeWarning, infrav1.InstanceStateUnexpectedReason, err.Error())
 		return reconcile.Result{}, nil
 	}
 }
@@ -311,9 +601,10 @@ func (r *DOMachineReconciler) reconcileDeleteVolumes(ctx context.Context, mscope
 			continue
 		}
 		if err = computesvc.DeleteVolume(vol.ID); err != nil {
+			recordFailure(r.Recorder, domachine, infrav1.VolumeDeletingErrorReason, errors.Wrapf(err, "failed to delete storage volume %s", vol.Name))
 			return reconcile.Result{}, err
 		}
-		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, "VolumeDeleted", "Deleted the storage volume - %s", vol.Name)
+		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.VolumeDeletedReason, "Deleted the storage volume - %s", vol.Name)
 	}
 	return reconcile.Result{}, nil
 }
@@ -330,16 +621,18 @@ func (r *DOMachineReconciler) reconcileDelete(ctx context.Context, machineScope
 
 	if droplet != nil {
 		if err := computesvc.DeleteDroplet(machineScope.GetInstanceID()); err != nil {
+			recordFailure(r.Recorder, domachine, infrav1.InstanceDeletingErrorReason, errors.Wrapf(err, "failed to delete droplet instance %s", droplet.Name))
 			return reconcile.Result{}, err
 		}
+		computesvc.ForgetDropletTags(droplet.Name)
 	} else {
 		clusterScope.V(2).Info("Unable to locate droplet instance")
-		r.Recorder.Eventf(domachine, corev1.EventTypeWarning, "NoInstanceFound", "Skip deleting")
+		r.Recorder.Eventf(domachine, corev1.EventTypeWarning, infrav1.NoInstanceFoundReason, "Skip deleting")
 	}
 	if result, err := r.reconcileDeleteVolumes(ctx, machineScope, clusterScope); err != nil {
 		return result, fmt.Errorf("failed to reconcile delete volumes: %w", err)
 	}
-	r.Recorder.Eventf(domachine, corev1.EventTypeNormal, "InstanceDeleted", "Deleted a instance - %s", machineScope.Name())
+	r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.InstanceDeletedReason, "Deleted a instance - %s", machineScope.Name())
 	controllerutil.RemoveFinalizer(domachine, infrav1.MachineFinalizer)
 	return reconcile.Result{}, nil
 }
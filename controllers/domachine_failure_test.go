@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDOMachineReconcileFailures(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func(s *fakedo.Server, id int)
+		wantReason string
+	}{
+		{
+			name:       "droplet deleted outside of the provider",
+			setup:      func(s *fakedo.Server, id int) { s.RemoveDroplet(id) },
+			wantReason: infrav1.InstanceDeletedExternallyReason,
+		},
+		{
+			name:       "droplet powered off",
+			setup:      func(s *fakedo.Server, id int) { s.SetDropletStatus(id, "off") },
+			wantReason: infrav1.InstanceStateUnexpectedReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			defer func() {
+				scope.SetAccessToken("")
+				_ = scope.InitSessions(scope.SessionOptions{})
+			}()
+			g.Expect(scope.InitSessions(scope.SessionOptions{APIURL: s.URL})).To(Succeed())
+			scope.SetAccessToken("token")
+
+			c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+			g.Expect(err).NotTo(HaveOccurred())
+			droplet, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+				Name: "foo-md-0", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.setup(s, droplet.ID)
+			droplets := len(s.Droplets())
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cluster := newCluster("foo")
+			cluster.Status.InfrastructureReady = true
+			machine := newMachine("foo", "foo-md-0")
+			machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr("bootstrap")
+			docluster := &infrav1.DOCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"}}
+			domachine := &infrav1.DOMachine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-md-0"},
+				Spec:       infrav1.DOMachineSpec{ProviderID: pointer.StringPtr(fmt.Sprintf("digitalocean://%d", droplet.ID))},
+				Status:     infrav1.DOMachineStatus{Ready: true},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster, domachine).Build()
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{Client: client, Cluster: cluster, DOCluster: docluster})
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client: client, Cluster: cluster, Machine: machine, DOCluster: docluster, DOMachine: domachine,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			r := &DOMachineReconciler{Client: client, Recorder: record.NewFakeRecorder(10)}
+			result, err := r.reconcile(ctx, machineScope, clusterScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result.Requeue || result.RequeueAfter > 0).To(BeFalse())
+
+			g.Expect(domachine.Status.Ready).To(BeFalse())
+			g.Expect(domachine.Status.FailureReason).To(Equal(capierrors.MachineStatusErrorPtr(capierrors.UpdateMachineError)))
+			g.Expect(domachine.Status.FailureMessage).NotTo(BeNil())
+			g.Expect(conditions.GetReason(domachine, infrav1.InstanceReadyCondition)).To(Equal(tc.wantReason))
+			// No replacement droplet is created, the Machine is remediated instead.
+			g.Expect(s.Droplets()).To(HaveLen(droplets))
+		})
+	}
+}
+
+func TestDOMachineCreateErrors(t *testing.T) {
+	testCases := []struct {
+		name              string
+		setup             func(s *fakedo.Server)
+		bootstrapData     []byte
+		wantReason        string
+		wantFailureReason *capierrors.MachineStatusError
+		wantRequeueAfter  time.Duration
+	}{
+		{
+			name: "droplet limit reached",
+			setup: func(s *fakedo.Server) {
+				c, _ := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+				_, _, _ = c.Droplets.Create(context.Background(), &godo.DropletCreateRequest{
+					Name: "other", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+				})
+			},
+			wantReason:       infrav1.QuotaExceededReason,
+			wantRequeueAfter: DefaultBlockedRequeueAfter,
+		},
+		{
+			name:              "droplet spec rejected",
+			setup:             func(s *fakedo.Server) { s.FailNext(http.MethodPost, "/v2/droplets", http.StatusUnprocessableEntity, 1) },
+			wantReason:        infrav1.InvalidRequestReason,
+			wantFailureReason: capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError),
+		},
+		{
+			name:              "bootstrap data too large",
+			setup:             func(s *fakedo.Server) {},
+			bootstrapData:     make([]byte, 64*1024+1),
+			wantReason:        infrav1.InvalidRequestReason,
+			wantFailureReason: capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			s := fakedo.NewServer(fakedo.Options{DropletLimit: 1})
+			defer s.Close()
+			defer func() {
+				scope.SetAccessToken("")
+				_ = scope.InitSessions(scope.SessionOptions{})
+			}()
+			g.Expect(scope.InitSessions(scope.SessionOptions{APIURL: s.URL})).To(Succeed())
+			scope.SetAccessToken("token")
+
+			tc.setup(s)
+			bootstrapData := tc.bootstrapData
+			if bootstrapData == nil {
+				bootstrapData = []byte("#cloud-config")
+			}
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			cluster := newCluster("foo")
+			cluster.Status.InfrastructureReady = true
+			machine := newMachine("foo", "foo-md-0")
+			machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr("bootstrap")
+			bootstrap := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "bootstrap"},
+				Data:       map[string][]byte{"value": bootstrapData},
+			}
+			docluster := &infrav1.DOCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"},
+				Spec:       infrav1.DOClusterSpec{Region: "nyc1"},
+			}
+			domachine := &infrav1.DOMachine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-md-0"},
+				Spec:       infrav1.DOMachineSpec{Size: "s-1vcpu-1gb", Image: intstr.FromInt(42)},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster, domachine, bootstrap).Build()
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{Client: client, Cluster: cluster, DOCluster: docluster})
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client: client, Cluster: cluster, Machine: machine, DOCluster: docluster, DOMachine: domachine,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			r := &DOMachineReconciler{Client: client, Recorder: record.NewFakeRecorder(10)}
+			result, err := r.reconcile(ctx, machineScope, clusterScope)
+			setErrorCondition(domachine, infrav1.InstanceReadyCondition, err)
+			result, err = requeueOnErrorClass(domachine, 0)(result, err)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(tc.wantRequeueAfter))
+
+			g.Expect(domachine.Status.FailureReason).To(Equal(tc.wantFailureReason))
+			g.Expect(conditions.GetReason(domachine, infrav1.InstanceReadyCondition)).To(Equal(tc.wantReason))
+			g.Expect(conditions.Get(domachine, infrav1.InstanceReadyCondition).Severity).To(Equal(clusterv1.ConditionSeverityError))
+		})
+	}
+}
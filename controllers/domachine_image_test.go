@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDOMachineImageLookup(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer func() {
+		scope.SetAccessToken("")
+		_ = scope.InitSessions(scope.SessionOptions{})
+	}()
+	g.Expect(scope.InitSessions(scope.SessionOptions{APIURL: s.URL})).To(Succeed())
+	scope.SetAccessToken("token")
+
+	s.AddImage(godo.Image{Name: "ubuntu-2004-kube-v1.21.2", Regions: []string{"nyc1"}, Created: "2021-06-01T00:00:00Z"})
+	latest := s.AddImage(godo.Image{Name: "ubuntu-2004-kube-v1.21.2", Regions: []string{"nyc1"}, Created: "2021-07-01T00:00:00Z"})
+	s.AddImage(godo.Image{Name: "ubuntu-2004-kube-v1.21.2", Regions: []string{"ams3"}, Created: "2021-08-01T00:00:00Z"})
+	s.AddImage(godo.Image{Name: "ubuntu-2004-kube-v1.20.8", Regions: []string{"nyc1"}, Created: "2021-08-01T00:00:00Z"})
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	cluster := newCluster("foo")
+	cluster.Status.InfrastructureReady = true
+	machine := newMachine("foo", "foo-md-0")
+	machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr("bootstrap")
+	machine.Spec.Version = pointer.StringPtr("v1.21.2")
+	bootstrap := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "bootstrap"},
+		Data:       map[string][]byte{"value": []byte("#cloud-config")},
+	}
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"},
+		Spec:       infrav1.DOClusterSpec{Region: "nyc1"},
+	}
+	domachine := &infrav1.DOMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-md-0"},
+		Spec:       infrav1.DOMachineSpec{Size: "s-1vcpu-1gb"},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster, domachine, bootstrap).Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{Client: client, Cluster: cluster, DOCluster: docluster})
+	g.Expect(err).NotTo(HaveOccurred())
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client: client, Cluster: cluster, Machine: machine, DOCluster: docluster, DOMachine: domachine,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &DOMachineReconciler{Client: client, Recorder: record.NewFakeRecorder(10)}
+	_, err = r.reconcile(ctx, machineScope, clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	droplets := s.Droplets()
+	g.Expect(droplets).To(HaveLen(1))
+	g.Expect(droplets[0].Image.ID).To(Equal(latest.ID))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DOMachineTemplateReconciler publishes the capacity of the droplet size of
+// the DOMachineTemplates, for the cluster-autoscaler to scale their
+// MachineDeployments from zero.
+type DOMachineTemplateReconciler struct {
+	client.Client
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+
+	// session returns the DigitalOcean client and the catalogs of its account.
+	// Defaults to the session of the manager token.
+	session func() (*godo.Client, *doclient.Catalog, error)
+}
+
+func (r *DOMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.DOMachineTemplate{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
+		WithOptions(options).
+		Build(r)
+	if err != nil {
+		return errors.Wrapf(err, "error creating controller")
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=domachinetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=domachinetemplates/status,verbs=get;update;patch
+
+func (r *DOMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOMachineTemplate", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	template := &infrav1.DOMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if !template.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, orDefault(r.ReconcileTimeout, DefaultReconcileTimeout))
+	defer cancel()
+	capacity, err := r.sizeCapacity(ctx, template.Spec.Template.Spec.Size)
+	observeReconcile(DOMachineTemplateControllerName, reconcile.Result{}, err)
+	if err != nil {
+		return requeueOnRateLimit(reconcile.Result{}, err)
+	}
+	if capacity == nil || equalResourceLists(capacity, template.Status.Capacity) {
+		return reconcile.Result{}, nil
+	}
+
+	helper, err := patch.NewHelper(template, r.Client)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+	template.Status.Capacity = capacity
+	return reconcile.Result{}, helper.Patch(ctx, template)
+}
+
+// sizeCapacity returns the capacity of the droplet size slug, or nil when the
+// size is not offered, which the validation webhooks report.
+func (r *DOMachineTemplateReconciler) sizeCapacity(ctx context.Context, slug string) (corev1.ResourceList, error) {
+	session := r.session
+	if session == nil {
+		session = func() (*godo.Client, *doclient.Catalog, error) {
+			clients := &scope.DOClients{}
+			c, err := clients.Session()
+			if err != nil {
+				return nil, nil, err
+			}
+			catalog, err := clients.SessionCatalog()
+			return c, catalog, err
+		}
+	}
+	c, catalog, err := session()
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := catalog.Sizes(ctx, c.Sizes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list sizes")
+	}
+	for _, size := range sizes {
+		if size.Slug != slug {
+			continue
+		}
+		return corev1.ResourceList{
+			corev1.ResourceCPU:              *resource.NewQuantity(int64(size.Vcpus), resource.DecimalSI),
+			corev1.ResourceMemory:           *resource.NewQuantity(int64(size.Memory)*1024*1024, resource.BinarySI),
+			corev1.ResourceEphemeralStorage: *resource.NewQuantity(int64(size.Disk)*1024*1024*1024, resource.BinarySI),
+		}, nil
+	}
+	return nil, nil
+}
+
+func equalResourceLists(a, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, q := range a {
+		if other, ok := b[name]; !ok || q.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
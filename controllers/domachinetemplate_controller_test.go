@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDOMachineTemplateReconcileCapacity(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	s.AddSize(godo.Size{Slug: "s-2vcpu-4gb", Vcpus: 2, Memory: 4096, Disk: 80, Available: true, Regions: []string{"nyc1"}})
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	template := &infrav1.DOMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "workers"},
+		Spec: infrav1.DOMachineTemplateSpec{
+			Template: infrav1.DOMachineTemplateResource{Spec: infrav1.DOMachineSpec{Size: "s-2vcpu-4gb"}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build()
+	catalog := doclient.NewCatalog(time.Minute)
+	r := &DOMachineTemplateReconciler{
+		Client:  client,
+		session: func() (*godo.Client, *doclient.Catalog, error) { return c, catalog, nil },
+	}
+
+	key := types.NamespacedName{Namespace: namespace, Name: "workers"}
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := &infrav1.DOMachineTemplate{}
+	g.Expect(client.Get(ctx, key, got)).To(Succeed())
+	g.Expect(equalResourceLists(got.Status.Capacity, corev1.ResourceList{
+		corev1.ResourceCPU:              resource.MustParse("2"),
+		corev1.ResourceMemory:           resource.MustParse("4Gi"),
+		corev1.ResourceEphemeralStorage: resource.MustParse("80Gi"),
+	})).To(BeTrue(), "capacity %v", got.Status.Capacity)
+}
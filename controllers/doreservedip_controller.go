@@ -0,0 +1,361 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/computes"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/reservedips"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// DefaultReservedIPRequeueAfter is how long to wait before checking again
+// whether a reserved IP assignment completed.
+const DefaultReservedIPRequeueAfter = 10 * time.Second
+
+// DOReservedIPReconciler reconciles a DOReservedIP object.
+type DOReservedIPReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// ShutdownGracePeriod is how long the DigitalOcean API calls in flight may
+	// complete once the manager is stopped. Defaults to DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+	// TimeoutRequeueAfter is the delay before retrying a reconcile that ran out
+	// of time. Defaults to DefaultTimeoutRequeueAfter.
+	TimeoutRequeueAfter time.Duration
+	// BlockedRequeueAfter is the delay before retrying a reconcile blocked by
+	// a DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota. Defaults to DefaultBlockedRequeueAfter.
+	BlockedRequeueAfter time.Duration
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+}
+
+func (r *DOReservedIPReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.DOReservedIP{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
+		Watches(
+			&source.Kind{Type: &infrav1.DOMachine{}},
+			handler.EnqueueRequestsFromMapFunc(r.DOMachineToDOReservedIPs(ctx)),
+		).
+		WithOptions(options).
+		Build(r)
+	if err != nil {
+		return errors.Wrapf(err, "error creating controller")
+	}
+	return nil
+}
+
+// DOMachineToDOReservedIPs is a handler.ToRequestsFunc to be used to enqueue
+// the DOReservedIPs targeting a DOMachine, or the control plane it is part of.
+func (r *DOReservedIPReconciler) DOMachineToDOReservedIPs(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(o client.Object) []ctrl.Request {
+		m, ok := o.(*infrav1.DOMachine)
+		if !ok {
+			log.Error(errors.Errorf("expected a DOMachine but got a %T", o), "failed to get DOReservedIP for DOMachine")
+			return nil
+		}
+
+		list := &infrav1.DOReservedIPList{}
+		if err := r.List(ctx, list, client.InNamespace(m.Namespace)); err != nil {
+			log.Error(err, "failed to list DOReservedIPs")
+			return nil
+		}
+		_, controlPlane := m.Labels[clusterv1.MachineControlPlaneLabelName]
+		result := []ctrl.Request{}
+		for _, rip := range list.Items {
+			t := rip.Spec.Target
+			if t == nil {
+				continue
+			}
+			if (t.MachineRef != nil && t.MachineRef.Name == m.Name) ||
+				(t.ControlPlaneRef != nil && controlPlane && t.ControlPlaneRef.Name == m.Labels[clusterv1.ClusterLabelName]) {
+				result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&rip)})
+			}
+		}
+		return result
+	}
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=doreservedips,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=doreservedips/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=domachines,verbs=get;list;watch
+
+func (r *DOReservedIPReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Do not start new work once the manager is stopping, the reconciles in
+	// flight are still given ShutdownGracePeriod to complete.
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOReservedIP", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	doreservedip := &infrav1.DOReservedIP{}
+	if err := r.Get(ctx, req.NamespacedName, doreservedip); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	reservedIPScope, err := scope.NewReservedIPScope(scope.ReservedIPScopeParams{
+		Client:       r.Client,
+		Logger:       log,
+		DOReservedIP: doreservedip,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any changes.
+	defer func() {
+		if err := reservedIPScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	reconcileCtx, cancel := withReconcileTimeout(ctx, r.ReconcileTimeout, r.ShutdownGracePeriod)
+	defer cancel()
+
+	var result reconcile.Result
+	if !doreservedip.DeletionTimestamp.IsZero() {
+		result, err = r.reconcileDelete(reconcileCtx, reservedIPScope)
+	} else {
+		result, err = r.reconcile(reconcileCtx, reservedIPScope)
+	}
+	recordThrottling(r.Recorder, doreservedip, err)
+	observeReconcile(DOReservedIPControllerName, result, err)
+	setErrorCondition(doreservedip, infrav1.ReservedIPAssignedCondition, err)
+	return requeueOnTimeout(r.TimeoutRequeueAfter)(requeueOnRateLimit(requeueOnErrorClass(doreservedip, r.BlockedRequeueAfter)(result, err)))
+}
+
+func (r *DOReservedIPReconciler) reconcile(ctx context.Context, reservedIPScope *scope.ReservedIPScope) (reconcile.Result, error) {
+	reservedIPScope.Info("Reconciling DOReservedIP")
+	doreservedip := reservedIPScope.DOReservedIP
+
+	// If the DOReservedIP doesn't have our finalizer, add it.
+	controllerutil.AddFinalizer(doreservedip, infrav1.ReservedIPFinalizer)
+
+	svc := reservedips.NewService(ctx, reservedIPScope)
+
+	var fip *godo.FloatingIP
+	if ip := reservedIPScope.IP(); ip != "" {
+		var err error
+		fip, err = svc.GetReservedIP(ip)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if fip == nil {
+			conditions.MarkFalse(doreservedip, infrav1.ReservedIPAssignedCondition, infrav1.ReservedIPReleasedExternallyReason, clusterv1.ConditionSeverityWarning,
+				"reserved IP %s was released outside of the provider", ip)
+			r.Recorder.Eventf(doreservedip, corev1.EventTypeWarning, infrav1.ReservedIPReleasedExternallyReason, "Reserved IP %s was released outside of the provider, allocating a new one", ip)
+			reservedIPScope.SetIP("")
+			reservedIPScope.SetDropletID(0)
+		}
+	}
+	if fip == nil {
+		var err error
+		fip, err = svc.CreateReservedIP()
+		if err != nil {
+			recordFailure(r.Recorder, doreservedip, infrav1.ReservedIPCreatingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		reservedIPScope.SetIP(fip.IP)
+		r.Recorder.Eventf(doreservedip, corev1.EventTypeNormal, infrav1.ReservedIPCreatedReason, "Allocated reserved IP %s in region %s", fip.IP, reservedIPScope.Region())
+	}
+
+	assigned := 0
+	if fip.Droplet != nil {
+		assigned = fip.Droplet.ID
+	}
+	reservedIPScope.SetDropletID(assigned)
+
+	want, err := r.targetDropletID(ctx, doreservedip)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if want == 0 && doreservedip.Spec.Target != nil {
+		// The DOMachine watch triggers a new reconcile once a droplet is available.
+		reservedIPScope.Info("Waiting for a droplet to assign the reserved IP to")
+		reservedIPScope.SetReady(false)
+		conditions.MarkFalse(doreservedip, infrav1.ReservedIPAssignedCondition, infrav1.WaitingForTargetReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{}, nil
+	}
+
+	if assigned != want {
+		var action *godo.Action
+		if want == 0 {
+			action, err = svc.UnassignReservedIP(fip.IP, reservedips.DefaultActionTimeout)
+		} else {
+			action, err = svc.AssignReservedIP(fip.IP, want, reservedips.DefaultActionTimeout)
+		}
+		computes.SetActionCondition(doreservedip, infrav1.ReservedIPAssignedCondition, action, err)
+		if err != nil {
+			reservedIPScope.SetReady(false)
+			var inProgress *doclient.ActionInProgressError
+			if errors.As(err, &inProgress) {
+				return reconcile.Result{RequeueAfter: DefaultReservedIPRequeueAfter}, nil
+			}
+			recordFailure(r.Recorder, doreservedip, infrav1.ReservedIPAssigningErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		reservedIPScope.SetDropletID(want)
+		if want == 0 {
+			r.Recorder.Eventf(doreservedip, corev1.EventTypeNormal, infrav1.ReservedIPUnassignedReason, "Unassigned reserved IP %s from droplet %d", fip.IP, assigned)
+		} else {
+			r.Recorder.Eventf(doreservedip, corev1.EventTypeNormal, infrav1.ReservedIPAssignedReason, "Assigned reserved IP %s to droplet %d", fip.IP, want)
+		}
+	}
+
+	conditions.MarkTrue(doreservedip, infrav1.ReservedIPAssignedCondition)
+	reservedIPScope.SetReady(true)
+	return reconcile.Result{}, nil
+}
+
+func (r *DOReservedIPReconciler) reconcileDelete(ctx context.Context, reservedIPScope *scope.ReservedIPScope) (reconcile.Result, error) {
+	reservedIPScope.Info("Reconciling delete DOReservedIP")
+	doreservedip := reservedIPScope.DOReservedIP
+
+	if ip := reservedIPScope.IP(); ip != "" {
+		svc := reservedips.NewService(ctx, reservedIPScope)
+		fip, err := svc.GetReservedIP(ip)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if fip != nil && fip.Droplet != nil {
+			if _, err := svc.UnassignReservedIP(ip, reservedips.DefaultActionTimeout); err != nil {
+				var inProgress *doclient.ActionInProgressError
+				if errors.As(err, &inProgress) {
+					return reconcile.Result{RequeueAfter: DefaultReservedIPRequeueAfter}, nil
+				}
+				recordFailure(r.Recorder, doreservedip, infrav1.ReservedIPReleasingErrorReason, err)
+				return reconcile.Result{}, err
+			}
+		}
+		if fip != nil {
+			if err := svc.DeleteReservedIP(ip); err != nil {
+				recordFailure(r.Recorder, doreservedip, infrav1.ReservedIPReleasingErrorReason, err)
+				return reconcile.Result{}, err
+			}
+			r.Recorder.Eventf(doreservedip, corev1.EventTypeNormal, infrav1.ReservedIPReleasedReason, "Released reserved IP %s", ip)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(doreservedip, infrav1.ReservedIPFinalizer)
+	return reconcile.Result{}, nil
+}
+
+// targetDropletID returns the ID of the droplet the reserved IP must be
+// assigned to, or 0 when it must be left unassigned or its target has no
+// droplet yet.
+func (r *DOReservedIPReconciler) targetDropletID(ctx context.Context, doreservedip *infrav1.DOReservedIP) (int, error) {
+	t := doreservedip.Spec.Target
+	switch {
+	case t == nil:
+		return 0, nil
+	case t.MachineRef != nil:
+		domachine := &infrav1.DOMachine{}
+		key := client.ObjectKey{Namespace: doreservedip.Namespace, Name: t.MachineRef.Name}
+		if err := r.Get(ctx, key, domachine); err != nil {
+			if apierrors.IsNotFound(err) {
+				return 0, nil
+			}
+			return 0, errors.Wrapf(err, "failed to get DOMachine %s", key)
+		}
+		if !domachine.DeletionTimestamp.IsZero() {
+			return 0, nil
+		}
+		return dropletIDOf(domachine), nil
+	case t.ControlPlaneRef != nil:
+		// Keep the reserved IP on its droplet as long as it is a ready
+		// control plane droplet, otherwise move it to the first one by name.
+		domachines := &infrav1.DOMachineList{}
+		if err := r.List(ctx, domachines, client.InNamespace(doreservedip.Namespace), client.MatchingLabels{
+			clusterv1.ClusterLabelName:             t.ControlPlaneRef.Name,
+			clusterv1.MachineControlPlaneLabelName: "",
+		}); err != nil {
+			return 0, errors.Wrap(err, "failed to list control plane DOMachines")
+		}
+		sort.Slice(domachines.Items, func(i, j int) bool { return domachines.Items[i].Name < domachines.Items[j].Name })
+		first := 0
+		for i := range domachines.Items {
+			m := &domachines.Items[i]
+			id := dropletIDOf(m)
+			if !m.Status.Ready || !m.DeletionTimestamp.IsZero() || id == 0 {
+				continue
+			}
+			if id == doreservedip.Status.DropletID {
+				return id, nil
+			}
+			if first == 0 {
+				first = id
+			}
+		}
+		return first, nil
+	}
+	return 0, nil
+}
+
+// dropletIDOf returns the ID of the droplet of a DOMachine, or 0 if it has none yet.
+func dropletIDOf(domachine *infrav1.DOMachine) int {
+	if domachine.Spec.ProviderID == nil {
+		return 0
+	}
+	parsed, err := noderefutil.NewProviderID(*domachine.Spec.ProviderID)
+	if err != nil {
+		return 0
+	}
+	id, err := strconv.Atoi(parsed.ID())
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestDOReservedIPReconcileControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("token")
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	labels := map[string]string{clusterv1.ClusterLabelName: "foo", clusterv1.MachineControlPlaneLabelName: ""}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	var droplets []int
+	for _, name := range []string{"foo-control-plane-0", "foo-control-plane-1"} {
+		d, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: name, Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		droplets = append(droplets, d.ID)
+		builder = builder.WithObjects(&infrav1.DOMachine{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+			Spec:       infrav1.DOMachineSpec{ProviderID: pointer.StringPtr(fmt.Sprintf("digitalocean://%d", d.ID))},
+			Status:     infrav1.DOMachineStatus{Ready: true},
+		})
+	}
+	doreservedip := &infrav1.DOReservedIP{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-api"},
+		Spec: infrav1.DOReservedIPSpec{
+			Region: "nyc1",
+			Target: &infrav1.DOReservedIPTarget{ControlPlaneRef: &corev1.LocalObjectReference{Name: "foo"}},
+		},
+	}
+	client := builder.WithObjects(doreservedip).Build()
+
+	newScope := func() *scope.ReservedIPScope {
+		reservedIPScope, err := scope.NewReservedIPScope(scope.ReservedIPScopeParams{
+			DOClients:    scope.DOClients{Actions: c.Actions, FloatingIPs: c.FloatingIPs, FloatingIPActions: c.FloatingIPActions},
+			Client:       client,
+			DOReservedIP: doreservedip,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return reservedIPScope
+	}
+	assignedTo := func() int {
+		fips := s.ReservedIPs()
+		g.Expect(fips).To(HaveLen(1))
+		g.Expect(fips[0].IP).To(Equal(doreservedip.Status.IP))
+		if fips[0].Droplet == nil {
+			return 0
+		}
+		return fips[0].Droplet.ID
+	}
+
+	r := &DOReservedIPReconciler{Client: client, Recorder: record.NewFakeRecorder(10)}
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(doreservedip.Status.Ready).To(BeTrue())
+	g.Expect(conditions.IsTrue(doreservedip, infrav1.ReservedIPAssignedCondition)).To(BeTrue())
+	g.Expect(doreservedip.Status.DropletID).To(Equal(droplets[0]))
+	g.Expect(assignedTo()).To(Equal(droplets[0]))
+
+	// The reserved IP follows the control plane when its droplet goes away.
+	g.Expect(client.Delete(ctx, &infrav1.DOMachine{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-control-plane-0"}})).To(Succeed())
+	_, err = r.reconcile(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(doreservedip.Status.DropletID).To(Equal(droplets[1]))
+	g.Expect(assignedTo()).To(Equal(droplets[1]))
+
+	_, err = r.reconcileDelete(ctx, newScope())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.ReservedIPs()).To(BeEmpty())
+	g.Expect(controllerutil.ContainsFinalizer(doreservedip, infrav1.ReservedIPFinalizer)).To(BeFalse())
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/computes"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/volumes"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultVolumeRequeueAfter is how long to wait before checking again whether
+// a volume was resized or detached.
+const DefaultVolumeRequeueAfter = 15 * time.Second
+
+// DOVolumeReconciler reconciles a DOVolume object.
+type DOVolumeReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// ReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	// Defaults to DefaultReconcileTimeout.
+	ReconcileTimeout time.Duration
+	// ShutdownGracePeriod is how long the DigitalOcean API calls in flight may
+	// complete once the manager is stopped. Defaults to DefaultShutdownGracePeriod.
+	ShutdownGracePeriod time.Duration
+	// TimeoutRequeueAfter is the delay before retrying a reconcile that ran out
+	// of time. Defaults to DefaultTimeoutRequeueAfter.
+	TimeoutRequeueAfter time.Duration
+	// BlockedRequeueAfter is the delay before retrying a reconcile blocked by
+	// a DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota. Defaults to DefaultBlockedRequeueAfter.
+	BlockedRequeueAfter time.Duration
+	// WatchFilterValue is the label value used to filter events prior to
+	// reconciliation, see clusterv1.WatchLabel.
+	WatchFilterValue string
+}
+
+func (r *DOVolumeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.DOVolume{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)). // don't queue reconcile if resource is paused or filtered out
+		WithOptions(options).
+		Build(r)
+	if err != nil {
+		return errors.Wrapf(err, "error creating controller")
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dovolumes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dovolumes/status,verbs=get;update;patch
+
+func (r *DOVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Do not start new work once the manager is stopping, the reconciles in
+	// flight are still given ShutdownGracePeriod to complete.
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+	ctx, span := startReconcileSpan(ctx, "DOVolume", req)
+	defer func() { tracing.End(span, reterr) }()
+
+	dovolume := &infrav1.DOVolume{}
+	if err := r.Get(ctx, req.NamespacedName, dovolume); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	volumeScope, err := scope.NewVolumeScope(scope.VolumeScopeParams{
+		Client:   r.Client,
+		Logger:   log,
+		DOVolume: dovolume,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any changes.
+	defer func() {
+		if err := volumeScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	reconcileCtx, cancel := withReconcileTimeout(ctx, r.ReconcileTimeout, r.ShutdownGracePeriod)
+	defer cancel()
+
+	var result reconcile.Result
+	if !dovolume.DeletionTimestamp.IsZero() {
+		result, err = r.reconcileDelete(reconcileCtx, volumeScope)
+	} else {
+		result, err = r.reconcile(reconcileCtx, volumeScope)
+	}
+	recordThrottling(r.Recorder, dovolume, err)
+	observeReconcile(DOVolumeControllerName, result, err)
+	setErrorCondition(dovolume, infrav1.VolumeReadyCondition, err)
+	return requeueOnTimeout(r.TimeoutRequeueAfter)(requeueOnRateLimit(requeueOnErrorClass(dovolume, r.BlockedRequeueAfter)(result, err)))
+}
+
+func (r *DOVolumeReconciler) reconcile(ctx context.Context, volumeScope *scope.VolumeScope) (reconcile.Result, error) {
+	volumeScope.Info("Reconciling DOVolume")
+	dovolume := volumeScope.DOVolume
+
+	// If the DOVolume doesn't have our finalizer, add it.
+	controllerutil.AddFinalizer(dovolume, infrav1.VolumeFinalizer)
+
+	svc := volumes.NewService(ctx, volumeScope)
+
+	var vol *godo.Volume
+	var err error
+	if id := volumeScope.VolumeID(); id != "" {
+		vol, err = svc.GetVolume(id)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if vol == nil {
+			// Creating the volume again would silently replace its data with
+			// an empty volume.
+			err := errors.Errorf("volume %s was deleted outside of the provider", id)
+			volumeScope.SetReady(false)
+			conditions.MarkFalse(dovolume, infrav1.VolumeReadyCondition, infrav1.VolumeDeletedExternallyReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			r.Recorder.Event(dovolume, corev1.EventTypeWarning, infrav1.VolumeDeletedExternallyReason, err.Error())
+			return reconcile.Result{}, nil
+		}
+	} else {
+		name := dovolume.VolumeName()
+		vol, err = svc.GetVolumeByName(name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if vol != nil {
+			r.Recorder.Eventf(dovolume, corev1.EventTypeNormal, infrav1.VolumeAdoptedReason, "Adopted existing storage volume - %s", vol.Name)
+		} else {
+			vol, err = svc.CreateVolume(name)
+			if err != nil {
+				recordFailure(r.Recorder, dovolume, infrav1.VolumeCreatingErrorReason, err)
+				return reconcile.Result{}, err
+			}
+			r.Recorder.Eventf(dovolume, corev1.EventTypeNormal, infrav1.VolumeCreatedReason, "Created new storage volume - %s", vol.Name)
+		}
+	}
+	volumeScope.SetVolume(vol)
+
+	if dovolume.Spec.SizeGigaBytes > vol.SizeGigaBytes {
+		action, err := svc.ResizeVolume(vol.ID, dovolume.Spec.SizeGigaBytes, volumes.DefaultActionTimeout)
+		if err != nil {
+			var inProgress *doclient.ActionInProgressError
+			if errors.As(err, &inProgress) {
+				conditions.MarkFalse(dovolume, infrav1.VolumeReadyCondition, infrav1.VolumeResizingReason, clusterv1.ConditionSeverityInfo,
+					"growing volume to %dGB", dovolume.Spec.SizeGigaBytes)
+				return reconcile.Result{RequeueAfter: DefaultVolumeRequeueAfter}, nil
+			}
+			computes.SetActionCondition(dovolume, infrav1.VolumeReadyCondition, action, err)
+			recordFailure(r.Recorder, dovolume, infrav1.VolumeResizingErrorReason, err)
+			return reconcile.Result{}, err
+		}
+		dovolume.Status.SizeGigaBytes = dovolume.Spec.SizeGigaBytes
+		r.Recorder.Eventf(dovolume, corev1.EventTypeNormal, infrav1.VolumeResizedReason, "Grew storage volume %s to %dGB", vol.Name, dovolume.Spec.SizeGigaBytes)
+	}
+
+	conditions.MarkTrue(dovolume, infrav1.VolumeReadyCondition)
+	volumeScope.SetReady(true)
+	return reconcile.Result{}, nil
+}
+
+func (r *DOVolumeReconciler) reconcileDelete(ctx context.Context, volumeScope *scope.VolumeScope) (reconcile.Result, error) {
+	volumeScope.Info("Reconciling delete DOVolume")
+	dovolume := volumeScope.DOVolume
+
+	id := volumeScope.VolumeID()
+	if id != "" && dovolume.Spec.DeletionPolicy != infrav1.VolumeDeletionPolicyRetain {
+		svc := volumes.NewService(ctx, volumeScope)
+		vol, err := svc.GetVolume(id)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if vol != nil {
+			volumeScope.SetVolume(vol)
+			if len(vol.DropletIDs) > 0 {
+				// Attached volumes can not be deleted, they are detached when
+				// the droplets of their DOMachines are deleted.
+				volumeScope.Info("Waiting for volume to be detached", "volume-id", id, "droplet-ids", vol.DropletIDs)
+				return reconcile.Result{RequeueAfter: DefaultVolumeRequeueAfter}, nil
+			}
+			if err := svc.DeleteVolume(id); err != nil {
+				recordFailure(r.Recorder, dovolume, infrav1.VolumeDeletingErrorReason, err)
+				return reconcile.Result{}, err
+			}
+			r.Recorder.Eventf(dovolume, corev1.EventTypeNormal, infrav1.VolumeDeletedReason, "Deleted the storage volume - %s", vol.Name)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(dovolume, infrav1.VolumeFinalizer)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestDOVolumeReconcile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		existing   bool
+		wantReason string
+	}{
+		{
+			name:       "volume is created",
+			wantReason: infrav1.VolumeCreatedReason,
+		},
+		{
+			name:       "existing volume is adopted",
+			existing:   true,
+			wantReason: infrav1.VolumeAdoptedReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			defer scope.SetAccessToken("")
+			scope.SetAccessToken("token")
+			c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.existing {
+				_, _, err := c.Storage.CreateVolume(ctx, &godo.VolumeCreateRequest{Name: "data", Region: "nyc1", SizeGigaBytes: 10})
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			dovolume := &infrav1.DOVolume{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "data"},
+				Spec:       infrav1.DOVolumeSpec{Region: "nyc1", SizeGigaBytes: 10},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dovolume).Build()
+			newScope := func() *scope.VolumeScope {
+				volumeScope, err := scope.NewVolumeScope(scope.VolumeScopeParams{
+					DOClients: scope.DOClients{Actions: c.Actions, Storage: c.Storage, StorageActions: c.StorageActions},
+					Client:    client,
+					DOVolume:  dovolume,
+				})
+				g.Expect(err).NotTo(HaveOccurred())
+				return volumeScope
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := &DOVolumeReconciler{Client: client, Recorder: recorder}
+			_, err = r.reconcile(ctx, newScope())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(dovolume.Status.Ready).To(BeTrue())
+			g.Expect(dovolume.Status.VolumeID).NotTo(BeEmpty())
+			g.Expect(recorder.Events).To(Receive(ContainSubstring(tc.wantReason)))
+
+			// Reconciling again neither creates nor adopts another volume.
+			_, err = r.reconcile(ctx, newScope())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(recorder.Events).NotTo(Receive())
+
+			_, err = r.reconcileDelete(ctx, newScope())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(controllerutil.ContainsFinalizer(dovolume, infrav1.VolumeFinalizer)).To(BeFalse())
+			_, _, err = c.Storage.GetVolume(ctx, dovolume.Status.VolumeID)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
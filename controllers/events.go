@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// recordFailure records a Warning event with the given reason for a failed
+// DigitalOcean operation. Droplet actions that errored are reported with
+// infrav1.ActionFailedReason whatever the operation. Rate limited and timed
+// out calls are left to recordThrottling.
+func recordFailure(recorder record.EventRecorder, obj runtime.Object, reason string, err error) {
+	if _, ok := doclient.RetryAfter(err); ok || doclient.IsTimeout(err) {
+		return
+	}
+	var failed *doclient.ActionFailedError
+	if errors.As(err, &failed) {
+		reason = infrav1.ActionFailedReason
+	}
+	recorder.Event(obj, corev1.EventTypeWarning, reason, doclient.ErrorMessage(err))
+}
+
+// recordThrottling records an event when a reconcile was cut short by the
+// DigitalOcean API rate limit or ran out of time, both of which are requeued
+// rather than reported as a reconcile error.
+func recordThrottling(recorder record.EventRecorder, obj runtime.Object, err error) {
+	if retryAfter, ok := doclient.RetryAfter(err); ok {
+		recorder.Eventf(obj, corev1.EventTypeWarning, infrav1.RateLimitedReason, "DigitalOcean API rate limit exhausted, retrying in %s", retryAfter)
+		return
+	}
+	if err != nil && doclient.IsTimeout(err) {
+		recorder.Eventf(obj, corev1.EventTypeWarning, infrav1.APITimeoutReason, "DigitalOcean API calls timed out: %s", doclient.ErrorMessage(err))
+	}
+}
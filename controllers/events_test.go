@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordEvents(t *testing.T) {
+	rateLimited := errors.Wrap(&doclient.RateLimitedError{RetryAfter: time.Minute}, "failed to create droplet")
+	timedOut := errors.Wrap(context.DeadlineExceeded, "failed to create droplet")
+	actionFailed := errors.Wrap(&doclient.ActionFailedError{Action: &godo.Action{ID: 1, Type: "resize"}}, "failed to resize droplet")
+
+	testCases := []struct {
+		name   string
+		record func(record.EventRecorder)
+		want   []string
+	}{
+		{
+			name: "failure is recorded with the given reason",
+			record: func(r record.EventRecorder) {
+				recordFailure(r, &infrav1.DOMachine{}, infrav1.InstanceCreatingErrorReason, errors.New("boom"))
+			},
+			want: []string{"Warning InstanceCreatingError boom"},
+		},
+		{
+			name: "errored action is recorded as ActionFailed",
+			record: func(r record.EventRecorder) {
+				recordFailure(r, &infrav1.DOMachine{}, infrav1.InstanceCreatingErrorReason, actionFailed)
+			},
+			want: []string{"Warning ActionFailed " + actionFailed.Error()},
+		},
+		{
+			name: "throttling is only recorded once",
+			record: func(r record.EventRecorder) {
+				recordFailure(r, &infrav1.DOMachine{}, infrav1.InstanceCreatingErrorReason, rateLimited)
+				recordThrottling(r, &infrav1.DOMachine{}, rateLimited)
+			},
+			want: []string{"Warning RateLimited DigitalOcean API rate limit exhausted, retrying in 1m0s"},
+		},
+		{
+			name: "timeouts are recorded",
+			record: func(r record.EventRecorder) {
+				recordFailure(r, &infrav1.DOMachine{}, infrav1.InstanceCreatingErrorReason, timedOut)
+				recordThrottling(r, &infrav1.DOMachine{}, timedOut)
+			},
+			want: []string{"Warning APITimeout DigitalOcean API calls timed out: " + timedOut.Error()},
+		},
+		{
+			name: "nothing is recorded for other errors",
+			record: func(r record.EventRecorder) {
+				recordThrottling(r, &infrav1.DOMachine{}, errors.New("boom"))
+				recordThrottling(r, &infrav1.DOMachine{}, nil)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			recorder := record.NewFakeRecorder(10)
+			tc.record(recorder)
+			close(recorder.Events)
+
+			var got []string
+			for e := range recorder.Events {
+				got = append(got, e)
+			}
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultGarbageCollectorMinAge is how old a leaked resource must be to be
+// collected, so that resources of a Cluster that is not in the cache yet are
+// left alone.
+const DefaultGarbageCollectorMinAge = 30 * time.Minute
+
+var (
+	gcLeakedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_gc_leaked_resources_total",
+		Help: "Number of leaked DigitalOcean resources found by the garbage collector, by resource type.",
+	}, []string{"type"})
+	gcDeletedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_gc_deleted_resources_total",
+		Help: "Number of leaked DigitalOcean resources deleted by the garbage collector, by resource type.",
+	}, []string{"type"})
+	gcSweepErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "capdo_gc_sweep_errors_total",
+		Help: "Number of garbage collector sweeps that failed.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(gcLeakedResources, gcDeletedResources, gcSweepErrors)
+}
+
+// GarbageCollector periodically deletes the droplets and load balancers
+// tagged for a Cluster that no longer exists, e.g. after a deletion that was
+// interrupted. It must only run against a DigitalOcean account dedicated to
+// the management cluster, the resources of Clusters it can not see being
+// considered leaked. It implements the manager Runnable interface.
+type GarbageCollector struct {
+	// Client lists the Clusters of the management cluster.
+	Client client.Reader
+	Log    logr.Logger
+
+	// Interval is the delay between two sweeps.
+	Interval time.Duration
+	// TTL, when set, also collects the resources older than TTL whose Cluster
+	// still exists, e.g. in accounts used by CI.
+	TTL time.Duration
+	// MinAge is how old a resource must be to be collected. Defaults to
+	// DefaultGarbageCollectorMinAge.
+	MinAge time.Duration
+	// DryRun only logs and counts the leaked resources.
+	DryRun bool
+
+	// session returns the DigitalOcean client. Defaults to the session of the
+	// manager token.
+	session func() (*godo.Client, error)
+}
+
+// Start sweeps every Interval until ctx is done.
+func (g *GarbageCollector) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := g.Sweep(ctx); err != nil {
+			gcSweepErrors.Inc()
+			g.Log.Error(err, "Garbage collection of leaked DigitalOcean resources failed")
+		}
+	}, g.Interval)
+	return nil
+}
+
+// NeedLeaderElection implements the manager LeaderElectionRunnable interface.
+func (g *GarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep deletes the leaked droplets and load balancers once.
+func (g *GarbageCollector) Sweep(ctx context.Context) error {
+	session := g.session
+	if session == nil {
+		session = (&scope.DOClients{}).Session
+	}
+	c, err := session()
+	if err != nil {
+		return err
+	}
+
+	clusters := &clusterv1.ClusterList{}
+	if err := g.Client.List(ctx, clusters); err != nil {
+		return errors.Wrap(err, "failed to list Clusters")
+	}
+	live := make(map[string]bool, len(clusters.Items))
+	for i := range clusters.Items {
+		live[string(clusters.Items[i].UID)] = true
+	}
+
+	var droplets []godo.Droplet
+	err = doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := c.Droplets.List(ctx, opt)
+		droplets = append(droplets, page...)
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list droplets")
+	}
+	for _, d := range droplets {
+		if !g.leaked(live, d.Tags, d.Created) {
+			continue
+		}
+		if err := g.collect(string(godo.DropletResourceType), strconv.Itoa(d.ID), d.Name, func() (*godo.Response, error) {
+			return c.Droplets.Delete(ctx, d.ID)
+		}); err != nil {
+			return err
+		}
+	}
+
+	var lbs []godo.LoadBalancer
+	err = doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := c.LoadBalancers.List(ctx, opt)
+		lbs = append(lbs, page...)
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list load balancers")
+	}
+	for _, lb := range lbs {
+		if !g.leaked(live, lb.Tags, lb.Created) {
+			continue
+		}
+		if err := g.collect(string(godo.LoadBalancerResourceType), lb.ID, lb.Name, func() (*godo.Response, error) {
+			return c.LoadBalancers.Delete(ctx, lb.ID)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leaked returns whether a resource with the given tags and creation time
+// belongs to a Cluster that no longer exists or outlived the TTL. A resource
+// tagged with several Cluster UIDs, e.g. after a clusterctl move, is live as
+// long as one of them is.
+func (g *GarbageCollector) leaked(live map[string]bool, tags []string, created string) bool {
+	uids := infrav1.ClusterUIDsFromTags(tags)
+	if len(uids) == 0 {
+		// Not created by the provider.
+		return false
+	}
+	alive := false
+	for _, uid := range uids {
+		alive = alive || live[uid]
+	}
+	createdAt, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		return false
+	}
+	age := time.Since(createdAt)
+	if age < orDefault(g.MinAge, DefaultGarbageCollectorMinAge) {
+		return false
+	}
+	return !alive || (g.TTL > 0 && age > g.TTL)
+}
+
+func (g *GarbageCollector) collect(resourceType, id, name string, del func() (*godo.Response, error)) error {
+	log := g.Log.WithValues("resource-type", resourceType, "resource-id", id, "resource-name", name)
+	gcLeakedResources.WithLabelValues(resourceType).Inc()
+	if g.DryRun {
+		log.Info("Found leaked DigitalOcean resource, not deleting it in dry-run mode")
+		return nil
+	}
+	log.Info("Deleting leaked DigitalOcean resource")
+	if res, err := del(); err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete %s %s", resourceType, id)
+	}
+	gcDeletedResources.WithLabelValues(resourceType).Inc()
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGarbageCollectorSweep(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		g := NewWithT(t)
+		ctx := context.Background()
+
+		s := fakedo.NewServer(fakedo.Options{})
+		defer s.Close()
+		c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+		g.Expect(err).NotTo(HaveOccurred())
+
+		createDroplet := func(name string, tags []string) {
+			_, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+				Name: name, Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42}, Tags: tags,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		tagsOf := func(cluster, uid string) []string {
+			return infrav1.BuildTags(infrav1.BuildTagParams{ClusterName: cluster, ClusterUID: uid, Name: cluster, Role: infrav1.NodeRoleTagValue})
+		}
+		createDroplet("live", tagsOf("live", "live-uid"))
+		createDroplet("leaked", tagsOf("leaked", "leaked-uid"))
+		createDroplet("unrelated", []string{"team:infra"})
+		// Moved to a new management cluster, where its Cluster got a new UID,
+		// and still carrying the UID tag it was created with.
+		createDroplet("moved", append(tagsOf("live", "previous-uid"), infrav1.ClusterNameUIDTag("live", "live-uid")))
+		_, _, err = c.LoadBalancers.Create(ctx, &godo.LoadBalancerRequest{
+			Name: "leaked", Region: "nyc1", ForwardingRules: []godo.ForwardingRule{{EntryPort: 6443}}, Tags: tagsOf("leaked", "leaked-uid"),
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		cluster := newCluster("live")
+		cluster.UID = "live-uid"
+
+		gc := &GarbageCollector{
+			Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build(),
+			Log:     ctrl.Log,
+			MinAge:  time.Nanosecond,
+			DryRun:  dryRun,
+			session: func() (*godo.Client, error) { return c, nil },
+		}
+		g.Expect(gc.Sweep(ctx)).To(Succeed())
+
+		var names []string
+		for _, d := range s.Droplets() {
+			names = append(names, d.Name)
+		}
+		if dryRun {
+			g.Expect(names).To(ConsistOf("live", "leaked", "unrelated", "moved"))
+			g.Expect(s.LoadBalancers()).To(HaveLen(1))
+		} else {
+			g.Expect(names).To(ConsistOf("live", "unrelated", "moved"))
+			g.Expect(s.LoadBalancers()).To(BeEmpty())
+		}
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultReconcileTimeout bounds the DigitalOcean API calls of a single reconcile.
+	DefaultReconcileTimeout = 2 * time.Minute
+
+	// DefaultDropletRequeueAfter is how long to wait before checking again
+	// whether a new droplet is active.
+	DefaultDropletRequeueAfter = 10 * time.Second
+	// DefaultLoadBalancerRequeueAfter is how long to wait before checking again
+	// whether a new load balancer got its IP address.
+	DefaultLoadBalancerRequeueAfter = 15 * time.Second
+	// DefaultDNSRequeueAfter is how long to wait before checking again whether
+	// the control plane DNS record is propagated.
+	DefaultDNSRequeueAfter = 10 * time.Second
+	// DefaultTimeoutRequeueAfter is how long a reconcile that ran out of time
+	// waits before it is retried.
+	DefaultTimeoutRequeueAfter = 30 * time.Second
+	// DefaultBlockedRequeueAfter is how long a reconcile blocked by a
+	// DigitalOcean error that must be fixed outside of the provider, e.g. an
+	// exceeded quota or a revoked token, waits before it is retried.
+	DefaultBlockedRequeueAfter = 5 * time.Minute
+	// DefaultDriftCheckInterval is how often ready DOClusters and DOMachines
+	// are compared with their DigitalOcean resources.
+	DefaultDriftCheckInterval = 10 * time.Minute
+	// DefaultShutdownGracePeriod is how long the in-flight reconciles may keep
+	// calling the DigitalOcean API once the manager is stopped.
+	DefaultShutdownGracePeriod = 30 * time.Second
+)
+
+// startReconcileSpan starts the span of the reconcile of the kind object of
+// req. The service calls and DigitalOcean API requests of the reconcile are
+// recorded as its children.
+func startReconcileSpan(ctx context.Context, kind string, req ctrl.Request) (context.Context, trace.Span) {
+	return tracing.Start(ctx, kind+".Reconcile",
+		attribute.String("kind", kind), attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+}
+
+// withClusterAccounting returns a copy of ctx whose DigitalOcean API requests
+// count towards the share of cluster in the rate limit budget of its token.
+func withClusterAccounting(ctx context.Context, cluster *clusterv1.Cluster) context.Context {
+	return doclient.WithCluster(ctx, cluster.Namespace+"/"+cluster.Name)
+}
+
+// handleReconcileAnnotation removes the infrav1.ReconcileAnnotation of obj, if
+// any, the update setting it having already queued the reconcile. The cached
+// catalogs are invalidated so that the reconcile sees the changes made outside
+// of the provider. The removal is persisted when the scope is closed.
+func handleReconcileAnnotation(recorder record.EventRecorder, obj client.Object, catalog *doclient.Catalog) {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[infrav1.ReconcileAnnotation]; !ok {
+		return
+	}
+	delete(annotations, infrav1.ReconcileAnnotation)
+	obj.SetAnnotations(annotations)
+	if catalog != nil {
+		catalog.Invalidate()
+	}
+	recorder.Event(obj, corev1.EventTypeNormal, infrav1.ReconcileRequestedReason, "Reconcile requested with the "+infrav1.ReconcileAnnotation+" annotation")
+}
+
+// runConcurrently runs the independent steps of a reconcile concurrently, as
+// each mostly waits on DigitalOcean round trips. It returns the error of the
+// first step that failed, in the order of steps, so that its class still
+// decides how the reconcile is requeued, and logs the errors of the others.
+func runConcurrently(log logr.Logger, steps ...func() error) error {
+	errs := make([]error, len(steps))
+	var wg sync.WaitGroup
+	for i := range steps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = steps[i]()
+		}(i)
+	}
+	wg.Wait()
+
+	var first error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+			continue
+		}
+		log.Error(err, "Reconcile step failed")
+	}
+	return first
+}
+
+// orDefault returns d, or def when d is not set.
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// withReconcileTimeout bounds ctx to the DigitalOcean API budget of a
+// reconcile. A zero timeout means DefaultReconcileTimeout.
+//
+// The returned context is not cancelled with ctx: when the manager is
+// stopped, the DigitalOcean API calls in flight, e.g. a droplet creation and
+// its action polls, are given gracePeriod to complete so that their outcome
+// is persisted in the status rather than leaking the resource. A zero
+// gracePeriod means DefaultShutdownGracePeriod.
+func withReconcileTimeout(ctx context.Context, timeout, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	reconcileCtx, cancel := context.WithTimeout(detachedContext{ctx}, orDefault(timeout, DefaultReconcileTimeout))
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-reconcileCtx.Done():
+			return
+		}
+		t := time.NewTimer(orDefault(gracePeriod, DefaultShutdownGracePeriod))
+		defer t.Stop()
+		select {
+		case <-t.C:
+			cancel()
+		case <-reconcileCtx.Done():
+		}
+	}()
+	return reconcileCtx, cancel
+}
+
+// detachedContext carries the values of its parent but not its deadline nor
+// its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// requeueOnRateLimit turns DigitalOcean API rate limit errors into a delayed
+// requeue, so that an exhausted budget does not trigger the exponential
+// backoff of the workqueue nor get reported as a reconcile error.
+func requeueOnRateLimit(result reconcile.Result, err error) (reconcile.Result, error) {
+	if retryAfter, ok := doclient.RetryAfter(err); ok {
+		return reconcile.Result{RequeueAfter: retryAfter}, nil
+	}
+	return result, err
+}
+
+// requeueOnTimeout returns a function turning DigitalOcean API calls that ran
+// out of time into a requeue after requeueAfter, so that a slow DigitalOcean
+// API is not reported as a reconcile failure.
+func requeueOnTimeout(requeueAfter time.Duration) func(reconcile.Result, error) (reconcile.Result, error) {
+	return func(result reconcile.Result, err error) (reconcile.Result, error) {
+		if err != nil && doclient.IsTimeout(err) {
+			return reconcile.Result{RequeueAfter: orDefault(requeueAfter, DefaultTimeoutRequeueAfter)}, nil
+		}
+		return result, err
+	}
+}
+
+// requeueOnErrorClass returns a function mapping the DigitalOcean errors that
+// retrying right away does not fix to their requeue. The errors that must be
+// fixed outside of the provider are retried after requeueAfter rather than
+// with the exponential backoff of the workqueue. The rejections of the spec of
+// obj are not retried, the next change of obj triggers a new reconcile, unless
+// obj is being deleted.
+func requeueOnErrorClass(obj metav1.Object, requeueAfter time.Duration) func(reconcile.Result, error) (reconcile.Result, error) {
+	return func(result reconcile.Result, err error) (reconcile.Result, error) {
+		switch class := doclient.Classify(err); {
+		case class.NeedsIntervention():
+			return reconcile.Result{RequeueAfter: orDefault(requeueAfter, DefaultBlockedRequeueAfter)}, nil
+		case class.Terminal() && obj.GetDeletionTimestamp().IsZero():
+			return reconcile.Result{}, nil
+		default:
+			return result, err
+		}
+	}
+}
+
+// errorClassReasons are the condition reasons of the DigitalOcean error
+// classes that retrying does not fix.
+var errorClassReasons = map[doclient.ErrorClass]string{
+	doclient.ErrorClassUnauthorized:  infrav1.UnauthorizedReason,
+	doclient.ErrorClassQuotaExceeded: infrav1.QuotaExceededReason,
+	doclient.ErrorClassUnavailable:   infrav1.RegionOrSizeUnavailableReason,
+	doclient.ErrorClassImageNotFound: infrav1.ImageNotFoundReason,
+	doclient.ErrorClassInvalid:       infrav1.InvalidRequestReason,
+}
+
+// setErrorCondition reflects a DigitalOcean error that retrying does not fix
+// on the condition t of obj, with the Error severity. The other errors are
+// only reported by events and reconcile errors.
+func setErrorCondition(obj conditions.Setter, t clusterv1.ConditionType, err error) {
+	if reason, ok := errorClassReasons[doclient.Classify(err)]; ok {
+		conditions.MarkFalse(obj, t, reason, clusterv1.ConditionSeverityError, "%s", doclient.ErrorMessage(err))
+	}
+}
+
+// machineStatusError returns the failure reason of a DOMachine whose droplet
+// creation failed with a terminal DigitalOcean error.
+func machineStatusError(class doclient.ErrorClass) capierrors.MachineStatusError {
+	if class == doclient.ErrorClassInvalid {
+		return capierrors.CreateMachineError
+	}
+	return capierrors.InvalidConfigurationMachineError
+}
+
+// setDriftCondition reflects the outcome of a drift check on the
+// DriftDetectedCondition of obj and records an event when drift was found.
+func setDriftCondition(recorder record.EventRecorder, obj driftObject, corrected, uncorrected []string) {
+	switch {
+	case len(uncorrected) > 0:
+		message := strings.Join(append(uncorrected, corrected...), "; ")
+		conditions.Set(obj, &clusterv1.Condition{
+			Type:     infrav1.DriftDetectedCondition,
+			Status:   corev1.ConditionTrue,
+			Severity: clusterv1.ConditionSeverityWarning,
+			Reason:   infrav1.DriftNotCorrectableReason,
+			Message:  message,
+		})
+		recorder.Event(obj, corev1.EventTypeWarning, infrav1.DriftDetectedReason, message)
+	case len(corrected) > 0:
+		message := strings.Join(corrected, "; ")
+		conditions.MarkFalse(obj, infrav1.DriftDetectedCondition, infrav1.DriftCorrectedReason, clusterv1.ConditionSeverityInfo, "%s", message)
+		recorder.Event(obj, corev1.EventTypeNormal, infrav1.DriftCorrectedReason, message)
+	default:
+		conditions.MarkFalse(obj, infrav1.DriftDetectedCondition, infrav1.NoDriftReason, clusterv1.ConditionSeverityInfo, "")
+	}
+}
+
+// driftObject is a DOCluster or a DOMachine.
+type driftObject interface {
+	conditions.Setter
+	runtime.Object
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWithReconcileTimeoutOnShutdown(t *testing.T) {
+	g := NewWithT(t)
+
+	type key struct{}
+	ctx, stop := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	reconcileCtx, cancel := withReconcileTimeout(ctx, time.Minute, 100*time.Millisecond)
+	defer cancel()
+	g.Expect(reconcileCtx.Value(key{})).To(Equal("value"))
+
+	// In-flight calls outlive the manager for the grace period only.
+	stop()
+	g.Consistently(reconcileCtx.Done(), 50*time.Millisecond).ShouldNot(BeClosed())
+	g.Eventually(reconcileCtx.Done(), time.Second).Should(BeClosed())
+}
+
+func TestRunConcurrently(t *testing.T) {
+	g := NewWithT(t)
+
+	// Each step waits for the other, they would never complete one after the
+	// other.
+	first, second := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- runConcurrently(logr.Discard(),
+			func() error {
+				close(first)
+				<-second
+				return nil
+			},
+			func() error {
+				close(second)
+				<-first
+				return errors.New("second failed")
+			},
+			func() error { return errors.New("third failed") },
+		)
+	}()
+	var err error
+	g.Eventually(done, time.Second).Should(Receive(&err))
+	g.Expect(err).To(MatchError("second failed"))
+
+	g.Expect(runConcurrently(logr.Discard(), func() error { return nil })).To(Succeed())
+}
+
+func TestHandleReconcileAnnotation(t *testing.T) {
+	g := NewWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	domachine := &infrav1.DOMachine{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo-md-0",
+		Annotations: map[string]string{infrav1.ReconcileAnnotation: "now", "other": "kept"},
+	}}
+
+	handleReconcileAnnotation(recorder, domachine, nil)
+	g.Expect(domachine.Annotations).To(Equal(map[string]string{"other": "kept"}))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.ReconcileRequestedReason)))
+
+	// Reconciles not requested with the annotation are not reported.
+	handleReconcileAnnotation(recorder, domachine, nil)
+	g.Expect(recorder.Events).NotTo(Receive())
+}
+
+func TestRequeueOnErrorClass(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://api.digitalocean.com/v2/droplets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiError := func(status int, message string) error {
+		return errors.Wrap(&godo.ErrorResponse{Response: &http.Response{Request: req, StatusCode: status}, Message: message}, "failed to create droplet")
+	}
+	drift := reconcile.Result{RequeueAfter: time.Minute}
+
+	testCases := []struct {
+		name       string
+		err        error
+		wantResult reconcile.Result
+		wantErr    bool
+	}{
+		{name: "no error", wantResult: drift},
+		// A resource deleted meanwhile is recreated or forgotten by the next reconcile.
+		{name: "not found", err: apiError(http.StatusNotFound, "The resource you were accessing could not be found."), wantResult: drift, wantErr: true},
+		{name: "rejected create", err: apiError(http.StatusUnprocessableEntity, "name is required")},
+		{name: "quota", err: apiError(http.StatusUnprocessableEntity, "creating this/these droplet(s) will exceed your droplet limit"), wantResult: reconcile.Result{RequeueAfter: DefaultBlockedRequeueAfter}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			obj := &infrav1.DOCluster{}
+			result, err := requeueOnErrorClass(obj, 0)(drift, tc.err)
+			g.Expect(err != nil).To(Equal(tc.wantErr))
+			g.Expect(result).To(Equal(tc.wantResult))
+		})
+	}
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// These specs run the DOCluster and DOMachine controllers of the suite
+// against envtest and the fake DigitalOcean API, the Cluster API controllers
+// are played by the specs themselves.
+
+const (
+	integrationTimeout  = 20 * time.Second
+	integrationInterval = 100 * time.Millisecond
+)
+
+var _ = Describe("DOCluster controller", func() {
+	var ctx context.Context
+	var ns string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ns = createIntegrationNamespace(ctx)
+	})
+
+	It("creates the API server load balancer and deletes it with the DOCluster", func() {
+		cluster, docluster := createIntegrationCluster(ctx, ns, false)
+
+		By("waiting for the DOCluster to be ready")
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(docluster), docluster); err != nil {
+				return false
+			}
+			return docluster.Status.Ready
+		}, integrationTimeout, integrationInterval).Should(BeTrue())
+		Expect(controllerutil.ContainsFinalizer(docluster, infrav1.ClusterFinalizer)).To(BeTrue())
+		lbs := clusterLoadBalancers(cluster)
+		Expect(lbs).To(HaveLen(1))
+		Expect(docluster.Spec.ControlPlaneEndpoint.Host).To(Equal(lbs[0].IP))
+		Expect(docluster.Status.Network.APIServerLoadbalancersRef.ResourceID).To(Equal(lbs[0].ID))
+
+		By("replacing the load balancer deleted outside of the provider")
+		doServer.RemoveLoadBalancer(lbs[0].ID)
+		Eventually(func() []godo.LoadBalancer {
+			return clusterLoadBalancers(cluster)
+		}, integrationTimeout, integrationInterval).Should(ConsistOf(WithTransform(func(lb godo.LoadBalancer) string { return lb.ID }, Not(Equal(lbs[0].ID)))))
+
+		By("deleting the DOCluster")
+		Expect(k8sClient.Delete(ctx, docluster)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(docluster), docluster))
+		}, integrationTimeout, integrationInterval).Should(BeTrue())
+		Expect(clusterLoadBalancers(cluster)).To(BeEmpty())
+	})
+
+	It("leaves the DOCluster of a paused Cluster alone until it is unpaused", func() {
+		cluster, docluster := createIntegrationCluster(ctx, ns, true)
+
+		Consistently(func() []string {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(docluster), docluster)).To(Succeed())
+			return docluster.Finalizers
+		}, 2*time.Second, integrationInterval).Should(BeEmpty())
+		Expect(clusterLoadBalancers(cluster)).To(BeEmpty())
+
+		By("unpausing the Cluster")
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.Paused = false
+		Expect(k8sClient.Patch(ctx, cluster, patch)).To(Succeed())
+		Eventually(func() []godo.LoadBalancer {
+			return clusterLoadBalancers(cluster)
+		}, integrationTimeout, integrationInterval).Should(HaveLen(1))
+
+		Expect(k8sClient.Delete(ctx, docluster)).To(Succeed())
+		Eventually(func() []godo.LoadBalancer {
+			return clusterLoadBalancers(cluster)
+		}, integrationTimeout, integrationInterval).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("DOMachine controller", func() {
+	var ctx context.Context
+	var ns string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ns = createIntegrationNamespace(ctx)
+	})
+
+	It("creates the droplet and deletes it with the DOMachine", func() {
+		cluster, _ := createIntegrationCluster(ctx, ns, false)
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.InfrastructureReady = true
+		Expect(k8sClient.Status().Patch(ctx, cluster, patch)).To(Succeed())
+		domachine := createIntegrationMachine(ctx, cluster, "md-0")
+
+		By("waiting for the DOMachine to be ready")
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(domachine), domachine); err != nil {
+				return false
+			}
+			return domachine.Status.Ready
+		}, integrationTimeout, integrationInterval).Should(BeTrue())
+		Expect(controllerutil.ContainsFinalizer(domachine, infrav1.MachineFinalizer)).To(BeTrue())
+		droplets := clusterDroplets(cluster)
+		Expect(droplets).To(HaveLen(1))
+		Expect(domachine.Spec.ProviderID).To(Equal(pointer.StringPtr(fmt.Sprintf("digitalocean://%d", droplets[0].ID))))
+		Expect(domachine.Status.Addresses).NotTo(BeEmpty())
+
+		By("deleting the DOMachine")
+		Expect(k8sClient.Delete(ctx, domachine)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(domachine), domachine))
+		}, integrationTimeout, integrationInterval).Should(BeTrue())
+		Expect(clusterDroplets(cluster)).To(BeEmpty())
+	})
+
+	It("waits for the infrastructure of the Cluster before creating the droplet", func() {
+		cluster, _ := createIntegrationCluster(ctx, ns, false)
+		domachine := createIntegrationMachine(ctx, cluster, "md-0")
+
+		Eventually(func() bool {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(domachine), domachine)).To(Succeed())
+			return controllerutil.ContainsFinalizer(domachine, infrav1.MachineFinalizer)
+		}, integrationTimeout, integrationInterval).Should(BeTrue())
+		Consistently(func() []godo.Droplet {
+			return clusterDroplets(cluster)
+		}, 2*time.Second, integrationInterval).Should(BeEmpty())
+
+		By("marking the infrastructure of the Cluster ready")
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.InfrastructureReady = true
+		Expect(k8sClient.Status().Patch(ctx, cluster, patch)).To(Succeed())
+		Eventually(func() []godo.Droplet {
+			return clusterDroplets(cluster)
+		}, integrationTimeout, integrationInterval).Should(HaveLen(1))
+
+		Expect(k8sClient.Delete(ctx, domachine)).To(Succeed())
+		Eventually(func() []godo.Droplet {
+			return clusterDroplets(cluster)
+		}, integrationTimeout, integrationInterval).Should(BeEmpty())
+	})
+
+	It("leaves the DOMachine of a paused Cluster alone", func() {
+		cluster, _ := createIntegrationCluster(ctx, ns, true)
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.InfrastructureReady = true
+		Expect(k8sClient.Status().Patch(ctx, cluster, patch)).To(Succeed())
+		domachine := createIntegrationMachine(ctx, cluster, "md-0")
+
+		Consistently(func() []string {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(domachine), domachine)).To(Succeed())
+			return domachine.Finalizers
+		}, 2*time.Second, integrationInterval).Should(BeEmpty())
+		Expect(clusterDroplets(cluster)).To(BeEmpty())
+	})
+})
+
+func createIntegrationNamespace(ctx context.Context) string {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("integration-%s", util.RandomString(6))}}
+	Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+	return ns.Name
+}
+
+// createIntegrationCluster creates a Cluster and its DOCluster, owned by the
+// Cluster the way the Cluster API controllers would.
+func createIntegrationCluster(ctx context.Context, ns string, paused bool) (*clusterv1.Cluster, *infrav1.DOCluster) {
+	name := fmt.Sprintf("capdo-%s", util.RandomString(6))
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec: clusterv1.ClusterSpec{
+			Paused: paused,
+			InfrastructureRef: &corev1.ObjectReference{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "DOCluster",
+				Namespace:  ns,
+				Name:       name,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+	docluster := &infrav1.DOCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       cluster.Name,
+				UID:        cluster.UID,
+			}},
+		},
+		Spec: infrav1.DOClusterSpec{Region: "nyc1"},
+	}
+	Expect(k8sClient.Create(ctx, docluster)).To(Succeed())
+	return cluster, docluster
+}
+
+// createIntegrationMachine creates a Machine of cluster with its bootstrap
+// data and its DOMachine.
+func createIntegrationMachine(ctx context.Context, cluster *clusterv1.Cluster, name string) *infrav1.DOMachine {
+	name = fmt.Sprintf("%s-%s", cluster.Name, name)
+	bootstrap := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: name},
+		Data:       map[string][]byte{"value": []byte("#cloud-config")},
+	}
+	Expect(k8sClient.Create(ctx, bootstrap)).To(Succeed())
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      name,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: cluster.Name,
+			Bootstrap:   clusterv1.Bootstrap{DataSecretName: pointer.StringPtr(bootstrap.Name)},
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "DOMachine",
+				Namespace:  cluster.Namespace,
+				Name:       name,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+	domachine := &infrav1.DOMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      name,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Machine",
+				Name:       machine.Name,
+				UID:        machine.UID,
+			}},
+		},
+		Spec: infrav1.DOMachineSpec{
+			Size:    "s-2vcpu-2gb",
+			Image:   intstr.FromInt(doImage.ID),
+			SSHKeys: []intstr.IntOrString{intstr.FromInt(doSSHKey.ID)},
+		},
+	}
+	Expect(k8sClient.Create(ctx, domachine)).To(Succeed())
+	return domachine
+}
+
+// clusterLoadBalancers returns the load balancers of the fake API named after
+// the UID of cluster.
+func clusterLoadBalancers(cluster *clusterv1.Cluster) []godo.LoadBalancer {
+	var lbs []godo.LoadBalancer
+	for _, lb := range doServer.LoadBalancers() {
+		if strings.HasSuffix(lb.Name, string(cluster.UID)) {
+			lbs = append(lbs, lb)
+		}
+	}
+	return lbs
+}
+
+// clusterDroplets returns the droplets of the fake API tagged for cluster.
+func clusterDroplets(cluster *clusterv1.Cluster) []godo.Droplet {
+	tag := infrav1.ClusterNameUIDTag(cluster.Name, string(cluster.UID))
+	var droplets []godo.Droplet
+	for _, droplet := range doServer.Droplets() {
+		for _, t := range droplet.Tags {
+			if t == tag {
+				droplets = append(droplets, droplet)
+				break
+			}
+		}
+	}
+	return droplets
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// MachineStageActive is the provisioning stage ending when the droplet of
+	// a DOMachine is active.
+	MachineStageActive = "active"
+	// MachineStageNodeReady is the provisioning stage ending when the Node of
+	// a DOMachine is healthy.
+	MachineStageNodeReady = "node_ready"
+
+	// nodeReadyObservationWindow is how recent the Node of a DOMachine must
+	// have become healthy for its provisioning duration to be recorded, so
+	// that the Machines already provisioned are not recorded again when the
+	// manager restarts.
+	nodeReadyObservationWindow = 15 * time.Minute
+)
+
+var provisioningBuckets = []float64{30, 60, 90, 120, 180, 240, 300, 420, 600, 900, 1200, 1800, 3600}
+
+var (
+	machineProvisioningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "capdo_machine_provisioning_duration_seconds",
+		Help:    "Time from the creation of a DOMachine to its droplet being active (stage=active) and to its Node being healthy (stage=node_ready), by region and size.",
+		Buckets: provisioningBuckets,
+	}, []string{"stage", "region", "size"})
+	clusterInfrastructureReadyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "capdo_cluster_infrastructure_ready_duration_seconds",
+		Help:    "Time from the creation of a DOCluster to its infrastructure being ready, by region.",
+		Buckets: provisioningBuckets,
+	}, []string{"region"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(machineProvisioningDuration, clusterInfrastructureReadyDuration, reconcileTotal, reconcileErrors)
+}
+
+const (
+	// ReconcileOutcomeSuccess is a reconcile that completed.
+	ReconcileOutcomeSuccess = "success"
+	// ReconcileOutcomeRequeue is a reconcile that completed but has to run
+	// again, e.g. while waiting on a droplet to become active.
+	ReconcileOutcomeRequeue = "requeue"
+	// ReconcileOutcomeError is a reconcile that failed.
+	ReconcileOutcomeError = "error"
+
+	// ErrorSourceDigitalOcean is an error of the DigitalOcean API, see doclient.IsAPIError.
+	ErrorSourceDigitalOcean = "digitalocean"
+	// ErrorSourceKubernetes is any other error, e.g. of the Kubernetes API.
+	ErrorSourceKubernetes = "kubernetes"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_reconcile_total",
+		Help: "Number of reconciles, by controller and outcome (success, requeue or error).",
+	}, []string{"controller", "outcome"})
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_reconcile_errors_total",
+		Help: "Number of failed reconciles, by controller, source of the error (digitalocean or kubernetes) and DigitalOcean error class.",
+	}, []string{"controller", "source", "class"})
+)
+
+// observeReconcile counts the outcome of a reconcile of controller, before
+// its errors are turned into requeues.
+func observeReconcile(controller string, result reconcile.Result, err error) {
+	switch {
+	case err != nil:
+		reconcileTotal.WithLabelValues(controller, ReconcileOutcomeError).Inc()
+		source, class := ErrorSourceKubernetes, ""
+		if doclient.IsAPIError(err) {
+			source, class = ErrorSourceDigitalOcean, string(doclient.Classify(err))
+			if class == "" {
+				class = "Unknown"
+			}
+		}
+		reconcileErrors.WithLabelValues(controller, source, class).Inc()
+	case result.Requeue || result.RequeueAfter > 0:
+		reconcileTotal.WithLabelValues(controller, ReconcileOutcomeRequeue).Inc()
+	default:
+		reconcileTotal.WithLabelValues(controller, ReconcileOutcomeSuccess).Inc()
+	}
+}
+
+// managedObjectsCollector exports the number of DOClusters and DOMachines, by
+// kind, read from the cache of the manager when scraped.
+type managedObjectsCollector struct {
+	client client.Reader
+	desc   *prometheus.Desc
+}
+
+// NewManagedObjectsCollector returns the collector of the
+// capdo_managed_objects gauge, listing the objects with c.
+func NewManagedObjectsCollector(c client.Reader) prometheus.Collector {
+	return &managedObjectsCollector{
+		client: c,
+		desc:   prometheus.NewDesc("capdo_managed_objects", "Number of objects managed by the provider, by kind.", []string{"kind"}, nil),
+	}
+}
+
+func (c *managedObjectsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *managedObjectsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for kind, list := range map[string]client.ObjectList{
+		"DOCluster": &infrav1.DOClusterList{},
+		"DOMachine": &infrav1.DOMachineList{},
+	} {
+		// The cache may not be started yet, the gauge is left out then.
+		if err := c.client.List(ctx, list); err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(meta.LenList(list)), kind)
+	}
+}
+
+var (
+	nodeReadyObservedMu sync.Mutex
+	// nodeReadyObserved holds when the node_ready stage of the DOMachines was
+	// recorded, for the DOMachines that became healthy within the observation window.
+	nodeReadyObserved = map[types.UID]time.Time{}
+)
+
+// observeMachineActive records the active stage of a DOMachine whose droplet
+// was not active yet during the previous reconcile.
+func observeMachineActive(region string, domachine *infrav1.DOMachine) {
+	machineProvisioningDuration.WithLabelValues(MachineStageActive, region, domachine.Spec.Size).
+		Observe(time.Since(domachine.CreationTimestamp.Time).Seconds())
+}
+
+// observeMachineNodeReady records the node_ready stage of a DOMachine once its
+// Machine reports a healthy Node.
+func observeMachineNodeReady(region string, domachine *infrav1.DOMachine, machine *clusterv1.Machine) {
+	if machine.Status.NodeRef == nil || !conditions.IsTrue(machine, clusterv1.MachineNodeHealthyCondition) {
+		return
+	}
+	healthyAt := conditions.GetLastTransitionTime(machine, clusterv1.MachineNodeHealthyCondition).Time
+	now := time.Now()
+	if now.Sub(healthyAt) > nodeReadyObservationWindow {
+		return
+	}
+
+	nodeReadyObservedMu.Lock()
+	defer nodeReadyObservedMu.Unlock()
+	if _, ok := nodeReadyObserved[domachine.UID]; ok {
+		return
+	}
+	for uid, at := range nodeReadyObserved {
+		if now.Sub(at) > nodeReadyObservationWindow {
+			delete(nodeReadyObserved, uid)
+		}
+	}
+	nodeReadyObserved[domachine.UID] = now
+	machineProvisioningDuration.WithLabelValues(MachineStageNodeReady, region, domachine.Spec.Size).
+		Observe(healthyAt.Sub(domachine.CreationTimestamp.Time).Seconds())
+}
+
+// observeClusterReady records the infrastructure ready time of a DOCluster
+// that was not ready yet during the previous reconcile.
+func observeClusterReady(docluster *infrav1.DOCluster) {
+	clusterInfrastructureReadyDuration.WithLabelValues(docluster.Spec.Region).
+		Observe(time.Since(docluster.CreationTimestamp.Time).Seconds())
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestObserveMachineNodeReady(t *testing.T) {
+	g := NewWithT(t)
+
+	newMachines := func(uid string, healthyFor time.Duration) (*infrav1.DOMachine, *clusterv1.Machine) {
+		domachine := &infrav1.DOMachine{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID("uid-" + uid), CreationTimestamp: metav1.NewTime(time.Now().Add(-healthyFor - 5*time.Minute))},
+			Spec:       infrav1.DOMachineSpec{Size: "s-metrics-test"},
+		}
+		machine := newMachine("foo", uid)
+		machine.Status.NodeRef = &corev1.ObjectReference{Name: uid}
+		machine.Status.Conditions = clusterv1.Conditions{{
+			Type:               clusterv1.MachineNodeHealthyCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-healthyFor)),
+		}}
+		return domachine, machine
+	}
+	count := func() uint64 {
+		m := &dto.Metric{}
+		g.Expect(machineProvisioningDuration.WithLabelValues(MachineStageNodeReady, "nyc1", "s-metrics-test").(prometheus.Histogram).Write(m)).To(Succeed())
+		return m.GetHistogram().GetSampleCount()
+	}
+
+	domachine, machine := newMachines("foo-md-0", time.Minute)
+	observeMachineNodeReady("nyc1", domachine, machine)
+	observeMachineNodeReady("nyc1", domachine, machine)
+	g.Expect(count()).To(BeEquivalentTo(1))
+
+	// Provisioned before the manager started.
+	domachine, machine = newMachines("foo-md-1", time.Hour)
+	observeMachineNodeReady("nyc1", domachine, machine)
+	g.Expect(count()).To(BeEquivalentTo(1))
+}
+
+func TestObserveReconcile(t *testing.T) {
+	g := NewWithT(t)
+	count := func(c prometheus.Counter) float64 {
+		m := &dto.Metric{}
+		g.Expect(c.Write(m)).To(Succeed())
+		return m.GetCounter().GetValue()
+	}
+	const controller = "metrics-test"
+
+	observeReconcile(controller, reconcile.Result{}, nil)
+	observeReconcile(controller, reconcile.Result{RequeueAfter: time.Second}, nil)
+	observeReconcile(controller, reconcile.Result{}, errors.Wrap(&godo.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+		Message:  "creating this/these droplet(s) will exceed your droplet limit",
+	}, "failed to create droplet"))
+	observeReconcile(controller, reconcile.Result{}, errors.New("failed to patch DOMachine"))
+
+	g.Expect(count(reconcileTotal.WithLabelValues(controller, ReconcileOutcomeSuccess))).To(Equal(1.0))
+	g.Expect(count(reconcileTotal.WithLabelValues(controller, ReconcileOutcomeRequeue))).To(Equal(1.0))
+	g.Expect(count(reconcileTotal.WithLabelValues(controller, ReconcileOutcomeError))).To(Equal(2.0))
+	g.Expect(count(reconcileErrors.WithLabelValues(controller, ErrorSourceDigitalOcean, "QuotaExceeded"))).To(Equal(1.0))
+	g.Expect(count(reconcileErrors.WithLabelValues(controller, ErrorSourceKubernetes, ""))).To(Equal(1.0))
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/services/computes"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OrphanDropletPolicy is what the provider does with droplets tagged for a
+// cluster that are not referenced by the status of a DOMachine, e.g. after a
+// droplet was created but the DOMachine status could not be updated.
+type OrphanDropletPolicy string
+
+const (
+	// OrphanDropletPolicyAdopt adopts an orphaned droplet named after a
+	// DOMachine without droplet and reports the other orphaned droplets with
+	// an event.
+	OrphanDropletPolicyAdopt OrphanDropletPolicy = "adopt"
+	// OrphanDropletPolicyDelete deletes orphaned droplets.
+	OrphanDropletPolicyDelete OrphanDropletPolicy = "delete"
+
+	// DefaultOrphanGracePeriod is how old a droplet matching no DOMachine must
+	// be to be considered orphaned, so that a droplet whose DOMachine is being
+	// created is left alone.
+	DefaultOrphanGracePeriod = 10 * time.Minute
+
+	// orphanSweepRetryAfter is how long to wait before retrying a failed sweep
+	// of the orphaned droplets of a ready DOCluster.
+	orphanSweepRetryAfter = time.Minute
+)
+
+// IsValid returns whether p is a known policy.
+func (p OrphanDropletPolicy) IsValid() bool {
+	return p == OrphanDropletPolicyAdopt || p == OrphanDropletPolicyDelete
+}
+
+// adoptDroplet looks for a droplet created for the DOMachine of machineScope
+// that was never recorded in its status. Depending on policy it returns the
+// droplet to be adopted or deletes it and returns nil.
+func (r *DOMachineReconciler) adoptDroplet(computesvc *computes.Service, machineScope *scope.MachineScope) (*godo.Droplet, error) {
+	name := infrav1.DOSafeName(machineScope.Name())
+	droplet, err := computesvc.GetDropletByName(name)
+	if err != nil || droplet == nil {
+		return nil, err
+	}
+
+	domachine := machineScope.DOMachine
+	if r.OrphanDropletPolicy == OrphanDropletPolicyDelete {
+		if err := computesvc.DeleteDroplet(strconv.Itoa(droplet.ID)); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete orphaned droplet %d", droplet.ID)
+		}
+		r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.OrphanedInstanceDeletedReason, "Deleted orphaned droplet instance %s (%d)", droplet.Name, droplet.ID)
+		return nil, nil
+	}
+
+	// The droplet may have been created before the Cluster was moved.
+	if _, err := computesvc.RemoveStaleDropletTags(droplet); err != nil {
+		return nil, err
+	}
+	r.Recorder.Eventf(domachine, corev1.EventTypeNormal, infrav1.InstanceAdoptedReason, "Adopted droplet instance %s (%d)", droplet.Name, droplet.ID)
+	return droplet, nil
+}
+
+// reconcileOrphans deletes or reports the droplets tagged for the cluster
+// that belong to none of its DOMachines. On the way it tags the droplets of
+// its DOMachines with its current UID, see ReconcileClusterDropletTags.
+func (r *DOClusterReconciler) reconcileOrphans(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	docluster := clusterScope.DOCluster
+	domachines := &infrav1.DOMachineList{}
+	if err := r.List(ctx, domachines, client.InNamespace(docluster.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: clusterScope.Cluster.Name}); err != nil {
+		return errors.Wrap(err, "failed to list DOMachines")
+	}
+	names, ids := map[string]bool{}, map[string]bool{}
+	for i := range domachines.Items {
+		names[infrav1.DOSafeName(domachines.Items[i].Name)] = true
+		if id := domachines.Items[i].Spec.ProviderID; id != nil {
+			if parsed, err := noderefutil.NewProviderID(*id); err == nil {
+				ids[parsed.ID()] = true
+			}
+		}
+	}
+
+	computesvc := computes.NewService(ctx, clusterScope)
+	droplets, err := computesvc.ListClusterNameDroplets()
+	if err != nil {
+		return err
+	}
+	var owned []godo.Droplet
+	for _, droplet := range droplets {
+		// The droplet names are shared by the clusters with the same name.
+		if ids[strconv.Itoa(droplet.ID)] {
+			owned = append(owned, droplet)
+		}
+	}
+	if err := computesvc.ReconcileClusterDropletTags(owned); err != nil {
+		return err
+	}
+
+	for i := range droplets {
+		droplet := &droplets[i]
+		if !computesvc.IsClusterDroplet(droplet) || names[droplet.Name] || ids[strconv.Itoa(droplet.ID)] {
+			continue
+		}
+		if created, err := time.Parse(time.RFC3339, droplet.Created); err == nil && time.Since(created) < orDefault(r.OrphanGracePeriod, DefaultOrphanGracePeriod) {
+			continue
+		}
+
+		if r.OrphanDropletPolicy != OrphanDropletPolicyDelete {
+			r.Recorder.Eventf(docluster, corev1.EventTypeWarning, infrav1.OrphanedInstanceReason, "Droplet instance %s (%d) belongs to no DOMachine", droplet.Name, droplet.ID)
+			continue
+		}
+		if err := computesvc.DeleteDroplet(strconv.Itoa(droplet.ID)); err != nil {
+			return errors.Wrapf(err, "failed to delete orphaned droplet %d", droplet.ID)
+		}
+		computesvc.ForgetDropletTags(droplet.Name)
+		r.Recorder.Eventf(docluster, corev1.EventTypeNormal, infrav1.OrphanedInstanceDeletedReason, "Deleted orphaned droplet instance %s (%d)", droplet.Name, droplet.ID)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileOrphans(t *testing.T) {
+	for _, policy := range []OrphanDropletPolicy{OrphanDropletPolicyAdopt, OrphanDropletPolicyDelete} {
+		t.Run(string(policy), func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			defer scope.SetAccessToken("")
+			scope.SetAccessToken("token")
+			c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			createDroplet := func(name string) *godo.Droplet {
+				tags := infrav1.BuildTags(infrav1.BuildTagParams{ClusterName: "foo", ClusterUID: "uid", Name: name, Role: infrav1.NodeRoleTagValue})
+				d, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+					Name: name, Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42}, Tags: tags,
+				})
+				g.Expect(err).NotTo(HaveOccurred())
+				return d
+			}
+			createDroplet("foo-md-0")
+			// Created before the Cluster was moved and got a new UID.
+			moved, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+				Name: "foo-md-3", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+				Tags: infrav1.BuildTags(infrav1.BuildTagParams{ClusterName: "foo", ClusterUID: "previous-uid", Name: "foo-md-3", Role: infrav1.NodeRoleTagValue}),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			renamed := createDroplet("renamed")
+			orphan := createDroplet("foo-md-1")
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cluster := newCluster("foo")
+			cluster.UID = "uid"
+			docluster := &infrav1.DOCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"}}
+			labels := map[string]string{clusterv1.ClusterLabelName: "foo"}
+			objects := []infrav1.DOMachine{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-md-0", Labels: labels}},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-md-2", Labels: labels},
+					Spec:       infrav1.DOMachineSpec{ProviderID: pointer.StringPtr(fmt.Sprintf("digitalocean://%d", renamed.ID))},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo-md-3", Labels: labels},
+					Spec:       infrav1.DOMachineSpec{ProviderID: pointer.StringPtr(fmt.Sprintf("digitalocean://%d", moved.ID))},
+				},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(docluster)
+			for i := range objects {
+				builder = builder.WithObjects(&objects[i])
+			}
+			client := builder.Build()
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				DOClients: scope.DOClients{Droplets: c.Droplets, Tags: c.Tags},
+				Client:    client,
+				Cluster:   cluster,
+				DOCluster: docluster,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			recorder := record.NewFakeRecorder(10)
+			r := &DOClusterReconciler{
+				Client:              client,
+				Recorder:            recorder,
+				OrphanDropletPolicy: policy,
+				OrphanGracePeriod:   time.Nanosecond,
+			}
+			g.Expect(r.reconcileOrphans(ctx, clusterScope)).To(Succeed())
+
+			var names []string
+			for _, d := range s.Droplets() {
+				names = append(names, d.Name)
+				if d.ID == moved.ID {
+					g.Expect(infrav1.ClusterUIDsFromTags(d.Tags)).To(ConsistOf("uid"))
+				}
+			}
+			if policy == OrphanDropletPolicyDelete {
+				g.Expect(names).To(ConsistOf("foo-md-0", "renamed", "foo-md-3"))
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.OrphanedInstanceDeletedReason)))
+			} else {
+				g.Expect(names).To(ConsistOf("foo-md-0", "renamed", "foo-md-1", "foo-md-3"))
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(fmt.Sprintf("%s Droplet instance foo-md-1 (%d)", infrav1.OrphanedInstanceReason, orphan.ID))))
+			}
+			g.Expect(recorder.Events).NotTo(Receive())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// RateLimiterOptions configures the rate limiter of the workqueue of every
+// controller, i.e. how fast the objects whose reconcile failed are retried.
+type RateLimiterOptions struct {
+	// BaseDelay is the delay before the first retry of an object, doubled on
+	// each following failure.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two retries of an object.
+	MaxDelay time.Duration
+	// QPS and Burst bound the rate at which a controller dequeues objects.
+	QPS   float64
+	Burst int
+	// Jitter is the maximal fraction of the retry delay of an object added at
+	// random to it, so that the objects that failed together, e.g. during a
+	// DigitalOcean API outage, are not all retried at once.
+	Jitter float64
+}
+
+// DefaultRateLimiterOptions are the controller-runtime defaults, with jitter.
+var DefaultRateLimiterOptions = RateLimiterOptions{
+	BaseDelay: 5 * time.Millisecond,
+	MaxDelay:  1000 * time.Second,
+	QPS:       10,
+	Burst:     100,
+	Jitter:    0.5,
+}
+
+// Validate checks that the options describe a usable rate limiter.
+func (o RateLimiterOptions) Validate() error {
+	switch {
+	case o.BaseDelay <= 0:
+		return errors.New("base delay must be positive")
+	case o.MaxDelay < o.BaseDelay:
+		return errors.New("max delay must not be below the base delay")
+	case o.QPS <= 0 || o.Burst < 1:
+		return errors.New("QPS and burst must be positive")
+	case o.Jitter < 0 || o.Jitter > 1:
+		return errors.New("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// NewRateLimiter returns a new workqueue rate limiter. Each controller needs
+// its own.
+func NewRateLimiter(opts RateLimiterOptions) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		&jitterRateLimiter{
+			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(opts.BaseDelay, opts.MaxDelay),
+			jitter:      opts.Jitter,
+			maxDelay:    opts.MaxDelay,
+		},
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(opts.QPS), opts.Burst)},
+	)
+}
+
+// jitterRateLimiter adds up to jitter times the delay of its RateLimiter,
+// within maxDelay.
+type jitterRateLimiter struct {
+	workqueue.RateLimiter
+	jitter   float64
+	maxDelay time.Duration
+}
+
+func (r *jitterRateLimiter) When(item interface{}) time.Duration {
+	d := r.RateLimiter.When(item)
+	d += time.Duration(rand.Float64() * r.jitter * float64(d)) //nolint:gosec
+	if d > r.maxDelay {
+		return r.maxDelay
+	}
+	return d
+}
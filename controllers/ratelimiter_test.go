@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := RateLimiterOptions{BaseDelay: time.Second, MaxDelay: 10 * time.Second, QPS: 1000, Burst: 1000, Jitter: 0.5}
+	g.Expect(opts.Validate()).To(Succeed())
+	rl := NewRateLimiter(opts)
+
+	for i, base := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		d := rl.When("item")
+		g.Expect(d).To(BeNumerically(">=", base), "retry %d", i)
+		g.Expect(d).To(BeNumerically("<=", base+base/2), "retry %d", i)
+	}
+	g.Expect(rl.When("item")).To(Equal(10 * time.Second))
+	g.Expect(rl.NumRequeues("item")).To(Equal(5))
+
+	rl.Forget("item")
+	g.Expect(rl.When("item")).To(BeNumerically("<=", time.Second+time.Second/2))
+
+	opts.Jitter = 2
+	g.Expect(opts.Validate()).NotTo(Succeed())
+	g.Expect(DefaultRateLimiterOptions.Validate()).To(Succeed())
+}
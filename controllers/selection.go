@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Names of the controllers, as accepted by the --controllers flag.
+const (
+	DOClusterControllerName    = "docluster"
+	DOMachineControllerName    = "domachine"
+	DOReservedIPControllerName = "doreservedip"
+	DOVolumeControllerName     = "dovolume"
+	DOImageControllerName      = "doimage"
+	DOFirewallControllerName   = "dofirewall"
+	// DOMachineTemplateControllerName publishes the capacity of the
+	// DOMachineTemplates for the cluster-autoscaler.
+	DOMachineTemplateControllerName = "domachinetemplate"
+)
+
+// ControllerNames are the names of all the controllers of the manager.
+var ControllerNames = []string{
+	DOClusterControllerName,
+	DOMachineControllerName,
+	DOReservedIPControllerName,
+	DOVolumeControllerName,
+	DOImageControllerName,
+	DOFirewallControllerName,
+	DOMachineTemplateControllerName,
+}
+
+// EnabledControllers returns the set of the controllers enabled by selection,
+// a list of controller names where "*" stands for all the controllers and a
+// name prefixed with "-" disables that controller, e.g. "*,-dofirewall".
+func EnabledControllers(selection []string) (map[string]bool, error) {
+	known := make(map[string]bool, len(ControllerNames))
+	for _, name := range ControllerNames {
+		known[name] = true
+	}
+
+	enabled := map[string]bool{}
+	var disabled []string
+	for _, s := range selection {
+		s = strings.ToLower(strings.TrimSpace(s))
+		switch {
+		case s == "*":
+			for _, name := range ControllerNames {
+				enabled[name] = true
+			}
+		case strings.HasPrefix(s, "-") && known[s[1:]]:
+			disabled = append(disabled, s[1:])
+		case known[s]:
+			enabled[s] = true
+		default:
+			return nil, errors.Errorf("unknown controller %q, expected one of %s", s, strings.Join(ControllerNames, ", "))
+		}
+	}
+	// Disabling wins regardless of the order, e.g. "-dofirewall,*".
+	for _, name := range disabled {
+		delete(enabled, name)
+	}
+	return enabled, nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEnabledControllers(t *testing.T) {
+	testCases := []struct {
+		selection []string
+		want      []string
+		wantErr   bool
+	}{
+		{selection: []string{"*"}, want: ControllerNames},
+		{selection: []string{"docluster", "DOMachine"}, want: []string{DOClusterControllerName, DOMachineControllerName}},
+		{selection: []string{"-dofirewall", "*", "-doimage"}, want: []string{DOClusterControllerName, DOMachineControllerName, DOReservedIPControllerName, DOVolumeControllerName, DOMachineTemplateControllerName}},
+		{selection: []string{"dodroplet"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		g := NewWithT(t)
+		enabled, err := EnabledControllers(tc.selection)
+		if tc.wantErr {
+			g.Expect(err).To(HaveOccurred())
+			continue
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for name := range enabled {
+			names = append(names, name)
+		}
+		g.Expect(names).To(ConsistOf(tc.want))
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// DefaultStatusResyncInterval is how often the status of the DOClusters and
+// DOMachines is compared with their DigitalOcean resources.
+const DefaultStatusResyncInterval = 5 * time.Minute
+
+// StatusResyncer periodically lists the droplets and load balancers of the
+// DigitalOcean account and triggers a reconcile of the DOMachines and
+// DOClusters whose status no longer matches them, e.g. a droplet powered off
+// or a load balancer deleted in the DigitalOcean console. Unlike the drift
+// check of every object, a sweep costs a couple of list calls regardless of
+// the number of objects. It implements the manager Runnable interface.
+type StatusResyncer struct {
+	// Client lists the DOClusters and DOMachines.
+	Client client.Reader
+	Log    logr.Logger
+
+	// Interval is the delay between two sweeps.
+	Interval time.Duration
+	// Clusters and Machines receive the DOClusters and DOMachines to
+	// reconcile, see DOClusterReconciler.ResyncEvents. A nil channel disables
+	// the resync of the kind.
+	Clusters chan<- event.GenericEvent
+	Machines chan<- event.GenericEvent
+
+	// session returns the DigitalOcean client. Defaults to the session of the
+	// manager token.
+	session func() (*godo.Client, error)
+}
+
+// Start sweeps every Interval until ctx is done.
+func (s *StatusResyncer) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.Sweep(ctx); err != nil {
+			s.Log.Error(err, "Status resync from DigitalOcean failed")
+		}
+	}, s.Interval)
+	return nil
+}
+
+// NeedLeaderElection implements the manager LeaderElectionRunnable interface.
+func (s *StatusResyncer) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep triggers a reconcile of the objects whose status is stale once.
+func (s *StatusResyncer) Sweep(ctx context.Context) error {
+	session := s.session
+	if session == nil {
+		session = (&scope.DOClients{}).Session
+	}
+	c, err := session()
+	if err != nil {
+		return err
+	}
+	if s.Machines != nil {
+		if err := s.sweepMachines(ctx, c); err != nil {
+			return err
+		}
+	}
+	if s.Clusters != nil {
+		if err := s.sweepClusters(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StatusResyncer) sweepMachines(ctx context.Context, c *godo.Client) error {
+	machines := &infrav1.DOMachineList{}
+	if err := s.Client.List(ctx, machines); err != nil {
+		return errors.Wrap(err, "failed to list DOMachines")
+	}
+	if len(machines.Items) == 0 {
+		return nil
+	}
+
+	var droplets []godo.Droplet
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := c.Droplets.List(ctx, opt)
+		droplets = append(droplets, page...)
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list droplets")
+	}
+	status := make(map[string]string, len(droplets))
+	for _, d := range droplets {
+		status[strconv.Itoa(d.ID)] = d.Status
+	}
+
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		// Failed DOMachines are not reconciled anymore.
+		if !m.DeletionTimestamp.IsZero() || m.Spec.ProviderID == nil || m.Status.FailureReason != nil {
+			continue
+		}
+		providerID, err := noderefutil.NewProviderID(*m.Spec.ProviderID)
+		if err != nil {
+			continue
+		}
+		current, ok := status[providerID.ID()]
+		if ok && m.Status.InstanceStatus != nil && string(*m.Status.InstanceStatus) == current {
+			continue
+		}
+		s.Log.V(4).Info("Droplet status changed, resyncing DOMachine", "domachine", client.ObjectKeyFromObject(m), "droplet-status", current)
+		if !send(ctx, s.Machines, m) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *StatusResyncer) sweepClusters(ctx context.Context, c *godo.Client) error {
+	clusters := &infrav1.DOClusterList{}
+	if err := s.Client.List(ctx, clusters); err != nil {
+		return errors.Wrap(err, "failed to list DOClusters")
+	}
+	if len(clusters.Items) == 0 {
+		return nil
+	}
+
+	var lbs []godo.LoadBalancer
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := c.LoadBalancers.List(ctx, opt)
+		lbs = append(lbs, page...)
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list load balancers")
+	}
+	status := make(map[string]string, len(lbs))
+	for _, lb := range lbs {
+		status[lb.ID] = lb.Status
+	}
+
+	for i := range clusters.Items {
+		dc := &clusters.Items[i]
+		ref := dc.Status.Network.APIServerLoadbalancersRef
+		if !dc.DeletionTimestamp.IsZero() || ref.ResourceID == "" {
+			continue
+		}
+		current, ok := status[ref.ResourceID]
+		if ok && string(ref.ResourceStatus) == current {
+			continue
+		}
+		s.Log.V(4).Info("Load balancer status changed, resyncing DOCluster", "docluster", client.ObjectKeyFromObject(dc), "load-balancer-status", current)
+		if !send(ctx, s.Clusters, dc) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// send hands obj to the controller watching ch, it returns false once ctx is
+// done.
+func send(ctx context.Context, ch chan<- event.GenericEvent, obj client.Object) bool {
+	select {
+	case ch <- event.GenericEvent{Object: obj}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestStatusResyncerSweep(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c, err := godo.New(s.Client(), godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	active := infrav1.DOResourceStatusRunning
+	newMachine := func(name string) (*infrav1.DOMachine, int) {
+		d, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: name, Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		s.SetDropletStatus(d.ID, string(active))
+		return &infrav1.DOMachine{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       infrav1.DOMachineSpec{ProviderID: pointer.StringPtr(fmt.Sprintf("digitalocean://%d", d.ID))},
+			Status:     infrav1.DOMachineStatus{InstanceStatus: &active},
+		}, d.ID
+	}
+	unchanged, _ := newMachine("unchanged")
+	poweredOff, id := newMachine("powered-off")
+	s.SetDropletStatus(id, "off")
+
+	lb, _, err := c.LoadBalancers.Create(ctx, &godo.LoadBalancerRequest{
+		Name: "foo", Region: "nyc1", ForwardingRules: []godo.ForwardingRule{{EntryPort: 6443}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	s.SetLoadBalancerStatus(lb.ID, string(active))
+	docluster := &infrav1.DOCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo"}}
+	docluster.Status.Network.APIServerLoadbalancersRef = infrav1.DOResourceReference{ResourceID: lb.ID, ResourceStatus: active}
+	deleted := &infrav1.DOCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "deleted"}}
+	deleted.Status.Network.APIServerLoadbalancersRef = infrav1.DOResourceReference{ResourceID: "gone", ResourceStatus: infrav1.DOResourceStatusRunning}
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	clusters := make(chan event.GenericEvent, 10)
+	machines := make(chan event.GenericEvent, 10)
+	resyncer := &StatusResyncer{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(unchanged, poweredOff, docluster, deleted).Build(),
+		Log:      ctrl.Log,
+		Clusters: clusters,
+		Machines: machines,
+		session:  func() (*godo.Client, error) { return c, nil },
+	}
+	g.Expect(resyncer.Sweep(ctx)).To(Succeed())
+
+	names := func(ch chan event.GenericEvent) []string {
+		close(ch)
+		var names []string
+		for e := range ch {
+			names = append(names, e.Object.GetName())
+		}
+		return names
+	}
+	g.Expect(names(machines)).To(ConsistOf("powered-off"))
+	g.Expect(names(clusters)).To(ConsistOf("deleted"))
+}
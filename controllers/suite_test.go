@@ -17,14 +17,22 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	// +kubebuilder:scaffold:imports
-	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
 
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -32,7 +40,9 @@ import (
 	"k8s.io/klog/v2/klogr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -45,6 +55,20 @@ var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
 
+// doServer is the fake DigitalOcean API the controllers of the suite talk to.
+var doServer *fakedo.Server
+
+// doImage and doSSHKey are the image and key of the fake account, to be used
+// by the DOMachines of the suite.
+var doImage godo.Image
+var doSSHKey godo.Key
+
+var stopManager context.CancelFunc
+
+// integrationRequeueAfter replaces the requeue delays of the controllers, so
+// that the specs wait for droplets and load balancers in seconds.
+const integrationRequeueAfter = 200 * time.Millisecond
+
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -60,7 +84,11 @@ var _ = BeforeSuite(func(done Done) {
 
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
-		CRDDirectoryPaths: []string{filepath.Join("..", "config", "crd", "bases")},
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "config", "crd", "bases"),
+			clusterAPICRDs(),
+		},
+		ErrorIfCRDPathMissing: true,
 	}
 
 	var err error
@@ -78,10 +106,55 @@ var _ = BeforeSuite(func(done Done) {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(k8sClient).ToNot(BeNil())
 
+	By("starting the fake DigitalOcean API")
+	doServer = fakedo.NewServer(fakedo.Options{DropletBootPolls: 1, LoadBalancerBootPolls: 1})
+	doImage = doServer.AddImage(godo.Image{Name: "ubuntu-2004-kube-v1.21.2", Regions: []string{"nyc1"}})
+	doSSHKey = doServer.AddSSHKey(godo.Key{Name: "capdo"})
+	Expect(scope.InitSessions(scope.SessionOptions{APIURL: doServer.URL})).To(Succeed())
+	scope.SetAccessToken("token")
+
+	By("starting the controllers")
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: scheme.Scheme, MetricsBindAddress: "0"})
+	Expect(err).ToNot(HaveOccurred())
+	var ctx context.Context
+	ctx, stopManager = context.WithCancel(context.Background())
+	Expect((&DOClusterReconciler{
+		Client:                   mgr.GetClient(),
+		Recorder:                 mgr.GetEventRecorderFor("docluster-controller"),
+		LoadBalancerRequeueAfter: integrationRequeueAfter,
+		DriftCheckInterval:       integrationRequeueAfter,
+	}).SetupWithManager(ctx, mgr, controller.Options{})).To(Succeed())
+	Expect((&DOMachineReconciler{
+		Client:              mgr.GetClient(),
+		Recorder:            mgr.GetEventRecorderFor("domachine-controller"),
+		DropletRequeueAfter: integrationRequeueAfter,
+		DriftCheckInterval:  integrationRequeueAfter,
+	}).SetupWithManager(ctx, mgr, controller.Options{})).To(Succeed())
+	go func() {
+		defer GinkgoRecover()
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+
 	close(done)
 }, 60)
 
 var _ = AfterSuite(func() {
 	By("tearing down the test environment")
+	if stopManager != nil {
+		stopManager()
+	}
+	if doServer != nil {
+		doServer.Close()
+		scope.SetAccessToken("")
+		Expect(scope.InitSessions(scope.SessionOptions{})).To(Succeed())
+	}
 	Expect(testEnv.Stop()).To(Succeed())
 })
+
+// clusterAPICRDs returns the directory of the Cluster API CRDs in the module
+// cache, the controllers read the Clusters and Machines owning their objects.
+func clusterAPICRDs() string {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "sigs.k8s.io/cluster-api").Output()
+	Expect(err).ToNot(HaveOccurred(), "failed to locate the sigs.k8s.io/cluster-api module")
+	return filepath.Join(strings.TrimSpace(string(out)), "config", "crd", "bases")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultTokenFileInterval is how often the DigitalOcean token file is read
+// again.
+const DefaultTokenFileInterval = 10 * time.Second
+
+// ReadTokenFile returns the DigitalOcean token held by the file path, e.g. a
+// projected Secret volume or a file rendered by the Vault agent.
+func ReadTokenFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read DigitalOcean token file")
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", errors.Errorf("DigitalOcean token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// TokenFileWatcher reads the file holding the DigitalOcean token of the
+// manager every Interval and switches the sessions to the new token when the
+// file changed, so that a rotation does not require a restart of the manager.
+// It implements the manager Runnable interface.
+type TokenFileWatcher struct {
+	// Path is the file holding the DigitalOcean token.
+	Path string
+	Log  logr.Logger
+	// Interval is the delay between two reads of the file. Defaults to
+	// DefaultTokenFileInterval.
+	Interval time.Duration
+
+	// rejected is the last token that could not be used, it is not validated
+	// again until the file changes.
+	rejected string
+	// validate checks a token before switching to it. Defaults to scope.ValidateAccessToken.
+	validate func(ctx context.Context, accessToken string) error
+	// probeScopes probes the scopes of a token once switched to. Defaults to
+	// ProbeTokenScopes.
+	probeScopes func(ctx context.Context, log logr.Logger)
+}
+
+// Start reloads the token every Interval until ctx is done.
+func (w *TokenFileWatcher) Start(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultTokenFileInterval
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := w.Reload(ctx); err != nil {
+			w.Log.Error(err, "Failed to reload DigitalOcean token file", "path", w.Path)
+		}
+	}, interval)
+	return nil
+}
+
+// NeedLeaderElection implements the manager LeaderElectionRunnable interface.
+// Every replica uses the token, e.g. to serve the webhooks.
+func (w *TokenFileWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload switches to the token of the file once when it changed and can be
+// used. A token that can not be used is logged and the current one is kept.
+func (w *TokenFileWatcher) Reload(ctx context.Context) error {
+	token, err := ReadTokenFile(w.Path)
+	if err != nil {
+		return err
+	}
+	if token == scope.AccessToken() || token == w.rejected {
+		return nil
+	}
+
+	validate := w.validate
+	if validate == nil {
+		validate = func(ctx context.Context, accessToken string) error {
+			_, err := scope.ValidateAccessToken(ctx, accessToken)
+			return err
+		}
+	}
+	if err := validate(ctx, token); err != nil {
+		if doclient.IsInvalidCredentials(err) {
+			w.rejected = token
+			w.Log.Error(err, "Keeping the current DigitalOcean token", "path", w.Path)
+			return nil
+		}
+		return errors.Wrap(err, "failed to validate rotated DigitalOcean token")
+	}
+
+	scope.SetAccessToken(token)
+	w.Log.Info("Switched to rotated DigitalOcean token", "path", w.Path)
+	probeScopes := w.probeScopes
+	if probeScopes == nil {
+		probeScopes = ProbeTokenScopes
+	}
+	probeScopes(ctx, w.Log)
+	return nil
+}
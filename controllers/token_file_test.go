@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+func TestTokenFileWatcherReload(t *testing.T) {
+	g := NewWithT(t)
+	defer scope.SetAccessToken("")
+	scope.SetAccessToken("old")
+
+	path := filepath.Join(t.TempDir(), "token")
+	g.Expect(ioutil.WriteFile(path, []byte("old\n"), 0600)).To(Succeed())
+	var validated []string
+	var validateErr error
+	probed := 0
+	w := &TokenFileWatcher{
+		Path: path,
+		Log:  logr.Discard(),
+		validate: func(ctx context.Context, accessToken string) error {
+			validated = append(validated, accessToken)
+			return validateErr
+		},
+		probeScopes: func(context.Context, logr.Logger) { probed++ },
+	}
+	ctx := context.Background()
+
+	g.Expect(w.Reload(ctx)).To(Succeed())
+	g.Expect(validated).To(BeEmpty())
+
+	// A revoked token is kept out, and not validated again until the file
+	// changes.
+	validateErr = &doclient.InvalidCredentialsError{Message: "revoked"}
+	g.Expect(ioutil.WriteFile(path, []byte("revoked"), 0600)).To(Succeed())
+	g.Expect(w.Reload(ctx)).To(Succeed())
+	g.Expect(w.Reload(ctx)).To(Succeed())
+	g.Expect(validated).To(Equal([]string{"revoked"}))
+	g.Expect(scope.AccessToken()).To(Equal("old"))
+
+	validateErr = nil
+	g.Expect(ioutil.WriteFile(path, []byte("new\n"), 0600)).To(Succeed())
+	g.Expect(w.Reload(ctx)).To(Succeed())
+	g.Expect(scope.AccessToken()).To(Equal("new"))
+	g.Expect(probed).To(Equal(1))
+
+	// An emptied file, e.g. while it is being rendered, keeps the current token.
+	g.Expect(ioutil.WriteFile(path, nil, 0600)).To(Succeed())
+	g.Expect(w.Reload(ctx)).NotTo(Succeed())
+	g.Expect(scope.AccessToken()).To(Equal("new"))
+}
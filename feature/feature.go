@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package feature implements the feature gates of the provider.
+package feature
+
+import (
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// Every feature gate should add method here following this template:
+	//
+	// // MyFeature is a feature gate for the MyFeature functionality.
+	// //
+	// // alpha: v0.X
+	// MyFeature featuregate.Feature = "MyFeature"
+
+	// ReservedIP is a feature gate for the DOReservedIP functionality.
+	//
+	// alpha: v0.5
+	ReservedIP featuregate.Feature = "ReservedIP"
+
+	// Volume is a feature gate for the DOVolume functionality and the
+	// volumeRefs of DOMachines.
+	//
+	// alpha: v0.5
+	Volume featuregate.Feature = "Volume"
+
+	// GoldenImage is a feature gate for the DOImage functionality and the
+	// imageRef of DOMachines.
+	//
+	// alpha: v0.5
+	GoldenImage featuregate.Feature = "GoldenImage"
+
+	// Firewall is a feature gate for the DOFirewall functionality and the
+	// firewallRefs of DOClusters and DOMachines.
+	//
+	// alpha: v0.5
+	Firewall featuregate.Feature = "Firewall"
+)
+
+func init() {
+	runtime.Must(MutableGates.Add(defaultCAPDOFeatureGates))
+}
+
+// defaultCAPDOFeatureGates consists of all known provider-specific feature keys.
+// To add a new feature, define a key for it above and add it here.
+var defaultCAPDOFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	// Every feature should be initiated here:
+	ReservedIP:  {Default: false, PreRelease: featuregate.Alpha},
+	Volume:      {Default: false, PreRelease: featuregate.Alpha},
+	GoldenImage: {Default: false, PreRelease: featuregate.Alpha},
+	Firewall:    {Default: false, PreRelease: featuregate.Alpha},
+}
@@ -14,179 +14,234 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// do-janitor deletes the DigitalOcean resources leaked by the e2e tests, e.g.
+// by aborted CI runs: the resources of the e2e clusters older than
+// --max-age.
 package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/digitalocean/godo"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
 )
 
-const timeToCleanInHours = 12
+var (
+	maxAge          = flag.Duration("max-age", 12*time.Hour, "Minimum age of the resources deleted, e.g. 12h. Keep it above the duration of an e2e run.")
+	clusterPrefixes = flag.String("cluster-prefixes", "capdo-e2e-,capdo-conf-", "Comma separated prefixes of the names of the e2e clusters whose resources are deleted")
+	dryRun          = flag.Bool("dry-run", false, "Only log the resources that would be deleted")
+)
 
 func main() {
+	flag.Parse()
 	log.Println("Starting DO Janitor")
 	token := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
 	if token == "" {
 		log.Fatal("missing DO token")
 	}
 
-	ctx := context.Background()
-	client := godo.NewFromToken(token)
-
-	droplets, err := dropletList(ctx, client)
-	if err != nil {
-		log.Fatalf("failed to list droplets: %+v", err.Error())
+	j := &janitor{
+		ctx:      context.Background(),
+		client:   godo.NewFromToken(token),
+		prefixes: strings.Split(*clusterPrefixes, ","),
+		deadline: time.Now().Add(-*maxAge),
+		dryRun:   *dryRun,
 	}
+	// Droplets go first, so that their volumes are detached and their VPCs
+	// emptied when these are cleaned.
+	j.cleanDroplets()
+	j.cleanLoadBalancers()
+	j.cleanVolumes()
+	j.cleanSnapshots()
+	j.cleanVPCs()
+
+	if j.failures > 0 {
+		log.Fatalf("Completed DO Janitor, %d resources could not be deleted", j.failures)
+	}
+	log.Println("Completed DO Janitor")
+}
 
-	for _, droplet := range droplets {
-		dropletCreated, err := time.Parse(time.RFC3339, droplet.Created)
-		if err != nil {
-			log.Fatalf("failed to parse time: %+v", err.Error())
-		}
+type janitor struct {
+	ctx      context.Context
+	client   *godo.Client
+	prefixes []string
+	// deadline is the creation time after which resources are kept.
+	deadline time.Time
+	dryRun   bool
+	failures int
+}
 
-		hours := time.Since(dropletCreated).Hours()
-		if hours >= timeToCleanInHours {
-			log.Printf("%s is older than %d hours will terminate\n", droplet.Name, timeToCleanInHours)
-			_, err := client.Droplets.Delete(ctx, droplet.ID)
-			if err != nil {
-				log.Printf("failed to delete droplet %s: %+v\n", droplet.Name, err.Error())
-				continue
+// owned returns whether tags carry the cluster name tag of an e2e cluster.
+func (j *janitor) owned(tags []string) bool {
+	for _, tag := range tags {
+		for _, prefix := range j.prefixes {
+			if prefix != "" && strings.HasPrefix(tag, infrav1.ClusterNameTag(prefix)) {
+				return true
 			}
-
-			log.Printf("droplet %s terminated\n", droplet.Name)
 		}
 	}
+	return false
+}
 
-	lbs, err := lbList(ctx, client)
-	if err != nil {
-		log.Fatalf("failed to list LoadBalancers: %+v", err.Error())
-	}
-
-	for _, lb := range lbs {
-		lbCreated, err := time.Parse(time.RFC3339, lb.Created)
-		if err != nil {
-			log.Fatalf("failed to parse time: %+v", err.Error())
-		}
-
-		hours := time.Since(lbCreated).Hours()
-		if hours >= timeToCleanInHours {
-			log.Printf("%s is older than %d hours will terminate\n", lb.Name, timeToCleanInHours)
-			_, err := client.LoadBalancers.Delete(ctx, lb.ID)
-			if err != nil {
-				log.Printf("failed to delete droplet %s: %+v\n", lb.Name, err.Error())
-				continue
-			}
-
-			log.Printf("droplet %s terminated\n", lb.Name)
+// named returns whether name is derived from the name of an e2e cluster.
+func (j *janitor) named(name string) bool {
+	for _, prefix := range j.prefixes {
+		if prefix != "" && strings.HasPrefix(name, prefix) {
+			return true
 		}
 	}
+	return false
+}
 
-	volumes, err := volumeList(ctx, client)
+// expired returns whether a resource created at created, as formatted by
+// the DigitalOcean API, is older than --max-age.
+func (j *janitor) expired(kind, name, created string) bool {
+	t, err := time.Parse(time.RFC3339, created)
 	if err != nil {
-		log.Fatalf("failed to list volumes: %+v", err.Error())
+		log.Printf("failed to parse the creation time of %s %s: %v\n", kind, name, err)
+		return false
 	}
+	return t.Before(j.deadline)
+}
 
-	for _, volume := range volumes {
-		hours := time.Since(volume.CreatedAt).Hours()
-		if hours >= timeToCleanInHours && len(volume.DropletIDs) < 1 {
-			log.Printf("%s is older than %d hours will terminate\n", volume.Name, timeToCleanInHours)
-			_, err := client.Storage.DeleteVolume(ctx, volume.ID)
-			if err != nil {
-				log.Printf("failed to delete volume %s: %+v\n", volume.Name, err.Error())
-				continue
-			}
-
-			log.Printf("volume %s terminated\n", volume.Name)
-		}
+func (j *janitor) delete(kind, name string, del func() (*godo.Response, error)) {
+	if j.dryRun {
+		log.Printf("would delete %s %s\n", kind, name)
+		return
 	}
-
-	log.Println("Completed DO Janitor")
-	os.Exit(0)
+	if _, err := del(); err != nil {
+		log.Printf("failed to delete %s %s: %v\n", kind, name, err)
+		j.failures++
+		return
+	}
+	log.Printf("deleted %s %s\n", kind, name)
 }
 
-func dropletList(ctx context.Context, client *godo.Client) ([]godo.Droplet, error) {
-	list := []godo.Droplet{}
-
-	// create options. initially, these will be blank
-	opt := &godo.ListOptions{}
-	for {
-		droplets, resp, err := client.Droplets.List(ctx, opt)
-		if err != nil {
-			return nil, err
-		}
+func (j *janitor) list(kind string, list func(opt *godo.ListOptions) (*godo.Response, error)) bool {
+	if err := doclient.ListAll(nil, list); err != nil {
+		log.Printf("failed to list %ss: %v\n", kind, err)
+		j.failures++
+		return false
+	}
+	return true
+}
 
-		list = append(list, droplets...)
+func (j *janitor) cleanDroplets() {
+	var droplets []godo.Droplet
+	if !j.list("droplet", func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := j.client.Droplets.List(j.ctx, opt)
+		droplets = append(droplets, page...)
+		return resp, err
+	}) {
+		return
+	}
+	var ips []godo.FloatingIP
+	if !j.list("reserved IP", func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := j.client.FloatingIPs.List(j.ctx, opt)
+		ips = append(ips, page...)
+		return resp, err
+	}) {
+		return
+	}
 
-		if resp.Links == nil || resp.Links.IsLastPage() {
-			break
+	for _, droplet := range droplets {
+		droplet := droplet
+		if !j.owned(droplet.Tags) || !j.expired("droplet", droplet.Name, droplet.Created) {
+			continue
 		}
-
-		page, err := resp.Links.CurrentPage()
-		if err != nil {
-			return nil, err
+		// Reserved IPs carry no tags, they are released with their droplet
+		// rather than left unassigned.
+		for _, ip := range ips {
+			if ip.Droplet != nil && ip.Droplet.ID == droplet.ID {
+				ip := ip
+				j.delete("reserved IP", ip.IP, func() (*godo.Response, error) { return j.client.FloatingIPs.Delete(j.ctx, ip.IP) })
+			}
 		}
-
-		opt.Page = page + 1
+		j.delete("droplet", droplet.Name, func() (*godo.Response, error) { return j.client.Droplets.Delete(j.ctx, droplet.ID) })
 	}
-
-	return list, nil
 }
 
-func lbList(ctx context.Context, client *godo.Client) ([]godo.LoadBalancer, error) {
-	list := []godo.LoadBalancer{}
-
-	opt := &godo.ListOptions{}
-	for {
-		lbs, resp, err := client.LoadBalancers.List(ctx, opt)
-		if err != nil {
-			return nil, err
+func (j *janitor) cleanLoadBalancers() {
+	var lbs []godo.LoadBalancer
+	if !j.list("load balancer", func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := j.client.LoadBalancers.List(j.ctx, opt)
+		lbs = append(lbs, page...)
+		return resp, err
+	}) {
+		return
+	}
+	for _, lb := range lbs {
+		lb := lb
+		if !(j.owned(lb.Tags) || j.named(lb.Name)) || !j.expired("load balancer", lb.Name, lb.Created) {
+			continue
 		}
+		j.delete("load balancer", lb.Name, func() (*godo.Response, error) { return j.client.LoadBalancers.Delete(j.ctx, lb.ID) })
+	}
+}
 
-		list = append(list, lbs...)
-
-		if resp.Links == nil || resp.Links.IsLastPage() {
-			break
+func (j *janitor) cleanVolumes() {
+	var volumes []godo.Volume
+	if !j.list("volume", func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := j.client.Storage.ListVolumes(j.ctx, &godo.ListVolumeParams{ListOptions: opt})
+		volumes = append(volumes, page...)
+		return resp, err
+	}) {
+		return
+	}
+	for _, volume := range volumes {
+		volume := volume
+		if !(j.owned(volume.Tags) || j.named(volume.Name)) || !volume.CreatedAt.Before(j.deadline) {
+			continue
 		}
-
-		page, err := resp.Links.CurrentPage()
-		if err != nil {
-			return nil, err
+		if len(volume.DropletIDs) > 0 {
+			log.Printf("skipping volume %s attached to droplets %v\n", volume.Name, volume.DropletIDs)
+			continue
 		}
-
-		opt.Page = page + 1
+		j.delete("volume", volume.Name, func() (*godo.Response, error) { return j.client.Storage.DeleteVolume(j.ctx, volume.ID) })
 	}
-
-	return list, nil
 }
 
-func volumeList(ctx context.Context, client *godo.Client) ([]godo.Volume, error) {
-	list := []godo.Volume{}
-
-	// create options. initially, these will be blank
-	opt := &godo.ListVolumeParams{}
-	for {
-		volumes, resp, err := client.Storage.ListVolumes(ctx, opt)
-		if err != nil {
-			return nil, err
-		}
-
-		list = append(list, volumes...)
-
-		if resp.Links == nil || resp.Links.IsLastPage() {
-			break
+func (j *janitor) cleanSnapshots() {
+	var snapshots []godo.Snapshot
+	if !j.list("snapshot", func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := j.client.Snapshots.List(j.ctx, opt)
+		snapshots = append(snapshots, page...)
+		return resp, err
+	}) {
+		return
+	}
+	for _, snapshot := range snapshots {
+		snapshot := snapshot
+		if !(j.owned(snapshot.Tags) || j.named(snapshot.Name)) || !j.expired("snapshot", snapshot.Name, snapshot.Created) {
+			continue
 		}
+		j.delete("snapshot", snapshot.Name, func() (*godo.Response, error) { return j.client.Snapshots.Delete(j.ctx, snapshot.ID) })
+	}
+}
 
-		page, err := resp.Links.CurrentPage()
-		if err != nil {
-			return nil, err
+func (j *janitor) cleanVPCs() {
+	var vpcs []*godo.VPC
+	if !j.list("VPC", func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := j.client.VPCs.List(j.ctx, opt)
+		vpcs = append(vpcs, page...)
+		return resp, err
+	}) {
+		return
+	}
+	for _, vpc := range vpcs {
+		vpc := vpc
+		// VPCs carry no tags.
+		if vpc.Default || !j.named(vpc.Name) || !vpc.CreatedAt.Before(j.deadline) {
+			continue
 		}
-
-		opt.ListOptions.Page = page + 1
+		j.delete("VPC", vpc.Name+" ("+strconv.Quote(vpc.ID)+")", func() (*godo.Response, error) { return j.client.VPCs.Delete(j.ctx, vpc.ID) })
 	}
-
-	return list, nil
 }
@@ -17,38 +17,53 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
-	"net/http"
-	_ "net/http/pprof" //nolint
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	// +kubebuilder:scaffold:imports
 
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/klogr"
 
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha3"
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha4"
+	infrav1beta1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/cloud/validation"
 	"sigs.k8s.io/cluster-api-provider-digitalocean/controllers"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/feature"
 	dnsutil "sigs.k8s.io/cluster-api-provider-digitalocean/util/dns"
 	dnsresolver "sigs.k8s.io/cluster-api-provider-digitalocean/util/dns/resolver"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+	healthcheck "sigs.k8s.io/cluster-api-provider-digitalocean/util/healthz"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/profiler"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+	zapOpts  = zap.Options{}
 )
 
 func init() {
@@ -57,7 +72,9 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = infrav1alpha3.AddToScheme(scheme)
 	_ = infrav1alpha4.AddToScheme(scheme)
+	_ = infrav1beta1.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
+	_ = addonsv1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -65,59 +82,254 @@ var (
 	metricsAddr             string
 	enableLeaderElection    bool
 	leaderElectionNamespace string
+	leaderElectionName      string
+	leaderElectionLease     time.Duration
+	leaderElectionRenew     time.Duration
+	leaderElectionRetry     time.Duration
 	healthAddr              string
 	watchNamespace          string
 	profilerAddress         string
+	profilerAllowRemote     bool
+	otlpEndpoint            string
+	otlpInsecure            bool
+	traceSampleRatio        float64
 	syncPeriod              time.Duration
 	webhookPort             int
+	doAPIURL                string
+	doAPICABundle           string
+	doAPIDebug              bool
+	doCatalogTTL            time.Duration
+	doAPIRequestTimeout     time.Duration
+	doClusterRequestRate    int
+	doClusterRequestBurst   int
+	doReconcileTimeout      time.Duration
+	shutdownGracePeriod     time.Duration
+	dropletRequeueAfter     time.Duration
+	lbRequeueAfter          time.Duration
+	dnsRequeueAfter         time.Duration
+	timeoutRequeueAfter     time.Duration
+	blockedRequeueAfter     time.Duration
+	rateLimiterOptions      controllers.RateLimiterOptions
+	deletionConcurrency     int
+	driftCheckInterval      time.Duration
+	orphanDropletPolicy     string
+	orphanGracePeriod       time.Duration
+	enabledControllers      []string
+	statusResyncInterval    time.Duration
+	gcInterval              time.Duration
+	gcTTL                   time.Duration
+	gcDryRun                bool
+	capacityMetricsInterval time.Duration
+	watchFilterValue        string
+	credentialsSecret       string
+	credentialsSecretKey    string
+	doTokenFile             string
+	webhookCertDir          string
+	webhookLiveValidation   bool
+	dropletQuotaGuard       string
+	tlsMinVersion           string
 )
 
 func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	fs.BoolVar(&enableLeaderElection, "enable-leader-election", false, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	fs.StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "Namespace that the controller performs leader election in. If unspecified, the controller will discover which namespace it is running in.")
+	fs.StringVar(&leaderElectionName, "leader-election-id", "", "Name of the resource that the controller uses for leader election. Defaults to controller-leader-election-capdo, suffixed with the --watch-filter value when set.")
+	fs.DurationVar(&leaderElectionLease, "leader-elect-lease-duration", 15*time.Second, "Interval at which non-leader candidates will wait to force acquire leadership (duration string)")
+	fs.DurationVar(&leaderElectionRenew, "leader-elect-renew-deadline", 10*time.Second, "Duration that the leading controller manager will retry refreshing leadership before giving up (duration string)")
+	fs.DurationVar(&leaderElectionRetry, "leader-elect-retry-period", 2*time.Second, "Duration the LeaderElector clients should wait between tries of actions (duration string)")
 	fs.StringVar(&healthAddr, "health-addr", ":9440", "The address the health endpoint binds to.")
 	fs.StringVar(&watchNamespace, "namespace", "", "Namespace that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
-	fs.StringVar(&profilerAddress, "profiler-address", "", "Bind address to expose the pprof profiler (e.g. localhost:6060)")
+	fs.StringVar(&watchFilterValue, "watch-filter", "", fmt.Sprintf("Label value that the controller watches to reconcile cluster-api objects. Label key is always %s. If unspecified, the controller watches for all cluster-api objects.", clusterv1.WatchLabel))
+	fs.StringVar(&profilerAddress, "profiler-address", "", "Bind address to expose the pprof profiles and runtime metrics under /debug/pprof/ and /debug/vars (e.g. localhost:6060). Disabled by default; use kubectl port-forward to reach it.")
+	fs.BoolVar(&profilerAllowRemote, "profiler-allow-remote", false, "Allow --profiler-address to listen on non-loopback interfaces. The profiler is not authenticated.")
+	fs.StringVar(&otlpEndpoint, "otlp-endpoint", "", "host:port of the OTLP gRPC collector the traces of the reconciles and DigitalOcean API calls are exported to (e.g. otel-collector.observability:4317). Tracing is disabled by default.")
+	fs.BoolVar(&otlpInsecure, "otlp-insecure", false, "Connect to --otlp-endpoint without TLS, e.g. to a collector sidecar")
+	fs.Float64Var(&traceSampleRatio, "trace-sample-ratio", 1, "Fraction of the reconciles traced when --otlp-endpoint is set, between 0 and 1")
+	fs.StringVar(&infrav1beta1.DefaultRegion, "default-region", "", "DigitalOcean region of the DOClusters created without one (e.g. nyc1). The region is required when unset.")
+	fs.StringVar(&infrav1beta1.DefaultMachineSize, "default-machine-size", infrav1beta1.DefaultMachineSize, "Droplet size of the DOMachines and DOMachineTemplates created without one")
+	fs.StringVar(&infrav1beta1.ImageLookupFormat, "image-lookup-format", infrav1beta1.ImageLookupFormat, "Go template of the name of the image of the account used by the DOMachines created with neither image nor imageRef, rendered with {{.BaseOS}} and the {{.K8sVersion}} of the Machine. The image is required when empty.")
+	fs.StringVar(&infrav1beta1.ImageLookupBaseOS, "image-lookup-base-os", infrav1beta1.ImageLookupBaseOS, "BaseOS the --image-lookup-format is rendered with")
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Minute, "The minimum interval at which watched resources are reconciled (e.g. 10m)")
-	fs.IntVar(&webhookPort, "webhook-port", 9443, "Webhook Server port, disabled by default. When enabled, the manager will only work as webhook server, no reconcilers are installed.")
+	fs.IntVar(&webhookPort, "webhook-port", 9443, "Webhook Server port. 0 disables the webhook server, e.g. to run the manager out of the cluster with --kubeconfig while the webhooks are not deployed.")
+	fs.BoolVar(&webhookLiveValidation, "webhook-live-validation", false, "Check in the validation webhooks that the regions, sizes, images, SSH keys and VPCs of the DOClusters, DOMachines and DOMachineTemplates being created exist in DigitalOcean, using the cached catalogs. The checks are skipped when the DigitalOcean API can not be reached.")
+	fs.StringVar(&dropletQuotaGuard, "droplet-quota-guard", string(validation.QuotaGuardWarn), "What to do with the MachineDeployments of DOMachines whose droplets would go over the droplet limit of the DigitalOcean account: 'warn' on admission, 'deny' them or 'off'")
+	fs.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "Directory holding the tls.crt and tls.key of the webhook server. They are reloaded when they change, e.g. when cert-manager renews them.")
+	fs.StringVar(&tlsMinVersion, "tls-min-version", "1.2", "Minimum TLS version accepted by the webhook server, 1.2 or 1.3")
+	fs.StringVar(&doAPIURL, "do-api-url", "", "Override the DigitalOcean API base URL, e.g. to target a mock or a proxy. Defaults to the DIGITALOCEAN_API_URL env var, then https://api.digitalocean.com/.")
+	fs.StringVar(&doAPICABundle, "do-api-ca-bundle", "", "Path to a PEM encoded CA bundle trusted in addition to the system roots when calling the DigitalOcean API, e.g. for TLS-intercepting proxies. Defaults to the DIGITALOCEAN_CA_BUNDLE env var.")
+	fs.BoolVar(&doAPIDebug, "do-api-debug", false, "Log every DigitalOcean API call with its status, request ID and rate limit counters. Sensitive request fields are redacted. Logged at verbosity 4, so requires --zap-log-level=4 or higher.")
+	fs.DurationVar(&doCatalogTTL, "do-catalog-ttl", 10*time.Minute, "How long the DigitalOcean regions, sizes, images and SSH keys catalogs, and the existing tags, are cached (e.g. 10m)")
+	fs.DurationVar(&doAPIRequestTimeout, "do-api-request-timeout", doclient.DefaultRequestTimeout, "Timeout of a single DigitalOcean API request, including reading its response (e.g. 30s). Timed out requests are retried when safe.")
+	fs.IntVar(&doClusterRequestRate, "do-cluster-request-rate", doclient.DefaultClusterRequestRate, "DigitalOcean API requests per hour each cluster may send once less than half of the rate limit budget of its token is left, so that a single cluster can not starve the others sharing the token. Cluster consumption is exported as capdo_digitalocean_api_cluster_requests_total.")
+	fs.IntVar(&doClusterRequestBurst, "do-cluster-request-burst", doclient.DefaultClusterRequestBurst, "DigitalOcean API requests each cluster may send at once on top of --do-cluster-request-rate")
+	fs.DurationVar(&doReconcileTimeout, "do-reconcile-timeout", controllers.DefaultReconcileTimeout, "Time budget of the DigitalOcean API calls of a single reconcile (e.g. 2m). A reconcile running out of time is requeued rather than failed.")
+	fs.DurationVar(&shutdownGracePeriod, "shutdown-grace-period", controllers.DefaultShutdownGracePeriod, "How long the DigitalOcean API calls in flight, e.g. droplet creations, may complete once the manager is stopped (e.g. 30s). Keep it below the terminationGracePeriodSeconds of the manager Pod.")
+	fs.DurationVar(&dropletRequeueAfter, "droplet-requeue-after", controllers.DefaultDropletRequeueAfter, "Delay between two checks of a droplet waiting to become active (e.g. 10s)")
+	fs.DurationVar(&lbRequeueAfter, "load-balancer-requeue-after", controllers.DefaultLoadBalancerRequeueAfter, "Delay between two checks of a load balancer waiting for its IP address (e.g. 15s)")
+	fs.DurationVar(&dnsRequeueAfter, "dns-requeue-after", controllers.DefaultDNSRequeueAfter, "Delay between two checks of the propagation of the control plane DNS record (e.g. 10s)")
+	fs.DurationVar(&timeoutRequeueAfter, "timeout-requeue-after", controllers.DefaultTimeoutRequeueAfter, "Delay before retrying a reconcile that ran out of its DigitalOcean API time budget (e.g. 30s)")
+	fs.DurationVar(&blockedRequeueAfter, "blocked-requeue-after", controllers.DefaultBlockedRequeueAfter, "Delay before retrying a reconcile blocked by a DigitalOcean error that must be fixed outside of the provider, e.g. an exceeded quota or an invalid token (e.g. 5m)")
+	fs.DurationVar(&rateLimiterOptions.BaseDelay, "rate-limiter-base-delay", controllers.DefaultRateLimiterOptions.BaseDelay, "Delay before retrying a failed reconcile, doubled on each following failure of the same object (e.g. 5ms)")
+	fs.DurationVar(&rateLimiterOptions.MaxDelay, "rate-limiter-max-delay", controllers.DefaultRateLimiterOptions.MaxDelay, "Maximum delay between two retries of a failed reconcile (e.g. 1000s)")
+	fs.Float64Var(&rateLimiterOptions.QPS, "rate-limiter-qps", controllers.DefaultRateLimiterOptions.QPS, "Overall rate at which each controller dequeues objects, retries included")
+	fs.IntVar(&rateLimiterOptions.Burst, "rate-limiter-burst", controllers.DefaultRateLimiterOptions.Burst, "Burst of --rate-limiter-qps")
+	fs.Float64Var(&rateLimiterOptions.Jitter, "rate-limiter-jitter", controllers.DefaultRateLimiterOptions.Jitter, "Maximum fraction of the retry delay of a failed reconcile added at random, so that objects failing together, e.g. during a DigitalOcean API outage, are not retried at once (between 0 and 1)")
+	fs.IntVar(&deletionConcurrency, "deletion-concurrency", 1, "Number of DOClusters and of DOMachines being deleted reconciled at once by controllers of their own, so that teardown does not wait behind the creations and retries queued in the main controllers. 0 reconciles them in the main controllers.")
+	fs.DurationVar(&driftCheckInterval, "drift-check-interval", controllers.DefaultDriftCheckInterval, "Interval at which ready DOClusters and DOMachines are compared with their DigitalOcean resources to detect and revert changes made outside of the provider (e.g. 10m)")
+	fs.StringVar(&orphanDropletPolicy, "orphan-droplet-policy", string(controllers.OrphanDropletPolicyAdopt), "What to do with droplets tagged for a cluster but referenced by no DOMachine: 'adopt' droplets named after a DOMachine without droplet and report the others, or 'delete' them")
+	fs.DurationVar(&orphanGracePeriod, "orphan-grace-period", controllers.DefaultOrphanGracePeriod, "Minimum age of a droplet referenced by no DOMachine before it is considered orphaned (e.g. 10m)")
+	fs.StringSliceVar(&enabledControllers, "controllers", []string{"*"}, fmt.Sprintf("Controllers to run, '*' for all of them, 'foo' to run foo and '-foo' to not run foo, e.g. --controllers=*,-dofirewall. Controllers: %s. Their webhooks are served regardless.", strings.Join(controllers.ControllerNames, ", ")))
+	fs.DurationVar(&statusResyncInterval, "status-resync-interval", controllers.DefaultStatusResyncInterval, "Interval at which the droplets and load balancers are listed to reconcile the DOMachines and DOClusters whose DigitalOcean resources changed outside of the provider, e.g. a droplet powered off in the console (e.g. 5m). 0 disables it.")
+	fs.DurationVar(&gcInterval, "gc-interval", 0, "Interval at which droplets and load balancers tagged for Clusters that no longer exist are deleted (e.g. 1h). Only enable it if the DigitalOcean account is dedicated to this management cluster. Disabled by default.")
+	fs.DurationVar(&gcTTL, "gc-ttl", 0, "When set with --gc-interval, also delete the droplets and load balancers of existing Clusters older than this (e.g. 24h), e.g. in accounts used by CI")
+	fs.BoolVar(&gcDryRun, "gc-dry-run", false, "Only log and count the resources --gc-interval would delete")
+	fs.DurationVar(&capacityMetricsInterval, "capacity-metrics-interval", controllers.DefaultCapacityMonitorInterval, "Interval at which the limits of the DigitalOcean account and its droplet, volume and reserved IP counts are polled and exported as metrics (e.g. 5m). 0 disables it.")
+	feature.MutableGates.AddFlag(fs)
+	fs.StringVar(&credentialsSecret, "credentials-secret", "", "Secret holding the DigitalOcean token, as namespace/name. When set, the Secret is watched and a rotated token is used without restarting the manager. Ignored when --do-token-file is set.")
+	fs.StringVar(&credentialsSecretKey, "credentials-secret-key", controllers.DefaultCredentialsSecretKey, "Key of the DigitalOcean token in the credentials Secret.")
+	fs.StringVar(&doTokenFile, "do-token-file", "", "File holding the DigitalOcean token, e.g. a projected Secret volume or a file rendered by the Vault agent, used instead of the DIGITALOCEAN_ACCESS_TOKEN env var. The file is read again every 10s and a rotated token is used without restarting the manager. Takes precedence over --credentials-secret.")
+}
+
+// leaderElectionID returns the leader election ID of the manager. Instances
+// with different watch filters reconcile different objects, so they must not
+// wait on each other.
+func leaderElectionID() string {
+	if leaderElectionName != "" {
+		return leaderElectionName
+	}
+	if watchFilterValue != "" {
+		return "controller-leader-election-capdo-" + watchFilterValue
+	}
+	return "controller-leader-election-capdo"
+}
+
+// inCluster returns whether the manager runs in a Pod, where the leader
+// election namespace can be discovered.
+func inCluster() bool {
+	return os.Getenv("KUBERNETES_SERVICE_HOST") != "" && os.Getenv("KUBERNETES_SERVICE_PORT") != ""
 }
 
 func main() {
 	InitFlags(pflag.CommandLine)
+	zapOpts.BindFlags(flag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 
-	if watchNamespace != "" {
-		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
+	// Structured logs, JSON encoded unless --zap-devel is set. klog output,
+	// e.g. from client-go, goes through the same logger.
+	logger := zap.New(zap.UseFlagOptions(&zapOpts))
+	ctrl.SetLogger(logger)
+	klog.SetLogger(logger)
+
+	if !controllers.OrphanDropletPolicy(orphanDropletPolicy).IsValid() {
+		setupLog.Error(nil, "invalid --orphan-droplet-policy, expected adopt or delete", "orphan-droplet-policy", orphanDropletPolicy)
+		os.Exit(1)
 	}
 
-	if profilerAddress != "" {
-		setupLog.Info("Profiler listening for requests", "profiler-address", profilerAddress)
-		go func() {
-			setupLog.Error(http.ListenAndServe(profilerAddress, nil), "listen and serve error")
-		}()
+	if infrav1beta1.ImageLookupFormat != "" {
+		if _, err := infrav1beta1.ImageLookupName("v0.0.0"); err != nil {
+			setupLog.Error(err, "invalid --image-lookup-format")
+			os.Exit(1)
+		}
+	}
+
+	if !validation.QuotaGuardMode(dropletQuotaGuard).IsValid() {
+		setupLog.Error(nil, "invalid --droplet-quota-guard, expected off, warn or deny", "droplet-quota-guard", dropletQuotaGuard)
+		os.Exit(1)
+	}
+
+	enabled, err := controllers.EnabledControllers(enabledControllers)
+	if err != nil {
+		setupLog.Error(err, "invalid --controllers")
+		os.Exit(1)
+	}
+
+	if err := rateLimiterOptions.Validate(); err != nil {
+		setupLog.Error(err, "invalid --rate-limiter flags")
+		os.Exit(1)
+	}
+
+	if tlsMinVersion != "1.2" && tlsMinVersion != "1.3" {
+		setupLog.Error(nil, "invalid --tls-min-version, expected 1.2 or 1.3", "tls-min-version", tlsMinVersion)
+		os.Exit(1)
+	}
+
+	if enableLeaderElection && leaderElectionNamespace == "" && !inCluster() {
+		setupLog.Error(nil, "--leader-election-namespace is required to run the manager out of the cluster with --enable-leader-election")
+		os.Exit(1)
+	}
+
+	if watchNamespace != "" {
+		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
 	}
 
-	ctrl.SetLogger(klogr.New())
 	ctx := ctrl.SetupSignalHandler()
 
+	// Give the reconciles in flight their grace period, then time to persist
+	// their progress, before the manager exits.
+	gracefulShutdownTimeout := shutdownGracePeriod + 10*time.Second
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                  scheme,
 		MetricsBindAddress:      metricsAddr,
 		LeaderElection:          enableLeaderElection,
-		LeaderElectionID:        "controller-leader-election-capdo",
+		LeaderElectionID:        leaderElectionID(),
 		LeaderElectionNamespace: leaderElectionNamespace,
-		Namespace:               watchNamespace,
-		SyncPeriod:              &syncPeriod,
-		Port:                    webhookPort,
-		HealthProbeBindAddress:  healthAddr,
+		LeaseDuration:           &leaderElectionLease,
+		RenewDeadline:           &leaderElectionRenew,
+		RetryPeriod:             &leaderElectionRetry,
+		// Hand over leadership as soon as the manager is stopped, e.g. on
+		// rollouts, rather than waiting for the lease to expire.
+		LeaderElectionReleaseOnCancel: true,
+		Namespace:                     watchNamespace,
+		SyncPeriod:                    &syncPeriod,
+		Port:                          webhookPort,
+		CertDir:                       webhookCertDir,
+		HealthProbeBindAddress:        healthAddr,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	if webhookPort != 0 {
+		mgr.GetWebhookServer().TLSMinVersion = tlsMinVersion
+	} else {
+		setupLog.Info("Webhook server disabled, the DigitalOcean objects are neither defaulted nor validated")
+	}
+
+	if profilerAddress != "" {
+		if !profilerAllowRemote && !profiler.IsLoopback(profilerAddress) {
+			setupLog.Error(nil, "refusing to expose the unauthenticated profiler on a non-loopback address, set --profiler-allow-remote to do so", "profiler-address", profilerAddress)
+			os.Exit(1)
+		}
+		if err := mgr.Add(&profiler.Server{Addr: profilerAddress}); err != nil {
+			setupLog.Error(err, "unable to add profiler")
+			os.Exit(1)
+		}
+		setupLog.Info("Profiler listening for requests", "profiler-address", profilerAddress)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Endpoint:    otlpEndpoint,
+		Insecure:    otlpInsecure,
+		SampleRatio: traceSampleRatio,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		// Flush the spans of the last reconciles.
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			setupLog.Error(err, "unable to flush traces")
+		}
+	}()
+
 	// Initialize event recorder.
 	record.InitFromRecorder(mgr.GetEventRecorderFor("digitalocean-controller"))
 
@@ -129,44 +341,269 @@ func main() {
 
 	dnsutil.InitFromDNSResolver(dnsresolver)
 
-	if err = (&controllers.DOClusterReconciler{
-		Client:   mgr.GetClient(),
-		Recorder: mgr.GetEventRecorderFor("docluster-controller"),
-	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "DOCluster")
+	if err := scope.InitSessions(scope.SessionOptions{
+		APIURL:              doAPIURL,
+		CABundle:            doAPICABundle,
+		Debug:               doAPIDebug,
+		CatalogTTL:          doCatalogTTL,
+		RequestTimeout:      doAPIRequestTimeout,
+		ClusterRequestRate:  doClusterRequestRate,
+		ClusterRequestBurst: doClusterRequestBurst,
+	}); err != nil {
+		setupLog.Error(err, "unable to configure DigitalOcean API client")
 		os.Exit(1)
 	}
-	if err = (&controllers.DOMachineReconciler{
-		Client:   mgr.GetClient(),
-		Recorder: mgr.GetEventRecorderFor("domachine-controller"),
-	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "DOMachine")
-		os.Exit(1)
+
+	if doTokenFile != "" {
+		// The file takes precedence, so that it can be added to the default
+		// manifest which sets --credentials-secret.
+		if credentialsSecret != "" {
+			setupLog.Info("Using the DigitalOcean token file, not watching the credentials Secret", "do-token-file", doTokenFile, "credentials-secret", credentialsSecret)
+			credentialsSecret = ""
+		}
+		token, err := controllers.ReadTokenFile(doTokenFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read DigitalOcean token file")
+			os.Exit(1)
+		}
+		scope.SetAccessToken(token)
+		if err := mgr.Add(&controllers.TokenFileWatcher{
+			Path: doTokenFile,
+			Log:  ctrl.Log.WithName("token-file"),
+		}); err != nil {
+			setupLog.Error(err, "unable to add DigitalOcean token file watcher")
+			os.Exit(1)
+		}
 	}
 
-	if err := (&infrav1alpha4.DOCluster{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "DOCluster")
-		os.Exit(1)
+	// Fail fast on unusable credentials rather than failing every reconcile.
+	// Other errors are only logged so that a DigitalOcean API outage does not
+	// crash loop the manager.
+	if account, err := scope.ValidateCredentials(ctx); err != nil {
+		if doclient.IsInvalidCredentials(err) {
+			setupLog.Error(err, "invalid DigitalOcean credentials")
+			os.Exit(1)
+		}
+		setupLog.Error(err, "unable to validate DigitalOcean credentials")
+	} else {
+		setupLog.Info("Validated DigitalOcean credentials", "account", account.UUID, "dropletLimit", account.DropletLimit)
+		controllers.ProbeTokenScopes(ctx, setupLog)
 	}
-	if err := (&infrav1alpha4.DOMachine{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "DOMachine")
-		os.Exit(1)
+
+	if credentialsSecret != "" {
+		parts := strings.SplitN(credentialsSecret, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			setupLog.Error(nil, "invalid --credentials-secret, expected namespace/name", "credentials-secret", credentialsSecret)
+			os.Exit(1)
+		}
+		if err = (&controllers.CredentialsReconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("credentials-controller"),
+			Secret:   types.NamespacedName{Namespace: parts[0], Name: parts[1]},
+			Key:      credentialsSecretKey,
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Credentials")
+			os.Exit(1)
+		}
 	}
-	if err := (&infrav1alpha4.DOMachineTemplate{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "DOMachineTemplate")
+	// Each deletion controller has a queue, and a rate limiter, of its own.
+	deletionOptions := func() controller.Options {
+		return controller.Options{MaxConcurrentReconciles: deletionConcurrency, RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}
+	}
+	// The status resync only triggers the controllers that run.
+	var clusterResyncEvents, machineResyncEvents chan event.GenericEvent
+	if statusResyncInterval > 0 {
+		if enabled[controllers.DOClusterControllerName] {
+			clusterResyncEvents = make(chan event.GenericEvent)
+		}
+		if enabled[controllers.DOMachineControllerName] {
+			machineResyncEvents = make(chan event.GenericEvent)
+		}
+	}
+	if enabled[controllers.DOClusterControllerName] {
+		if err = (&controllers.DOClusterReconciler{
+			Client:                   mgr.GetClient(),
+			Recorder:                 mgr.GetEventRecorderFor("docluster-controller"),
+			ReconcileTimeout:         doReconcileTimeout,
+			ShutdownGracePeriod:      shutdownGracePeriod,
+			LoadBalancerRequeueAfter: lbRequeueAfter,
+			DNSRequeueAfter:          dnsRequeueAfter,
+			TimeoutRequeueAfter:      timeoutRequeueAfter,
+			BlockedRequeueAfter:      blockedRequeueAfter,
+			DriftCheckInterval:       driftCheckInterval,
+			OrphanDropletPolicy:      controllers.OrphanDropletPolicy(orphanDropletPolicy),
+			OrphanGracePeriod:        orphanGracePeriod,
+			WatchFilterValue:         watchFilterValue,
+			ResyncEvents:             clusterResyncEvents,
+			DeletionOptions:          deletionOptions(),
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOCluster")
+			os.Exit(1)
+		}
+	}
+	if enabled[controllers.DOMachineControllerName] {
+		if err = (&controllers.DOMachineReconciler{
+			Client:              mgr.GetClient(),
+			Recorder:            mgr.GetEventRecorderFor("domachine-controller"),
+			ReconcileTimeout:    doReconcileTimeout,
+			ShutdownGracePeriod: shutdownGracePeriod,
+			DropletRequeueAfter: dropletRequeueAfter,
+			TimeoutRequeueAfter: timeoutRequeueAfter,
+			BlockedRequeueAfter: blockedRequeueAfter,
+			DriftCheckInterval:  driftCheckInterval,
+			OrphanDropletPolicy: controllers.OrphanDropletPolicy(orphanDropletPolicy),
+			WatchFilterValue:    watchFilterValue,
+			ResyncEvents:        machineResyncEvents,
+			DeletionOptions:     deletionOptions(),
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOMachine")
+			os.Exit(1)
+		}
+	}
+	if enabled[controllers.DOReservedIPControllerName] && feature.Gates.Enabled(feature.ReservedIP) {
+		if err = (&controllers.DOReservedIPReconciler{
+			Client:              mgr.GetClient(),
+			Recorder:            mgr.GetEventRecorderFor("doreservedip-controller"),
+			ReconcileTimeout:    doReconcileTimeout,
+			ShutdownGracePeriod: shutdownGracePeriod,
+			TimeoutRequeueAfter: timeoutRequeueAfter,
+			BlockedRequeueAfter: blockedRequeueAfter,
+			WatchFilterValue:    watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOReservedIP")
+			os.Exit(1)
+		}
+	}
+	if enabled[controllers.DOVolumeControllerName] && feature.Gates.Enabled(feature.Volume) {
+		if err = (&controllers.DOVolumeReconciler{
+			Client:              mgr.GetClient(),
+			Recorder:            mgr.GetEventRecorderFor("dovolume-controller"),
+			ReconcileTimeout:    doReconcileTimeout,
+			ShutdownGracePeriod: shutdownGracePeriod,
+			TimeoutRequeueAfter: timeoutRequeueAfter,
+			BlockedRequeueAfter: blockedRequeueAfter,
+			WatchFilterValue:    watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOVolume")
+			os.Exit(1)
+		}
+	}
+	if enabled[controllers.DOImageControllerName] && feature.Gates.Enabled(feature.GoldenImage) {
+		if err = (&controllers.DOImageReconciler{
+			Client:              mgr.GetClient(),
+			Recorder:            mgr.GetEventRecorderFor("doimage-controller"),
+			ReconcileTimeout:    doReconcileTimeout,
+			ShutdownGracePeriod: shutdownGracePeriod,
+			TimeoutRequeueAfter: timeoutRequeueAfter,
+			BlockedRequeueAfter: blockedRequeueAfter,
+			WatchFilterValue:    watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOImage")
+			os.Exit(1)
+		}
+	}
+	if enabled[controllers.DOFirewallControllerName] && feature.Gates.Enabled(feature.Firewall) {
+		if err = (&controllers.DOFirewallReconciler{
+			Client:              mgr.GetClient(),
+			Recorder:            mgr.GetEventRecorderFor("dofirewall-controller"),
+			ReconcileTimeout:    doReconcileTimeout,
+			ShutdownGracePeriod: shutdownGracePeriod,
+			TimeoutRequeueAfter: timeoutRequeueAfter,
+			BlockedRequeueAfter: blockedRequeueAfter,
+			WatchFilterValue:    watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOFirewall")
+			os.Exit(1)
+		}
+	}
+	if enabled[controllers.DOMachineTemplateControllerName] {
+		if err = (&controllers.DOMachineTemplateReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: doReconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{RateLimiter: controllers.NewRateLimiter(rateLimiterOptions)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DOMachineTemplate")
+			os.Exit(1)
+		}
+	}
+
+	if webhookPort != 0 {
+		setupWebhooks(mgr)
+	}
+
+	if clusterResyncEvents != nil || machineResyncEvents != nil {
+		if err := mgr.Add(&controllers.StatusResyncer{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("status-resyncer"),
+			Interval: statusResyncInterval,
+			Clusters: clusterResyncEvents,
+			Machines: machineResyncEvents,
+		}); err != nil {
+			setupLog.Error(err, "unable to add status resyncer")
+			os.Exit(1)
+		}
+	}
+
+	if gcInterval > 0 {
+		// Clusters of other namespaces can not be seen, their resources would be deleted.
+		if watchNamespace != "" {
+			setupLog.Error(nil, "--gc-interval can not be used with --namespace")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&controllers.GarbageCollector{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("garbage-collector"),
+			Interval: gcInterval,
+			TTL:      gcTTL,
+			DryRun:   gcDryRun,
+		}); err != nil {
+			setupLog.Error(err, "unable to add garbage collector")
+			os.Exit(1)
+		}
+	}
+
+	if err := metrics.Registry.Register(controllers.NewManagedObjectsCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register managed objects metrics")
 		os.Exit(1)
 	}
 
+	if capacityMetricsInterval > 0 {
+		if err := mgr.Add(&controllers.CapacityMonitor{
+			Log:      ctrl.Log.WithName("capacity-monitor"),
+			Interval: capacityMetricsInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add capacity monitor")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
-	if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to create ready check")
-		os.Exit(1)
+	readyzChecks := map[string]healthz.Checker{
+		"ping":        healthz.Ping,
+		"informers":   healthcheck.CacheSyncChecker(mgr.GetCache()),
+		"credentials": scope.CredentialsChecker(scope.DefaultCredentialsCheckInterval),
+	}
+	healthzChecks := map[string]healthz.Checker{
+		"ping": healthz.Ping,
+	}
+	if webhookPort != 0 {
+		webhookChecker := healthcheck.WebhookChecker("localhost", webhookPort)
+		readyzChecks["webhook"] = webhookChecker
+		healthzChecks["webhook"] = webhookChecker
+	}
+	for name, check := range readyzChecks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to create ready check", "check", name)
+			os.Exit(1)
+		}
 	}
 
-	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to create health check")
-		os.Exit(1)
+	for name, check := range healthzChecks {
+		if err := mgr.AddHealthzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to create health check", "check", name)
+			os.Exit(1)
+		}
 	}
 
 	setupLog.Info("starting manager")
@@ -175,3 +612,49 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// setupWebhooks registers the defaulting and validation webhooks of the
+// DigitalOcean objects and the droplet quota guard.
+func setupWebhooks(mgr ctrl.Manager) {
+	if webhookLiveValidation {
+		infrav1beta1.Live = &validation.LiveValidator{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("live-validation"),
+		}
+	}
+	mgr.GetWebhookServer().Register(validation.QuotaGuardPath, &webhook.Admission{Handler: &validation.QuotaGuard{
+		Mode: validation.QuotaGuardMode(dropletQuotaGuard),
+		Log:  ctrl.Log.WithName("droplet-quota-guard"),
+	}})
+	if err := (&infrav1beta1.DOCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOCluster")
+		os.Exit(1)
+	}
+	if err := (&infrav1beta1.DOMachine{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOMachine")
+		os.Exit(1)
+	}
+	if err := (&infrav1beta1.DOMachineTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOMachineTemplate")
+		os.Exit(1)
+	}
+	// The webhooks of the gated kinds are served regardless of the feature
+	// gates, the webhook configuration installs them all. They reject the
+	// creates while their feature is disabled.
+	if err := (&infrav1beta1.DOReservedIP{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOReservedIP")
+		os.Exit(1)
+	}
+	if err := (&infrav1beta1.DOVolume{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOVolume")
+		os.Exit(1)
+	}
+	if err := (&infrav1beta1.DOImage{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOImage")
+		os.Exit(1)
+	}
+	if err := (&infrav1beta1.DOFirewall{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DOFirewall")
+		os.Exit(1)
+	}
+}
@@ -51,12 +51,21 @@ remove_ssh_key() {
     ${REPO_ROOT}/hack/log/redact.sh || true
 }
 
+# run_janitor deletes the resources leaked by previous runs, the resources of
+# this run are younger than the janitor max age.
+run_janitor() {
+    echo "cleaning leaked e2e resources"
+    make do-janitor || true
+}
+
+run_janitor
 create_ssh_key
 SSH_KEY_FINGERPRINT=$(ssh-keygen -E md5 -lf "${SSH_KEY_PATH}" | awk '{ print $2 }' | cut -c 5-)
-trap 'remove_ssh_key ${SSH_KEY_FINGERPRINT}' EXIT
+trap 'run_janitor; remove_ssh_key ${SSH_KEY_FINGERPRINT}' EXIT
 
 export DO_SSH_KEY_FINGERPRINT=${SSH_KEY_FINGERPRINT}
+export DO_SSH_PRIVATE_KEY_PATH=${SSH_KEY_PATH}
 
-export GINKGO_FOCUS="Conformance Tests"
+export GINKGO_FOCUS="\\[conformance\\]"
 make test-conformance
 test_status="${?}"
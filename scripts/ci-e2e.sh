@@ -51,11 +51,22 @@ remove_ssh_key() {
     ${REPO_ROOT}/hack/log/redact.sh || true
 }
 
+# run_janitor deletes the resources leaked by previous runs, the resources of
+# this run are younger than the janitor max age.
+run_janitor() {
+    echo "cleaning leaked e2e resources"
+    make do-janitor || true
+}
+
+run_janitor
 create_ssh_key
 SSH_KEY_FINGERPRINT=$(ssh-keygen -E md5 -lf "${SSH_KEY_PATH}" | awk '{ print $2 }' | cut -c 5-)
-trap 'remove_ssh_key ${SSH_KEY_FINGERPRINT}' EXIT
+trap 'run_janitor; remove_ssh_key ${SSH_KEY_FINGERPRINT}' EXIT
 
 export DO_SSH_KEY_FINGERPRINT=${SSH_KEY_FINGERPRINT}
+export DO_SSH_PRIVATE_KEY_PATH=${SSH_KEY_PATH}
 
-make test-e2e
+# E2E_TARGET=test-e2e-nightly runs the full suite in parallel, the smoke specs
+# are run by default.
+make "${E2E_TARGET:-test-e2e}"
 test_status="${?}"
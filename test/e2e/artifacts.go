@@ -0,0 +1,216 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SSHPrivateKeyPath is the env var holding the path to the private key of the
+// DO_SSH_KEY_FINGERPRINT SSH key, used to collect the logs of the droplets.
+const SSHPrivateKeyPath = "DO_SSH_PRIVATE_KEY_PATH"
+
+// machineLogs are the files written in the log folder of a Machine, and the
+// commands run on its droplet to get them.
+var machineLogs = map[string]string{
+	"kubelet.log":           "journalctl --no-pager --output=short-precise -u kubelet.service",
+	"containerd.log":        "journalctl --no-pager --output=short-precise -u containerd.service",
+	"journal.log":           "journalctl --no-pager --output=short-precise",
+	"cloud-init.log":        "cat /var/log/cloud-init.log",
+	"cloud-init-output.log": "cat /var/log/cloud-init-output.log",
+}
+
+// sshLogCollector collects the logs of the droplets of the Machines over SSH.
+type sshLogCollector struct{}
+
+var _ framework.ClusterLogCollector = sshLogCollector{}
+
+// CollectMachineLog writes the journal and the cloud-init logs of the droplet
+// of m to outputPath. It does nothing without DO_SSH_PRIVATE_KEY_PATH.
+func (sshLogCollector) CollectMachineLog(ctx context.Context, managementClusterClient client.Client, m *clusterv1.Machine, outputPath string) error {
+	keyPath := os.Getenv(SSHPrivateKeyPath)
+	if keyPath == "" {
+		return nil
+	}
+	domachine := &infrav1.DOMachine{}
+	key := client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.InfrastructureRef.Name}
+	if err := managementClusterClient.Get(ctx, key, domachine); err != nil {
+		return errors.Wrapf(err, "failed to get DOMachine %s", key)
+	}
+	address := ""
+	for _, a := range domachine.Status.Addresses {
+		if a.Type == corev1.NodeExternalIP {
+			address = a.Address
+			break
+		}
+	}
+	if address == "" {
+		return errors.Errorf("DOMachine %s has no public address", key)
+	}
+
+	pem, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", keyPath)
+	}
+	signer, err := ssh.ParsePrivateKey(pem)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse %s", keyPath)
+	}
+	conn, err := ssh.Dial("tcp", net.JoinHostPort(address, "22"), &ssh.ClientConfig{
+		User: "root",
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		// The droplets are created by the test and their host keys are unknown.
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to %s", address)
+	}
+	defer conn.Close()
+
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return err
+	}
+	var errs []error
+	for file, command := range machineLogs {
+		if err := runToFile(conn, command, filepath.Join(outputPath, file)); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to run %q on %s", command, address))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// CollectMachinePoolLog does nothing, the provider has no MachinePools.
+func (sshLogCollector) CollectMachinePoolLog(_ context.Context, _ client.Client, _ *expv1.MachinePool, _ string) error {
+	return nil
+}
+
+func runToFile(conn *ssh.Client, command, path string) error {
+	session, err := conn.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	session.Stdout = f
+	session.Stderr = f
+	return session.Run("sudo " + command)
+}
+
+// dumpDOResources writes the droplets, load balancers, firewalls and volumes
+// tagged for cluster to outputPath, as returned by the DigitalOcean API.
+func dumpDOResources(ctx context.Context, cluster *clusterv1.Cluster, outputPath string) error {
+	c := godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"))
+	nameTag := infrav1.ClusterNameTag(infrav1.DOSafeName(cluster.Name))
+	tagged := func(tags ...string) bool {
+		for _, tag := range tags {
+			if tag == nameTag || strings.HasPrefix(tag, nameTag+":") {
+				return true
+			}
+		}
+		return false
+	}
+
+	var droplets []godo.Droplet
+	var lbs []godo.LoadBalancer
+	var firewalls []godo.Firewall
+	var volumes []godo.Volume
+	err := kerrors.NewAggregate([]error{
+		doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			page, resp, err := c.Droplets.ListByTag(ctx, nameTag, opt)
+			droplets = append(droplets, page...)
+			return resp, err
+		}),
+		doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			page, resp, err := c.LoadBalancers.List(ctx, opt)
+			for _, lb := range page {
+				if tagged(append(lb.Tags, lb.Tag)...) {
+					lbs = append(lbs, lb)
+				}
+			}
+			return resp, err
+		}),
+		doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			page, resp, err := c.Firewalls.List(ctx, opt)
+			for _, firewall := range page {
+				if tagged(firewall.Tags...) {
+					firewalls = append(firewalls, firewall)
+				}
+			}
+			return resp, err
+		}),
+		doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			page, resp, err := c.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+			for _, volume := range page {
+				if tagged(volume.Tags...) {
+					volumes = append(volumes, volume)
+				}
+			}
+			return resp, err
+		}),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the DigitalOcean resources")
+	}
+
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return err
+	}
+	for file, resources := range map[string]interface{}{
+		"droplets.json":       droplets,
+		"load-balancers.json": lbs,
+		"firewalls.json":      firewalls,
+		"volumes.json":        volumes,
+	} {
+		data, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(outputPath, file), data, 0600); err != nil {
+			return errors.Wrapf(err, "failed to write %s", file)
+		}
+	}
+	return nil
+}
@@ -20,7 +20,6 @@ package e2e
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"path/filepath"
 
@@ -31,18 +30,16 @@ import (
 	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
-	"sigs.k8s.io/cluster-api/util"
 )
 
 var _ = Describe("Workload cluster creation", func() {
 	var (
-		ctx                 = context.TODO()
-		specName            = "create-workload-cluster"
-		namespace           *corev1.Namespace
-		cancelWatches       context.CancelFunc
-		result              *clusterctl.ApplyClusterTemplateAndWaitResult
-		clusterName         string
-		clusterctlLogFolder string
+		ctx           = context.TODO()
+		specName      = "create-workload-cluster"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
 	)
 
 	BeforeEach(func() {
@@ -53,13 +50,11 @@ var _ = Describe("Workload cluster creation", func() {
 
 		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
 
-		clusterName = fmt.Sprintf("capdo-e2e-%s", util.RandomString(6))
+		clusterName = specClusterName("capdo-e2e")
 
 		// Setup a Namespace where to host objects for this spec and create a watcher for the namespace events.
 		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
 
-		clusterctlLogFolder = filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName())
-
 		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
 	})
 
@@ -68,71 +63,48 @@ var _ = Describe("Workload cluster creation", func() {
 		redactLogs(e2eConfig.GetVariable)
 	})
 
-	Context("Creating a single control-plane cluster", func() {
+	Context("Creating a single control-plane cluster [smoke]", func() {
 		It("Should create a cluster with 1 worker node and can be scaled", func() {
+			requireDropletCapacity(ctx, specName, 4)
+
 			By("Initializes with 1 worker node")
-			clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
-				ClusterProxy: bootstrapClusterProxy,
-				ConfigCluster: clusterctl.ConfigClusterInput{
-					LogFolder:                clusterctlLogFolder,
-					ClusterctlConfigPath:     clusterctlConfigPath,
-					KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
-					InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
-					Flavor:                   clusterctl.DefaultFlavor,
-					Namespace:                namespace.Name,
-					ClusterName:              clusterName,
-					KubernetesVersion:        e2eConfig.GetVariable(KubernetesVersion),
-					ControlPlaneMachineCount: pointer.Int64Ptr(1),
-					WorkerMachineCount:       pointer.Int64Ptr(1),
-				},
-				WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
-				WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
-				WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
-			}, result)
+			applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
 
 			By("Scaling worker node to 3")
-			clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
-				ClusterProxy: bootstrapClusterProxy,
-				ConfigCluster: clusterctl.ConfigClusterInput{
-					LogFolder:                clusterctlLogFolder,
-					ClusterctlConfigPath:     clusterctlConfigPath,
-					KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
-					InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
-					Flavor:                   clusterctl.DefaultFlavor,
-					Namespace:                namespace.Name,
-					ClusterName:              clusterName,
-					KubernetesVersion:        e2eConfig.GetVariable(KubernetesVersion),
-					ControlPlaneMachineCount: pointer.Int64Ptr(1),
-					WorkerMachineCount:       pointer.Int64Ptr(3),
-				},
-				WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
-				WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
-				WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
-			}, result)
+			applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 3, result)
 		})
 	})
 
-	Context("Creating a highly available control-plane cluster", func() {
+	Context("Creating a highly available control-plane cluster [lifecycle]", func() {
 		It("Should create a cluster with 3 control-plane and 2 worker nodes", func() {
+			requireDropletCapacity(ctx, specName, 5)
+
 			By("Creating a high available cluster")
-			clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
-				ClusterProxy: bootstrapClusterProxy,
-				ConfigCluster: clusterctl.ConfigClusterInput{
-					LogFolder:                clusterctlLogFolder,
-					ClusterctlConfigPath:     clusterctlConfigPath,
-					KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
-					InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
-					Flavor:                   clusterctl.DefaultFlavor,
-					Namespace:                namespace.Name,
-					ClusterName:              clusterName,
-					KubernetesVersion:        e2eConfig.GetVariable(KubernetesVersion),
-					ControlPlaneMachineCount: pointer.Int64Ptr(3),
-					WorkerMachineCount:       pointer.Int64Ptr(2),
-				},
-				WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
-				WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
-				WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
-			}, result)
+			applyClusterTemplate(ctx, specName, namespace.Name, clusterName, "ha", e2eConfig.GetVariable(KubernetesVersion), 3, 2, result)
 		})
 	})
 })
+
+// applyClusterTemplate applies the cluster template flavor of the e2e config
+// with the given Kubernetes version and machine counts and waits for the
+// machines to be running.
+func applyClusterTemplate(ctx context.Context, specName, namespace, clusterName, flavor, kubernetesVersion string, controlPlaneMachineCount, workerMachineCount int64, result *clusterctl.ApplyClusterTemplateAndWaitResult) {
+	clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+		ClusterProxy: bootstrapClusterProxy,
+		ConfigCluster: clusterctl.ConfigClusterInput{
+			LogFolder:                filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+			ClusterctlConfigPath:     clusterctlConfigPath,
+			KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
+			InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+			Flavor:                   flavor,
+			Namespace:                namespace,
+			ClusterName:              clusterName,
+			KubernetesVersion:        kubernetesVersion,
+			ControlPlaneMachineCount: pointer.Int64Ptr(controlPlaneMachineCount),
+			WorkerMachineCount:       pointer.Int64Ptr(workerMachineCount),
+		},
+		WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
+		WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+		WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+	}, result)
+}
@@ -35,7 +35,7 @@ var _ = Describe("Running the Cluster API E2E tests", func() {
 		redactLogs(e2eConfig.GetVariable)
 	})
 
-	Context("Running the quick-start spec", func() {
+	Context("Running the quick-start spec [smoke]", func() {
 		capi_e2e.QuickStartSpec(context.TODO(), func() capi_e2e.QuickStartSpecInput {
 			return capi_e2e.QuickStartSpecInput{
 				E2EConfig:             e2eConfig,
@@ -0,0 +1,189 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Out of band deletions [lifecycle]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "chaos"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should recover from the deletion of a worker droplet and of the load balancer", func() {
+		// The droplet of the worker is gone before its replacement is created.
+		requireDropletCapacity(ctx, specName, 2)
+		c := godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"))
+
+		By("Creating a cluster with 1 worker")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+		Expect(result.MachineDeployments).To(HaveLen(1))
+		machineDeployment := result.MachineDeployments[0]
+		machines := machineDeploymentMachines(ctx, machineDeployment)
+		Expect(machines).To(HaveLen(1))
+		broken := machines[0]
+
+		By("Deleting the droplet of the worker through the DigitalOcean API")
+		dropletID, err := strconv.Atoi(machineDropletID(broken))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = c.Droplets.Delete(ctx, dropletID)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("Waiting for the Machine of the worker to be marked failed")
+		Eventually(func() error {
+			machine := &clusterv1.Machine{}
+			if err := bootstrapClusterProxy.GetClient().Get(ctx, client.ObjectKeyFromObject(&broken), machine); err != nil {
+				return err
+			}
+			if machine.Status.FailureReason == nil {
+				return errors.Errorf("machine %s has not failed yet", machine.Name)
+			}
+			if *machine.Status.FailureReason != capierrors.UpdateMachineError {
+				return errors.Errorf("machine %s failed with %s", machine.Name, *machine.Status.FailureReason)
+			}
+			return nil
+		}, e2eConfig.GetIntervals(specName, "wait-machine-remediation")...).Should(Succeed())
+
+		By("Installing a MachineHealthCheck for the workers")
+		mhc := &clusterv1.MachineHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: clusterName + "-md-0"},
+			Spec: clusterv1.MachineHealthCheckSpec{
+				ClusterName: clusterName,
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{
+					clusterv1.MachineDeploymentLabelName: machineDeployment.Name,
+				}},
+				UnhealthyConditions: []clusterv1.UnhealthyCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+					{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+				},
+			},
+		}
+		Expect(bootstrapClusterProxy.GetClient().Create(ctx, mhc)).To(Succeed())
+
+		By("Waiting for the failed worker to be replaced")
+		Eventually(func() ([]string, error) {
+			machines := machineDeploymentMachines(ctx, machineDeployment)
+			names := []string{}
+			for _, machine := range machines {
+				if machine.Status.NodeRef == nil {
+					return nil, fmt.Errorf("machine %s has no node yet", machine.Name)
+				}
+				names = append(names, machine.Name)
+			}
+			return names, nil
+		}, e2eConfig.GetIntervals(specName, "wait-machine-remediation")...).Should(And(HaveLen(1), Not(ContainElement(broken.Name))))
+		replacement := machineDeploymentMachines(ctx, machineDeployment)
+		Expect(replacement).To(HaveLen(1))
+		Expect(workerDropletIDs(ctx, result.Cluster)).To(ConsistOf(machineDropletID(replacement[0])))
+		workloadClient := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName).GetClient()
+		Eventually(func() (int, error) {
+			return readyNodes(ctx, workloadClient, replacement)
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Equal(1))
+
+		// The new load balancer has another IP, so that the control plane
+		// endpoint of a cluster without DNS record is left unreachable: the
+		// load balancer goes last and only its replacement is checked.
+		By("Deleting the load balancer of the control plane through the DigitalOcean API")
+		docluster := &infrav1.DOCluster{}
+		key := client.ObjectKey{Namespace: namespace.Name, Name: result.Cluster.Spec.InfrastructureRef.Name}
+		Expect(bootstrapClusterProxy.GetClient().Get(ctx, key, docluster)).To(Succeed())
+		deletedID := docluster.Status.Network.APIServerLoadbalancersRef.ResourceID
+		Expect(deletedID).ToNot(BeEmpty())
+		_, err = c.LoadBalancers.Delete(ctx, deletedID)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("Waiting for the load balancer to be replaced")
+		Eventually(func() error {
+			docluster := &infrav1.DOCluster{}
+			if err := bootstrapClusterProxy.GetClient().Get(ctx, key, docluster); err != nil {
+				return err
+			}
+			if id := docluster.Status.Network.APIServerLoadbalancersRef.ResourceID; id == deletedID {
+				return errors.Errorf("DOCluster %s still references load balancer %s", docluster.Name, id)
+			}
+			if !conditions.IsTrue(docluster, infrav1.LoadBalancerReadyCondition) {
+				return errors.Errorf("the load balancer of DOCluster %s is not ready", docluster.Name)
+			}
+			return nil
+		}, e2eConfig.GetIntervals(specName, "wait-cluster")...).Should(Succeed())
+		Eventually(func() ([]string, error) {
+			return loadBalancerDropletIDs(ctx, result.Cluster)
+		}, e2eConfig.GetIntervals(specName, "wait-cluster")...).Should(ConsistOf(controlPlaneDropletIDs(ctx, result.Cluster)))
+		Expect(clusterEventReasons(ctx, namespace.Name, docluster.Name)).To(ContainElement(infrav1.LoadBalancerDeletedExternallyReason))
+	})
+})
+
+// clusterEventReasons returns the reasons of the events of the DOCluster name.
+func clusterEventReasons(ctx context.Context, namespace, name string) ([]string, error) {
+	events := &corev1.EventList{}
+	if err := bootstrapClusterProxy.GetClient().List(ctx, events, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	reasons := []string{}
+	for _, event := range events.Items {
+		if event.InvolvedObject.Kind == "DOCluster" && event.InvolvedObject.Name == name {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+	return reasons, nil
+}
@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
@@ -44,12 +45,20 @@ func Byf(format string, a ...interface{}) {
 	By(fmt.Sprintf(format, a...))
 }
 
+// specClusterName returns a random cluster name with prefix and the number
+// of the parallel ginkgo node running the spec. The DigitalOcean resources are
+// tagged and named after the cluster, so that those of each node of a parallel
+// run can be told apart, and cleaned up by the janitor from the prefix.
+func specClusterName(prefix string) string {
+	return fmt.Sprintf("%s-%d-%s", prefix, config.GinkgoConfig.ParallelNode, util.RandomString(6))
+}
+
 func setupSpecNamespace(ctx context.Context, specName string, clusterProxy framework.ClusterProxy, artifactFolder string) (*corev1.Namespace, context.CancelFunc) {
 	Byf("Creating a namespace for hosting the %q test spec", specName)
 	namespace, cancelWatches := framework.CreateNamespaceAndWatchEvents(ctx, framework.CreateNamespaceAndWatchEventsInput{
 		Creator:   clusterProxy.GetClient(),
 		ClientSet: clusterProxy.GetClientSet(),
-		Name:      fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+		Name:      fmt.Sprintf("%s-%d-%s", specName, config.GinkgoConfig.ParallelNode, util.RandomString(6)),
 		LogFolder: filepath.Join(artifactFolder, "clusters", clusterProxy.GetName()),
 	})
 
@@ -57,10 +66,21 @@ func setupSpecNamespace(ctx context.Context, specName string, clusterProxy frame
 }
 
 func dumpSpecResourcesAndCleanup(ctx context.Context, specName string, clusterProxy framework.ClusterProxy, artifactFolder string, namespace *corev1.Namespace, cancelWatches context.CancelFunc, cluster *clusterv1.Cluster, intervalsGetter func(spec, key string) []interface{}, skipCleanup bool) {
-	Byf("Dumping logs from the %q workload cluster", cluster.Name)
-
-	// Dump all the logs from the workload cluster before deleting them.
-	clusterProxy.CollectWorkloadClusterLogs(ctx, cluster.Namespace, cluster.Name, filepath.Join(artifactFolder, "clusters", cluster.Name, "machines"))
+	// The cluster is not created when the spec is skipped, e.g. for lack of
+	// droplet quota, or fails early.
+	if cluster != nil {
+		Byf("Dumping logs from the %q workload cluster", cluster.Name)
+
+		// Dump all the logs from the workload cluster before deleting them.
+		clusterProxy.CollectWorkloadClusterLogs(ctx, cluster.Namespace, cluster.Name, filepath.Join(artifactFolder, "clusters", cluster.Name, "machines"))
+
+		if CurrentGinkgoTestDescription().Failed {
+			Byf("Dumping the DigitalOcean resources of the %q workload cluster", cluster.Name)
+			if err := dumpDOResources(ctx, cluster, filepath.Join(artifactFolder, "clusters", cluster.Name, "digitalocean")); err != nil {
+				fmt.Fprintf(GinkgoWriter, "Failed to dump the DigitalOcean resources of %s: %v\n", cluster.Name, err)
+			}
+		}
+	}
 
 	Byf("Dumping all the Cluster API resources in the %q namespace", namespace.Name)
 
@@ -72,7 +92,7 @@ func dumpSpecResourcesAndCleanup(ctx context.Context, specName string, clusterPr
 	})
 
 	if !skipCleanup {
-		Byf("Deleting cluster %s/%s", cluster.Namespace, cluster.Name)
+		Byf("Deleting the clusters of namespace %s", namespace.Name)
 		// While https://github.com/kubernetes-sigs/cluster-api/issues/2955 is addressed in future iterations, there is a chance
 		// that cluster variable is not set even if the cluster exists, so we are calling DeleteAllClustersAndWait
 		// instead of DeleteClusterAndWait
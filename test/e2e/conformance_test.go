@@ -20,7 +20,6 @@ package e2e
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -28,22 +27,19 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/pointer"
 	capi_e2e "sigs.k8s.io/cluster-api/test/e2e"
 	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
 	"sigs.k8s.io/cluster-api/test/framework/kubetest"
-	"sigs.k8s.io/cluster-api/util"
 )
 
-var _ = Describe("Conformance Tests", func() {
+var _ = Describe("Conformance Tests [conformance]", func() {
 	var (
-		ctx                 = context.TODO()
-		specName            = "conformance-tests"
-		namespace           *corev1.Namespace
-		cancelWatches       context.CancelFunc
-		result              *clusterctl.ApplyClusterTemplateAndWaitResult
-		clusterName         string
-		clusterctlLogFolder string
+		ctx           = context.TODO()
+		specName      = "conformance-tests"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
 	)
 
 	BeforeEach(func() {
@@ -52,19 +48,20 @@ var _ = Describe("Conformance Tests", func() {
 		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
 		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
 		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
-		Expect(kubetestConfigFilePath).ToNot(BeNil(), "Invalid argument. kubetestConfigFilePath can't be nil")
+		if kubetestConfigFilePath == "" {
+			Skip("The conformance suite only runs with -kubetest.config-file, see make test-conformance")
+		}
+		Expect(kubetestConfigFilePath).To(BeAnExistingFile(), "Invalid argument. kubetestConfigFilePath must be an existing file")
 
 		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
 		Expect(e2eConfig.Variables).To(HaveKey(capi_e2e.KubernetesVersion))
 		Expect(e2eConfig.Variables).To(HaveKey(capi_e2e.CNIPath))
 
-		clusterName = fmt.Sprintf("capdo-conf-%s", util.RandomString(6))
+		clusterName = specClusterName("capdo-conf")
 
 		// Setup a Namespace where to host objects for this spec and create a watcher for the namespace events.
 		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
 
-		clusterctlLogFolder = filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName())
-
 		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
 	})
 
@@ -80,38 +77,25 @@ var _ = Describe("Conformance Tests", func() {
 		Expect(err).NotTo(HaveOccurred())
 		controlPlaneMachineCount, err := strconv.ParseInt(e2eConfig.GetVariable("CONFORMANCE_CONTROL_PLANE_MACHINE_COUNT"), 10, 64)
 		Expect(err).NotTo(HaveOccurred())
+		requireDropletCapacity(ctx, specName, int(controlPlaneMachineCount+workerMachineCount))
 
 		runtime := b.Time("cluster creation", func() {
-			clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
-				ClusterProxy: bootstrapClusterProxy,
-				ConfigCluster: clusterctl.ConfigClusterInput{
-					LogFolder:                clusterctlLogFolder,
-					ClusterctlConfigPath:     clusterctlConfigPath,
-					KubeconfigPath:           bootstrapClusterProxy.GetKubeconfigPath(),
-					InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
-					Flavor:                   clusterctl.DefaultFlavor,
-					Namespace:                namespace.Name,
-					ClusterName:              clusterName,
-					KubernetesVersion:        e2eConfig.GetVariable(capi_e2e.KubernetesVersion),
-					ControlPlaneMachineCount: pointer.Int64Ptr(controlPlaneMachineCount),
-					WorkerMachineCount:       pointer.Int64Ptr(workerMachineCount),
-				},
-				WaitForClusterIntervals:      e2eConfig.GetIntervals(specName, "wait-cluster"),
-				WaitForControlPlaneIntervals: e2eConfig.GetIntervals(specName, "wait-control-plane"),
-				WaitForMachineDeployments:    e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
-			}, result)
+			applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(capi_e2e.KubernetesVersion), controlPlaneMachineCount, workerMachineCount, result)
 		})
 
 		b.RecordValue("cluster creation", runtime.Seconds())
 		workloadProxy := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
 		runtime = b.Time("conformance suite", func() {
-			kubetest.Run(context.Background(),
+			// The JUnit reports of the suite are archived with the other artifacts.
+			err := kubetest.Run(context.Background(),
 				kubetest.RunInput{
-					ClusterProxy:   workloadProxy,
-					NumberOfNodes:  int(workerMachineCount),
-					ConfigFilePath: kubetestConfigFilePath,
+					ClusterProxy:       workloadProxy,
+					NumberOfNodes:      int(workerMachineCount),
+					ArtifactsDirectory: filepath.Join(artifactFolder, "conformance", clusterName),
+					ConfigFilePath:     kubetestConfigFilePath,
 				},
 			)
+			Expect(err).NotTo(HaveOccurred(), "The conformance suite failed, see the JUnit reports in %s", filepath.Join(artifactFolder, "conformance", clusterName))
 		})
 		b.RecordValue("conformance suite run time", runtime.Seconds())
 	}, 1)
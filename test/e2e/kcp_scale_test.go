@@ -0,0 +1,134 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("KubeadmControlPlane scaling [lifecycle]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "kcp-scale"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should scale the control plane from 1 to 3 and back to 1", func() {
+		requireDropletCapacity(ctx, specName, 4)
+
+		By("Creating a cluster with 1 control plane machine")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+
+		By("Probing the API server through the load balancer while scaling")
+		probe := newAPIServerProbe(bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName))
+		probeCtx, stopProbe := context.WithCancel(ctx)
+		defer stopProbe()
+		go probe.Run(probeCtx, 5*time.Second)
+
+		for _, replicas := range []int32{3, 1} {
+			Byf("Scaling the control plane to %d", replicas)
+			framework.ScaleAndWaitControlPlane(ctx, framework.ScaleAndWaitControlPlaneInput{
+				ClusterProxy:        bootstrapClusterProxy,
+				Cluster:             result.Cluster,
+				ControlPlane:        result.ControlPlane,
+				Replicas:            replicas,
+				WaitForControlPlane: e2eConfig.GetIntervals(specName, "wait-control-plane"),
+			})
+
+			Byf("Checking that the load balancer targets the %d control plane droplets", replicas)
+			Eventually(func() ([]string, error) {
+				return loadBalancerDropletIDs(ctx, result.Cluster)
+			}, e2eConfig.GetIntervals(specName, "wait-control-plane")...).Should(And(
+				HaveLen(int(replicas)),
+				ConsistOf(controlPlaneDropletIDs(ctx, result.Cluster)),
+			))
+
+			By("Checking the health of the etcd members")
+			Eventually(func() error {
+				return etcdHealthy(ctx, result.Cluster, result.ControlPlane, replicas)
+			}, e2eConfig.GetIntervals(specName, "wait-control-plane")...).Should(Succeed())
+		}
+		stopProbe()
+
+		By("Checking that the API server stayed reachable through the load balancer")
+		Expect(probe.MaxConsecutiveFailures()).To(BeNumerically("<=", apiServerMaxConsecutiveFailures), "API server unreachable through the load balancer, last error: %v", probe.LastError())
+	})
+})
+
+// etcdHealthy returns an error unless the KubeadmControlPlane reports a
+// healthy etcd cluster of replicas members, one per control plane Machine.
+func etcdHealthy(ctx context.Context, cluster *clusterv1.Cluster, controlPlane *controlplanev1.KubeadmControlPlane, replicas int32) error {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := bootstrapClusterProxy.GetClient().Get(ctx, client.ObjectKeyFromObject(controlPlane), kcp); err != nil {
+		return err
+	}
+	if !conditions.IsTrue(kcp, controlplanev1.EtcdClusterHealthyCondition) {
+		return fmt.Errorf("etcd cluster not healthy: %s", conditions.GetMessage(kcp, controlplanev1.EtcdClusterHealthyCondition))
+	}
+	machines := framework.GetControlPlaneMachinesByCluster(ctx, framework.GetControlPlaneMachinesByClusterInput{
+		Lister:      bootstrapClusterProxy.GetClient(),
+		ClusterName: cluster.Name,
+		Namespace:   cluster.Namespace,
+	})
+	if len(machines) != int(replicas) {
+		return fmt.Errorf("%d control plane machines, expected %d", len(machines), replicas)
+	}
+	for i := range machines {
+		if !conditions.IsTrue(&machines[i], controlplanev1.MachineEtcdMemberHealthyCondition) {
+			return fmt.Errorf("etcd member of machine %s not healthy: %s", machines[i].Name, conditions.GetMessage(&machines[i], controlplanev1.MachineEtcdMemberHealthyCondition))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MachineDeployment scaling [lifecycle]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "md-scale"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should scale the workers from 1 to 5 and back to 1", func() {
+		requireDropletCapacity(ctx, specName, 6)
+
+		By("Creating a cluster with 1 worker")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+		Expect(result.MachineDeployments).To(HaveLen(1))
+		machineDeployment := result.MachineDeployments[0]
+		workloadClient := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName).GetClient()
+
+		By("Scaling the workers to 5")
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              bootstrapClusterProxy,
+			Cluster:                   result.Cluster,
+			MachineDeployment:         machineDeployment,
+			Replicas:                  5,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+		machines := machineDeploymentMachines(ctx, machineDeployment)
+		Expect(machines).To(HaveLen(5))
+		Eventually(func() ([]string, error) {
+			return workerDropletIDs(ctx, result.Cluster)
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(ConsistOf(dropletIDs(machines)))
+		Eventually(func() (int, error) {
+			return readyNodes(ctx, workloadClient, machines)
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Equal(5))
+
+		By("Marking all the workers but one for deletion")
+		kept, deleted := machines[0], machines[1:]
+		for i := range deleted {
+			machine := &deleted[i]
+			base := machine.DeepCopy()
+			if machine.Annotations == nil {
+				machine.Annotations = map[string]string{}
+			}
+			machine.Annotations[clusterv1.DeleteMachineAnnotation] = "yes"
+			Expect(bootstrapClusterProxy.GetClient().Patch(ctx, machine, client.MergeFrom(base))).To(Succeed())
+		}
+
+		By("Scaling the workers back to 1")
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              bootstrapClusterProxy,
+			Cluster:                   result.Cluster,
+			MachineDeployment:         machineDeployment,
+			Replicas:                  1,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+
+		By("Checking that the marked workers were drained and their droplets deleted")
+		remaining := machineDeploymentMachines(ctx, machineDeployment)
+		Expect(remaining).To(HaveLen(1))
+		Expect(remaining[0].Name).To(Equal(kept.Name))
+		Eventually(func() ([]string, error) {
+			return workerDropletIDs(ctx, result.Cluster)
+		}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...).Should(ConsistOf(machineDropletID(kept)))
+		// The Machine controller deletes the node once drained.
+		for _, machine := range deleted {
+			Eventually(func() bool {
+				err := workloadClient.Get(ctx, client.ObjectKey{Name: machine.Status.NodeRef.Name}, &corev1.Node{})
+				return err != nil && client.IgnoreNotFound(err) == nil
+			}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...).Should(BeTrue(), "node of Machine %s was not deleted", machine.Name)
+		}
+		Expect(readyNodes(ctx, workloadClient, remaining)).To(Equal(1))
+	})
+})
+
+// machineDeploymentMachines returns the Machines of machineDeployment.
+func machineDeploymentMachines(ctx context.Context, machineDeployment *clusterv1.MachineDeployment) []clusterv1.Machine {
+	machines := &clusterv1.MachineList{}
+	Expect(bootstrapClusterProxy.GetClient().List(ctx, machines,
+		client.InNamespace(machineDeployment.Namespace),
+		client.MatchingLabels{clusterv1.MachineDeploymentLabelName: machineDeployment.Name},
+	)).To(Succeed())
+	return machines.Items
+}
+
+func dropletIDs(machines []clusterv1.Machine) []string {
+	ids := []string{}
+	for _, machine := range machines {
+		ids = append(ids, machineDropletID(machine))
+	}
+	return ids
+}
+
+// workerDropletIDs returns the IDs of the worker droplets of cluster, as
+// tagged by the provider.
+func workerDropletIDs(ctx context.Context, cluster *clusterv1.Cluster) ([]string, error) {
+	return taggedDropletIDs(ctx, infrav1.ClusterNameUIDRoleTag(infrav1.DOSafeName(cluster.Name), string(cluster.UID), infrav1.NodeRoleTagValue))
+}
+
+// taggedDropletIDs returns the IDs of the droplets carrying tag.
+func taggedDropletIDs(ctx context.Context, tag string) ([]string, error) {
+	c := godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"))
+	ids := []string{}
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		droplets, resp, err := c.Droplets.ListByTag(ctx, tag, opt)
+		for _, droplet := range droplets {
+			ids = append(ids, fmt.Sprint(droplet.ID))
+		}
+		return resp, err
+	})
+	return ids, err
+}
+
+// readyNodes returns how many nodes of machines are Ready in the workload
+// cluster.
+func readyNodes(ctx context.Context, workloadClient client.Client, machines []clusterv1.Machine) (int, error) {
+	ready := 0
+	for _, machine := range machines {
+		if machine.Status.NodeRef == nil {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := workloadClient.Get(ctx, client.ObjectKey{Name: machine.Status.NodeRef.Name}, node); err != nil {
+			return 0, err
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+	return ready, nil
+}
@@ -0,0 +1,160 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// managerMetricsPort is the port the manager serves its metrics on, on the
+// loopback interface of its pod.
+const managerMetricsPort = 8080
+
+// apiRequestCode matches the status code label of the DigitalOcean API
+// request counter, "error" when no response came back.
+var apiRequestCode = regexp.MustCompile(`code="([^"]*)"`)
+
+// managerMetrics are the DigitalOcean API and process metrics of the manager.
+type managerMetrics struct {
+	sampledAt time.Time
+	// pod is the name of the manager pod the metrics were read from, its
+	// counters start over when it is replaced.
+	pod      string
+	restarts int32
+
+	requests int
+	// apiErrors are the requests that got no response, were rate limited or
+	// failed with a server error.
+	apiErrors          int
+	rateLimitRemaining int
+
+	goroutines          int
+	residentMemoryBytes int64
+}
+
+// scrapeManagerMetrics reads the metrics of the manager through a port
+// forward, its metrics endpoint only listening on the loopback interface of
+// its pod.
+func scrapeManagerMetrics(ctx context.Context) (managerMetrics, error) {
+	metrics := managerMetrics{sampledAt: time.Now()}
+	pods := &corev1.PodList{}
+	if err := bootstrapClusterProxy.GetClient().List(ctx, pods, client.InNamespace("capdo-system"),
+		client.MatchingLabels{"control-plane": "capdo-controller-manager"}); err != nil {
+		return metrics, errors.Wrap(err, "failed to list the manager pods")
+	}
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			pod = &pods.Items[i]
+		}
+	}
+	if pod == nil {
+		return metrics, errors.New("the manager has no running pod")
+	}
+	metrics.pod = pod.Name
+	for _, status := range pod.Status.ContainerStatuses {
+		metrics.restarts += status.RestartCount
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(bootstrapClusterProxy.GetRESTConfig())
+	if err != nil {
+		return metrics, err
+	}
+	url := bootstrapClusterProxy.GetClientSet().CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+	stop, ready := make(chan struct{}), make(chan struct{})
+	defer close(stop)
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", managerMetricsPort)}, stop, ready, ioutil.Discard, GinkgoWriter)
+	if err != nil {
+		return metrics, err
+	}
+	forwardErr := make(chan error, 1)
+	go func() { forwardErr <- forwarder.ForwardPorts() }()
+	select {
+	case <-ready:
+	case err := <-forwardErr:
+		return metrics, errors.Wrapf(err, "failed to forward the metrics port of pod %s", pod.Name)
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return metrics, err
+	}
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", ports[0].Local))
+	if err != nil {
+		return metrics, errors.Wrap(err, "failed to get the metrics of the manager")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return metrics, err
+	}
+
+	for _, line := range strings.Split(string(body), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(fields[0], "capdo_digitalocean_api_requests_total{"):
+			metrics.requests += int(value)
+			if match := apiRequestCode.FindStringSubmatch(fields[0]); match != nil && isAPIError(match[1]) {
+				metrics.apiErrors += int(value)
+			}
+		case fields[0] == "capdo_digitalocean_api_rate_limit_remaining":
+			metrics.rateLimitRemaining = int(value)
+		case fields[0] == "go_goroutines":
+			metrics.goroutines = int(value)
+		case fields[0] == "process_resident_memory_bytes":
+			metrics.residentMemoryBytes = int64(value)
+		}
+	}
+	return metrics, nil
+}
+
+// isAPIError returns whether code, the status code label of a DigitalOcean
+// API request, is a failure of the API rather than of the request.
+func isAPIError(code string) bool {
+	if code == "error" {
+		return true
+	}
+	status, err := strconv.Atoi(code)
+	return err == nil && (status == http.StatusTooManyRequests || status >= 500)
+}
@@ -0,0 +1,131 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MachineHealthCheck remediation [lifecycle]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "mhc-remediation"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should replace a worker whose droplet was powered off", func() {
+		// The replacement of the worker is created before its droplet is deleted.
+		requireDropletCapacity(ctx, specName, 3)
+
+		By("Creating a cluster with 1 worker")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+		Expect(result.MachineDeployments).To(HaveLen(1))
+		machineDeployment := result.MachineDeployments[0]
+		machines := machineDeploymentMachines(ctx, machineDeployment)
+		Expect(machines).To(HaveLen(1))
+		broken := machines[0]
+
+		By("Installing a MachineHealthCheck for the workers")
+		mhc := &clusterv1.MachineHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: clusterName + "-md-0"},
+			Spec: clusterv1.MachineHealthCheckSpec{
+				ClusterName: clusterName,
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{
+					clusterv1.MachineDeploymentLabelName: machineDeployment.Name,
+				}},
+				UnhealthyConditions: []clusterv1.UnhealthyCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+					{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+				},
+			},
+		}
+		Expect(bootstrapClusterProxy.GetClient().Create(ctx, mhc)).To(Succeed())
+		Eventually(func() (int32, error) {
+			err := bootstrapClusterProxy.GetClient().Get(ctx, client.ObjectKeyFromObject(mhc), mhc)
+			return mhc.Status.CurrentHealthy, err
+		}, e2eConfig.GetIntervals(specName, "wait-deployment")...).Should(Equal(int32(1)))
+
+		By("Powering off the droplet of the worker")
+		dropletID, err := strconv.Atoi(machineDropletID(broken))
+		Expect(err).ToNot(HaveOccurred())
+		_, _, err = godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")).DropletActions.PowerOff(ctx, dropletID)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("Waiting for the worker to be replaced")
+		Eventually(func() ([]string, error) {
+			machines := machineDeploymentMachines(ctx, machineDeployment)
+			names := []string{}
+			for _, machine := range machines {
+				if machine.Status.NodeRef == nil {
+					return nil, fmt.Errorf("machine %s has no node yet", machine.Name)
+				}
+				names = append(names, machine.Name)
+			}
+			return names, nil
+		}, e2eConfig.GetIntervals(specName, "wait-machine-remediation")...).Should(And(HaveLen(1), Not(ContainElement(broken.Name))))
+
+		By("Checking that the replacement runs on a new droplet")
+		replacement := machineDeploymentMachines(ctx, machineDeployment)
+		Expect(replacement).To(HaveLen(1))
+		Expect(machineDropletID(replacement[0])).ToNot(Equal(machineDropletID(broken)))
+		Eventually(func() ([]string, error) {
+			return workerDropletIDs(ctx, result.Cluster)
+		}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...).Should(ConsistOf(machineDropletID(replacement[0])))
+		workloadClient := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName).GetClient()
+		Eventually(func() (int, error) {
+			return readyNodes(ctx, workloadClient, replacement)
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Equal(1))
+	})
+})
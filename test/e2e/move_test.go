@@ -0,0 +1,180 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/bootstrap"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Moving a workload cluster between management clusters [lifecycle]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "move"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+
+		targetClusterProvider bootstrap.ClusterProvider
+		targetClusterProxy    framework.ClusterProxy
+		targetCancelWatches   context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+		targetClusterProvider, targetClusterProxy, targetCancelWatches = nil, nil, nil
+	})
+
+	AfterEach(func() {
+		if targetClusterProxy != nil {
+			framework.DumpAllResources(ctx, framework.DumpAllResourcesInput{
+				Lister:    targetClusterProxy.GetClient(),
+				Namespace: namespace.Name,
+				LogPath:   filepath.Join(artifactFolder, "clusters", targetClusterProxy.GetName(), "resources"),
+			})
+			if !skipCleanup {
+				// Delete what a failed spec left in the target cluster before disposing of it.
+				framework.DeleteAllClustersAndWait(ctx, framework.DeleteAllClustersAndWaitInput{
+					Client:    targetClusterProxy.GetClient(),
+					Namespace: namespace.Name,
+				}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...)
+			}
+		}
+		if targetCancelWatches != nil {
+			targetCancelWatches()
+		}
+		if !skipCleanup {
+			tearDown(targetClusterProvider, targetClusterProxy)
+		}
+
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should keep reconciling and delete a cluster moved to another management cluster", func() {
+		requireDropletCapacity(ctx, specName, 3)
+
+		By("Creating a workload cluster")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+		clusterTag := infrav1.ClusterNameUIDTag(infrav1.DOSafeName(clusterName), string(result.Cluster.UID))
+
+		By("Creating a second management cluster")
+		targetClusterProvider = bootstrap.CreateKindBootstrapClusterAndLoadImages(ctx, bootstrap.CreateKindBootstrapClusterAndLoadImagesInput{
+			Name:   fmt.Sprintf("%s-%s", e2eConfig.ManagementClusterName, util.RandomString(6)),
+			Images: e2eConfig.Images,
+		})
+		Expect(targetClusterProvider).ToNot(BeNil(), "Failed to create the target management cluster")
+		targetClusterProxy = framework.NewClusterProxy("target", targetClusterProvider.GetKubeconfigPath(), initScheme(), framework.WithMachineLogCollector(sshLogCollector{}))
+		_, targetCancelWatches = framework.CreateNamespaceAndWatchEvents(ctx, framework.CreateNamespaceAndWatchEventsInput{
+			Creator:   targetClusterProxy.GetClient(),
+			ClientSet: targetClusterProxy.GetClientSet(),
+			Name:      namespace.Name,
+			LogFolder: filepath.Join(artifactFolder, "clusters", targetClusterProxy.GetName()),
+		})
+		initBootstrapCluster(targetClusterProxy, e2eConfig, clusterctlConfigPath, artifactFolder)
+
+		By("Moving the cluster to the second management cluster")
+		waitForStableAPIServers(ctx, bootstrapClusterProxy, targetClusterProxy)
+		clusterctl.Move(ctx, clusterctl.MoveInput{
+			LogFolder:            filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+			ClusterctlConfigPath: clusterctlConfigPath,
+			FromKubeconfigPath:   bootstrapClusterProxy.GetKubeconfigPath(),
+			ToKubeconfigPath:     targetClusterProxy.GetKubeconfigPath(),
+			Namespace:            namespace.Name,
+		})
+		clusters := &clusterv1.ClusterList{}
+		Expect(bootstrapClusterProxy.GetClient().List(ctx, clusters, client.InNamespace(namespace.Name))).To(Succeed())
+		Expect(clusters.Items).To(BeEmpty(), "the cluster is still in the first management cluster")
+
+		By("Checking that the moved objects are reconciled")
+		movedCluster := framework.DiscoveryAndWaitForCluster(ctx, framework.DiscoveryAndWaitForClusterInput{
+			Getter:    targetClusterProxy.GetClient(),
+			Namespace: namespace.Name,
+			Name:      clusterName,
+		}, e2eConfig.GetIntervals(specName, "wait-cluster")...)
+		Expect(targetClusterProxy.GetClient().Get(ctx, client.ObjectKey{Namespace: namespace.Name, Name: secret.Name(clusterName, secret.Kubeconfig)}, &corev1.Secret{})).To(Succeed())
+		Eventually(func() (bool, error) {
+			docluster := &infrav1.DOCluster{}
+			err := targetClusterProxy.GetClient().Get(ctx, client.ObjectKey{Namespace: namespace.Name, Name: movedCluster.Spec.InfrastructureRef.Name}, docluster)
+			return docluster.Status.Ready, err
+		}, e2eConfig.GetIntervals(specName, "wait-cluster")...).Should(BeTrue())
+		Eventually(func() ([]string, error) {
+			domachines := &infrav1.DOMachineList{}
+			err := targetClusterProxy.GetClient().List(ctx, domachines, client.InNamespace(namespace.Name))
+			notReady := []string{}
+			for _, domachine := range domachines.Items {
+				if !domachine.Status.Ready {
+					notReady = append(notReady, domachine.Name)
+				}
+			}
+			return notReady, err
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(BeEmpty())
+
+		By("Scaling the workers from the second management cluster")
+		machineDeployments := framework.GetMachineDeploymentsByCluster(ctx, framework.GetMachineDeploymentsByClusterInput{
+			Lister:      targetClusterProxy.GetClient(),
+			ClusterName: clusterName,
+			Namespace:   namespace.Name,
+		})
+		Expect(machineDeployments).To(HaveLen(1))
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              targetClusterProxy,
+			Cluster:                   movedCluster,
+			MachineDeployment:         machineDeployments[0],
+			Replicas:                  2,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+
+		By("Deleting the cluster from the second management cluster")
+		framework.DeleteClusterAndWait(ctx, framework.DeleteClusterAndWaitInput{
+			Client:  targetClusterProxy.GetClient(),
+			Cluster: movedCluster,
+		}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...)
+		Eventually(func() ([]string, error) {
+			return taggedDropletIDs(ctx, clusterTag)
+		}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...).Should(BeEmpty(), "droplets of the cluster were left behind")
+	})
+})
@@ -0,0 +1,128 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/pointer"
+
+	capi_e2e "sigs.k8s.io/cluster-api/test/e2e"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+)
+
+// Test suite constants for the e2e config variables of the workload clusters.
+const (
+	DORegion                   = "DO_REGION"
+	DOControlPlaneMachineType  = "DO_CONTROL_PLANE_MACHINE_TYPE"
+	DOControlPlaneMachineImage = "DO_CONTROL_PLANE_MACHINE_IMAGE"
+	DONodeMachineType          = "DO_NODE_MACHINE_TYPE"
+	DONodeMachineImage         = "DO_NODE_MACHINE_IMAGE"
+	DOSSHKeyFingerprint        = "DO_SSH_KEY_FINGERPRINT"
+)
+
+// suiteParameter is an e2e config variable, or a set of variables sharing a
+// value, that can be set from a flag of the suite.
+type suiteParameter struct {
+	flag      string
+	variables []string
+	// defaultValue is used when neither the flag, the env var nor the e2e
+	// config sets the variables. Parameters without default are required.
+	defaultValue *string
+	usage        string
+	value        string
+}
+
+// suiteParameters are resolved, by order of precedence, from their flag, the
+// env vars named after their variables, the e2e config and their default.
+var suiteParameters = []*suiteParameter{
+	{
+		flag:         "e2e.region",
+		variables:    []string{DORegion},
+		defaultValue: pointer.StringPtr("nyc1"),
+		usage:        "DigitalOcean region of the workload clusters",
+	},
+	{
+		flag:         "e2e.droplet-size",
+		variables:    []string{DOControlPlaneMachineType, DONodeMachineType},
+		defaultValue: pointer.StringPtr("s-2vcpu-2gb"),
+		usage:        "size slug of the droplets of the workload clusters",
+	},
+	{
+		flag:      "e2e.image",
+		variables: []string{DOControlPlaneMachineImage, DONodeMachineImage},
+		// The manager looks up the ubuntu-2004-kube-<version> image of the
+		// account for the DOMachines without image.
+		defaultValue: pointer.StringPtr(""),
+		usage:        "ID or slug of the image of the droplets of the workload clusters, the ubuntu-2004-kube-<version> image of the account when empty",
+	},
+	{
+		flag:      "e2e.ssh-key-fingerprint",
+		variables: []string{DOSSHKeyFingerprint},
+		usage:     "fingerprint of the SSH key of the account authorized on the droplets of the workload clusters",
+	},
+	{
+		flag:      "e2e.kubernetes-version",
+		variables: []string{KubernetesVersion},
+		usage:     "Kubernetes version of the workload clusters",
+	},
+	{
+		flag:      "e2e.kubernetes-version-upgrade-from",
+		variables: []string{capi_e2e.KubernetesVersionUpgradeFrom},
+		usage:     "Kubernetes version of the workload clusters before the upgrade",
+	},
+	{
+		flag:      "e2e.kubernetes-version-upgrade-to",
+		variables: []string{capi_e2e.KubernetesVersionUpgradeTo},
+		usage:     "Kubernetes version of the workload clusters after the upgrade",
+	},
+}
+
+func init() {
+	for _, p := range suiteParameters {
+		flag.StringVar(&p.value, p.flag, "", fmt.Sprintf("%s, overrides the %v variables", p.usage, p.variables))
+	}
+}
+
+// resolveSuiteParameters sets the variables of the suite parameters in config.
+// The flags are also exported as env vars, since env vars take precedence
+// over the e2e config for both GetVariable and clusterctl.
+func resolveSuiteParameters(config *clusterctl.E2EConfig) {
+	for _, p := range suiteParameters {
+		for _, variable := range p.variables {
+			if p.value != "" {
+				Expect(os.Setenv(variable, p.value)).To(Succeed())
+			}
+			value, ok := os.LookupEnv(variable)
+			if !ok {
+				value, ok = config.Variables[variable]
+			}
+			if (!ok || value == "") && p.defaultValue != nil {
+				value = *p.defaultValue
+			}
+			Expect(value != "" || p.defaultValue != nil).To(BeTrue(),
+				"Missing %s variable: set the -%s flag, the %s env var or the variable in the e2e config", variable, p.flag, variable)
+			config.Variables[variable] = value
+		}
+	}
+}
@@ -0,0 +1,339 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InitWithBinary is the URL of the clusterctl of the previous release,
+	// with {OS} and {ARCH} placeholders.
+	InitWithBinary = "INIT_WITH_BINARY"
+	// DOProviderUpgradeMachineImage is the image of the droplets of the v1alpha3
+	// template, set by the provider upgrade spec: the previous release can not
+	// look the image up.
+	DOProviderUpgradeMachineImage = "DO_PROVIDER_UPGRADE_MACHINE_IMAGE"
+)
+
+// v1alpha3Version is the API version of the previous release.
+const v1alpha3Version = "v1alpha3"
+
+var _ = Describe("Provider upgrade [upgrade]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "provider-upgrade"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+
+		managementClusterProxy framework.ClusterProxy
+		testNamespace          *corev1.Namespace
+		testCancelWatches      context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+		Expect(e2eConfig.GetVariable(InitWithBinary)).ToNot(BeEmpty(), "Invalid argument. %s variable can't be empty when calling %s spec", InitWithBinary, specName)
+		Expect(e2eConfig.GetProviderVersions(e2eConfig.InfrastructureProviders()[0])).To(HaveLen(2), "Invalid argument. The e2e config must have the previous release and the current build of the provider when calling %s spec", specName)
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+		managementClusterProxy, testNamespace, testCancelWatches = nil, nil, nil
+	})
+
+	AfterEach(func() {
+		if testNamespace != nil {
+			framework.DumpAllResources(ctx, framework.DumpAllResourcesInput{
+				Lister:    managementClusterProxy.GetClient(),
+				Namespace: testNamespace.Name,
+				LogPath:   filepath.Join(artifactFolder, "clusters", clusterName, "resources"),
+			})
+
+			if !skipCleanup {
+				Byf("Deleting the clusters of namespace %s of the management cluster", testNamespace.Name)
+				framework.DeleteAllClustersAndWait(ctx, framework.DeleteAllClustersAndWaitInput{
+					Client:    managementClusterProxy.GetClient(),
+					Namespace: testNamespace.Name,
+				}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...)
+			}
+			testCancelWatches()
+		}
+
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should keep reconciling the clusters of the previous release after clusterctl upgrade", func() {
+		// The management cluster, and the workload cluster scaled to two workers.
+		requireDropletCapacity(ctx, specName, 5)
+
+		By("Creating a workload cluster to be used as a management cluster")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+		managementClusterProxy = bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
+
+		By("Installing the previous release of the providers with its clusterctl")
+		clusterctlBinaryPath := downloadClusterctl(e2eConfig.GetVariable(InitWithBinary))
+		defer os.Remove(clusterctlBinaryPath)
+		clusterctl.InitManagementClusterAndWatchControllerLogs(ctx, clusterctl.InitManagementClusterAndWatchControllerLogsInput{
+			ClusterctlBinaryPath:    clusterctlBinaryPath,
+			ClusterProxy:            managementClusterProxy,
+			ClusterctlConfigPath:    clusterctlConfigPath,
+			CoreProvider:            e2eConfig.GetProvidersWithOldestVersion(config.ClusterAPIProviderName)[0],
+			BootstrapProviders:      e2eConfig.GetProvidersWithOldestVersion(config.KubeadmBootstrapProviderName),
+			ControlPlaneProviders:   e2eConfig.GetProvidersWithOldestVersion(config.KubeadmControlPlaneProviderName),
+			InfrastructureProviders: e2eConfig.GetProvidersWithOldestVersion(e2eConfig.InfrastructureProviders()...),
+			LogFolder:               filepath.Join(artifactFolder, "clusters", clusterName),
+		}, e2eConfig.GetIntervals(specName, "wait-controllers")...)
+
+		By("Creating a cluster with the previous release")
+		testNamespace, testCancelWatches = framework.CreateNamespaceAndWatchEvents(ctx, framework.CreateNamespaceAndWatchEventsInput{
+			Creator:   managementClusterProxy.GetClient(),
+			ClientSet: managementClusterProxy.GetClientSet(),
+			Name:      namespace.Name,
+			LogFolder: filepath.Join(artifactFolder, "clusters", clusterName),
+		})
+		kubernetesVersion := e2eConfig.GetVariable(KubernetesVersion)
+		image := e2eConfig.GetVariable(DONodeMachineImage)
+		if image == "" {
+			imageID, err := lookupMachineImageID(ctx, fmt.Sprintf("ubuntu-2004-kube-%s", kubernetesVersion), e2eConfig.GetVariable(DORegion))
+			Expect(err).ToNot(HaveOccurred())
+			image = fmt.Sprint(imageID)
+		}
+		// The clusterctl of the previous release reads the variable from the
+		// environment.
+		Expect(os.Setenv(DOProviderUpgradeMachineImage, image)).To(Succeed())
+		defer os.Unsetenv(DOProviderUpgradeMachineImage)
+
+		testClusterName := specClusterName("capdo-e2e")
+		template := clusterctl.ConfigClusterWithBinary(ctx, clusterctlBinaryPath, clusterctl.ConfigClusterInput{
+			LogFolder:                filepath.Join(artifactFolder, "clusters", clusterName),
+			ClusterctlConfigPath:     clusterctlConfigPath,
+			KubeconfigPath:           managementClusterProxy.GetKubeconfigPath(),
+			InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+			Flavor:                   clusterctl.DefaultFlavor,
+			Namespace:                testNamespace.Name,
+			ClusterName:              testClusterName,
+			KubernetesVersion:        kubernetesVersion,
+			ControlPlaneMachineCount: pointer.Int64Ptr(1),
+			WorkerMachineCount:       pointer.Int64Ptr(1),
+		})
+		Expect(managementClusterProxy.Apply(ctx, template)).To(Succeed())
+
+		// The v1alpha4 types of the framework can not be used before the
+		// upgrade, the v1alpha3 Machines are read unstructured.
+		Eventually(func() (int, error) {
+			machines := &unstructured.UnstructuredList{}
+			machines.SetGroupVersionKind(schema.GroupVersionKind{Group: clusterv1.GroupVersion.Group, Version: v1alpha3Version, Kind: "MachineList"})
+			if err := managementClusterProxy.GetClient().List(ctx, machines, client.InNamespace(testNamespace.Name), client.MatchingLabels{clusterv1.ClusterLabelName: testClusterName}); err != nil {
+				return 0, err
+			}
+			provisioned := 0
+			for _, machine := range machines.Items {
+				if _, ok, _ := unstructured.NestedMap(machine.Object, "status", "nodeRef"); ok {
+					provisioned++
+				}
+			}
+			return provisioned, nil
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Equal(2), "Machines of cluster %s were not provisioned by the previous release", testClusterName)
+
+		dropletIDs, err := taggedDropletIDs(ctx, infrav1.ClusterNameTag(testClusterName))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dropletIDs).To(HaveLen(2))
+		oldDOCluster := getDOCluster(ctx, managementClusterProxy, v1alpha3Version, testNamespace.Name, testClusterName)
+		loadBalancerID, _, _ := unstructured.NestedString(oldDOCluster.Object, "status", "network", "apiServerLoadbalancersRef", "resourceId")
+		Expect(loadBalancerID).ToNot(BeEmpty(), "DOCluster %s has no load balancer", testClusterName)
+
+		By("Upgrading the providers to the current build")
+		clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
+			ClusterctlConfigPath: clusterctlConfigPath,
+			ClusterProxy:         managementClusterProxy,
+			Contract:             clusterv1.GroupVersion.Version,
+			LogFolder:            filepath.Join(artifactFolder, "clusters", clusterName),
+		}, e2eConfig.GetIntervals(specName, "wait-controllers")...)
+
+		By("Checking that the DOCluster and the DOMachines convert to every served version")
+		for _, version := range []string{v1alpha3Version, "v1alpha4", infrav1.GroupVersion.Version} {
+			doCluster := getDOCluster(ctx, managementClusterProxy, version, testNamespace.Name, testClusterName)
+			Expect(doCluster.GetFinalizers()).To(ContainElement(infrav1.ClusterFinalizer), "DOCluster %s lost its finalizer as %s", testClusterName, version)
+
+			doMachines := &unstructured.UnstructuredList{}
+			doMachines.SetGroupVersionKind(schema.GroupVersionKind{Group: infrav1.GroupVersion.Group, Version: version, Kind: "DOMachineList"})
+			Expect(managementClusterProxy.GetClient().List(ctx, doMachines, client.InNamespace(testNamespace.Name), client.MatchingLabels{clusterv1.ClusterLabelName: testClusterName})).To(Succeed())
+			Expect(doMachines.Items).To(HaveLen(2))
+			for _, doMachine := range doMachines.Items {
+				Expect(doMachine.GetFinalizers()).To(ContainElement(infrav1.MachineFinalizer), "DOMachine %s lost its finalizer as %s", doMachine.GetName(), version)
+			}
+		}
+
+		By("Checking that the upgraded provider keeps the DigitalOcean resources")
+		Eventually(func() error {
+			doCluster := &infrav1.DOCluster{}
+			if err := managementClusterProxy.GetClient().Get(ctx, client.ObjectKey{Namespace: testNamespace.Name, Name: testClusterName}, doCluster); err != nil {
+				return err
+			}
+			if !doCluster.Status.Ready {
+				return errors.Errorf("DOCluster %s is not ready", testClusterName)
+			}
+			if id := doCluster.Status.Network.APIServerLoadbalancersRef.ResourceID; id != loadBalancerID {
+				return errors.Errorf("DOCluster %s has load balancer %s, want %s", testClusterName, id, loadBalancerID)
+			}
+
+			doMachines := &infrav1.DOMachineList{}
+			if err := managementClusterProxy.GetClient().List(ctx, doMachines, client.InNamespace(testNamespace.Name), client.MatchingLabels{clusterv1.ClusterLabelName: testClusterName}); err != nil {
+				return err
+			}
+			for _, doMachine := range doMachines.Items {
+				if !doMachine.Status.Ready {
+					return errors.Errorf("DOMachine %s is not ready", doMachine.Name)
+				}
+			}
+			return nil
+		}, e2eConfig.GetIntervals(specName, "wait-cluster")...).Should(Succeed())
+		Expect(taggedDropletIDs(ctx, infrav1.ClusterNameTag(testClusterName))).To(ConsistOf(dropletIDs), "The upgraded provider replaced the droplets of cluster %s", testClusterName)
+
+		By("Scaling the workers of the cluster with the upgraded provider")
+		testCluster := framework.GetClusterByName(ctx, framework.GetClusterByNameInput{
+			Getter:    managementClusterProxy.GetClient(),
+			Namespace: testNamespace.Name,
+			Name:      testClusterName,
+		})
+		machineDeployments := framework.GetMachineDeploymentsByCluster(ctx, framework.GetMachineDeploymentsByClusterInput{
+			Lister:      managementClusterProxy.GetClient(),
+			ClusterName: testClusterName,
+			Namespace:   testNamespace.Name,
+		})
+		Expect(machineDeployments).To(HaveLen(1))
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              managementClusterProxy,
+			Cluster:                   testCluster,
+			MachineDeployment:         machineDeployments[0],
+			Replicas:                  2,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+
+		By("Deleting the cluster with the upgraded provider")
+		framework.DeleteClusterAndWait(ctx, framework.DeleteClusterAndWaitInput{
+			Client:  managementClusterProxy.GetClient(),
+			Cluster: testCluster,
+		}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...)
+		Eventually(func() ([]string, error) {
+			return taggedDropletIDs(ctx, infrav1.ClusterNameTag(testClusterName))
+		}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...).Should(BeEmpty(), "The droplets of cluster %s were not deleted", testClusterName)
+	})
+})
+
+// getDOCluster gets a DOCluster as the given version of the API.
+func getDOCluster(ctx context.Context, clusterProxy framework.ClusterProxy, version, namespace, name string) *unstructured.Unstructured {
+	doCluster := &unstructured.Unstructured{}
+	doCluster.SetGroupVersionKind(schema.GroupVersionKind{Group: infrav1.GroupVersion.Group, Version: version, Kind: "DOCluster"})
+	Expect(clusterProxy.GetClient().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, doCluster)).To(Succeed(), "Failed to get DOCluster %s as %s", name, version)
+	return doCluster
+}
+
+// downloadClusterctl downloads the clusterctl binary of url, with the {OS} and
+// {ARCH} placeholders replaced, and returns its path.
+func downloadClusterctl(url string) string {
+	url = strings.NewReplacer("{OS}", runtime.GOOS, "{ARCH}", runtime.GOARCH).Replace(url)
+	Byf("Downloading clusterctl from %s", url)
+
+	resp, err := http.Get(url) //nolint:gosec
+	Expect(err).ToNot(HaveOccurred(), "Failed to download clusterctl")
+	defer resp.Body.Close()
+	Expect(resp.StatusCode).To(Equal(http.StatusOK), "Failed to download clusterctl from %s", url)
+
+	binary, err := ioutil.TempFile("", "clusterctl")
+	Expect(err).ToNot(HaveOccurred())
+	defer binary.Close()
+	_, err = io.Copy(binary, resp.Body)
+	Expect(err).ToNot(HaveOccurred(), "Failed to download clusterctl")
+	Expect(binary.Chmod(0755)).To(Succeed())
+	return binary.Name()
+}
+
+// lookupMachineImageID returns the ID of the most recent image of the account
+// named name available in region, as the current build looks it up for the
+// DOMachines without image.
+func lookupMachineImageID(ctx context.Context, name, region string) (int, error) {
+	c := godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"))
+	var found *godo.Image
+	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		images, resp, err := c.Images.ListUser(ctx, opt)
+		for i := range images {
+			image := images[i]
+			if image.Name != name || !containsString(image.Regions, region) {
+				continue
+			}
+			if found == nil || image.Created > found.Created {
+				found = &image
+			}
+		}
+		return resp, err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list images")
+	}
+	if found == nil {
+		return 0, errors.Errorf("no image %s in region %s", name, region)
+	}
+	return found.ID, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// minSpecDroplets is the number of droplets of the smallest spec, a single
+// control plane and a single worker.
+const minSpecDroplets = 2
+
+// dropletCapacity returns how many more droplets the DigitalOcean account can
+// create, and its droplet limit.
+func dropletCapacity(ctx context.Context) (int, int, error) {
+	c := godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"))
+	account, _, err := c.Account.Get(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to get the account")
+	}
+	if account.Status != "active" {
+		return 0, 0, errors.Errorf("the account is %s: %s", account.Status, account.StatusMessage)
+	}
+	// The total of the first page is the number of droplets of the account.
+	_, resp, err := c.Droplets.List(ctx, &godo.ListOptions{PerPage: 1})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to list droplets")
+	}
+	used := 0
+	if resp.Meta != nil {
+		used = resp.Meta.Total
+	}
+	free := account.DropletLimit - used
+	if free < 0 {
+		free = 0
+	}
+	return free, account.DropletLimit, nil
+}
+
+// checkDropletQuota fails the suite before anything is created when the
+// account cannot fit even the smallest spec.
+func checkDropletQuota(ctx context.Context) {
+	free, limit, err := dropletCapacity(ctx)
+	Expect(err).ToNot(HaveOccurred(), "Failed to get the droplet quota of the DigitalOcean account")
+	Expect(free).To(BeNumerically(">=", minSpecDroplets),
+		"The DigitalOcean account has room for %d droplets of its limit of %d, the smallest spec needs %d: delete the leaked droplets, e.g. with make do-janitor, or raise the limit", free, limit, minSpecDroplets)
+}
+
+// requireDropletCapacity skips the spec when the account has no room left for
+// the droplets it creates at its peak, rather than leaving a half provisioned
+// cluster behind once the droplet limit is reached. Specs running in parallel
+// share the limit, so the check only narrows the window.
+func requireDropletCapacity(ctx context.Context, specName string, droplets int) {
+	free, limit, err := dropletCapacity(ctx)
+	Expect(err).ToNot(HaveOccurred(), "Failed to get the droplet quota of the DigitalOcean account")
+	if free < droplets {
+		Skip(fmt.Sprintf("The %s spec needs %d droplets, the DigitalOcean account has room for %d of its limit of %d", specName, droplets, free, limit))
+	}
+}
@@ -0,0 +1,150 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+)
+
+// Test suite constants for the scale spec.
+const (
+	ScaleWorkerMachineCount  = "SCALE_WORKER_MACHINE_COUNT"
+	ScaleBaseline            = "SCALE_BASELINE"
+	ScaleRegressionTolerance = "SCALE_REGRESSION_TOLERANCE"
+)
+
+// scaleMeasurement is what the scale spec measures, and the format of its
+// baseline.
+type scaleMeasurement struct {
+	Workers int `json:"workers"`
+	// TimeToReadySeconds is the time from the creation of the cluster to all
+	// its nodes being Ready.
+	TimeToReadySeconds float64 `json:"timeToReadySeconds"`
+	// APIRequests is the number of DigitalOcean API requests sent by the
+	// manager meanwhile, retries included.
+	APIRequests int `json:"apiRequests"`
+	// RateLimitRemaining is what was left of the rate limit window of the
+	// token once the nodes were Ready.
+	RateLimitRemaining int `json:"rateLimitRemaining"`
+}
+
+var _ = Describe("Provisioning at scale [scale]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "scale"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+		Expect(e2eConfig.Variables).To(HaveKey(ScaleWorkerMachineCount))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should provision the workers within the baseline", func() {
+		workers, err := strconv.Atoi(e2eConfig.GetVariable(ScaleWorkerMachineCount))
+		Expect(err).ToNot(HaveOccurred(), "%s must be a number", ScaleWorkerMachineCount)
+		requireDropletCapacity(ctx, specName, workers+1)
+
+		before, err := scrapeManagerMetrics(ctx)
+		Expect(err).ToNot(HaveOccurred(), "Failed to read the metrics of the manager")
+		start := time.Now()
+
+		Byf("Creating a cluster with %d workers", workers)
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, int64(workers), result)
+		Expect(result.MachineDeployments).To(HaveLen(1))
+		workloadClient := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName).GetClient()
+		machines := machineDeploymentMachines(ctx, result.MachineDeployments[0])
+		Eventually(func() (int, error) {
+			return readyNodes(ctx, workloadClient, machines)
+		}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Equal(workers))
+		timeToReady := time.Since(start)
+
+		after, err := scrapeManagerMetrics(ctx)
+		Expect(err).ToNot(HaveOccurred(), "Failed to read the metrics of the manager")
+		measurement := scaleMeasurement{
+			Workers:            workers,
+			TimeToReadySeconds: timeToReady.Seconds(),
+			APIRequests:        after.requests - before.requests,
+			RateLimitRemaining: after.rateLimitRemaining,
+		}
+		Byf("Provisioned %d workers in %s with %d DigitalOcean API requests, %d left in the rate limit window",
+			workers, timeToReady.Round(time.Second), measurement.APIRequests, measurement.RateLimitRemaining)
+
+		data, err := json.MarshalIndent(measurement, "", "  ")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(artifactFolder, "scale"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(artifactFolder, "scale", clusterName+".json"), append(data, '\n'), 0600)).To(Succeed())
+
+		By("Comparing the measurements with the baseline")
+		baseline, ok := readScaleBaseline(e2eConfig.GetVariable(ScaleBaseline))
+		if !ok || baseline.Workers != workers {
+			Byf("No baseline recorded for %d workers in %s, skipping the comparison", workers, e2eConfig.GetVariable(ScaleBaseline))
+			return
+		}
+		tolerance, err := strconv.ParseFloat(e2eConfig.GetVariable(ScaleRegressionTolerance), 64)
+		Expect(err).ToNot(HaveOccurred(), "%s must be a fraction, e.g. 0.2", ScaleRegressionTolerance)
+		Expect(measurement.TimeToReadySeconds).To(BeNumerically("<=", baseline.TimeToReadySeconds*(1+tolerance)),
+			"The workers took %.0fs to be Ready, more than the %.0fs of the baseline", measurement.TimeToReadySeconds, baseline.TimeToReadySeconds)
+		Expect(float64(measurement.APIRequests)).To(BeNumerically("<=", float64(baseline.APIRequests)*(1+tolerance)),
+			"The manager sent %d DigitalOcean API requests, more than the %d of the baseline", measurement.APIRequests, baseline.APIRequests)
+	})
+})
+
+// readScaleBaseline returns the measurements recorded in path, and false when
+// there are none.
+func readScaleBaseline(path string) (scaleMeasurement, bool) {
+	baseline := scaleMeasurement{}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return baseline, false
+	}
+	Expect(err).ToNot(HaveOccurred(), "Failed to read the scale baseline")
+	Expect(json.Unmarshal(data, &baseline)).To(Succeed(), "Failed to decode the scale baseline %s", path)
+	return baseline, true
+}
@@ -0,0 +1,160 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Self-hosted workload cluster [lifecycle]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "self-hosted"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+
+		selfHostedClusterProxy  framework.ClusterProxy
+		selfHostedCancelWatches context.CancelFunc
+		selfHostedCluster       *clusterv1.Cluster
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+		selfHostedClusterProxy, selfHostedCancelWatches, selfHostedCluster = nil, nil, nil
+	})
+
+	AfterEach(func() {
+		if selfHostedCluster != nil {
+			framework.DumpAllResources(ctx, framework.DumpAllResourcesInput{
+				Lister:    selfHostedClusterProxy.GetClient(),
+				Namespace: namespace.Name,
+				LogPath:   filepath.Join(artifactFolder, "clusters", clusterName, "resources"),
+			})
+
+			By("Moving the cluster back to the bootstrap cluster")
+			waitForStableAPIServers(ctx, bootstrapClusterProxy, selfHostedClusterProxy)
+			clusterctl.Move(ctx, clusterctl.MoveInput{
+				LogFolder:            filepath.Join(artifactFolder, "clusters", clusterName),
+				ClusterctlConfigPath: clusterctlConfigPath,
+				FromKubeconfigPath:   selfHostedClusterProxy.GetKubeconfigPath(),
+				ToKubeconfigPath:     bootstrapClusterProxy.GetKubeconfigPath(),
+				Namespace:            namespace.Name,
+			})
+			result.Cluster = framework.DiscoveryAndWaitForCluster(ctx, framework.DiscoveryAndWaitForClusterInput{
+				Getter:    bootstrapClusterProxy.GetClient(),
+				Namespace: namespace.Name,
+				Name:      clusterName,
+			}, e2eConfig.GetIntervals(specName, "wait-cluster")...)
+		}
+		if selfHostedCancelWatches != nil {
+			selfHostedCancelWatches()
+		}
+
+		// Deleting the cluster from the bootstrap cluster checks that the
+		// provider still owns the DigitalOcean resources after the round trip.
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should keep reconciling a cluster moved into itself", func() {
+		requireDropletCapacity(ctx, specName, 3)
+
+		By("Creating a workload cluster")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+
+		By("Turning the workload cluster into a management cluster")
+		selfHostedClusterProxy = bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
+		_, selfHostedCancelWatches = framework.CreateNamespaceAndWatchEvents(ctx, framework.CreateNamespaceAndWatchEventsInput{
+			Creator:   selfHostedClusterProxy.GetClient(),
+			ClientSet: selfHostedClusterProxy.GetClientSet(),
+			Name:      namespace.Name,
+			LogFolder: filepath.Join(artifactFolder, "clusters", clusterName),
+		})
+		clusterctl.InitManagementClusterAndWatchControllerLogs(ctx, clusterctl.InitManagementClusterAndWatchControllerLogsInput{
+			ClusterProxy:            selfHostedClusterProxy,
+			ClusterctlConfigPath:    clusterctlConfigPath,
+			InfrastructureProviders: e2eConfig.InfrastructureProviders(),
+			LogFolder:               filepath.Join(artifactFolder, "clusters", clusterName),
+		}, e2eConfig.GetIntervals(specName, "wait-controllers")...)
+
+		By("Moving the cluster into itself")
+		waitForStableAPIServers(ctx, bootstrapClusterProxy, selfHostedClusterProxy)
+		clusterctl.Move(ctx, clusterctl.MoveInput{
+			LogFolder:            filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName()),
+			ClusterctlConfigPath: clusterctlConfigPath,
+			FromKubeconfigPath:   bootstrapClusterProxy.GetKubeconfigPath(),
+			ToKubeconfigPath:     selfHostedClusterProxy.GetKubeconfigPath(),
+			Namespace:            namespace.Name,
+		})
+		selfHostedCluster = framework.DiscoveryAndWaitForCluster(ctx, framework.DiscoveryAndWaitForClusterInput{
+			Getter:    selfHostedClusterProxy.GetClient(),
+			Namespace: namespace.Name,
+			Name:      clusterName,
+		}, e2eConfig.GetIntervals(specName, "wait-cluster")...)
+
+		By("Scaling the workers from the self-hosted cluster")
+		machineDeployments := framework.GetMachineDeploymentsByCluster(ctx, framework.GetMachineDeploymentsByClusterInput{
+			Lister:      selfHostedClusterProxy.GetClient(),
+			ClusterName: clusterName,
+			Namespace:   namespace.Name,
+		})
+		Expect(machineDeployments).To(HaveLen(1))
+		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+			ClusterProxy:              selfHostedClusterProxy,
+			Cluster:                   selfHostedCluster,
+			MachineDeployment:         machineDeployments[0],
+			Replicas:                  2,
+			WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+	})
+})
+
+// waitForStableAPIServers checks that the API servers of both clusters answer
+// for a few seconds, so that clusterctl move does not fail on a flake.
+func waitForStableAPIServers(ctx context.Context, proxies ...framework.ClusterProxy) {
+	for _, proxy := range proxies {
+		proxy := proxy
+		Consistently(func() error {
+			return proxy.GetClient().Get(ctx, client.ObjectKey{Name: "kube-system"}, &corev1.Namespace{})
+		}, "5s", "100ms").Should(Succeed(), "API server of %s is not stable", proxy.GetName())
+	}
+}
@@ -0,0 +1,257 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Test suite constants for the soak spec.
+const (
+	SoakDuration           = "SOAK_DURATION"
+	SoakCycleInterval      = "SOAK_CYCLE_INTERVAL"
+	SoakMaxGoroutineGrowth = "SOAK_MAX_GOROUTINE_GROWTH"
+	SoakMaxMemoryGrowth    = "SOAK_MAX_MEMORY_GROWTH"
+	SoakMaxAPIErrorRate    = "SOAK_MAX_API_ERROR_RATE"
+
+	// soakWorkers is the number of workers the soak spec scales to in each
+	// cycle, from 1.
+	soakWorkers = 3
+)
+
+// soakSample is what the soak spec records of the manager after each cycle.
+type soakSample struct {
+	Cycle int       `json:"cycle"`
+	Time  time.Time `json:"time"`
+
+	Goroutines          int   `json:"goroutines"`
+	ResidentMemoryBytes int64 `json:"residentMemoryBytes"`
+
+	// APIRequests and APIErrors are counted since the manager started.
+	APIRequests        int `json:"apiRequests"`
+	APIErrors          int `json:"apiErrors"`
+	RateLimitRemaining int `json:"rateLimitRemaining"`
+}
+
+var _ = Describe("Long running cluster [soak]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "soak"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(KubernetesVersion))
+		Expect(e2eConfig.Variables).To(HaveKey(SoakDuration))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should scale, roll and remediate the workers without the manager leaking", func() {
+		duration, err := time.ParseDuration(e2eConfig.GetVariable(SoakDuration))
+		Expect(err).ToNot(HaveOccurred(), "%s must be a duration, e.g. 24h", SoakDuration)
+		cycleInterval, err := time.ParseDuration(e2eConfig.GetVariable(SoakCycleInterval))
+		Expect(err).ToNot(HaveOccurred(), "%s must be a duration, e.g. 30m", SoakCycleInterval)
+		maxGoroutineGrowth := soakFraction(SoakMaxGoroutineGrowth)
+		maxMemoryGrowth := soakFraction(SoakMaxMemoryGrowth)
+		maxAPIErrorRate := soakFraction(SoakMaxAPIErrorRate)
+		// The control plane and the workers scaled to, a single worker being
+		// rolled or remediated meanwhile.
+		requireDropletCapacity(ctx, specName, 1+soakWorkers)
+
+		start, err := scrapeManagerMetrics(ctx)
+		Expect(err).ToNot(HaveOccurred(), "Failed to read the metrics of the manager")
+
+		By("Creating a cluster with 1 worker")
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, clusterctl.DefaultFlavor, e2eConfig.GetVariable(KubernetesVersion), 1, 1, result)
+		Expect(result.MachineDeployments).To(HaveLen(1))
+		machineDeployment := result.MachineDeployments[0]
+		workloadClient := bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName).GetClient()
+
+		By("Installing a MachineHealthCheck for the workers")
+		mhc := &clusterv1.MachineHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: clusterName + "-md-0"},
+			Spec: clusterv1.MachineHealthCheckSpec{
+				ClusterName: clusterName,
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{
+					clusterv1.MachineDeploymentLabelName: machineDeployment.Name,
+				}},
+				UnhealthyConditions: []clusterv1.UnhealthyCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+					{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+				},
+			},
+		}
+		Expect(bootstrapClusterProxy.GetClient().Create(ctx, mhc)).To(Succeed())
+
+		samples := []soakSample{}
+		timeline := filepath.Join(artifactFolder, "soak", clusterName+".json")
+		Expect(os.MkdirAll(filepath.Dir(timeline), 0755)).To(Succeed())
+		for cycle := 1; time.Since(start.sampledAt) < duration; cycle++ {
+			Byf("Cycle %d: scaling the workers to %d", cycle, soakWorkers)
+			framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+				ClusterProxy:              bootstrapClusterProxy,
+				Cluster:                   result.Cluster,
+				MachineDeployment:         machineDeployment,
+				Replicas:                  soakWorkers,
+				WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+			})
+			waitForSoakWorkers(ctx, specName, result.Cluster, machineDeployment, workloadClient, soakWorkers)
+
+			Byf("Cycle %d: scaling the workers back to 1", cycle)
+			framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+				ClusterProxy:              bootstrapClusterProxy,
+				Cluster:                   result.Cluster,
+				MachineDeployment:         machineDeployment,
+				Replicas:                  1,
+				WaitForMachineDeployments: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+			})
+			waitForSoakWorkers(ctx, specName, result.Cluster, machineDeployment, workloadClient, 1)
+
+			Byf("Cycle %d: rolling the workers onto a new DOMachineTemplate", cycle)
+			framework.UpgradeMachineDeploymentInfrastructureRefAndWait(ctx, framework.UpgradeMachineDeploymentInfrastructureRefAndWaitInput{
+				ClusterProxy:                bootstrapClusterProxy,
+				Cluster:                     result.Cluster,
+				MachineDeployments:          []*clusterv1.MachineDeployment{machineDeployment},
+				WaitForMachinesToBeUpgraded: e2eConfig.GetIntervals(specName, "wait-machine-upgrade"),
+			})
+			broken := waitForSoakWorkers(ctx, specName, result.Cluster, machineDeployment, workloadClient, 1)[0]
+
+			Byf("Cycle %d: powering off the droplet of worker %s", cycle, broken.Name)
+			dropletID, err := strconv.Atoi(machineDropletID(broken))
+			Expect(err).ToNot(HaveOccurred())
+			_, _, err = godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")).DropletActions.PowerOff(ctx, dropletID)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() []string {
+				names := []string{}
+				for _, machine := range machineDeploymentMachines(ctx, machineDeployment) {
+					names = append(names, machine.Name)
+				}
+				return names
+			}, e2eConfig.GetIntervals(specName, "wait-machine-remediation")...).ShouldNot(ContainElement(broken.Name))
+			waitForSoakWorkers(ctx, specName, result.Cluster, machineDeployment, workloadClient, 1)
+
+			Byf("Cycle %d: idling for %s", cycle, cycleInterval)
+			time.Sleep(cycleInterval)
+
+			metrics, err := scrapeManagerMetrics(ctx)
+			Expect(err).ToNot(HaveOccurred(), "Failed to read the metrics of the manager")
+			sample := soakSample{
+				Cycle:               cycle,
+				Time:                metrics.sampledAt,
+				Goroutines:          metrics.goroutines,
+				ResidentMemoryBytes: metrics.residentMemoryBytes,
+				APIRequests:         metrics.requests,
+				APIErrors:           metrics.apiErrors,
+				RateLimitRemaining:  metrics.rateLimitRemaining,
+			}
+			samples = append(samples, sample)
+			data, err := json.MarshalIndent(samples, "", "  ")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ioutil.WriteFile(timeline, append(data, '\n'), 0600)).To(Succeed())
+			Byf("Cycle %d: the manager runs %d goroutines in %d MiB, %d of its %d DigitalOcean API requests failed",
+				cycle, sample.Goroutines, sample.ResidentMemoryBytes>>20, sample.APIErrors, sample.APIRequests)
+
+			// A restarted manager, e.g. killed out of memory, starts its
+			// counters over.
+			Expect(metrics.pod).To(Equal(start.pod), "The manager pod was replaced")
+			Expect(metrics.restarts).To(Equal(start.restarts), "The manager restarted")
+		}
+
+		By("Comparing the manager with its state after the first cycle")
+		// The first cycle fills the caches and work queues of the manager,
+		// growth from there on is a leak.
+		Expect(len(samples)).To(BeNumerically(">=", 2), "%s is too short for more than one cycle", SoakDuration)
+		first, last := samples[0], samples[len(samples)-1]
+		Expect(float64(last.Goroutines)).To(BeNumerically("<=", float64(first.Goroutines)*(1+maxGoroutineGrowth)),
+			"The goroutines of the manager grew from %d to %d", first.Goroutines, last.Goroutines)
+		Expect(float64(last.ResidentMemoryBytes)).To(BeNumerically("<=", float64(first.ResidentMemoryBytes)*(1+maxMemoryGrowth)),
+			"The memory of the manager grew from %d MiB to %d MiB", first.ResidentMemoryBytes>>20, last.ResidentMemoryBytes>>20)
+		requests, apiErrors := last.APIRequests-start.requests, last.APIErrors-start.apiErrors
+		Expect(requests).To(BeNumerically(">", 0))
+		Expect(float64(apiErrors)/float64(requests)).To(BeNumerically("<=", maxAPIErrorRate),
+			"%d of the %d DigitalOcean API requests of the manager failed", apiErrors, requests)
+	})
+})
+
+// soakFraction returns the fraction of the soak variable name.
+func soakFraction(name string) float64 {
+	fraction, err := strconv.ParseFloat(e2eConfig.GetVariable(name), 64)
+	Expect(err).ToNot(HaveOccurred(), "%s must be a fraction, e.g. 0.5", name)
+	return fraction
+}
+
+// waitForSoakWorkers waits for machineDeployment to have replicas Machines
+// with Ready nodes and no other worker droplets, and returns the Machines.
+func waitForSoakWorkers(ctx context.Context, specName string, cluster *clusterv1.Cluster, machineDeployment *clusterv1.MachineDeployment, workloadClient client.Client, replicas int) []clusterv1.Machine {
+	var machines []clusterv1.Machine
+	Eventually(func() error {
+		machines = machineDeploymentMachines(ctx, machineDeployment)
+		if len(machines) != replicas {
+			return fmt.Errorf("%d machines instead of %d", len(machines), replicas)
+		}
+		for _, machine := range machines {
+			if machine.Status.NodeRef == nil {
+				return fmt.Errorf("machine %s has no node yet", machine.Name)
+			}
+		}
+		return nil
+	}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Succeed())
+	Eventually(func() ([]string, error) {
+		return workerDropletIDs(ctx, cluster)
+	}, e2eConfig.GetIntervals(specName, "wait-delete-cluster")...).Should(ConsistOf(dropletIDs(machines)))
+	Eventually(func() (int, error) {
+		return readyNodes(ctx, workloadClient, machines)
+	}, e2eConfig.GetIntervals(specName, "wait-worker-nodes")...).Should(Equal(replicas))
+	return machines
+}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -34,10 +35,11 @@ import (
 	"github.com/onsi/ginkgo/reporters"
 	. "github.com/onsi/gomega"
 
-	"sigs.k8s.io/cluster-api-provider-digitalocean/api/v1alpha3"
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	capi_e2e "sigs.k8s.io/cluster-api/test/e2e"
 	"sigs.k8s.io/cluster-api/test/framework"
 	"sigs.k8s.io/cluster-api/test/framework/bootstrap"
@@ -49,9 +51,13 @@ var (
 	// configPath is the path to the e2e config file.
 	configPath string
 
-	// useExistingCluster instructs the test to use the current cluster instead of creating a new one (default discovery rules apply).
+	// useExistingCluster instructs the test to use the management cluster of existingClusterKubeconfig, with the providers
+	// already installed, instead of creating a new one.
 	useExistingCluster bool
 
+	// existingClusterKubeconfig is the kubeconfig of the existing management cluster (default discovery rules apply).
+	existingClusterKubeconfig string
+
 	// artifactFolder is the folder to store e2e test artifacts.
 	artifactFolder string
 
@@ -83,7 +89,9 @@ func init() {
 	flag.StringVar(&configPath, "e2e.config", "", "path to the e2e config file")
 	flag.StringVar(&artifactFolder, "e2e.artifacts-folder", "", "folder where e2e test artifact should be stored")
 	flag.BoolVar(&skipCleanup, "e2e.skip-resource-cleanup", false, "if true, the resource cleanup after tests will be skipped")
-	flag.BoolVar(&useExistingCluster, "e2e.use-existing-cluster", false, "if true, the test uses the current cluster instead of creating a new one (default discovery rules apply)")
+	skipBootstrap, _ := strconv.ParseBool(os.Getenv("E2E_SKIP_BOOTSTRAP"))
+	flag.BoolVar(&useExistingCluster, "e2e.use-existing-cluster", skipBootstrap, "if true, the test uses the management cluster of e2e.kubeconfig, with the providers already installed, instead of creating a kind cluster and installing the providers. Defaults to the E2E_SKIP_BOOTSTRAP env var")
+	flag.StringVar(&existingClusterKubeconfig, "e2e.kubeconfig", "", "kubeconfig of the existing management cluster (default discovery rules apply)")
 	flag.StringVar(&kubetestConfigFilePath, "kubetest.config-file", "", "path to the kubetest configuration file")
 }
 
@@ -109,19 +117,24 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	By(fmt.Sprintf("Loading the e2e test configuration from %q", configPath))
 	e2eConfig = loadE2EConfig(configPath)
 
+	By("Checking the droplet quota of the DigitalOcean account")
+	checkDropletQuota(context.TODO())
+
 	By(fmt.Sprintf("Creating a clusterctl local repository into %q", artifactFolder))
 	clusterctlConfigPath = createClusterctlLocalRepository(e2eConfig, filepath.Join(artifactFolder, "repository"))
 
 	By("Setting up the bootstrap cluster")
 	bootstrapClusterProvider, bootstrapClusterProxy = setupBootstrapCluster(e2eConfig, scheme, useExistingCluster)
 
-	// envsubst the credentials
-	credentials := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
-	b64credentials := base64.StdEncoding.EncodeToString([]byte(credentials))
-	os.Setenv("DO_B64ENCODED_CREDENTIALS", b64credentials)
+	setCredentialsVariable()
 
-	By("Initializing the bootstrap cluster")
-	initBootstrapCluster(bootstrapClusterProxy, e2eConfig, clusterctlConfigPath, artifactFolder)
+	if useExistingCluster {
+		By("Checking the providers of the existing management cluster")
+		watchExistingClusterControllers(bootstrapClusterProxy, e2eConfig, artifactFolder)
+	} else {
+		By("Initializing the bootstrap cluster")
+		initBootstrapCluster(bootstrapClusterProxy, e2eConfig, clusterctlConfigPath, artifactFolder)
+	}
 
 	return []byte(
 		strings.Join([]string{
@@ -142,7 +155,9 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	kubeconfigPath := parts[3]
 
 	e2eConfig = loadE2EConfig(configPath)
-	bootstrapClusterProxy = framework.NewClusterProxy("bootstrap", kubeconfigPath, initScheme())
+	bootstrapClusterProxy = framework.NewClusterProxy("bootstrap", kubeconfigPath, initScheme(), framework.WithMachineLogCollector(sshLogCollector{}))
+	// Self-hosted specs install the provider from every ParallelNode.
+	setCredentialsVariable()
 })
 
 // Using a SynchronizedAfterSuite for controlling how to delete resources shared across ParallelNodes (~ginkgo threads).
@@ -162,14 +177,22 @@ var _ = SynchronizedAfterSuite(func() {
 func initScheme() *runtime.Scheme {
 	sc := runtime.NewScheme()
 	framework.TryAddDefaultSchemes(sc)
-	_ = v1alpha3.AddToScheme(sc)
+	_ = infrav1.AddToScheme(sc)
 
 	return sc
 }
 
+// setCredentialsVariable sets the DO_B64ENCODED_CREDENTIALS variable of the
+// provider components from the DigitalOcean token.
+func setCredentialsVariable() {
+	credentials := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
+	os.Setenv("DO_B64ENCODED_CREDENTIALS", base64.StdEncoding.EncodeToString([]byte(credentials)))
+}
+
 func loadE2EConfig(configPath string) *clusterctl.E2EConfig {
 	config := clusterctl.LoadE2EConfig(context.TODO(), clusterctl.LoadE2EConfigInput{ConfigPath: configPath})
 	Expect(config).ToNot(BeNil(), "Failed to load E2E config from %s", configPath)
+	resolveSuiteParameters(config)
 
 	return config
 }
@@ -200,7 +223,7 @@ func createClusterctlLocalRepository(config *clusterctl.E2EConfig, repositoryFol
 
 func setupBootstrapCluster(config *clusterctl.E2EConfig, scheme *runtime.Scheme, useExistingCluster bool) (bootstrap.ClusterProvider, framework.ClusterProxy) {
 	var clusterProvider bootstrap.ClusterProvider
-	kubeconfigPath := ""
+	kubeconfigPath := existingClusterKubeconfig
 	if !useExistingCluster {
 		clusterProvider = bootstrap.CreateKindBootstrapClusterAndLoadImages(context.TODO(), bootstrap.CreateKindBootstrapClusterAndLoadImagesInput{
 			Name:               config.ManagementClusterName,
@@ -213,7 +236,7 @@ func setupBootstrapCluster(config *clusterctl.E2EConfig, scheme *runtime.Scheme,
 		Expect(kubeconfigPath).To(BeAnExistingFile(), "Failed to get the kubeconfig file for the bootstrap cluster")
 	}
 
-	clusterProxy := framework.NewClusterProxy("bootstrap", kubeconfigPath, scheme)
+	clusterProxy := framework.NewClusterProxy("bootstrap", kubeconfigPath, scheme, framework.WithMachineLogCollector(sshLogCollector{}))
 	Expect(clusterProxy).ToNot(BeNil(), "Failed to get a bootstrap cluster proxy")
 
 	return clusterProvider, clusterProxy
@@ -228,6 +251,36 @@ func initBootstrapCluster(bootstrapClusterProxy framework.ClusterProxy, config *
 	}, config.GetIntervals(bootstrapClusterProxy.GetName(), "wait-controllers")...)
 }
 
+// watchExistingClusterControllers waits for the provider controllers of an
+// existing management cluster, installed by its owner rather than by the
+// suite, and streams their logs to the artifact folder.
+func watchExistingClusterControllers(clusterProxy framework.ClusterProxy, config *clusterctl.E2EConfig, artifactFolder string) {
+	ctx := context.TODO()
+	client := clusterProxy.GetClient()
+	deployments := framework.GetControllerDeployments(ctx, framework.GetControllerDeploymentsInput{Lister: client})
+	var providers []string
+	for _, deployment := range deployments {
+		providers = append(providers, deployment.Labels[clusterv1.ProviderLabelName])
+	}
+	for _, provider := range config.InfrastructureProviders() {
+		Expect(providers).To(ContainElement("infrastructure-"+provider),
+			"The %s provider is not installed by clusterctl in the existing management cluster %s", provider, clusterProxy.GetKubeconfigPath())
+	}
+
+	for _, deployment := range deployments {
+		framework.WaitForDeploymentsAvailable(ctx, framework.WaitForDeploymentsAvailableInput{
+			Getter:     client,
+			Deployment: deployment,
+		}, config.GetIntervals(clusterProxy.GetName(), "wait-controllers")...)
+		framework.WatchDeploymentLogs(ctx, framework.WatchDeploymentLogsInput{
+			GetLister:  client,
+			ClientSet:  clusterProxy.GetClientSet(),
+			Deployment: deployment,
+			LogPath:    filepath.Join(artifactFolder, "clusters", clusterProxy.GetName(), "controllers"),
+		})
+	}
+}
+
 func tearDown(bootstrapClusterProvider bootstrap.ClusterProvider, bootstrapClusterProxy framework.ClusterProxy) {
 	if bootstrapClusterProxy != nil {
 		bootstrapClusterProxy.Dispose(context.TODO())
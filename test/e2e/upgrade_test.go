@@ -0,0 +1,245 @@
+// +build e2e
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-digitalocean/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	capi_e2e "sigs.k8s.io/cluster-api/test/e2e"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiServerMaxConsecutiveFailures is the number of consecutive failed probes
+// of the API server through the load balancer tolerated during an upgrade,
+// e.g. while the load balancer notices a removed control plane droplet.
+const apiServerMaxConsecutiveFailures = 3
+
+var _ = Describe("Workload cluster upgrade [upgrade]", func() {
+	var (
+		ctx           = context.TODO()
+		specName      = "upgrade-workload-cluster"
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		result        *clusterctl.ApplyClusterTemplateAndWaitResult
+		clusterName   string
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "Invalid argument. e2eConfig can't be nil when calling %s spec", specName)
+		Expect(clusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. clusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(bootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. bootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(artifactFolder, 0755)).To(Succeed(), "Invalid argument. artifactFolder can't be created for %s spec", specName)
+
+		Expect(e2eConfig.Variables).To(HaveKey(capi_e2e.KubernetesVersionUpgradeFrom))
+		Expect(e2eConfig.Variables).To(HaveKey(capi_e2e.KubernetesVersionUpgradeTo))
+		Expect(e2eConfig.Variables).To(HaveKey(capi_e2e.EtcdVersionUpgradeTo))
+		Expect(e2eConfig.Variables).To(HaveKey(capi_e2e.CoreDNSVersionUpgradeTo))
+
+		clusterName = specClusterName("capdo-e2e")
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, bootstrapClusterProxy, artifactFolder)
+		result = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	AfterEach(func() {
+		dumpSpecResourcesAndCleanup(ctx, specName, bootstrapClusterProxy, artifactFolder, namespace, cancelWatches, result.Cluster, e2eConfig.GetIntervals, skipCleanup)
+		redactLogs(e2eConfig.GetVariable)
+	})
+
+	It("Should replace the droplets of a highly available cluster upgraded to the next Kubernetes version", func() {
+		// The rollouts surge by one control plane and one worker droplet.
+		requireDropletCapacity(ctx, specName, 7)
+
+		By("Creating a cluster at the previous Kubernetes version")
+		// The upgrades flavor leaves the image out of the DOMachineTemplates, so
+		// that the droplets use the image of the version of their Machine.
+		applyClusterTemplate(ctx, specName, namespace.Name, clusterName, "upgrades", e2eConfig.GetVariable(capi_e2e.KubernetesVersionUpgradeFrom), 3, 2, result)
+		oldDroplets := clusterDropletIDs(ctx, result.Cluster)
+
+		By("Probing the API server through the load balancer during the upgrade")
+		probe := newAPIServerProbe(bootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName))
+		probeCtx, stopProbe := context.WithCancel(ctx)
+		defer stopProbe()
+		go probe.Run(probeCtx, 5*time.Second)
+
+		By("Upgrading the KubeadmControlPlane")
+		framework.UpgradeControlPlaneAndWaitForUpgrade(ctx, framework.UpgradeControlPlaneAndWaitForUpgradeInput{
+			ClusterProxy:                bootstrapClusterProxy,
+			Cluster:                     result.Cluster,
+			ControlPlane:                result.ControlPlane,
+			KubernetesUpgradeVersion:    e2eConfig.GetVariable(capi_e2e.KubernetesVersionUpgradeTo),
+			EtcdImageTag:                e2eConfig.GetVariable(capi_e2e.EtcdVersionUpgradeTo),
+			DNSImageTag:                 e2eConfig.GetVariable(capi_e2e.CoreDNSVersionUpgradeTo),
+			WaitForMachinesToBeUpgraded: e2eConfig.GetIntervals(specName, "wait-machine-upgrade"),
+			WaitForDNSUpgrade:           e2eConfig.GetIntervals(specName, "wait-machine-upgrade"),
+			WaitForEtcdUpgrade:          e2eConfig.GetIntervals(specName, "wait-machine-upgrade"),
+		})
+
+		By("Upgrading the MachineDeployments")
+		framework.UpgradeMachineDeploymentsAndWait(ctx, framework.UpgradeMachineDeploymentsAndWaitInput{
+			ClusterProxy:                bootstrapClusterProxy,
+			Cluster:                     result.Cluster,
+			UpgradeVersion:              e2eConfig.GetVariable(capi_e2e.KubernetesVersionUpgradeTo),
+			MachineDeployments:          result.MachineDeployments,
+			WaitForMachinesToBeUpgraded: e2eConfig.GetIntervals(specName, "wait-worker-nodes"),
+		})
+		stopProbe()
+
+		By("Checking that the API server stayed reachable through the load balancer")
+		Expect(probe.MaxConsecutiveFailures()).To(BeNumerically("<=", apiServerMaxConsecutiveFailures), "API server unreachable through the load balancer, last error: %v", probe.LastError())
+
+		By("Checking that every droplet was replaced")
+		newDroplets := clusterDropletIDs(ctx, result.Cluster)
+		Expect(newDroplets).To(HaveLen(len(oldDroplets)))
+		for id := range oldDroplets {
+			Expect(newDroplets).ToNot(HaveKey(id), "droplet %s was not replaced", id)
+		}
+
+		By("Checking that the load balancer targets the new control plane droplets")
+		Eventually(func() ([]string, error) {
+			return loadBalancerDropletIDs(ctx, result.Cluster)
+		}, e2eConfig.GetIntervals(specName, "wait-control-plane")...).Should(ConsistOf(controlPlaneDropletIDs(ctx, result.Cluster)))
+	})
+})
+
+// apiServerProbe polls the API server of a workload cluster, which is reached
+// through the load balancer of its DOCluster.
+type apiServerProbe struct {
+	cluster framework.ClusterProxy
+
+	mu                     sync.Mutex
+	consecutiveFailures    int
+	maxConsecutiveFailures int
+	lastErr                error
+}
+
+func newAPIServerProbe(cluster framework.ClusterProxy) *apiServerProbe {
+	return &apiServerProbe{cluster: cluster}
+}
+
+// Run probes the API server every interval until ctx is done.
+func (p *apiServerProbe) Run(ctx context.Context, interval time.Duration) {
+	defer GinkgoRecover()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, err := p.cluster.GetClientSet().Discovery().ServerVersion()
+		p.mu.Lock()
+		if err != nil && ctx.Err() == nil {
+			p.lastErr = err
+			p.consecutiveFailures++
+			if p.consecutiveFailures > p.maxConsecutiveFailures {
+				p.maxConsecutiveFailures = p.consecutiveFailures
+			}
+		} else {
+			p.consecutiveFailures = 0
+		}
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MaxConsecutiveFailures returns the longest run of failed probes.
+func (p *apiServerProbe) MaxConsecutiveFailures() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.maxConsecutiveFailures
+}
+
+// LastError returns the error of the last failed probe.
+func (p *apiServerProbe) LastError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastErr
+}
+
+// clusterDropletIDs returns the IDs of the droplets of the Machines of cluster.
+func clusterDropletIDs(ctx context.Context, cluster *clusterv1.Cluster) map[string]bool {
+	machines := &clusterv1.MachineList{}
+	Expect(bootstrapClusterProxy.GetClient().List(ctx, machines, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name})).To(Succeed())
+	ids := map[string]bool{}
+	for _, machine := range machines.Items {
+		ids[machineDropletID(machine)] = true
+	}
+	return ids
+}
+
+// controlPlaneDropletIDs returns the IDs of the droplets of the control plane
+// Machines of cluster.
+func controlPlaneDropletIDs(ctx context.Context, cluster *clusterv1.Cluster) []string {
+	machines := framework.GetControlPlaneMachinesByCluster(ctx, framework.GetControlPlaneMachinesByClusterInput{
+		Lister:      bootstrapClusterProxy.GetClient(),
+		ClusterName: cluster.Name,
+		Namespace:   cluster.Namespace,
+	})
+	ids := []string{}
+	for _, machine := range machines {
+		ids = append(ids, machineDropletID(machine))
+	}
+	return ids
+}
+
+func machineDropletID(machine clusterv1.Machine) string {
+	Expect(machine.Spec.ProviderID).ToNot(BeNil(), "Machine %s has no provider ID", machine.Name)
+	providerID, err := noderefutil.NewProviderID(*machine.Spec.ProviderID)
+	Expect(err).ToNot(HaveOccurred())
+	return providerID.ID()
+}
+
+// loadBalancerDropletIDs returns the IDs of the droplets targeted by the API
+// server load balancer of cluster.
+func loadBalancerDropletIDs(ctx context.Context, cluster *clusterv1.Cluster) ([]string, error) {
+	docluster := &infrav1.DOCluster{}
+	key := client.ObjectKey{Namespace: cluster.Spec.InfrastructureRef.Namespace, Name: cluster.Spec.InfrastructureRef.Name}
+	if err := bootstrapClusterProxy.GetClient().Get(ctx, key, docluster); err != nil {
+		return nil, err
+	}
+	lb, _, err := godo.NewFromToken(os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")).LoadBalancers.Get(ctx, docluster.Status.Network.APIServerLoadbalancersRef.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if lb.Status != "active" {
+		return nil, fmt.Errorf("load balancer %s is %s", lb.ID, lb.Status)
+	}
+	ids := []string{}
+	for _, id := range lb.DropletIDs {
+		ids = append(ids, fmt.Sprint(id))
+	}
+	return ids, nil
+}
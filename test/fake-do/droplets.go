@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakedo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// dropletCreateRequest mirrors godo.DropletCreateRequest, keeping the image
+// and ssh keys raw since they are sent either as an id or as a slug/fingerprint.
+type dropletCreateRequest struct {
+	Name              string            `json:"name"`
+	Region            string            `json:"region"`
+	Size              string            `json:"size"`
+	Image             json.RawMessage   `json:"image"`
+	SSHKeys           []json.RawMessage `json:"ssh_keys"`
+	IPv6              bool              `json:"ipv6"`
+	PrivateNetworking bool              `json:"private_networking"`
+	UserData          string            `json:"user_data,omitempty"`
+	Volumes           []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"volumes,omitempty"`
+	Tags    []string `json:"tags"`
+	VPCUUID string   `json:"vpc_uuid,omitempty"`
+}
+
+// Droplets returns a snapshot of all droplets known to the server.
+func (s *Server) Droplets() []godo.Droplet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropletList("")
+}
+
+// SetDropletStatus changes the status of a droplet out of band, e.g. to
+// simulate a droplet powered off from the console.
+func (s *Server) SetDropletStatus(id int, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.droplets[id]; ok {
+		d.Status = status
+		delete(s.dropletPolls, id)
+	}
+}
+
+// RemoveDroplet deletes a droplet out of band.
+func (s *Server) RemoveDroplet(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.droplets, id)
+	delete(s.dropletPolls, id)
+}
+
+func (s *Server) dropletList(tag string) []godo.Droplet {
+	ids := make([]int, 0, len(s.droplets))
+	for id, d := range s.droplets {
+		if tag != "" && !hasTag(d.Tags, tag) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := make([]godo.Droplet, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, *s.droplets[id])
+	}
+	return list
+}
+
+func (s *Server) serveDroplets(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := s.dropletList(r.URL.Query().Get("tag_name"))
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"droplets": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			s.createDroplet(w, r)
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		s.notFound(w)
+		return
+	}
+	d, ok := s.droplets[id]
+	if !ok {
+		s.notFound(w)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "actions" {
+		s.dropletAction(w, r, d)
+		return
+	}
+	if len(parts) != 1 {
+		s.notFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if d.Status == "new" {
+			s.dropletPolls[id]++
+			if s.dropletPolls[id] > s.opts.DropletBootPolls {
+				d.Status = "active"
+				delete(s.dropletPolls, id)
+			}
+		}
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"droplet": d})
+	case http.MethodDelete:
+		delete(s.droplets, id)
+		delete(s.dropletPolls, id)
+		for _, v := range s.volumes {
+			v.DropletIDs = removeInt(v.DropletIDs, id)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+func (s *Server) createDroplet(w http.ResponseWriter, r *http.Request) {
+	req := &dropletCreateRequest{}
+	if !s.decode(w, r, req) {
+		return
+	}
+	if req.Name == "" || req.Region == "" || req.Size == "" || len(req.Image) == 0 {
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "name, region, size and image are required")
+		return
+	}
+
+	image, ok := s.findImage(req.Image)
+	if !ok {
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "You specified an invalid image for Droplet creation.")
+		return
+	}
+	if s.opts.DropletLimit > 0 && len(s.droplets) >= s.opts.DropletLimit {
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "creating this/these droplet(s) will exceed your droplet limit")
+		return
+	}
+
+	id := s.nextID()
+	d := &godo.Droplet{
+		ID:       id,
+		Name:     req.Name,
+		Region:   &godo.Region{Slug: req.Region, Name: req.Region, Available: true},
+		Image:    image,
+		SizeSlug: req.Size,
+		Size:     &godo.Size{Slug: req.Size, Available: true},
+		Status:   "new",
+		Created:  time.Now().UTC().Format(time.RFC3339),
+		Tags:     append([]string(nil), req.Tags...),
+		VPCUUID:  req.VPCUUID,
+		Networks: &godo.Networks{
+			V4: []godo.NetworkV4{
+				{IPAddress: fmt.Sprintf("10.10.%d.%d", id/256%256, id%256), Netmask: "255.255.0.0", Type: "private"},
+				{IPAddress: fmt.Sprintf("192.0.%d.%d", id/256%256, id%256), Netmask: "255.255.255.0", Type: "public"},
+			},
+		},
+		VolumeIDs: []string{},
+	}
+	for _, v := range req.Volumes {
+		for _, vol := range s.volumes {
+			if vol.ID == v.ID || (v.Name != "" && vol.Name == v.Name) {
+				vol.DropletIDs = append(vol.DropletIDs, id)
+				d.VolumeIDs = append(d.VolumeIDs, vol.ID)
+			}
+		}
+	}
+	for _, t := range d.Tags {
+		s.ensureTag(t)
+	}
+	s.droplets[id] = d
+
+	action := s.newAction("create", id, "droplet")
+
+	s.writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"droplet": d,
+		"links":   &godo.Links{Actions: []godo.LinkAction{{ID: action.ID, Rel: "create"}}},
+	})
+}
+
+func (s *Server) dropletAction(w http.ResponseWriter, r *http.Request, d *godo.Droplet) {
+	if r.Method != http.MethodPost {
+		s.methodNotAllowed(w)
+		return
+	}
+	req := map[string]interface{}{}
+	if !s.decode(w, r, &req) {
+		return
+	}
+	actionType, _ := req["type"].(string)
+	switch actionType {
+	case "power_off", "shutdown":
+		d.Status = "off"
+	case "power_on", "reboot", "power_cycle":
+		d.Status = "active"
+	case "resize":
+		if size, ok := req["size"].(string); ok {
+			d.SizeSlug = size
+			d.Size = &godo.Size{Slug: size, Available: true}
+		}
+	case "rebuild", "snapshot", "enable_ipv6", "enable_private_networking", "rename":
+	default:
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", fmt.Sprintf("unknown action type %q", actionType))
+		return
+	}
+	s.writeJSON(w, http.StatusCreated, map[string]interface{}{"action": s.newAction(actionType, d.ID, "droplet")})
+}
+
+func (s *Server) findImage(raw json.RawMessage) (*godo.Image, bool) {
+	var id int
+	if err := json.Unmarshal(raw, &id); err == nil {
+		for i := range s.images {
+			if s.images[i].ID == id {
+				return &s.images[i], true
+			}
+		}
+		// Unknown numeric images are treated as private snapshots.
+		return &godo.Image{ID: id, Name: strconv.Itoa(id), Type: "snapshot"}, true
+	}
+
+	var slug string
+	if err := json.Unmarshal(raw, &slug); err != nil {
+		return nil, false
+	}
+	for i := range s.images {
+		if s.images[i].Slug == slug {
+			return &s.images[i], true
+		}
+	}
+	return nil, false
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func removeInt(list []int, v int) []int {
+	out := list[:0]
+	for _, i := range list {
+		if i != v {
+			out = append(out, i)
+		}
+	}
+	return out
+}
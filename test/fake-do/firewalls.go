@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakedo
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// Firewalls returns a snapshot of all firewalls known to the server.
+func (s *Server) Firewalls() []godo.Firewall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.firewallList()
+}
+
+// RemoveFirewall deletes a firewall out of band.
+func (s *Server) RemoveFirewall(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.firewalls, id)
+}
+
+func (s *Server) firewallList() []godo.Firewall {
+	list := make([]godo.Firewall, 0, len(s.firewalls))
+	for _, id := range sortedKeys(s.firewalls) {
+		list = append(list, *s.firewalls[id])
+	}
+	return list
+}
+
+func (s *Server) serveFirewalls(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := s.firewallList()
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"firewalls": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			req := &godo.FirewallRequest{}
+			if !s.decode(w, r, req) || !s.validFirewall(w, req) {
+				return
+			}
+			fw := &godo.Firewall{
+				ID:      s.nextUUID(),
+				Status:  "succeeded",
+				Created: time.Now().UTC().Format(time.RFC3339),
+			}
+			applyFirewallRequest(fw, req)
+			s.firewalls[fw.ID] = fw
+			s.writeJSON(w, http.StatusAccepted, map[string]interface{}{"firewall": fw})
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	fw, ok := s.firewalls[parts[0]]
+	if !ok || len(parts) != 1 {
+		s.notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"firewall": fw})
+	case http.MethodPut:
+		req := &godo.FirewallRequest{}
+		if !s.decode(w, r, req) || !s.validFirewall(w, req) {
+			return
+		}
+		applyFirewallRequest(fw, req)
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"firewall": fw})
+	case http.MethodDelete:
+		delete(s.firewalls, fw.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+// validFirewall rejects the firewalls referencing unknown tags or droplets,
+// like the API does.
+func (s *Server) validFirewall(w http.ResponseWriter, req *godo.FirewallRequest) bool {
+	if req.Name == "" {
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "name is required")
+		return false
+	}
+	tags := append([]string{}, req.Tags...)
+	for _, rule := range req.InboundRules {
+		if rule.Sources != nil {
+			tags = append(tags, rule.Sources.Tags...)
+		}
+	}
+	for _, rule := range req.OutboundRules {
+		if rule.Destinations != nil {
+			tags = append(tags, rule.Destinations.Tags...)
+		}
+	}
+	for _, tag := range tags {
+		if _, ok := s.tags[tag]; !ok {
+			s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", fmt.Sprintf("tag %q does not exist", tag))
+			return false
+		}
+	}
+	for _, id := range req.DropletIDs {
+		if _, ok := s.droplets[id]; !ok {
+			s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", fmt.Sprintf("droplet %d does not exist", id))
+			return false
+		}
+	}
+	return true
+}
+
+func applyFirewallRequest(fw *godo.Firewall, req *godo.FirewallRequest) {
+	fw.Name = req.Name
+	fw.InboundRules = req.InboundRules
+	fw.OutboundRules = req.OutboundRules
+	fw.Tags = req.Tags
+	fw.DropletIDs = req.DropletIDs
+	for i := range fw.InboundRules {
+		if fw.InboundRules[i].PortRange == "all" {
+			fw.InboundRules[i].PortRange = "0"
+		}
+	}
+	for i := range fw.OutboundRules {
+		if fw.OutboundRules[i].PortRange == "all" {
+			fw.OutboundRules[i].PortRange = "0"
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakedo
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// LoadBalancers returns a snapshot of all load balancers known to the server.
+func (s *Server) LoadBalancers() []godo.LoadBalancer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loadBalancerList()
+}
+
+// SetLoadBalancerStatus changes the status of a load balancer out of band,
+// e.g. to simulate a degraded load balancer.
+func (s *Server) SetLoadBalancerStatus(id, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lb, ok := s.loadBalancers[id]; ok {
+		lb.Status = status
+		delete(s.lbPolls, id)
+	}
+}
+
+// RemoveLoadBalancer deletes a load balancer out of band.
+func (s *Server) RemoveLoadBalancer(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.loadBalancers, id)
+	delete(s.lbPolls, id)
+}
+
+func (s *Server) loadBalancerList() []godo.LoadBalancer {
+	list := make([]godo.LoadBalancer, 0, len(s.loadBalancers))
+	for _, lb := range s.loadBalancers {
+		list = append(list, *lb)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (s *Server) serveLoadBalancers(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := s.loadBalancerList()
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"load_balancers": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			req := &godo.LoadBalancerRequest{}
+			if !s.decode(w, r, req) {
+				return
+			}
+			if req.Name == "" || req.Region == "" || len(req.ForwardingRules) == 0 {
+				s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "name, region and forwarding_rules are required")
+				return
+			}
+			lb := &godo.LoadBalancer{ID: s.nextUUID(), Created: time.Now().UTC().Format(time.RFC3339), Status: "new"}
+			s.applyLoadBalancerRequest(lb, req)
+			s.loadBalancers[lb.ID] = lb
+			s.writeJSON(w, http.StatusAccepted, map[string]interface{}{"load_balancer": lb})
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	lb, ok := s.loadBalancers[parts[0]]
+	if !ok || len(parts) > 2 {
+		s.notFound(w)
+		return
+	}
+	if len(parts) == 2 {
+		s.loadBalancerMembers(w, r, lb, parts[1])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if lb.Status == "new" {
+			s.lbPolls[lb.ID]++
+			if s.lbPolls[lb.ID] > s.opts.LoadBalancerBootPolls {
+				lb.Status = "active"
+				lb.IP = fmt.Sprintf("203.0.113.%d", len(s.loadBalancers)%254+1)
+				delete(s.lbPolls, lb.ID)
+			}
+		}
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"load_balancer": lb})
+	case http.MethodPut:
+		req := &godo.LoadBalancerRequest{}
+		if !s.decode(w, r, req) {
+			return
+		}
+		s.applyLoadBalancerRequest(lb, req)
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"load_balancer": lb})
+	case http.MethodDelete:
+		delete(s.loadBalancers, lb.ID)
+		delete(s.lbPolls, lb.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+func (s *Server) applyLoadBalancerRequest(lb *godo.LoadBalancer, req *godo.LoadBalancerRequest) {
+	lb.Name = req.Name
+	lb.Algorithm = req.Algorithm
+	lb.Region = &godo.Region{Slug: req.Region, Name: req.Region, Available: true}
+	lb.SizeSlug = req.SizeSlug
+	lb.ForwardingRules = req.ForwardingRules
+	lb.HealthCheck = req.HealthCheck
+	lb.StickySessions = req.StickySessions
+	lb.DropletIDs = req.DropletIDs
+	lb.Tag = req.Tag
+	lb.Tags = req.Tags
+	lb.RedirectHttpToHttps = req.RedirectHttpToHttps
+	lb.EnableProxyProtocol = req.EnableProxyProtocol
+	lb.EnableBackendKeepalive = req.EnableBackendKeepalive
+	lb.VPCUUID = req.VPCUUID
+	if lb.Tag != "" {
+		s.ensureTag(lb.Tag)
+	}
+	for _, t := range lb.Tags {
+		s.ensureTag(t)
+	}
+}
+
+func (s *Server) loadBalancerMembers(w http.ResponseWriter, r *http.Request, lb *godo.LoadBalancer, member string) {
+	switch member {
+	case "droplets":
+		req := &struct {
+			IDs []int `json:"droplet_ids"`
+		}{}
+		if !s.decode(w, r, req) {
+			return
+		}
+		for _, id := range req.IDs {
+			lb.DropletIDs = removeInt(lb.DropletIDs, id)
+			if r.Method == http.MethodPost {
+				lb.DropletIDs = append(lb.DropletIDs, id)
+			}
+		}
+	case "forwarding_rules":
+		req := &struct {
+			Rules []godo.ForwardingRule `json:"forwarding_rules"`
+		}{}
+		if !s.decode(w, r, req) {
+			return
+		}
+		for _, rule := range req.Rules {
+			rules := lb.ForwardingRules[:0]
+			for _, existing := range lb.ForwardingRules {
+				if existing != rule {
+					rules = append(rules, existing)
+				}
+			}
+			lb.ForwardingRules = rules
+			if r.Method == http.MethodPost {
+				lb.ForwardingRules = append(lb.ForwardingRules, rule)
+			}
+		}
+	default:
+		s.notFound(w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
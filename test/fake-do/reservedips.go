@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakedo
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/digitalocean/godo"
+)
+
+// ReservedIPs returns a snapshot of all reserved IPs known to the server.
+func (s *Server) ReservedIPs() []godo.FloatingIP {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reservedIPList()
+}
+
+// RemoveReservedIP releases a reserved IP out of band.
+func (s *Server) RemoveReservedIP(ip string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reservedIPs, ip)
+}
+
+func (s *Server) reservedIPList() []godo.FloatingIP {
+	list := make([]godo.FloatingIP, 0, len(s.reservedIPs))
+	for _, fip := range s.reservedIPs {
+		list = append(list, *fip)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].IP < list[j].IP })
+	return list
+}
+
+// serveReservedIPs serves the reserved IPs, which are still called floating
+// IPs by the API version implemented by godo.
+func (s *Server) serveReservedIPs(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := s.reservedIPList()
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"floating_ips": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			req := &godo.FloatingIPCreateRequest{}
+			if !s.decode(w, r, req) {
+				return
+			}
+			if req.Region == "" && req.DropletID == 0 {
+				s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "region or droplet_id is required")
+				return
+			}
+			fip := &godo.FloatingIP{
+				IP:     fmt.Sprintf("198.51.100.%d", s.nextID()%254+1),
+				Region: &godo.Region{Slug: req.Region, Name: req.Region, Available: true},
+			}
+			s.reservedIPs[fip.IP] = fip
+			s.writeJSON(w, http.StatusAccepted, map[string]interface{}{"floating_ip": fip})
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	fip, ok := s.reservedIPs[parts[0]]
+	if !ok || len(parts) > 2 || (len(parts) == 2 && parts[1] != "actions") {
+		s.notFound(w)
+		return
+	}
+	if len(parts) == 2 {
+		s.reservedIPAction(w, r, fip)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"floating_ip": fip})
+	case http.MethodDelete:
+		delete(s.reservedIPs, fip.IP)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+// reservedIPAction applies an assignment right away. Unless Options.ActionPolls
+// is set, its action is also completed right away, as reserved IP assignments
+// usually are.
+func (s *Server) reservedIPAction(w http.ResponseWriter, r *http.Request, fip *godo.FloatingIP) {
+	if r.Method != http.MethodPost {
+		s.methodNotAllowed(w)
+		return
+	}
+	req := &godo.ActionRequest{}
+	if !s.decode(w, r, req) {
+		return
+	}
+	actionType, _ := (*req)["type"].(string)
+	switch actionType {
+	case "assign":
+		id, _ := (*req)["droplet_id"].(float64)
+		d, ok := s.droplets[int(id)]
+		if !ok {
+			s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", fmt.Sprintf("droplet %d not found", int(id)))
+			return
+		}
+		fip.Droplet = d
+	case "unassign":
+		fip.Droplet = nil
+	default:
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", fmt.Sprintf("unknown action type %q", actionType))
+		return
+	}
+	action := s.newAction(actionType, 0, "floating_ip")
+	if s.opts.ActionPolls == 0 {
+		action.Status = godo.ActionCompleted
+		action.CompletedAt = action.StartedAt
+	}
+	s.writeJSON(w, http.StatusCreated, map[string]interface{}{"action": action})
+}
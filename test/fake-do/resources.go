@@ -0,0 +1,429 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakedo
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// AddSSHKey registers an SSH key in the fake account and returns it with its ID set.
+func (s *Server) AddSSHKey(key godo.Key) godo.Key {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key.ID == 0 {
+		key.ID = s.nextID()
+	}
+	if key.Fingerprint == "" {
+		key.Fingerprint = fmt.Sprintf("00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:%02x", key.ID%256)
+	}
+	s.keys = append(s.keys, key)
+	return key
+}
+
+// AddImage registers an image and returns it with its ID set.
+func (s *Server) AddImage(image godo.Image) godo.Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if image.ID == 0 {
+		image.ID = s.nextID()
+	}
+	if image.Status == "" {
+		image.Status = "available"
+	}
+	s.images = append(s.images, image)
+	return image
+}
+
+// AddRegion registers a region.
+func (s *Server) AddRegion(region godo.Region) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.regions = append(s.regions, region)
+}
+
+// AddSize registers a droplet size.
+func (s *Server) AddSize(size godo.Size) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sizes = append(s.sizes, size)
+}
+
+// Tags returns the names of all tags known to the server.
+func (s *Server) Tags() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.tags))
+	for name := range s.tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Server) ensureTag(name string) *godo.Tag {
+	t, ok := s.tags[name]
+	if !ok {
+		t = &godo.Tag{Name: name, Resources: &godo.TaggedResources{}}
+		s.tags[name] = t
+	}
+	return t
+}
+
+func (s *Server) serveTags(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := make([]godo.Tag, 0, len(s.tags))
+			for _, name := range sortedKeys(s.tags) {
+				list = append(list, s.taggedResources(name))
+			}
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"tags": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			req := &godo.TagCreateRequest{}
+			if !s.decode(w, r, req) {
+				return
+			}
+			if req.Name == "" {
+				s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "name is required")
+				return
+			}
+			s.writeJSON(w, http.StatusCreated, map[string]interface{}{"tag": s.ensureTag(req.Name)})
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	t, ok := s.tags[parts[0]]
+	if !ok {
+		s.notFound(w)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "resources" {
+		req := &godo.TagResourcesRequest{}
+		if !s.decode(w, r, req) {
+			return
+		}
+		for _, res := range req.Resources {
+			s.tagResource(t.Name, res, r.Method == http.MethodPost)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"tag": s.taggedResources(t.Name)})
+	case http.MethodDelete:
+		delete(s.tags, t.Name)
+		for _, d := range s.droplets {
+			d.Tags = removeString(d.Tags, t.Name)
+		}
+		for _, v := range s.volumes {
+			v.Tags = removeString(v.Tags, t.Name)
+		}
+		for _, lb := range s.loadBalancers {
+			lb.Tags = removeString(lb.Tags, t.Name)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+// taggedResources returns the tag name along with the count of the droplets,
+// volumes and load balancers carrying it.
+func (s *Server) taggedResources(name string) godo.Tag {
+	count := 0
+	for _, d := range s.droplets {
+		if hasTag(d.Tags, name) {
+			count++
+		}
+	}
+	for _, v := range s.volumes {
+		if hasTag(v.Tags, name) {
+			count++
+		}
+	}
+	for _, lb := range s.loadBalancers {
+		if lb.Tag == name || hasTag(lb.Tags, name) {
+			count++
+		}
+	}
+	return godo.Tag{Name: name, Resources: &godo.TaggedResources{Count: count}}
+}
+
+func (s *Server) tagResource(tag string, res godo.Resource, add bool) {
+	var tags *[]string
+	switch res.Type {
+	case godo.DropletResourceType:
+		id, _ := strconv.Atoi(res.ID)
+		if d, ok := s.droplets[id]; ok {
+			tags = &d.Tags
+		}
+	case godo.VolumeResourceType:
+		if v, ok := s.volumes[res.ID]; ok {
+			tags = &v.Tags
+		}
+	case godo.LoadBalancerResourceType:
+		if lb, ok := s.loadBalancers[res.ID]; ok {
+			tags = &lb.Tags
+		}
+	}
+	if tags == nil {
+		return
+	}
+	*tags = removeString(*tags, tag)
+	if add {
+		*tags = append(*tags, tag)
+	}
+}
+
+func (s *Server) serveVPCs(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := make([]*godo.VPC, 0, len(s.vpcs))
+			for _, id := range sortedKeys(s.vpcs) {
+				list = append(list, s.vpcs[id])
+			}
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"vpcs": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			req := &godo.VPCCreateRequest{}
+			if !s.decode(w, r, req) {
+				return
+			}
+			if req.Name == "" || req.RegionSlug == "" {
+				s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "name and region are required")
+				return
+			}
+			vpc := &godo.VPC{
+				ID:          s.nextUUID(),
+				Name:        req.Name,
+				Description: req.Description,
+				IPRange:     req.IPRange,
+				RegionSlug:  req.RegionSlug,
+				CreatedAt:   time.Now().UTC(),
+			}
+			vpc.URN = "do:vpc:" + vpc.ID
+			if vpc.IPRange == "" {
+				vpc.IPRange = fmt.Sprintf("10.%d.0.0/20", 100+len(s.vpcs)%100)
+			}
+			s.vpcs[vpc.ID] = vpc
+			s.writeJSON(w, http.StatusCreated, map[string]interface{}{"vpc": vpc})
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	vpc, ok := s.vpcs[parts[0]]
+	if !ok || len(parts) != 1 {
+		s.notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc})
+	case http.MethodDelete:
+		for _, d := range s.droplets {
+			if d.VPCUUID == vpc.ID {
+				s.writeError(w, http.StatusForbidden, "forbidden", "VPC still has members")
+				return
+			}
+		}
+		delete(s.vpcs, vpc.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveVolumes(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			name, region := r.URL.Query().Get("name"), r.URL.Query().Get("region")
+			list := []godo.Volume{}
+			for _, id := range sortedKeys(s.volumes) {
+				v := s.volumes[id]
+				if (name == "" || v.Name == name) && (region == "" || v.Region.Slug == region) {
+					list = append(list, *v)
+				}
+			}
+			start, end, links, meta := s.paginate(r, len(list))
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"volumes": list[start:end], "links": links, "meta": meta})
+		case http.MethodPost:
+			req := &godo.VolumeCreateRequest{}
+			if !s.decode(w, r, req) {
+				return
+			}
+			if req.Name == "" || req.Region == "" || req.SizeGigaBytes == 0 {
+				s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "name, region and size_gigabytes are required")
+				return
+			}
+			v := &godo.Volume{
+				ID:              s.nextUUID(),
+				Region:          &godo.Region{Slug: req.Region, Name: req.Region, Available: true},
+				Name:            req.Name,
+				SizeGigaBytes:   req.SizeGigaBytes,
+				Description:     req.Description,
+				DropletIDs:      []int{},
+				CreatedAt:       time.Now().UTC(),
+				FilesystemType:  req.FilesystemType,
+				FilesystemLabel: req.FilesystemLabel,
+				Tags:            req.Tags,
+			}
+			s.volumes[v.ID] = v
+			s.writeJSON(w, http.StatusCreated, map[string]interface{}{"volume": v})
+		default:
+			s.methodNotAllowed(w)
+		}
+		return
+	}
+
+	v, ok := s.volumes[parts[0]]
+	if !ok || len(parts) != 1 {
+		s.notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"volume": v})
+	case http.MethodDelete:
+		if len(v.DropletIDs) > 0 {
+			s.writeError(w, http.StatusConflict, "conflict", "volume is attached to a droplet")
+			return
+		}
+		delete(s.volumes, v.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveImages(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		s.methodNotAllowed(w)
+		return
+	}
+	if len(parts) == 0 || parts[0] == "" {
+		query := r.URL.Query()
+		images := []godo.Image{}
+		for _, image := range s.images {
+			if query.Get("private") == "true" && image.Public {
+				continue
+			}
+			if tag := query.Get("tag_name"); tag != "" && !hasTag(image.Tags, tag) {
+				continue
+			}
+			images = append(images, image)
+		}
+		start, end, links, meta := s.paginate(r, len(images))
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"images": images[start:end], "links": links, "meta": meta})
+		return
+	}
+	id, _ := strconv.Atoi(parts[0])
+	for i := range s.images {
+		if (id != 0 && s.images[i].ID == id) || s.images[i].Slug == parts[0] {
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"image": &s.images[i]})
+			return
+		}
+	}
+	s.notFound(w)
+}
+
+func (s *Server) serveKeys(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		s.methodNotAllowed(w)
+		return
+	}
+	if len(parts) == 0 || parts[0] == "" {
+		start, end, links, meta := s.paginate(r, len(s.keys))
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"ssh_keys": s.keys[start:end], "links": links, "meta": meta})
+		return
+	}
+	id, _ := strconv.Atoi(parts[0])
+	for i := range s.keys {
+		if (id != 0 && s.keys[i].ID == id) || s.keys[i].Fingerprint == parts[0] {
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"ssh_key": &s.keys[i]})
+			return
+		}
+	}
+	s.notFound(w)
+}
+
+func (s *Server) serveRegions(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet || (len(parts) > 0 && parts[0] != "") {
+		s.notFound(w)
+		return
+	}
+	start, end, links, meta := s.paginate(r, len(s.regions))
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{"regions": s.regions[start:end], "links": links, "meta": meta})
+}
+
+func (s *Server) serveSizes(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet || (len(parts) > 0 && parts[0] != "") {
+		s.notFound(w)
+		return
+	}
+	start, end, links, meta := s.paginate(r, len(s.sizes))
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{"sizes": s.sizes[start:end], "links": links, "meta": meta})
+}
+
+func removeString(list []string, v string) []string {
+	out := list[:0]
+	for _, s := range list {
+		if s != v {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]*godo.Tag:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*godo.VPC:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*godo.Volume:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*godo.Firewall:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
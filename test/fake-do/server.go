@@ -0,0 +1,479 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakedo implements an in-process fake of the subset of the
+// DigitalOcean API used by the provider. It is meant to back integration
+// tests of full reconcile flows without talking to the real API.
+//
+// Point the provider at the fake by setting DIGITALOCEAN_API_URL to the
+// value of Server.URL.
+package fakedo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+const (
+	// DefaultRateLimit is the hourly request budget advertised by the fake server.
+	DefaultRateLimit = 5000
+
+	defaultPerPage = 20
+	maxPerPage     = 200
+)
+
+// Options configures a fake DigitalOcean API server.
+type Options struct {
+	// Latency is added to every request before it is served.
+	Latency time.Duration
+	// RateLimit is the hourly request budget. Requests beyond the budget are
+	// answered with 429 Too Many Requests. Defaults to DefaultRateLimit.
+	RateLimit int
+	// DropletBootPolls is the number of reads a newly created droplet stays in
+	// the "new" state before it becomes "active".
+	DropletBootPolls int
+	// LoadBalancerBootPolls is the number of reads a newly created load
+	// balancer stays in the "new" state, without an IP, before it becomes "active".
+	LoadBalancerBootPolls int
+	// ActionPolls is the number of reads an action stays "in-progress" before
+	// it becomes "completed".
+	ActionPolls int
+	// DropletLimit, when set, makes the creation of droplets beyond it fail
+	// the way the DigitalOcean API does once the account limit is reached.
+	DropletLimit int
+}
+
+// scopeResources are the names the DigitalOcean custom token scopes give to
+// the resources served under each path.
+var scopeResources = map[string]string{
+	"droplets":       "droplet",
+	"load_balancers": "load_balancer",
+	"vpcs":           "vpc",
+	"tags":           "tag",
+	"volumes":        "block_storage",
+	"images":         "image",
+	"floating_ips":   "reserved_ip",
+	"firewalls":      "firewall",
+}
+
+// scopeActions are the actions of the custom token scopes needed by each
+// request method.
+var scopeActions = map[string]string{
+	http.MethodGet:    "read",
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+// Server is a fake DigitalOcean API server backed by httptest.
+type Server struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	opts Options
+
+	lastID    int
+	requestID int
+	remaining int
+	reset     time.Time
+	failures  []*failure
+	requests  []string
+	// tokenScopes are the scopes of the tokens restricted by SetTokenScopes.
+	tokenScopes map[string]map[string]bool
+
+	account       godo.Account
+	droplets      map[int]*godo.Droplet
+	dropletPolls  map[int]int
+	loadBalancers map[string]*godo.LoadBalancer
+	lbPolls       map[string]int
+	vpcs          map[string]*godo.VPC
+	tags          map[string]*godo.Tag
+	actions       map[int]*godo.Action
+	actionPolls   map[int]int
+	volumes       map[string]*godo.Volume
+	reservedIPs   map[string]*godo.FloatingIP
+	firewalls     map[string]*godo.Firewall
+	keys          []godo.Key
+	images        []godo.Image
+	regions       []godo.Region
+	sizes         []godo.Size
+}
+
+type failure struct {
+	method string
+	path   string
+	status int
+	times  int
+}
+
+// NewServer starts a new fake DigitalOcean API server. Callers must Close it.
+func NewServer(opts Options) *Server {
+	if opts.RateLimit == 0 {
+		opts.RateLimit = DefaultRateLimit
+	}
+
+	s := &Server{
+		opts:      opts,
+		remaining: opts.RateLimit,
+		reset:     time.Now().Add(time.Hour),
+		account: godo.Account{
+			DropletLimit:    25,
+			FloatingIPLimit: 3,
+			VolumeLimit:     100,
+			Email:           "capdo@example.com",
+			UUID:            "fake-account",
+			EmailVerified:   true,
+			Status:          "active",
+		},
+		droplets:      map[int]*godo.Droplet{},
+		dropletPolls:  map[int]int{},
+		loadBalancers: map[string]*godo.LoadBalancer{},
+		lbPolls:       map[string]int{},
+		vpcs:          map[string]*godo.VPC{},
+		tags:          map[string]*godo.Tag{},
+		actions:       map[int]*godo.Action{},
+		actionPolls:   map[int]int{},
+		volumes:       map[string]*godo.Volume{},
+		reservedIPs:   map[string]*godo.FloatingIP{},
+		firewalls:     map[string]*godo.Firewall{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// FailNext makes the next times requests whose method matches and whose path
+// starts with pathPrefix fail with the given HTTP status. An empty method
+// matches any method.
+func (s *Server) FailNext(method, pathPrefix string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{method: method, path: pathPrefix, status: status, times: times})
+}
+
+// SetTokenScopes restricts token to the given custom scopes, e.g.
+// "droplet:create". Like the DigitalOcean API, the requests of the token
+// needing another scope are rejected with 403 Forbidden before their body is
+// validated. Tokens that were not restricted have every scope.
+func (s *Server) SetTokenScopes(token string, scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokenScopes == nil {
+		s.tokenScopes = map[string]map[string]bool{}
+	}
+	granted := map[string]bool{}
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+	s.tokenScopes[token] = granted
+}
+
+// SetLatency changes the latency added to every request.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts.Latency = d
+}
+
+// SetRateLimitRemaining overrides the remaining request budget of the current window.
+func (s *Server) SetRateLimitRemaining(remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining = remaining
+}
+
+// SetAccount overrides the account returned by the account endpoint.
+func (s *Server) SetAccount(account godo.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account = account
+}
+
+// Requests returns every request served so far as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	latency := s.opts.Latency
+	s.mu.Unlock()
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	s.requestID++
+	w.Header().Set("x-request-id", fmt.Sprintf("fake-%d", s.requestID))
+
+	if time.Now().After(s.reset) {
+		s.remaining = s.opts.RateLimit
+		s.reset = time.Now().Add(time.Hour)
+	}
+	exhausted := s.remaining <= 0
+	if !exhausted {
+		s.remaining--
+	}
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(s.opts.RateLimit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(s.remaining))
+	w.Header().Set("RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	if exhausted {
+		s.writeError(w, http.StatusTooManyRequests, "too_many_requests", "API Rate limit exceeded.")
+		return
+	}
+
+	if f := s.nextFailure(r); f != nil {
+		s.writeError(w, f.status, "injected_failure", fmt.Sprintf("injected failure for %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v2"), "/"), "/")
+	if !s.authorized(r, parts[0]) {
+		s.writeError(w, http.StatusForbidden, "forbidden", "You are not authorized to perform this operation")
+		return
+	}
+	switch parts[0] {
+	case "account":
+		s.serveAccount(w, r, parts[1:])
+	case "droplets":
+		s.serveDroplets(w, r, parts[1:])
+	case "load_balancers":
+		s.serveLoadBalancers(w, r, parts[1:])
+	case "vpcs":
+		s.serveVPCs(w, r, parts[1:])
+	case "tags":
+		s.serveTags(w, r, parts[1:])
+	case "actions":
+		s.serveActions(w, r, parts[1:])
+	case "volumes":
+		s.serveVolumes(w, r, parts[1:])
+	case "images":
+		s.serveImages(w, r, parts[1:])
+	case "floating_ips":
+		s.serveReservedIPs(w, r, parts[1:])
+	case "firewalls":
+		s.serveFirewalls(w, r, parts[1:])
+	case "regions":
+		s.serveRegions(w, r, parts[1:])
+	case "sizes":
+		s.serveSizes(w, r, parts[1:])
+	default:
+		s.notFound(w)
+	}
+}
+
+// authorized tells whether the token of r has the scope needed by r on
+// the resources served under path.
+func (s *Server) authorized(r *http.Request, path string) bool {
+	granted, ok := s.tokenScopes[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok {
+		return true
+	}
+	resource, ok := scopeResources[path]
+	if !ok {
+		return true
+	}
+	return granted[resource+":"+scopeActions[r.Method]]
+}
+
+// nextFailure returns the injected failure matching the request, if any.
+func (s *Server) nextFailure(r *http.Request) *failure {
+	for i, f := range s.failures {
+		if f.method != "" && f.method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.path) {
+			continue
+		}
+		f.times--
+		if f.times <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *Server) nextUUID() string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID())
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, id, message string) {
+	s.writeJSON(w, status, map[string]string{
+		"id":         id,
+		"message":    message,
+		"request_id": w.Header().Get("x-request-id"),
+	})
+}
+
+func (s *Server) notFound(w http.ResponseWriter) {
+	s.writeError(w, http.StatusNotFound, "not_found", "The resource you were accessing could not be found.")
+}
+
+func (s *Server) methodNotAllowed(w http.ResponseWriter) {
+	s.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed.")
+}
+
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", err.Error())
+		return false
+	}
+	return true
+}
+
+// paginate returns the bounds of the requested page and the matching links
+// for a collection of n items.
+func (s *Server) paginate(r *http.Request, n int) (int, int, *godo.Links, *godo.Meta) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	lastPage := (n + perPage - 1) / perPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+	start := (page - 1) * perPage
+	if start > n {
+		start = n
+	}
+	end := start + perPage
+	if end > n {
+		end = n
+	}
+
+	pageURL := func(p int) string {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("per_page", strconv.Itoa(perPage))
+		u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: q.Encode()}
+		return u.String()
+	}
+	pages := &godo.Pages{}
+	if page > 1 {
+		pages.First = pageURL(1)
+		pages.Prev = pageURL(page - 1)
+	}
+	if page < lastPage {
+		pages.Next = pageURL(page + 1)
+		pages.Last = pageURL(lastPage)
+	}
+
+	return start, end, &godo.Links{Pages: pages}, &godo.Meta{Total: n}
+}
+
+// SetActionStatus changes the status of an action out of band, e.g. to
+// simulate an errored action.
+func (s *Server) SetActionStatus(id int, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.actions[id]; ok {
+		a.Status = status
+		delete(s.actionPolls, id)
+	}
+}
+
+// newAction records an in progress action against a resource.
+func (s *Server) newAction(actionType string, resourceID int, resourceType string) *godo.Action {
+	a := &godo.Action{
+		ID:           s.nextID(),
+		Status:       godo.ActionInProgress,
+		Type:         actionType,
+		StartedAt:    &godo.Timestamp{Time: time.Now()},
+		ResourceID:   resourceID,
+		ResourceType: resourceType,
+	}
+	s.actions[a.ID] = a
+	return a
+}
+
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		account := s.account
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"account": &account})
+	case len(parts) >= 1 && parts[0] == "keys":
+		s.serveKeys(w, r, parts[1:])
+	default:
+		s.notFound(w)
+	}
+}
+
+func (s *Server) serveActions(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 1 || r.Method != http.MethodGet {
+		s.notFound(w)
+		return
+	}
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		s.notFound(w)
+		return
+	}
+	a, ok := s.actions[id]
+	if !ok {
+		s.notFound(w)
+		return
+	}
+	if a.Status == godo.ActionInProgress {
+		s.actionPolls[id]++
+		if s.actionPolls[id] > s.opts.ActionPolls {
+			a.Status = godo.ActionCompleted
+			a.CompletedAt = &godo.Timestamp{Time: time.Now()}
+			delete(s.actionPolls, id)
+		}
+	}
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{"action": a})
+}
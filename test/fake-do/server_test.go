@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakedo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+)
+
+func newClient(t *testing.T, s *Server) *godo.Client {
+	t.Helper()
+	c, err := godo.New(http.DefaultClient, godo.SetBaseURL(s.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestDropletLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{DropletBootPolls: 2})
+	defer s.Close()
+	s.AddImage(godo.Image{Slug: "ubuntu-20-04-x64"})
+	c := newClient(t, s)
+
+	d, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name:   "node-0",
+		Region: "nyc1",
+		Size:   "s-2vcpu-2gb",
+		Image:  godo.DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+		Tags:   []string{"cluster-a"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(d.Status).To(Equal("new"))
+
+	for i := 0; i < 2; i++ {
+		d, _, err = c.Droplets.Get(ctx, d.ID)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(d.Status).To(Equal("new"))
+	}
+	d, _, err = c.Droplets.Get(ctx, d.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(d.Status).To(Equal("active"))
+	g.Expect(d.PrivateIPv4()).NotTo(BeEmpty())
+	g.Expect(s.Tags()).To(ConsistOf("cluster-a"))
+
+	_, err = c.Droplets.Delete(ctx, d.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, resp, err := c.Droplets.Get(ctx, d.ID)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+}
+
+func TestListByTagPagination(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{})
+	defer s.Close()
+	c := newClient(t, s)
+
+	for i := 0; i < 5; i++ {
+		tag := "other"
+		if i%2 == 0 {
+			tag = "cluster-a"
+		}
+		_, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: fmt.Sprintf("node-%d", i), Region: "nyc1", Size: "s-1vcpu-1gb",
+			Image: godo.DropletCreateImage{ID: 42}, Tags: []string{tag},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	var names []string
+	opt := &godo.ListOptions{PerPage: 2}
+	for {
+		droplets, resp, err := c.Droplets.ListByTag(ctx, "cluster-a", opt)
+		g.Expect(err).NotTo(HaveOccurred())
+		for _, d := range droplets {
+			names = append(names, d.Name)
+		}
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		page, err := resp.Links.CurrentPage()
+		g.Expect(err).NotTo(HaveOccurred())
+		opt.Page = page + 1
+	}
+	g.Expect(names).To(Equal([]string{"node-0", "node-2", "node-4"}))
+}
+
+func TestFailNext(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{})
+	defer s.Close()
+	c := newClient(t, s)
+
+	s.FailNext(http.MethodGet, "/v2/load_balancers", http.StatusInternalServerError, 2)
+	for i := 0; i < 2; i++ {
+		_, resp, err := c.LoadBalancers.List(ctx, nil)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		g.Expect(err.(*godo.ErrorResponse).RequestID).NotTo(BeEmpty())
+	}
+	_, _, err := c.LoadBalancers.List(ctx, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestTokenScopes(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{})
+	defer s.Close()
+	s.SetTokenScopes("restricted", "droplet:read")
+	oc := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "restricted"}))
+	c, err := godo.New(oc, godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, _, err = c.Droplets.List(ctx, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The scope is checked before the body is validated.
+	_, resp, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+
+	// Other tokens have every scope.
+	_, resp, err = newClient(t, s).Droplets.Create(ctx, &godo.DropletCreateRequest{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+}
+
+func TestRateLimit(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{RateLimit: 2})
+	defer s.Close()
+	c := newClient(t, s)
+
+	_, resp, err := c.Account.Get(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Rate.Limit).To(Equal(2))
+	g.Expect(resp.Rate.Remaining).To(Equal(1))
+
+	_, resp, err = c.Account.Get(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Rate.Remaining).To(Equal(0))
+
+	_, resp, err = c.Account.Get(ctx)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	g.Expect(resp.Rate.Reset.Time).NotTo(BeZero())
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vcr records the DigitalOcean API calls of a test into a cassette
+// file, once, against a real account, and replays them in the following runs
+// without credentials. Unlike the fake-do server, the replayed responses keep
+// the exact shapes of the real API: pagination links, error bodies and action
+// payloads.
+//
+// A recorder replays by default. Set DO_VCR_MODE=record and
+// DIGITALOCEAN_ACCESS_TOKEN to record the cassettes of the tests again, and
+// review them before committing: the responses are stored as returned, only
+// the request headers are left out.
+package vcr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// ModeEnvVar is the env var selecting the Mode of the recorders.
+const ModeEnvVar = "DO_VCR_MODE"
+
+// Mode is whether a Recorder calls the real API or replays a cassette.
+type Mode string
+
+const (
+	// ModeReplay answers the requests from the cassette, and fails those
+	// that were not recorded.
+	ModeReplay Mode = "replay"
+	// ModeRecord sends the requests to the real API and saves them, with
+	// their responses, to the cassette on Stop.
+	ModeRecord Mode = "record"
+)
+
+// recordedHeaders are the response headers saved to the cassettes, the
+// others are left out as they vary between runs or identify the account.
+var recordedHeaders = []string{"Content-Type", "Link", "Ratelimit-Limit", "Ratelimit-Remaining", "Ratelimit-Reset", "Retry-After"}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. URL is relative to the API base URL.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// Mode defaults to the DO_VCR_MODE env var, then to ModeReplay.
+	Mode Mode
+	// Transport sends the requests to the real API in ModeRecord. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// Recorder is an http.RoundTripper recording or replaying the requests sent
+// through it.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// Start returns a Recorder of the cassette at path. In ModeReplay the
+// cassette must exist.
+func Start(path string, opts Options) (*Recorder, error) {
+	r := &Recorder{path: path, mode: opts.Mode, transport: opts.Transport}
+	if r.mode == "" {
+		r.mode = Mode(os.Getenv(ModeEnvVar))
+	}
+	if r.mode == "" {
+		r.mode = ModeReplay
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+
+	switch r.mode {
+	case ModeRecord:
+		return r, nil
+	case ModeReplay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read cassette, record it with %s=%s", ModeEnvVar, ModeRecord)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode cassette %s", path)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+		return r, nil
+	default:
+		return nil, errors.Errorf("invalid %s %q, must be %s or %s", ModeEnvVar, r.mode, ModeRecord, ModeReplay)
+	}
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns a godo client sending its requests through the recorder. In
+// ModeRecord it authenticates with the DIGITALOCEAN_ACCESS_TOKEN env var.
+func (r *Recorder) Client() (*godo.Client, error) {
+	httpClient := &http.Client{Transport: r}
+	if r.mode == ModeRecord {
+		token := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
+		if token == "" {
+			return nil, errors.Errorf("env var DIGITALOCEAN_ACCESS_TOKEN is required with %s=%s", ModeEnvVar, ModeRecord)
+		}
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	}
+	return godo.New(httpClient)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	header := http.Header{}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			header[name] = values
+		}
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: Response{StatusCode: resp.StatusCode, Header: header, Body: string(body)},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers req with the first interaction recorded for it that was not
+// replayed yet, so that repeated requests, e.g. polls, get the responses in
+// the recorded order.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request != recorded {
+			continue
+		}
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, errors.Errorf("no interaction of cassette %s left for %s %s", r.path, recorded.Method, recorded.URL)
+}
+
+// Unreplayed returns how many interactions of the cassette were not replayed,
+// e.g. to check that a test made every recorded call.
+func (r *Recorder) Unreplayed() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, replayed := range r.replayed {
+		if !replayed {
+			n++
+		}
+	}
+	return n
+}
+
+// Stop saves the cassette in ModeRecord.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cassette")
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return errors.Wrap(err, "failed to create cassette directory")
+	}
+	return errors.Wrapf(ioutil.WriteFile(r.path, append(data, '\n'), 0600), "failed to write cassette %s", r.path)
+}
+
+// newRequest returns the recorded form of req: its URL without scheme and
+// host, so that the cassettes do not depend on the API base URL, and its body
+// compacted when it is JSON.
+func newRequest(req *http.Request) (Request, error) {
+	u := url.URL{Path: req.URL.Path, RawQuery: req.URL.Query().Encode()}
+	recorded := Request{Method: req.Method, URL: u.String()}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return Request{}, errors.Wrap(err, "failed to read request")
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+	recorded.Body = string(body)
+	return recorded, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/doclient"
+)
+
+// listDroplets lists the droplets of the account two per page.
+func listDroplets(ctx context.Context, c *godo.Client) ([]int, error) {
+	ids := []int{}
+	err := doclient.ListAll(&godo.ListOptions{PerPage: 2}, func(opt *godo.ListOptions) (*godo.Response, error) {
+		droplets, resp, err := c.Droplets.List(ctx, opt)
+		for _, droplet := range droplets {
+			ids = append(ids, droplet.ID)
+		}
+		return resp, err
+	})
+	return ids, err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cassette := filepath.Join(t.TempDir(), "droplets.json")
+
+	// The fake server stands in for the real API of the recording.
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	s.AddImage(godo.Image{Slug: "ubuntu-20-04-x64"})
+
+	r, err := Start(cassette, Options{Mode: ModeRecord, Transport: http.DefaultTransport})
+	g.Expect(err).NotTo(HaveOccurred())
+	c, err := godo.New(&http.Client{Transport: r}, godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := 0; i < 3; i++ {
+		_, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: fmt.Sprintf("node-%d", i), Region: "nyc1", Size: "s-2vcpu-2gb", Image: godo.DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	recorded, err := listDroplets(ctx, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorded).To(HaveLen(3))
+	_, _, err = c.Droplets.Get(ctx, 404)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(r.Stop()).To(Succeed())
+
+	// The replay needs neither the server nor a token, and does not depend
+	// on the base URL of the recording.
+	s.Close()
+	r, err = Start(cassette, Options{Mode: ModeReplay})
+	g.Expect(err).NotTo(HaveOccurred())
+	c, err = r.Client()
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := 0; i < 3; i++ {
+		_, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: fmt.Sprintf("node-%d", i), Region: "nyc1", Size: "s-2vcpu-2gb", Image: godo.DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(listDroplets(ctx, c)).To(Equal(recorded))
+	_, _, err = c.Droplets.Get(ctx, 404)
+	g.Expect(doclient.IsNotFound(err)).To(BeTrue(), "unexpected error %v", err)
+	g.Expect(r.Unreplayed()).To(BeZero())
+
+	// Every interaction is replayed once.
+	_, _, err = c.Droplets.Get(ctx, 404)
+	g.Expect(err).To(MatchError(ContainSubstring("no interaction of cassette")))
+}
+
+func TestReplayMatchesTheRequestBody(t *testing.T) {
+	g := NewWithT(t)
+	cassette := filepath.Join(t.TempDir(), "tags.json")
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	r, err := Start(cassette, Options{Mode: ModeRecord})
+	g.Expect(err).NotTo(HaveOccurred())
+	c, err := godo.New(&http.Client{Transport: r}, godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, _, err = c.Tags.Create(context.Background(), &godo.TagCreateRequest{Name: "a"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(r.Stop()).To(Succeed())
+
+	r, err = Start(cassette, Options{Mode: ModeReplay})
+	g.Expect(err).NotTo(HaveOccurred())
+	c, err = r.Client()
+	g.Expect(err).NotTo(HaveOccurred())
+	_, _, err = c.Tags.Create(context.Background(), &godo.TagCreateRequest{Name: "b"})
+	g.Expect(err).To(MatchError(ContainSubstring("no interaction of cassette")))
+	_, _, err = c.Tags.Create(context.Background(), &godo.TagCreateRequest{Name: "a"})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestStartWithoutCassette(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := Start(filepath.Join(t.TempDir(), "missing.json"), Options{Mode: ModeReplay})
+	g.Expect(err).To(MatchError(ContainSubstring(ModeEnvVar)))
+	_, err = Start("", Options{Mode: "live"})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package addons renders the manifests of the DigitalOcean components
+// installed in workload clusters.
+package addons
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// CCMVersion is the version of the DigitalOcean cloud controller manager.
+	CCMVersion = "v0.1.33"
+	// CSIVersion is the version of the DigitalOcean CSI driver.
+	CSIVersion = "v3.0.0"
+
+	// TokenSecretName is the Secret of kube-system holding the DigitalOcean
+	// token of the addons, in its TokenSecretKey key.
+	TokenSecretName = "digitalocean"
+	// TokenSecretKey is the key of the token in TokenSecretName.
+	TokenSecretKey = "access-token"
+	// TokenHashAnnotation is set on the pod template of the addons reading
+	// the token, to restart them once it is rotated: they only read it from
+	// their environment when they start.
+	TokenHashAnnotation = "infrastructure.cluster.x-k8s.io/digitalocean-token-hash"
+)
+
+//go:embed manifests/*.yaml
+var manifests embed.FS
+
+var templates = template.Must(template.New("").ParseFS(manifests, "manifests/*.yaml"))
+
+// Params configure the addons of a workload cluster.
+type Params struct {
+	// ClusterName is the name load balancers and volumes are tagged with.
+	ClusterName string
+	// VPCID is the VPC of the cluster, empty for the default VPC of the region.
+	VPCID string
+	// Token is the DigitalOcean token of the addons.
+	Token string
+	// CloudControllerManager installs the cloud controller manager.
+	CloudControllerManager bool
+	// CSIDriver installs the CSI driver.
+	CSIDriver bool
+}
+
+// Render returns the manifests of the addons, by file name.
+func Render(p Params) (map[string][]byte, error) {
+	token, err := yaml.Marshal(&corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: TokenSecretName},
+		StringData: map[string]string{TokenSecretKey: p.Token},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render the token Secret")
+	}
+	out := map[string][]byte{"digitalocean-token.yaml": token}
+
+	values := struct {
+		Params
+		CCMVersion          string
+		CSIVersion          string
+		TokenHashAnnotation string
+		TokenHash           string
+	}{p, CCMVersion, CSIVersion, TokenHashAnnotation, TokenHash(p.Token)}
+	render := func(name, file string) error {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, name, values); err != nil {
+			return errors.Wrapf(err, "failed to render %s", name)
+		}
+		out[file] = buf.Bytes()
+		return nil
+	}
+	if p.CloudControllerManager {
+		if err := render("ccm.yaml", "digitalocean-cloud-controller-manager.yaml"); err != nil {
+			return nil, err
+		}
+	}
+	if p.CSIDriver {
+		if err := render("csi.yaml", "digitalocean-csi.yaml"); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// TokenHash returns the value of TokenHashAnnotation for token.
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// TokenConsumers returns the workloads of the addons reading the token, with
+// only their namespace and name set.
+func TokenConsumers(p Params) []client.Object {
+	var out []client.Object
+	if p.CloudControllerManager {
+		out = append(out, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "digitalocean-cloud-controller-manager"}})
+	}
+	if p.CSIDriver {
+		out = append(out, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "csi-do-controller"}})
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	out, err := Render(Params{ClusterName: "foo", Token: "token", CloudControllerManager: true, CSIDriver: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(HaveLen(3))
+
+	for file, manifest := range out {
+		for _, doc := range strings.Split(string(manifest), "\n---\n") {
+			if strings.TrimSpace(strings.Trim(doc, "-\n")) == "" {
+				continue
+			}
+			obj := &unstructured.Unstructured{}
+			g.Expect(yaml.Unmarshal([]byte(doc), &obj.Object)).To(Succeed(), file)
+			g.Expect(obj.GetKind()).NotTo(BeEmpty(), file)
+		}
+	}
+	ccm := string(out["digitalocean-cloud-controller-manager.yaml"])
+	g.Expect(ccm).To(ContainSubstring("--cluster-name=foo"))
+	g.Expect(ccm).To(ContainSubstring("digitalocean-cloud-controller-manager:" + CCMVersion))
+	// The default VPC of the region is used when none is set.
+	g.Expect(ccm).NotTo(ContainSubstring("DO_CLUSTER_VPC_ID"))
+
+	// The workloads reading the token restart when it is rotated.
+	hash := TokenHashAnnotation + `: "` + TokenHash("token") + `"`
+	g.Expect(ccm).To(ContainSubstring(hash))
+	g.Expect(string(out["digitalocean-csi.yaml"])).To(ContainSubstring(hash))
+	g.Expect(TokenHash("token")).NotTo(Equal(TokenHash("rotated")))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultActionPollInterval is how often the status of an action is polled.
+	DefaultActionPollInterval = 5 * time.Second
+
+	// ActionErrored is the status of an action that failed.
+	ActionErrored = "errored"
+)
+
+// actionPollInterval is a variable so that tests do not have to wait.
+var actionPollInterval = DefaultActionPollInterval
+
+// ActionFailedError is returned when a DigitalOcean action completed with the
+// errored status.
+type ActionFailedError struct {
+	Action *godo.Action
+}
+
+func (e *ActionFailedError) Error() string {
+	return fmt.Sprintf("DigitalOcean action %s %d on %s %d errored", e.Action.Type, e.Action.ID, e.Action.ResourceType, e.Action.ResourceID)
+}
+
+// ActionInProgressError is returned when a DigitalOcean action did not complete
+// before the deadline. The action keeps running on the DigitalOcean side and
+// can be polled again later with its ID.
+type ActionInProgressError struct {
+	Action *godo.Action
+}
+
+func (e *ActionInProgressError) Error() string {
+	return fmt.Sprintf("DigitalOcean action %s %d on %s %d is still in progress", e.Action.Type, e.Action.ID, e.Action.ResourceType, e.Action.ResourceID)
+}
+
+// ActionSubmitter submits a DigitalOcean action, e.g. DropletActionsService.PowerOff.
+type ActionSubmitter func(ctx context.Context) (*godo.Action, *godo.Response, error)
+
+// RunAction submits an action and waits for it, see WaitForAction.
+func RunAction(ctx context.Context, svc godo.ActionsService, submit ActionSubmitter, timeout time.Duration) (*godo.Action, error) {
+	action, _, err := submit(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to submit action")
+	}
+	return WaitForAction(ctx, svc, action, timeout)
+}
+
+// WaitForAction polls action until it completes or timeout elapses. It returns
+// an ActionFailedError if the action errored and an ActionInProgressError if
+// it is still running once timeout elapsed, in which case the caller is
+// expected to requeue and poll again rather than to submit the action again.
+func WaitForAction(ctx context.Context, svc godo.ActionsService, action *godo.Action, timeout time.Duration) (*godo.Action, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(actionPollInterval)
+	defer ticker.Stop()
+	for {
+		switch action.Status {
+		case godo.ActionCompleted:
+			return action, nil
+		case ActionErrored:
+			return action, &ActionFailedError{Action: action}
+		}
+
+		select {
+		case <-ctx.Done():
+			return action, &ActionInProgressError{Action: action}
+		case <-ticker.C:
+		}
+
+		current, _, err := svc.Get(ctx, action.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return action, &ActionInProgressError{Action: action}
+			}
+			return action, errors.Wrapf(err, "failed to get action %d", action.ID)
+		}
+		action = current
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestRunAction(t *testing.T) {
+	defer func(d time.Duration) { actionPollInterval = d }(actionPollInterval)
+	actionPollInterval = time.Millisecond
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		polls       int
+		timeout     time.Duration
+		errorAction bool
+		wantStatus  string
+		wantErr     interface{}
+	}{
+		{name: "completes", polls: 3, timeout: time.Minute, wantStatus: godo.ActionCompleted},
+		{name: "still in progress", polls: 1000, timeout: 20 * time.Millisecond, wantStatus: godo.ActionInProgress, wantErr: &ActionInProgressError{}},
+		{name: "errored", polls: 1000, timeout: time.Minute, errorAction: true, wantStatus: ActionErrored, wantErr: &ActionFailedError{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := fakedo.NewServer(fakedo.Options{ActionPolls: tc.polls})
+			defer s.Close()
+			c := newTestClient(t, s, nil)
+
+			d, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+				Name: "node-0", Region: "nyc1", Size: "s-1vcpu-1gb", Image: godo.DropletCreateImage{ID: 42},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			action, err := RunAction(ctx, c.Actions, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+				a, resp, err := c.DropletActions.PowerOff(ctx, d.ID)
+				if err == nil && tc.errorAction {
+					s.SetActionStatus(a.ID, ActionErrored)
+				}
+				return a, resp, err
+			}, tc.timeout)
+			g.Expect(action.Status).To(Equal(tc.wantStatus))
+			if tc.wantErr == nil {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeAssignableToTypeOf(tc.wantErr))
+		})
+	}
+}
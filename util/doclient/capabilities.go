@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// Scope is a permission of a DigitalOcean token the provider relies on, named
+// after the custom scopes of the DigitalOcean console. A read only token, or a
+// token restricted to some resources, lacks some of them.
+type Scope string
+
+const (
+	// ScopeDropletRead lets the token list the droplets of the account.
+	ScopeDropletRead Scope = "droplet:read"
+	// ScopeDropletCreate lets the token create the droplets of DOMachines.
+	ScopeDropletCreate Scope = "droplet:create"
+	// ScopeLoadBalancerCreate lets the token create the API server load
+	// balancers of DOClusters.
+	ScopeLoadBalancerCreate Scope = "load_balancer:create"
+	// ScopeTagCreate lets the token create the tags of the droplets.
+	ScopeTagCreate Scope = "tag:create"
+	// ScopeFirewallCreate lets the token create the firewalls of DOFirewalls.
+	ScopeFirewallCreate Scope = "firewall:create"
+	// ScopeVolumeCreate lets the token create the volumes of DOVolumes.
+	ScopeVolumeCreate Scope = "block_storage:create"
+	// ScopeReservedIPCreate lets the token allocate the reserved IPs of
+	// DOReservedIPs.
+	ScopeReservedIPCreate Scope = "reserved_ip:create"
+)
+
+// ScopeStatus is what a probe tells about a scope of a token.
+type ScopeStatus string
+
+const (
+	// ScopeGranted is a scope the token has.
+	ScopeGranted ScopeStatus = "Granted"
+	// ScopeMissing is a scope the token lacks.
+	ScopeMissing ScopeStatus = "Missing"
+	// ScopeUnknown is a scope the probe could not tell about, e.g. during a
+	// DigitalOcean API outage. It is assumed granted.
+	ScopeUnknown ScopeStatus = "Unknown"
+)
+
+type probeFunc func(ctx context.Context, c *godo.Client) (*godo.Response, error)
+
+// scopeProbes are the requests telling whether a token has each scope.
+//
+// The read request of a probe is a GET needing the read scope of the
+// resource. DigitalOcean grants the create scope of a resource only along with
+// its read scope, so a token that can not read the resource can not create it
+// either, and the create request is then not sent.
+//
+// The DigitalOcean API checks the scopes of a token before it validates the
+// body of a request, as described by the token scopes reference of its
+// documentation: a create request is rejected with a 403 Forbidden when the
+// token lacks the scope, whatever its body. The create requests are empty, so
+// that only a token with the scope gets them rejected with a 422
+// Unprocessable Entity, and nothing is created. Any other answer, including an
+// unexpected success, tells nothing about the scope.
+var scopeProbes = []struct {
+	scope Scope
+	read  probeFunc
+	// create is nil for the read scopes.
+	create probeFunc
+}{
+	{ScopeDropletRead, listDroplets, nil},
+	{ScopeDropletCreate, listDroplets, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{})
+		return resp, err
+	}},
+	{ScopeLoadBalancerCreate, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.LoadBalancers.List(ctx, &godo.ListOptions{PerPage: 1})
+		return resp, err
+	}, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.LoadBalancers.Create(ctx, &godo.LoadBalancerRequest{})
+		return resp, err
+	}},
+	{ScopeTagCreate, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Tags.List(ctx, &godo.ListOptions{PerPage: 1})
+		return resp, err
+	}, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Tags.Create(ctx, &godo.TagCreateRequest{})
+		return resp, err
+	}},
+	{ScopeFirewallCreate, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Firewalls.List(ctx, &godo.ListOptions{PerPage: 1})
+		return resp, err
+	}, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Firewalls.Create(ctx, &godo.FirewallRequest{})
+		return resp, err
+	}},
+	{ScopeVolumeCreate, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: &godo.ListOptions{PerPage: 1}})
+		return resp, err
+	}, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.Storage.CreateVolume(ctx, &godo.VolumeCreateRequest{})
+		return resp, err
+	}},
+	{ScopeReservedIPCreate, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.FloatingIPs.List(ctx, &godo.ListOptions{PerPage: 1})
+		return resp, err
+	}, func(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+		_, resp, err := c.FloatingIPs.Create(ctx, &godo.FloatingIPCreateRequest{})
+		return resp, err
+	}},
+}
+
+func listDroplets(ctx context.Context, c *godo.Client) (*godo.Response, error) {
+	_, resp, err := c.Droplets.List(ctx, &godo.ListOptions{PerPage: 1})
+	return resp, err
+}
+
+// ProbeScopes returns what the probes tell about each Scope of the token of c
+// and exports it as metrics. It returns an InvalidCredentialsError when the
+// token can not be used at all.
+func ProbeScopes(ctx context.Context, c *godo.Client) (map[Scope]ScopeStatus, error) {
+	statuses := map[Scope]ScopeStatus{}
+	for _, p := range scopeProbes {
+		status, err := probeScope(ctx, c, p.read, p.create)
+		if err != nil {
+			return nil, err
+		}
+		statuses[p.scope] = status
+
+		switch status {
+		case ScopeGranted:
+			tokenScopes.WithLabelValues(string(p.scope)).Set(1)
+		case ScopeMissing:
+			tokenScopes.WithLabelValues(string(p.scope)).Set(0)
+		default:
+			tokenScopes.DeleteLabelValues(string(p.scope))
+		}
+	}
+	return statuses, nil
+}
+
+func probeScope(ctx context.Context, c *godo.Client, read, create probeFunc) (ScopeStatus, error) {
+	status := ScopeUnknown
+	resp, err := read(ctx, c)
+	switch {
+	case resp == nil:
+	case resp.StatusCode == http.StatusUnauthorized:
+		return "", &InvalidCredentialsError{Message: "DigitalOcean token is invalid, expired or revoked"}
+	case resp.StatusCode == http.StatusForbidden:
+		return ScopeMissing, nil
+	case err == nil:
+		status = ScopeGranted
+	}
+	if create == nil || status != ScopeGranted {
+		return status, nil
+	}
+
+	resp, _ = create(ctx, c)
+	switch {
+	case resp == nil:
+	case resp.StatusCode == http.StatusUnauthorized:
+		return "", &InvalidCredentialsError{Message: "DigitalOcean token is invalid, expired or revoked"}
+	case resp.StatusCode == http.StatusForbidden:
+		return ScopeMissing, nil
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		// The request was authorized, then rejected.
+		return ScopeGranted, nil
+	}
+	return ScopeUnknown, nil
+}
+
+// MissingScopesError is returned before a request that the DigitalOcean token
+// is known to lack a scope for, rather than sending it to get a 403 Forbidden.
+type MissingScopesError struct {
+	Scopes []Scope
+}
+
+func (e *MissingScopesError) Error() string {
+	scopes := make([]string, 0, len(e.Scopes))
+	for _, scope := range e.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return "DigitalOcean token is missing the " + strings.Join(scopes, ", ") + " scope(s)"
+}
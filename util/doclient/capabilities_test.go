@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestProbeScopes(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c := newTestClient(t, s, nil)
+
+	statuses, err := ProbeScopes(ctx, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(len(scopeProbes)))
+	for scope, status := range statuses {
+		g.Expect(status).To(Equal(ScopeGranted), "scope %s", scope)
+	}
+	// The empty create requests were rejected.
+	droplets, _, err := c.Droplets.List(ctx, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(droplets).To(BeEmpty())
+
+	// A read only token.
+	s.FailNext(http.MethodPost, "/v2/", http.StatusForbidden, len(scopeProbes)-1)
+	statuses, err = ProbeScopes(ctx, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeDropletRead, ScopeGranted))
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeDropletCreate, ScopeMissing))
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeReservedIPCreate, ScopeMissing))
+
+	s.FailNext(http.MethodGet, "/v2/droplets", http.StatusUnauthorized, 1)
+	_, err = ProbeScopes(ctx, c)
+	g.Expect(IsInvalidCredentials(err)).To(BeTrue())
+
+	// Only a validation error proves a scope, an outage or an answer of a
+	// proxy say nothing about it.
+	s.FailNext(http.MethodPost, "/v2/tags", http.StatusServiceUnavailable, 1)
+	s.FailNext(http.MethodPost, "/v2/firewalls", http.StatusNotFound, 1)
+	s.FailNext(http.MethodPost, "/v2/volumes", http.StatusTooManyRequests, 1)
+	statuses, err = ProbeScopes(ctx, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeTagCreate, ScopeUnknown))
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeFirewallCreate, ScopeUnknown))
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeVolumeCreate, ScopeUnknown))
+	g.Expect(statuses).To(HaveKeyWithValue(ScopeDropletCreate, ScopeGranted))
+}
+
+// TestProbeScopesRestrictedToken relies on the fake API checking the scopes of
+// a token before it validates the body of a request, like the DigitalOcean API
+// does: an empty create request of a token lacking the scope gets a 403
+// Forbidden rather than a 422 Unprocessable Entity.
+func TestProbeScopesRestrictedToken(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	s.SetTokenScopes("restricted",
+		"droplet:read", "droplet:create",
+		"tag:read", "tag:create",
+		"firewall:read",
+	)
+	oc := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "restricted"}))
+	c, err := godo.New(oc, godo.SetBaseURL(s.URL+"/"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	statuses, err := ProbeScopes(ctx, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(Equal(map[Scope]ScopeStatus{
+		ScopeDropletRead:        ScopeGranted,
+		ScopeDropletCreate:      ScopeGranted,
+		ScopeTagCreate:          ScopeGranted,
+		ScopeFirewallCreate:     ScopeMissing,
+		ScopeLoadBalancerCreate: ScopeMissing,
+		ScopeVolumeCreate:       ScopeMissing,
+		ScopeReservedIPCreate:   ScopeMissing,
+	}))
+	// A token that can not read a resource is not sent its create request.
+	g.Expect(s.Requests()).NotTo(ContainElement("POST /v2/load_balancers"))
+	g.Expect(s.Requests()).NotTo(ContainElement("POST /v2/volumes"))
+	g.Expect(s.Requests()).NotTo(ContainElement("POST /v2/floating_ips"))
+	g.Expect(s.Requests()).To(ContainElement("POST /v2/firewalls"))
+	g.Expect(s.Droplets()).To(BeEmpty())
+}
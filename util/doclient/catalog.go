@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// DefaultCatalogTTL is how long catalog entries are served from the cache.
+const DefaultCatalogTTL = 10 * time.Minute
+
+const (
+	catalogAccount  = "account"
+	catalogRegions  = "regions"
+	catalogSizes    = "sizes"
+	catalogSSHKeys  = "ssh-keys"
+	catalogImagePfx = "image/"
+	catalogTagPfx   = "tag/"
+)
+
+type catalogEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// Catalog caches the DigitalOcean catalogs that rarely change, i.e. regions,
+// sizes, images, SSH keys, the account limits and the existing tags, so that
+// they are not listed again on every reconcile. A single Catalog is meant to be shared by all reconciles using
+// the same token.
+type Catalog struct {
+	// TTL is how long entries are served from the cache.
+	TTL time.Duration
+
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]catalogEntry
+	// pruned is when the expired tags were last dropped.
+	pruned time.Time
+}
+
+// NewCatalog returns an empty Catalog caching entries for ttl.
+func NewCatalog(ttl time.Duration) *Catalog {
+	return &Catalog{
+		TTL:     ttl,
+		now:     time.Now,
+		entries: map[string]catalogEntry{},
+	}
+}
+
+// Invalidate drops every cached entry, e.g. after the API rejected a request
+// referencing a catalog item that may have changed.
+func (c *Catalog) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]catalogEntry{}
+}
+
+// Account returns the account, along with its limits.
+func (c *Catalog) Account(ctx context.Context, svc godo.AccountService) (*godo.Account, error) {
+	v, err := c.get(catalogAccount, func() (interface{}, error) {
+		account, _, err := svc.Get(ctx)
+		return account, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*godo.Account), nil
+}
+
+// Regions returns all regions.
+func (c *Catalog) Regions(ctx context.Context, svc godo.RegionsService) ([]godo.Region, error) {
+	v, err := c.get(catalogRegions, func() (interface{}, error) {
+		var all []godo.Region
+		err := ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			regions, resp, err := svc.List(ctx, opt)
+			all = append(all, regions...)
+			return resp, err
+		})
+		return all, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]godo.Region), nil
+}
+
+// Sizes returns all droplet sizes.
+func (c *Catalog) Sizes(ctx context.Context, svc godo.SizesService) ([]godo.Size, error) {
+	v, err := c.get(catalogSizes, func() (interface{}, error) {
+		var all []godo.Size
+		err := ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			sizes, resp, err := svc.List(ctx, opt)
+			all = append(all, sizes...)
+			return resp, err
+		})
+		return all, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]godo.Size), nil
+}
+
+// SSHKeys returns all SSH keys of the account.
+func (c *Catalog) SSHKeys(ctx context.Context, svc godo.KeysService) ([]godo.Key, error) {
+	v, err := c.get(catalogSSHKeys, func() (interface{}, error) {
+		var all []godo.Key
+		err := ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+			keys, resp, err := svc.List(ctx, opt)
+			all = append(all, keys...)
+			return resp, err
+		})
+		return all, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]godo.Key), nil
+}
+
+// ImageBySlug returns the image with the given slug, or an ImageNotFoundError
+// when there is none. Failed lookups are not cached.
+func (c *Catalog) ImageBySlug(ctx context.Context, svc godo.ImagesService, slug string) (*godo.Image, error) {
+	v, err := c.get(catalogImagePfx+strings.ToLower(slug), func() (interface{}, error) {
+		image, _, err := svc.GetBySlug(ctx, slug)
+		if IsNotFound(err) {
+			return nil, &ImageNotFoundError{Image: slug, Err: err}
+		}
+		return image, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*godo.Image), nil
+}
+
+// HasTag returns whether the tag name was recorded as existing by AddTag.
+func (c *Catalog) HasTag(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[catalogTagPfx+name]
+	return ok && c.clock().Before(entry.expires)
+}
+
+// AddTag records that the tag name exists, so that it is not looked up again
+// before each resource carrying it is created. The expired tags are dropped
+// once per TTL, as most tags are only ever looked up once, e.g. the name tags
+// of the droplets.
+func (c *Catalog) AddTag(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock()
+	if !now.Before(c.pruned.Add(c.TTL)) {
+		for key, entry := range c.entries {
+			if strings.HasPrefix(key, catalogTagPfx) && !now.Before(entry.expires) {
+				delete(c.entries, key)
+			}
+		}
+		c.pruned = now
+	}
+	c.entries[catalogTagPfx+name] = catalogEntry{value: true, expires: now.Add(c.TTL)}
+}
+
+// ForgetTag drops the tag name, e.g. once it was deleted.
+func (c *Catalog) ForgetTag(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, catalogTagPfx+name)
+}
+
+// get returns the cached value for key, calling fetch when it is missing or
+// expired. Errors are returned as is and never cached.
+func (c *Catalog) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.clock().Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = catalogEntry{value: v, expires: c.clock().Add(c.TTL)}
+	return v, nil
+}
+
+func (c *Catalog) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// FindSSHKey returns the key of keys with the given ID or, when id is 0, with
+// ref as fingerprint or name. It returns nil when none matches.
+func FindSSHKey(keys []godo.Key, id int, ref string) *godo.Key {
+	for i := range keys {
+		if id != 0 {
+			if keys[i].ID == id {
+				return &keys[i]
+			}
+			continue
+		}
+		if keys[i].Fingerprint == ref || keys[i].Name == ref {
+			return &keys[i]
+		}
+	}
+	return nil
+}
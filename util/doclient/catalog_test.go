@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestCatalogSSHKeys(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	for i := 0; i < 250; i++ {
+		s.AddSSHKey(godo.Key{Name: fmt.Sprintf("key-%d", i)})
+	}
+	c := newTestClient(t, s, nil)
+
+	now := time.Now()
+	catalog := NewCatalog(time.Minute)
+	catalog.now = func() time.Time { return now }
+
+	keys, err := catalog.SSHKeys(ctx, c.Keys)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(keys).To(HaveLen(250))
+	g.Expect(s.Requests()).To(HaveLen(2))
+
+	_, err = catalog.SSHKeys(ctx, c.Keys)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Requests()).To(HaveLen(2))
+
+	now = now.Add(2 * time.Minute)
+	_, err = catalog.SSHKeys(ctx, c.Keys)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Requests()).To(HaveLen(4))
+
+	catalog.Invalidate()
+	_, err = catalog.SSHKeys(ctx, c.Keys)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Requests()).To(HaveLen(6))
+}
+
+func TestCatalogImageBySlug(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	want := s.AddImage(godo.Image{Slug: "ubuntu-20-04-x64"})
+	c := newTestClient(t, s, nil)
+	catalog := NewCatalog(time.Minute)
+
+	s.FailNext(http.MethodGet, "/v2/images", http.StatusInternalServerError, 1)
+	_, err := catalog.ImageBySlug(ctx, c.Images, "ubuntu-20-04-x64")
+	g.Expect(err).To(HaveOccurred())
+
+	for i := 0; i < 2; i++ {
+		image, err := catalog.ImageBySlug(ctx, c.Images, "ubuntu-20-04-x64")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(image.ID).To(Equal(want.ID))
+	}
+	g.Expect(s.Requests()).To(HaveLen(2))
+
+	_, err = catalog.ImageBySlug(ctx, c.Images, "missing")
+	g.Expect(IsPermanent(err)).To(BeTrue())
+}
+
+func TestCatalogPrunesExpiredTags(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	catalog := NewCatalog(time.Minute)
+	catalog.now = func() time.Time { return now }
+
+	catalog.AddTag("name:foo-md-0")
+	g.Expect(catalog.HasTag("name:foo-md-0")).To(BeTrue())
+
+	now = now.Add(2 * time.Minute)
+	catalog.AddTag("name:foo-md-1")
+	g.Expect(catalog.entries).NotTo(HaveKey(catalogTagPfx + "name:foo-md-0"))
+	g.Expect(catalog.HasTag("name:foo-md-1")).To(BeTrue())
+
+	catalog.ForgetTag("name:foo-md-1")
+	g.Expect(catalog.entries).To(BeEmpty())
+}
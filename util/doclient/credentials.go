@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+)
+
+// accountStatusLocked is the status of an account that can not create resources.
+const accountStatusLocked = "locked"
+
+// InvalidCredentialsError is returned when a DigitalOcean token can not be
+// used, i.e. it is invalid, expired, revoked or its account is locked.
+type InvalidCredentialsError struct {
+	Message string
+}
+
+func (e *InvalidCredentialsError) Error() string {
+	return e.Message
+}
+
+// IsInvalidCredentials reports whether err is an InvalidCredentialsError.
+func IsInvalidCredentials(err error) bool {
+	var credErr *InvalidCredentialsError
+	return errors.As(err, &credErr)
+}
+
+// ValidateCredentials checks with a lightweight account call that the token
+// used by svc can be used. It returns an InvalidCredentialsError when it can
+// not; other errors, e.g. network errors, say nothing about the token.
+func ValidateCredentials(ctx context.Context, svc godo.AccountService) (*godo.Account, error) {
+	account, resp, err := svc.Get(ctx)
+	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				return nil, &InvalidCredentialsError{Message: "DigitalOcean token is invalid, expired or revoked"}
+			case http.StatusForbidden:
+				return nil, &InvalidCredentialsError{Message: "DigitalOcean token is not allowed to read the account"}
+			}
+		}
+		return nil, errors.Wrap(err, "failed to get DigitalOcean account")
+	}
+	if account.Status == accountStatusLocked {
+		return nil, &InvalidCredentialsError{Message: "DigitalOcean account is locked: " + account.StatusMessage}
+	}
+	return account, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      int
+		account     *godo.Account
+		wantErr     bool
+		wantInvalid bool
+	}{
+		{name: "valid"},
+		{name: "expired token", status: http.StatusUnauthorized, wantErr: true, wantInvalid: true},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: true, wantInvalid: true},
+		{name: "locked account", account: &godo.Account{Status: "locked", StatusMessage: "billing"}, wantErr: true, wantInvalid: true},
+		{name: "API outage", status: http.StatusServiceUnavailable, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := fakedo.NewServer(fakedo.Options{})
+			defer s.Close()
+			if tc.status != 0 {
+				s.FailNext(http.MethodGet, "/v2/account", tc.status, 1)
+			}
+			if tc.account != nil {
+				s.SetAccount(*tc.account)
+			}
+			c := newTestClient(t, s, nil)
+
+			_, err := ValidateCredentials(context.Background(), c.Account)
+			g.Expect(err != nil).To(Equal(tc.wantErr))
+			g.Expect(IsInvalidCredentials(err)).To(Equal(tc.wantInvalid))
+		})
+	}
+}
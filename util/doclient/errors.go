@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+)
+
+// IsTransient reports whether err is a DigitalOcean API failure that is
+// expected to go away on its own: rate limiting, server errors and network errors.
+func IsTransient(err error) bool {
+	if _, ok := RetryAfter(err); ok {
+		return true
+	}
+	var errResp *godo.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		code := errResp.Response.StatusCode
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsNotFound reports whether err is a DigitalOcean API answer that the
+// requested resource does not exist.
+func IsNotFound(err error) bool {
+	var errResp *godo.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// IsPermanent reports whether err is a DigitalOcean API rejection of the
+// request itself, which retrying the same request will not fix. Authentication,
+// quota and conflict errors are not permanent since they can be fixed outside
+// of the request.
+func IsPermanent(err error) bool {
+	return Classify(err).Terminal()
+}
+
+// ErrorClass classifies the DigitalOcean API errors by what it takes to fix
+// them, so that controllers report and retry them consistently.
+type ErrorClass string
+
+const (
+	// ErrorClassUnknown is any other error, e.g. a conflict or a failed action.
+	ErrorClassUnknown ErrorClass = ""
+	// ErrorClassTransient is an error expected to go away on its own, see IsTransient.
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassUnauthorized is a token that is invalid, revoked or missing a scope.
+	ErrorClassUnauthorized ErrorClass = "Unauthorized"
+	// ErrorClassQuotaExceeded is a request going over a limit of the account,
+	// e.g. its droplet limit.
+	ErrorClassQuotaExceeded ErrorClass = "QuotaExceeded"
+	// ErrorClassUnavailable is a region or size that is not available.
+	ErrorClassUnavailable ErrorClass = "RegionOrSizeUnavailable"
+	// ErrorClassImageNotFound is an image that does not exist or is not
+	// available to the account.
+	ErrorClassImageNotFound ErrorClass = "ImageNotFound"
+	// ErrorClassNotFound is a resource that does not exist, e.g. one deleted
+	// outside of the provider. The next reconcile recreates or forgets it.
+	ErrorClassNotFound ErrorClass = "NotFound"
+	// ErrorClassInvalid is any other rejection of a create request.
+	ErrorClassInvalid ErrorClass = "InvalidRequest"
+)
+
+// ImageNotFoundError is an image referenced by a spec that does not exist or
+// is not available to the account. Unlike the other resources that are not
+// found, only a change of the spec fixes it.
+type ImageNotFoundError struct {
+	Image string
+	Err   error
+}
+
+func (e *ImageNotFoundError) Error() string {
+	return fmt.Sprintf("image %s not found: %v", e.Image, e.Err)
+}
+
+func (e *ImageNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidSpecError is a spec that the provider knows the DigitalOcean API
+// rejects, so that the request is not sent. It is classified as
+// ErrorClassInvalid.
+type InvalidSpecError struct {
+	Message string
+}
+
+func (e *InvalidSpecError) Error() string {
+	return e.Message
+}
+
+// Terminal reports whether retrying the same request can not succeed, only a
+// change of the spec it was built from can fix it.
+func (c ErrorClass) Terminal() bool {
+	switch c {
+	case ErrorClassUnavailable, ErrorClassImageNotFound, ErrorClassInvalid:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsIntervention reports whether the error must be fixed outside of the
+// provider, e.g. by raising a limit of the account or rotating the token,
+// before the same request can succeed.
+func (c ErrorClass) NeedsIntervention() bool {
+	return c == ErrorClassUnauthorized || c == ErrorClassQuotaExceeded
+}
+
+// Classify returns the class of a DigitalOcean API error. The DigitalOcean
+// API answers most rejections with 422 Unprocessable Entity, they are told
+// apart by their message. Only the 400 and 422 answers to create requests are
+// terminal, the other requests may reference resources that changed since.
+func Classify(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassUnknown
+	}
+	if IsTransient(err) || IsTimeout(err) {
+		return ErrorClassTransient
+	}
+	var missing *MissingScopesError
+	if errors.As(err, &missing) {
+		return ErrorClassUnauthorized
+	}
+	var image *ImageNotFoundError
+	if errors.As(err, &image) {
+		return ErrorClassImageNotFound
+	}
+	var invalid *InvalidSpecError
+	if errors.As(err, &invalid) {
+		return ErrorClassInvalid
+	}
+	var errResp *godo.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return ErrorClassUnknown
+	}
+	message := strings.ToLower(errResp.Message)
+	switch code := errResp.Response.StatusCode; {
+	case strings.Contains(message, "limit") && (strings.Contains(message, "exceed") || strings.Contains(message, "reached")):
+		return ErrorClassQuotaExceeded
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorClassUnauthorized
+	case code == http.StatusNotFound:
+		return ErrorClassNotFound
+	case code != http.StatusBadRequest && code != http.StatusUnprocessableEntity:
+		return ErrorClassUnknown
+	case errResp.Response.Request == nil || errResp.Response.Request.Method != http.MethodPost:
+		return ErrorClassUnknown
+	case !containsAny(message, "invalid", "not found", "does not exist", "not available", "unavailable"):
+		return ErrorClassInvalid
+	case strings.Contains(message, "image"):
+		return ErrorClassImageNotFound
+	case containsAny(message, "region", "size"):
+		return ErrorClassUnavailable
+	default:
+		return ErrorClassInvalid
+	}
+}
+
+// IsTimeout reports whether err is a DigitalOcean API call that ran out of
+// time, either the per request timeout or the deadline of its context.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsAPIError reports whether err comes from the DigitalOcean API rather than
+// from Kubernetes: an error response, an action that did not complete, or a
+// call that was rate limited, timed out or could not reach the API.
+func IsAPIError(err error) bool {
+	var (
+		errResp    *godo.ErrorResponse
+		failed     *ActionFailedError
+		inProgress *ActionInProgressError
+	)
+	return errors.As(err, &errResp) || errors.As(err, &failed) || errors.As(err, &inProgress) || IsTransient(err) || IsTimeout(err)
+}
+
+// RequestID returns the ID of the DigitalOcean API request that failed with
+// err, which DigitalOcean support asks for, or an empty string.
+func RequestID(err error) string {
+	var errResp *godo.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return ""
+	}
+	if errResp.RequestID != "" {
+		return errResp.RequestID
+	}
+	if errResp.Response != nil {
+		return errResp.Response.Header.Get(headerRequestID)
+	}
+	return ""
+}
+
+// ErrorMessage returns the message of err, including the ID of the failed
+// DigitalOcean API request when the error body did not carry it, e.g. for
+// errors returned by a proxy. It is meant for events and condition messages.
+func ErrorMessage(err error) string {
+	message := err.Error()
+	if id := RequestID(err); id != "" && !strings.Contains(message, id) {
+		message = fmt.Sprintf("%s (DigitalOcean request ID %s)", message, id)
+	}
+	return message
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
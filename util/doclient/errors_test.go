@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestClassify(t *testing.T) {
+	request := func(method string) *http.Request {
+		req, err := http.NewRequest(method, "https://api.digitalocean.com/v2/droplets", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+	methodError := func(method string, status int, message string) error {
+		return errors.Wrap(&godo.ErrorResponse{Response: &http.Response{Request: request(method), StatusCode: status}, Message: message}, "failed to call the DigitalOcean API")
+	}
+	apiError := func(status int, message string) error {
+		return methodError(http.MethodPost, status, message)
+	}
+	testCases := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"server error", apiError(http.StatusBadGateway, "bad gateway"), ErrorClassTransient},
+		{"rate limited", apiError(http.StatusTooManyRequests, "too many requests"), ErrorClassTransient},
+		{"timeout", errors.Wrap(context.DeadlineExceeded, "failed to get droplet"), ErrorClassTransient},
+		{"unauthorized", apiError(http.StatusUnauthorized, "Unable to authenticate you"), ErrorClassUnauthorized},
+		{"forbidden", apiError(http.StatusForbidden, "You are not authorized to perform this operation"), ErrorClassUnauthorized},
+		{"droplet limit", apiError(http.StatusUnprocessableEntity, "creating this/these droplet(s) will exceed your droplet limit"), ErrorClassQuotaExceeded},
+		{"volume limit", apiError(http.StatusForbidden, "You have reached the volume limit of your account"), ErrorClassQuotaExceeded},
+		{"size unavailable", apiError(http.StatusUnprocessableEntity, "Size is not available in this region."), ErrorClassUnavailable},
+		{"invalid region", apiError(http.StatusUnprocessableEntity, "You specified an invalid region for Droplet creation."), ErrorClassUnavailable},
+		{"invalid image", apiError(http.StatusUnprocessableEntity, "You specified an invalid image for Droplet creation."), ErrorClassImageNotFound},
+		{"invalid request", apiError(http.StatusUnprocessableEntity, "name is required"), ErrorClassInvalid},
+		{"conflict", apiError(http.StatusConflict, "resource is locked"), ErrorClassUnknown},
+		{"not found", methodError(http.MethodGet, http.StatusNotFound, "The resource you were accessing could not be found."), ErrorClassNotFound},
+		{"not found on create", apiError(http.StatusNotFound, "The resource you were accessing could not be found."), ErrorClassNotFound},
+		{"image not found", methodError(http.MethodGet, http.StatusNotFound, "image not found"), ErrorClassNotFound},
+		{"spec image not found", &ImageNotFoundError{Image: "ubuntu", Err: methodError(http.MethodGet, http.StatusNotFound, "not found")}, ErrorClassImageNotFound},
+		{"invalid spec", errors.Wrap(&InvalidSpecError{Message: "the bootstrap data is too large"}, "failed"), ErrorClassInvalid},
+		{"rejected update", methodError(http.MethodPut, http.StatusUnprocessableEntity, "name is required"), ErrorClassUnknown},
+		{"other client error", apiError(http.StatusMethodNotAllowed, "method not allowed"), ErrorClassUnknown},
+		{"no request", errors.Wrap(&godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}, Message: "name is required"}, "failed"), ErrorClassUnknown},
+		{"not an API error", errors.New("boom"), ErrorClassUnknown},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			class := Classify(tc.err)
+			g.Expect(class).To(Equal(tc.want))
+			g.Expect(IsPermanent(tc.err)).To(Equal(class.Terminal()))
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	g := NewWithT(t)
+	req, err := http.NewRequest(http.MethodPost, "https://api.digitalocean.com/v2/droplets", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	header := http.Header{}
+	header.Set(headerRequestID, "3d2b8b3f")
+	resp := &http.Response{Request: req, StatusCode: http.StatusBadGateway, Header: header}
+
+	// The request ID is only in the header when the body is not an API error.
+	err = errors.Wrap(&godo.ErrorResponse{Response: resp, Message: "<html>bad gateway</html>"}, "failed to create droplet")
+	g.Expect(RequestID(err)).To(Equal("3d2b8b3f"))
+	g.Expect(ErrorMessage(err)).To(HaveSuffix("(DigitalOcean request ID 3d2b8b3f)"))
+
+	// godo already quotes the request ID of the body.
+	err = &godo.ErrorResponse{Response: resp, Message: "bad gateway", RequestID: "3d2b8b3f"}
+	g.Expect(ErrorMessage(err)).To(Equal(err.Error()))
+
+	g.Expect(ErrorMessage(errors.New("not a DigitalOcean error"))).To(Equal("not a DigitalOcean error"))
+}
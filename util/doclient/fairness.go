@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultFairShareWatermark is the fraction of the rate limit budget below
+	// which the requests of each cluster are limited to its own share.
+	DefaultFairShareWatermark = 0.5
+	// DefaultClusterRequestRate is the number of requests per hour a cluster
+	// may send once the budget is below the fair share watermark.
+	DefaultClusterRequestRate = 1000
+	// DefaultClusterRequestBurst is the number of requests a cluster may send
+	// at once once the budget is below the fair share watermark.
+	DefaultClusterRequestBurst = 50
+
+	// clusterBudgetIdle is how long the budget of a cluster that sent no
+	// request is kept, a deleted cluster sending none anymore.
+	clusterBudgetIdle = time.Hour
+)
+
+type clusterKey struct{}
+
+// WithCluster returns a copy of ctx whose DigitalOcean API requests are
+// accounted to cluster, e.g. "namespace/name", by RateLimitTransport.
+func WithCluster(ctx context.Context, cluster string) context.Context {
+	return context.WithValue(ctx, clusterKey{}, cluster)
+}
+
+// ClusterFromContext returns the cluster the requests of ctx are accounted
+// to, empty when they are not accounted to any.
+func ClusterFromContext(ctx context.Context) string {
+	cluster, _ := ctx.Value(clusterKey{}).(string)
+	return cluster
+}
+
+// clusterBudget is the token bucket of the requests of a cluster.
+type clusterBudget struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// reserveCluster accounts for a request of cluster about to be sent and
+// returns how long it has to wait first. The share of the cluster is only
+// enforced while the budget is contended, i.e. below the fair share watermark, so that a
+// single cluster may still use the whole budget when alone. It must be called
+// with t.mu held.
+func (t *RateLimitTransport) reserveCluster(cluster string, now time.Time, contended bool) (time.Duration, error) {
+	if cluster == "" {
+		return 0, nil
+	}
+	if t.clusters == nil {
+		t.clusters = map[string]*clusterBudget{}
+	}
+	t.pruneClusters(now)
+	budget, ok := t.clusters[cluster]
+	if !ok {
+		budget = &clusterBudget{limiter: rate.NewLimiter(rate.Limit(float64(t.clusterRate())/time.Hour.Seconds()), t.clusterBurst())}
+		t.clusters[cluster] = budget
+	}
+	budget.lastUsed = now
+	if !contended {
+		// Still drain the bucket, so that the cluster that used up the
+		// budget is the first one held back.
+		budget.limiter.AllowN(now, 1)
+		return 0, nil
+	}
+
+	reservation := budget.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if !reservation.OK() || delay > t.MaxDelay {
+		reservation.CancelAt(now)
+		apiClusterThrottled.WithLabelValues(cluster).Inc()
+		return 0, &RateLimitedError{RetryAfter: delay}
+	}
+	return delay, nil
+}
+
+// pruneClusters drops the budgets of the clusters that sent no request for
+// clusterBudgetIdle, along with their metrics so that deleted clusters do not
+// leave series behind. It must be called with t.mu held.
+func (t *RateLimitTransport) pruneClusters(now time.Time) {
+	if now.Sub(t.pruned) < time.Minute {
+		return
+	}
+	t.pruned = now
+	for cluster, budget := range t.clusters {
+		if now.Sub(budget.lastUsed) > clusterBudgetIdle {
+			delete(t.clusters, cluster)
+			apiClusterRequests.DeleteLabelValues(cluster)
+			apiClusterThrottled.DeleteLabelValues(cluster)
+		}
+	}
+}
+
+func (t *RateLimitTransport) clusterRate() int {
+	if t.ClusterRate > 0 {
+		return t.ClusterRate
+	}
+	return DefaultClusterRequestRate
+}
+
+func (t *RateLimitTransport) clusterBurst() int {
+	if t.ClusterBurst > 0 {
+		return t.ClusterBurst
+	}
+	return DefaultClusterRequestBurst
+}
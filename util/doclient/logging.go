@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// DebugLogLevel is the verbosity at which DigitalOcean API calls are logged.
+	DebugLogLevel = 4
+
+	headerRequestID = "x-request-id"
+	redacted        = "REDACTED"
+)
+
+// sensitiveFields are request body fields whose values are never logged.
+// user_data carries the bootstrap data, including cluster secrets.
+var sensitiveFields = map[string]bool{
+	"user_data":        true,
+	"password":         true,
+	"token":            true,
+	"access_token":     true,
+	"private_key":      true,
+	"certificate":      true,
+	"leaf_certificate": true,
+}
+
+// LoggingTransport is an http.RoundTripper logging every DigitalOcean API call
+// with its response status, request ID and rate limit counters. Request bodies
+// are logged with sensitive fields redacted; headers are never logged.
+type LoggingTransport struct {
+	// Base is the underlying transport. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// Logger receives the log lines at DebugLogLevel.
+	Logger logr.Logger
+}
+
+// NewLoggingTransport returns a LoggingTransport logging to log.
+func NewLoggingTransport(base http.RoundTripper, log logr.Logger) *LoggingTransport {
+	return &LoggingTransport{Base: base, Logger: log}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !t.Logger.V(DebugLogLevel).Enabled() {
+		return base.RoundTrip(req)
+	}
+	log := t.Logger.V(DebugLogLevel).WithValues("method", req.Method, "path", req.URL.RequestURI())
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(body)
+			body.Close()
+			log = log.WithValues("body", redactBody(data))
+		}
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	log = log.WithValues("duration", time.Since(start).String())
+	if err != nil {
+		log.Info("DigitalOcean API request failed", "error", err.Error())
+		return nil, err
+	}
+
+	log.Info("DigitalOcean API request",
+		"status", resp.StatusCode,
+		"requestID", resp.Header.Get(headerRequestID),
+		"rateLimit", resp.Header.Get(headerRateLimit),
+		"rateLimitRemaining", resp.Header.Get(headerRateRemaining),
+	)
+	return resp, nil
+}
+
+// redactBody returns a JSON request body with the sensitive fields redacted.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return redacted
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if sensitiveFields[k] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(field)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
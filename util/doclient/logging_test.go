@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestLoggingTransportDisabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"name":"foo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The body is not read for the log when it is not logged.
+	req.GetBody = func() (io.ReadCloser, error) {
+		t.Error("request body read while debug logging is disabled")
+		return nil, nil
+	}
+	resp, err := NewLoggingTransport(nil, logr.Discard()).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestRedactBody(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+			body: "",
+			want: "",
+		},
+		{
+			name: "droplet create",
+			body: `{"name":"node-0","user_data":"#cloud-config secret","tags":["a"]}`,
+			want: `{"name":"node-0","tags":["a"],"user_data":"REDACTED"}`,
+		},
+		{
+			name: "nested",
+			body: `{"forwarding_rules":[{"certificate":"pem","entry_port":443}]}`,
+			want: `{"forwarding_rules":[{"certificate":"REDACTED","entry_port":443}]}`,
+		},
+		{
+			name: "not json",
+			body: "token=secret",
+			want: "REDACTED",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := redactBody([]byte(tc.body)); got != tc.want {
+				t.Errorf("redactBody() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
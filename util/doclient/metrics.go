@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_digitalocean_api_requests_total",
+		Help: "Number of DigitalOcean API requests sent, retries included, by method, route and status code (error when no response was received).",
+	}, []string{"method", "route", "code"})
+	apiRateLimitRemaining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capdo_digitalocean_api_rate_limit_remaining",
+		Help: "Requests left in the DigitalOcean API rate limit window, as of the last response.",
+	})
+	apiClusterRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_digitalocean_api_cluster_requests_total",
+		Help: "Number of DigitalOcean API requests sent on behalf of each cluster, retries included.",
+	}, []string{"cluster"})
+	apiClusterThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capdo_digitalocean_api_cluster_throttled_total",
+		Help: "Number of DigitalOcean API requests of each cluster held back because the cluster used up its share of a contended rate limit budget.",
+	}, []string{"cluster"})
+	tokenScopes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capdo_digitalocean_token_scope",
+		Help: "Whether the DigitalOcean token has each scope the provider relies on (1) or not (0), as of its last probe. Scopes the probe could not tell about have no series.",
+	}, []string{"scope"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRateLimitRemaining, apiClusterRequests, apiClusterThrottled, tokenScopes)
+}
+
+// MetricsTransport is an http.RoundTripper counting the DigitalOcean API
+// requests and exporting the remaining rate limit budget.
+type MetricsTransport struct {
+	// Base is the underlying transport. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// NewMetricsTransport returns a MetricsTransport on top of base.
+func NewMetricsTransport(base http.RoundTripper) *MetricsTransport {
+	return &MetricsTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *MetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		apiRequests.WithLabelValues(req.Method, route(req.URL.Path), "error").Inc()
+		return nil, err
+	}
+	apiRequests.WithLabelValues(req.Method, route(req.URL.Path), strconv.Itoa(resp.StatusCode)).Inc()
+	if remaining, err := strconv.Atoi(resp.Header.Get(headerRateRemaining)); err == nil {
+		apiRateLimitRemaining.Set(float64(remaining))
+	}
+	return resp, nil
+}
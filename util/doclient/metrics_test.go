@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestMetricsTransport(t *testing.T) {
+	g := NewWithT(t)
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	s.SetRateLimitRemaining(42)
+	c := newTestClient(t, s, NewMetricsTransport(nil))
+
+	notFound := apiRequests.WithLabelValues("GET", "/v2/droplets/{id}", "404")
+	before := testutil.ToFloat64(notFound)
+	_, _, err := c.Droplets.Get(context.Background(), 1234)
+	g.Expect(err).To(HaveOccurred())
+	_, _, err = c.Droplets.Get(context.Background(), 5678)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(testutil.ToFloat64(notFound) - before).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(apiRateLimitRemaining)).To(BeNumerically("<", 42))
+}
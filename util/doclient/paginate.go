@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"github.com/digitalocean/godo"
+)
+
+// MaxPerPage is the largest page size accepted by the DigitalOcean API.
+const MaxPerPage = 200
+
+// ListAll walks every page of a DigitalOcean list call. list is called once
+// per page with the options of that page and is expected to collect the items
+// it receives. Unless set, the page size defaults to MaxPerPage.
+//
+//	var droplets []godo.Droplet
+//	err := doclient.ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+//		page, resp, err := client.Droplets.ListByTag(ctx, tag, opt)
+//		droplets = append(droplets, page...)
+//		return resp, err
+//	})
+func ListAll(opt *godo.ListOptions, list func(opt *godo.ListOptions) (*godo.Response, error)) error {
+	if opt == nil {
+		opt = &godo.ListOptions{}
+	}
+	if opt.PerPage == 0 {
+		opt.PerPage = MaxPerPage
+	}
+
+	for {
+		resp, err := list(opt)
+		if err != nil {
+			return err
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return nil
+		}
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return err
+		}
+		opt.Page = page + 1
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestListAll(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c := newTestClient(t, s, nil)
+
+	for i := 0; i < 450; i++ {
+		_, _, err := c.Droplets.Create(ctx, &godo.DropletCreateRequest{
+			Name: fmt.Sprintf("node-%d", i), Region: "nyc1", Size: "s-1vcpu-1gb",
+			Image: godo.DropletCreateImage{ID: 42}, Tags: []string{"cluster-a"},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	var droplets []godo.Droplet
+	err := ListAll(nil, func(opt *godo.ListOptions) (*godo.Response, error) {
+		page, resp, err := c.Droplets.ListByTag(ctx, "cluster-a", opt)
+		droplets = append(droplets, page...)
+		return resp, err
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(droplets).To(HaveLen(450))
+	g.Expect(droplets[449].Name).To(Equal("node-449"))
+
+	calls := 0
+	s.FailNext(http.MethodGet, "/v2/droplets", http.StatusNotFound, 1)
+	err = ListAll(&godo.ListOptions{PerPage: 10}, func(opt *godo.ListOptions) (*godo.Response, error) {
+		calls++
+		_, resp, err := c.Droplets.List(ctx, opt)
+		return resp, err
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(calls).To(Equal(1))
+}
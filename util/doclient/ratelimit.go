@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package doclient contains the HTTP layers wrapped around the godo client
+// used to talk to the DigitalOcean API.
+package doclient
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	headerRateLimit     = "RateLimit-Limit"
+	headerRateRemaining = "RateLimit-Remaining"
+	headerRateReset     = "RateLimit-Reset"
+	headerRetryAfter    = "Retry-After"
+
+	// DefaultLowWatermark is the fraction of the rate limit budget below which
+	// requests start being paced until the budget resets.
+	DefaultLowWatermark = 0.1
+	// DefaultMaxThrottleDelay is the longest a single request is delayed
+	// in-line. Requests that would need to wait longer fail with a
+	// RateLimitedError so the caller can requeue instead of blocking a worker.
+	DefaultMaxThrottleDelay = 5 * time.Second
+)
+
+// RateLimitedError is returned when a request was not sent, or was rejected by
+// the API, because the rate limit budget is exhausted.
+type RateLimitedError struct {
+	// RetryAfter is how long to wait before the budget is expected to allow
+	// the request again.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("DigitalOcean API rate limit reached, retry after %s", e.RetryAfter)
+}
+
+// RetryAfter returns how long to wait before retrying if err was caused by the
+// DigitalOcean API rate limit.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rlErr *RateLimitedError
+	if errors.As(err, &rlErr) {
+		return rlErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// RateLimitTransport is an http.RoundTripper that tracks the DigitalOcean
+// rate limit headers and throttles requests once the remaining budget runs low.
+// A single RateLimitTransport must be shared by all clients using the same token.
+//
+// The requests whose context carries a cluster, see WithCluster, are also
+// accounted to that cluster. Once the budget is contended each cluster is held
+// to its own token bucket, so that e.g. the rollout of a large cluster does not
+// starve the reconciles of the other clusters sharing the token.
+type RateLimitTransport struct {
+	// Base is the underlying transport. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// LowWatermark is the fraction of the budget below which requests are
+	// spread evenly over the time left until the budget resets.
+	LowWatermark float64
+	// MaxDelay is the longest a request is delayed before a RateLimitedError
+	// is returned instead.
+	MaxDelay time.Duration
+	// FairShareWatermark is the fraction of the budget below which the
+	// requests of each cluster are limited to ClusterRate.
+	FairShareWatermark float64
+	// ClusterRate is the number of requests per hour a cluster may send, in
+	// bursts of ClusterBurst, once the budget is below FairShareWatermark.
+	// They default to DefaultClusterRequestRate and DefaultClusterRequestBurst.
+	ClusterRate  int
+	ClusterBurst int
+
+	now func() time.Time
+
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+	clusters  map[string]*clusterBudget
+	pruned    time.Time
+}
+
+// NewRateLimitTransport returns a RateLimitTransport with the default settings.
+func NewRateLimitTransport(base http.RoundTripper) *RateLimitTransport {
+	return &RateLimitTransport{
+		Base:               base,
+		LowWatermark:       DefaultLowWatermark,
+		MaxDelay:           DefaultMaxThrottleDelay,
+		FairShareWatermark: DefaultFairShareWatermark,
+		now:                time.Now,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cluster := ClusterFromContext(req.Context())
+	delay, err := t.reserve(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if cluster != "" {
+		apiClusterRequests.WithLabelValues(cluster).Inc()
+	}
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.update(resp)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := t.retryAfter(resp)
+		resp.Body.Close()
+		return nil, &RateLimitedError{RetryAfter: retryAfter}
+	}
+	return resp, nil
+}
+
+// reserve accounts for a request of cluster, possibly none, about to be sent
+// and returns how long it has to wait first.
+func (t *RateLimitTransport) reserve(cluster string) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock()
+	if t.limit == 0 || !now.Before(t.reset) {
+		// Nothing known about the current window yet.
+		return t.reserveCluster(cluster, now, false)
+	}
+	untilReset := t.reset.Sub(now)
+	if t.remaining <= 0 {
+		return 0, &RateLimitedError{RetryAfter: untilReset}
+	}
+
+	var delay time.Duration
+	if float64(t.remaining) < float64(t.limit)*t.LowWatermark {
+		delay = untilReset / time.Duration(t.remaining)
+		if delay > t.MaxDelay {
+			return 0, &RateLimitedError{RetryAfter: delay}
+		}
+	}
+	contended := float64(t.remaining) < float64(t.limit)*t.FairShareWatermark
+	clusterDelay, err := t.reserveCluster(cluster, now, contended)
+	if err != nil {
+		return 0, err
+	}
+	if clusterDelay > delay {
+		delay = clusterDelay
+	}
+	// Account for in-flight requests before the response headers arrive.
+	t.remaining--
+	return delay, nil
+}
+
+// update records the rate limit state advertised by a response.
+func (t *RateLimitTransport) update(resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get(headerRateLimit))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get(headerRateRemaining))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get(headerRateReset), 10, 64)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.limit = limit
+	t.remaining = remaining
+	t.reset = time.Unix(reset, 0)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		t.remaining = 0
+	}
+}
+
+func (t *RateLimitTransport) retryAfter(resp *http.Response) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get(headerRetryAfter)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if d := t.reset.Sub(t.clock()); d > 0 {
+		return d
+	}
+	return time.Second
+}
+
+func (t *RateLimitTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *RateLimitTransport) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func newTestClient(t *testing.T, s *fakedo.Server, rt http.RoundTripper) *godo.Client {
+	t.Helper()
+	c, err := godo.New(&http.Client{Transport: rt}, godo.SetBaseURL(s.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRateLimitTransportExhausted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{RateLimit: 1})
+	defer s.Close()
+	c := newTestClient(t, s, NewRateLimitTransport(nil))
+
+	_, _, err := c.Account.Get(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The budget is known to be exhausted, so the request is not even sent.
+	_, _, err = c.Account.Get(ctx)
+	retryAfter, ok := RetryAfter(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(retryAfter).To(BeNumerically(">", 59*time.Minute))
+	g.Expect(s.Requests()).To(HaveLen(1))
+}
+
+func TestRateLimitTransportTooManyRequests(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	s.SetRateLimitRemaining(0)
+	c := newTestClient(t, s, NewRateLimitTransport(nil))
+
+	_, _, err := c.Account.Get(ctx)
+	_, ok := RetryAfter(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(s.Requests()).To(HaveLen(1))
+}
+
+func TestRateLimitTransportThrottle(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	tr := NewRateLimitTransport(nil)
+	tr.now = func() time.Time { return now }
+
+	testCases := []struct {
+		name      string
+		remaining int
+		reset     time.Duration
+		wantDelay time.Duration
+		wantErr   bool
+	}{
+		{name: "unknown window", remaining: 0, reset: -time.Second},
+		{name: "plenty of budget", remaining: 4000, reset: time.Hour},
+		{name: "low budget is paced", remaining: 100, reset: 100 * time.Second, wantDelay: time.Second},
+		{name: "pacing beyond max delay", remaining: 10, reset: time.Hour, wantErr: true},
+		{name: "exhausted", remaining: 0, reset: time.Minute, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr.limit, tr.remaining, tr.reset = 5000, tc.remaining, now.Add(tc.reset)
+			delay, err := tr.reserve("")
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(delay).To(Equal(tc.wantDelay))
+		})
+	}
+}
+
+func TestRateLimitTransportClusterShare(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	tr := NewRateLimitTransport(nil)
+	tr.now = func() time.Time { return now }
+	tr.ClusterRate, tr.ClusterBurst = 360, 2
+
+	// A cluster alone may use the budget down to the fair share watermark.
+	tr.limit, tr.remaining, tr.reset = 5000, 4000, now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		_, err := tr.reserve("ns/a")
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	// Below it, each cluster is held to its own bucket, which the first
+	// requests already drained.
+	tr.remaining = 2000
+	_, err := tr.reserve("ns/a")
+	retryAfter, ok := RetryAfter(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(retryAfter).To(Equal(10 * time.Second))
+	g.Expect(testutil.ToFloat64(apiClusterThrottled.WithLabelValues("ns/a"))).To(Equal(1.0))
+
+	_, err = tr.reserve("ns/b")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = tr.reserve("")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The bucket refills over time.
+	now = now.Add(10 * time.Second)
+	_, err = tr.reserve("ns/a")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The budgets and metrics of the idle clusters are dropped.
+	now = now.Add(clusterBudgetIdle)
+	_, err = tr.reserve("ns/b")
+	g.Expect(err).NotTo(HaveOccurred())
+	now = now.Add(time.Minute)
+	_, err = tr.reserve("ns/b")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tr.clusters).NotTo(HaveKey("ns/a"))
+	g.Expect(tr.clusters).To(HaveKey("ns/b"))
+	g.Expect(testutil.CollectAndCount(apiClusterThrottled)).To(BeZero())
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried.
+	DefaultMaxRetries = 3
+	// DefaultInitialBackoff is the backoff before the first retry.
+	DefaultInitialBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff caps the backoff between two attempts.
+	DefaultMaxBackoff = 10 * time.Second
+)
+
+// RetryTransport is an http.RoundTripper retrying requests that failed with a
+// transient error using exponential backoff with jitter.
+//
+// Rate limited requests are always retried as they were not processed by the
+// API. Network errors and 5xx responses are only retried for idempotent
+// methods, since a POST may have been processed before the failure.
+type RetryTransport struct {
+	// Base is the underlying transport. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// MaxRetries is the maximum number of retries of a single request.
+	MaxRetries int
+	// InitialBackoff is the backoff before the first retry. It doubles on
+	// every following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff between two attempts. Rate limited requests
+	// asking to wait longer than MaxBackoff are not retried.
+	MaxBackoff time.Duration
+}
+
+// NewRetryTransport returns a RetryTransport with the default settings.
+func NewRetryTransport(base http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		Base:           base,
+		MaxRetries:     DefaultMaxRetries,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		resp, err := base.RoundTrip(r)
+		if attempt >= t.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		wait, retry := t.shouldRetry(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is worth retrying and
+// how long to wait before doing so.
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return 0, false
+		}
+		if retryAfter, ok := RetryAfter(err); ok {
+			return retryAfter, retryAfter <= t.MaxBackoff
+		}
+		return t.backoff(attempt), isIdempotent(req.Method)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if secs, err := strconv.Atoi(resp.Header.Get(headerRetryAfter)); err == nil {
+			wait := time.Duration(secs) * time.Second
+			return wait, wait <= t.MaxBackoff
+		}
+		return t.backoff(attempt), true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return t.backoff(attempt), isIdempotent(req.Method)
+	}
+	return 0, false
+}
+
+// backoff returns the exponential backoff for an attempt, with jitter spreading
+// it between half and the full value.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	d := t.InitialBackoff << uint(attempt)
+	if d <= 0 || d > t.MaxBackoff {
+		d = t.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func newTestRetryTransport() *RetryTransport {
+	rt := NewRetryTransport(NewRateLimitTransport(nil))
+	rt.InitialBackoff = time.Millisecond
+	rt.MaxBackoff = 10 * time.Millisecond
+	return rt
+}
+
+func TestRetryTransport(t *testing.T) {
+	ctx := context.Background()
+	createReq := &godo.LoadBalancerRequest{
+		Name:            "lb",
+		Region:          "nyc1",
+		ForwardingRules: []godo.ForwardingRule{{EntryProtocol: "tcp", EntryPort: 443, TargetProtocol: "tcp", TargetPort: 6443}},
+	}
+
+	t.Run("retries server errors of idempotent requests", func(t *testing.T) {
+		g := NewWithT(t)
+		s := fakedo.NewServer(fakedo.Options{})
+		defer s.Close()
+		c := newTestClient(t, s, newTestRetryTransport())
+
+		s.FailNext(http.MethodGet, "/v2/load_balancers", http.StatusBadGateway, 2)
+		_, _, err := c.LoadBalancers.List(ctx, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(s.Requests()).To(HaveLen(3))
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		g := NewWithT(t)
+		s := fakedo.NewServer(fakedo.Options{})
+		defer s.Close()
+		c := newTestClient(t, s, newTestRetryTransport())
+
+		s.FailNext(http.MethodGet, "/v2/load_balancers", http.StatusServiceUnavailable, 10)
+		_, _, err := c.LoadBalancers.List(ctx, nil)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(IsTransient(err)).To(BeTrue())
+		g.Expect(s.Requests()).To(HaveLen(DefaultMaxRetries + 1))
+	})
+
+	t.Run("does not retry server errors of creates", func(t *testing.T) {
+		g := NewWithT(t)
+		s := fakedo.NewServer(fakedo.Options{})
+		defer s.Close()
+		c := newTestClient(t, s, newTestRetryTransport())
+
+		s.FailNext(http.MethodPost, "/v2/load_balancers", http.StatusBadGateway, 1)
+		_, _, err := c.LoadBalancers.Create(ctx, createReq)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(s.Requests()).To(HaveLen(1))
+	})
+
+	t.Run("retries rate limited creates with the request body", func(t *testing.T) {
+		g := NewWithT(t)
+		s := fakedo.NewServer(fakedo.Options{})
+		defer s.Close()
+		rt := newTestRetryTransport()
+		rt.Base = nil
+		c := newTestClient(t, s, rt)
+
+		s.FailNext(http.MethodPost, "/v2/load_balancers", http.StatusTooManyRequests, 1)
+		lb, _, err := c.LoadBalancers.Create(ctx, createReq)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(lb.Name).To(Equal("lb"))
+		g.Expect(s.Requests()).To(HaveLen(2))
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		g := NewWithT(t)
+		s := fakedo.NewServer(fakedo.Options{})
+		defer s.Close()
+		c := newTestClient(t, s, newTestRetryTransport())
+
+		_, _, err := c.LoadBalancers.Create(ctx, &godo.LoadBalancerRequest{Name: "lb"})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(IsPermanent(err)).To(BeTrue())
+		g.Expect(IsTransient(err)).To(BeFalse())
+		g.Expect(s.Requests()).To(HaveLen(1))
+	})
+}
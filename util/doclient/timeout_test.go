@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+)
+
+func TestTimeoutTransport(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c := newTestClient(t, s, NewTimeoutTransport(nil, 50*time.Millisecond))
+
+	_, _, err := c.Account.Get(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s.SetLatency(time.Second)
+	_, _, err = c.Account.Get(ctx)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(IsTimeout(err)).To(BeTrue())
+	g.Expect(IsTransient(err)).To(BeTrue())
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, _, err = c.Account.Get(ctx)
+	g.Expect(IsTimeout(err)).To(BeTrue())
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	fakedo "sigs.k8s.io/cluster-api-provider-digitalocean/test/fake-do"
+	"sigs.k8s.io/cluster-api-provider-digitalocean/util/tracing"
+)
+
+func TestTracingTransport(t *testing.T) {
+	g := NewWithT(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	s := fakedo.NewServer(fakedo.Options{})
+	defer s.Close()
+	c := newTestClient(t, s, NewTracingTransport(nil))
+
+	ctx, parent := tracing.Start(context.Background(), "DOMachine.Reconcile")
+	_, _, err := c.Droplets.Get(ctx, 1234)
+	g.Expect(err).To(HaveOccurred())
+	parent.End()
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(HaveLen(2))
+	g.Expect(spans[0].Name()).To(Equal("DigitalOcean GET /v2/droplets/{id}"))
+	g.Expect(spans[0].Parent().SpanID()).To(Equal(spans[1].SpanContext().SpanID()))
+	g.Expect(spans[0].Status().Code).To(Equal(codes.Error))
+	g.Expect(spans[0].Attributes()).To(ContainElement(semconv.HTTPStatusCodeKey.Int(http.StatusNotFound)))
+}
+
+func TestRoute(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(route("/v2/load_balancers/4de7ac8b-495b-4884-9a69-1050c6793cd6")).To(Equal("/v2/load_balancers/{id}"))
+	g.Expect(route("/v2/floating_ips/192.0.2.1/actions")).To(Equal("/v2/floating_ips/{id}/actions"))
+	g.Expect(route("/v2/account/keys")).To(Equal("/v2/account/keys"))
+}
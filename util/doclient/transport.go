@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// NewBaseTransport returns the transport used to reach the DigitalOcean API.
+// It honors the HTTPS_PROXY, HTTP_PROXY and NO_PROXY env vars and, when
+// caBundle is set, trusts the PEM encoded certificates of that file on top of
+// the system roots, e.g. for TLS-intercepting egress proxies.
+func NewBaseTransport(caBundle string) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	if caBundle == "" {
+		return t, nil
+	}
+
+	pem, err := ioutil.ReadFile(caBundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CA bundle")
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no PEM encoded certificates found in CA bundle %s", caBundle)
+	}
+	t.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return t, nil
+}
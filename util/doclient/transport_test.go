@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doclient
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewBaseTransportCABundle(t *testing.T) {
+	g := NewWithT(t)
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "doclient")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+	caBundle := filepath.Join(dir, "ca.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+	g.Expect(ioutil.WriteFile(caBundle, caPEM, 0600)).To(Succeed())
+	invalid := filepath.Join(dir, "invalid.crt")
+	g.Expect(ioutil.WriteFile(invalid, []byte("not a certificate"), 0600)).To(Succeed())
+
+	tr, err := NewBaseTransport("")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = (&http.Client{Transport: tr}).Get(s.URL)
+	g.Expect(err).To(HaveOccurred())
+
+	tr, err = NewBaseTransport(caBundle)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err := (&http.Client{Transport: tr}).Get(s.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp.Body.Close()
+
+	_, err = NewBaseTransport(invalid)
+	g.Expect(err).To(HaveOccurred())
+	_, err = NewBaseTransport(filepath.Join(dir, "missing.crt"))
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthz contains the health and readiness checks of the manager.
+package healthz
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// checkTimeout bounds a single check, below the default probe timeout.
+const checkTimeout = 900 * time.Millisecond
+
+// WebhookChecker returns a check failing until the webhook server accepts TLS
+// connections on host:port.
+func WebhookChecker(host string, port int) healthz.Checker {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	return func(req *http.Request) error {
+		dialer := &net.Dialer{Timeout: checkTimeout}
+		// The serving certificate is issued for the webhook service, not for
+		// the pod, so it is not verified: the check is only about the server
+		// being up and having loaded a certificate.
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+		if err != nil {
+			return errors.Wrap(err, "webhook server is not reachable")
+		}
+		return conn.Close()
+	}
+}
+
+// CacheSyncChecker returns a check failing until the informers of c synced.
+func CacheSyncChecker(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informer caches are not synced")
+		}
+		return nil
+	}
+}
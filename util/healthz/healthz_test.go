@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWebhookChecker(t *testing.T) {
+	g := NewWithT(t)
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	host, portStr, err := net.SplitHostPort(s.Listener.Addr().String())
+	g.Expect(err).NotTo(HaveOccurred())
+	port, err := strconv.Atoi(portStr)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	check := WebhookChecker(host, port)
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	g.Expect(check(req)).To(Succeed())
+
+	s.Close()
+	g.Expect(check(req)).NotTo(Succeed())
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/goroutine?debug=1", "/debug/pprof/heap", "/debug/vars"} {
+		rec := httptest.NewRecorder()
+		Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		g.Expect(rec.Code).To(Equal(http.StatusOK), path)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	testCases := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "localhost:6060", want: true},
+		{addr: "127.0.0.1:6060", want: true},
+		{addr: "[::1]:6060", want: true},
+		{addr: ":6060", want: false},
+		{addr: "0.0.0.0:6060", want: false},
+		{addr: "10.0.0.1:6060", want: false},
+		{addr: "invalid", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			NewWithT(t).Expect(IsLoopback(tc.addr)).To(Equal(tc.want))
+		})
+	}
+}